@@ -23,6 +23,8 @@ import (
 	godelbinderconfig "github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
 	godelbinderscheme "github.com/kubewharf/godel-scheduler/pkg/binder/apis/config/scheme"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config/v1beta1"
+	godelschedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	godelschedulerscheme "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config/scheme"
 )
 
 func loadProfileFromFile(file string) (*godelbinderconfig.GodelBinderProfile, error) {
@@ -72,3 +74,20 @@ func loadConfig(data []byte) (*godelbinderconfig.GodelBinderConfiguration, error
 	}
 	return nil, fmt.Errorf("couldn't decode as GodelBinderConfiguration, got %s: ", gvk)
 }
+
+func loadSchedulerConfigFromFile(file string) (*godelschedulerconfig.GodelSchedulerConfiguration, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// The UniversalDecoder runs defaulting and returns the internal type by default.
+	obj, gvk, err := godelschedulerscheme.Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfgObj, ok := obj.(*godelschedulerconfig.GodelSchedulerConfiguration); ok {
+		return cfgObj, nil
+	}
+	return nil, fmt.Errorf("couldn't decode as GodelSchedulerConfiguration, got %s: ", gvk)
+}
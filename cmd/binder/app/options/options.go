@@ -62,6 +62,10 @@ type Options struct {
 	// WriteConfigTo is the path where the default configuration will be written.
 	WriteConfigTo string
 
+	// SchedulerConfigFile is the location of the scheduler's configuration file, if it is set,
+	// the binder configuration is validated to be consistent with the scheduler configuration.
+	SchedulerConfigFile string
+
 	Master string
 
 	CombinedInsecureServing *CombinedInsecureServingOptions
@@ -118,6 +122,7 @@ func (o *Options) Flags() (nfs cliflag.NamedFlagSets) {
 	fs := nfs.FlagSet("misc")
 	fs.StringVar(&o.ConfigFile, "config", o.ConfigFile, "The path to the configuration file. Flags override values in this file.")
 	fs.StringVar(&o.WriteConfigTo, "write-config-to", o.WriteConfigTo, "If set, write the configuration values to this file and exit.")
+	fs.StringVar(&o.SchedulerConfigFile, "scheduler-config", o.SchedulerConfigFile, "The path to the configuration file of scheduler. If set, the sub-cluster profiles of binder will be validated against the scheduler configuration.")
 	fs.StringVar(&o.Master, "master", o.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
 	fs.StringVar(&o.BinderConfig.ClientConnection.Kubeconfig, "kubeconfig", o.BinderConfig.ClientConnection.Kubeconfig, "path to kubeconfig file with authorization and master location information.")
 	fs.Float32Var(&o.BinderConfig.ClientConnection.QPS, "kube-api-qps", o.BinderConfig.ClientConnection.QPS, "QPS to use while talking with kubernetes apiserver. This parameter is ignored if a config file is specified in --config.")
@@ -125,6 +130,7 @@ func (o *Options) Flags() (nfs cliflag.NamedFlagSets) {
 
 	fs.StringVar(o.BinderConfig.SchedulerName, "scheduler-name", *o.BinderConfig.SchedulerName, "components will deal with pods that pod.Spec.SchedulerName is equal to scheduler-name / is default-scheduler or empty.")
 	fs.Int64Var(&o.BinderConfig.VolumeBindingTimeoutSeconds, "volume-binding-timeout-seconds", o.BinderConfig.VolumeBindingTimeoutSeconds, "timeout for binding pod volumes")
+	fs.StringVar(o.BinderConfig.SubClusterKey, "sub-cluster-key", *o.BinderConfig.SubClusterKey, "the key to determine a sub cluster. This parameter overrides the value defined in config file, which is specified in --config.")
 	fs.Int64Var(&o.BinderConfig.ReservationTimeOutSeconds, "reservation-ttl", o.BinderConfig.ReservationTimeOutSeconds, "how long resources will be reserved (for resource reservation).")
 
 	o.CombinedInsecureServing.AddFlags(nfs.FlagSet("insecure serving"))
@@ -215,6 +221,9 @@ func (o *Options) ApplyTo(c *binderappconfig.Config) error {
 			if o.BinderConfig.ReservationTimeOutSeconds != binderconfig.DefaultReservationTimeOutSeconds {
 				toUse.ReservationTimeOutSeconds = o.BinderConfig.ReservationTimeOutSeconds
 			}
+			if *o.BinderConfig.SubClusterKey != binderconfig.DefaultSubClusterKey {
+				toUse.SubClusterKey = o.BinderConfig.SubClusterKey
+			}
 		}
 		// 5. Godel Profiles (Default)
 		// nothing to overwrite in this version.
@@ -232,6 +241,16 @@ func (o *Options) ApplyTo(c *binderappconfig.Config) error {
 		}
 	}
 
+	if len(o.SchedulerConfigFile) > 0 {
+		schedulerCfg, err := loadSchedulerConfigFromFile(o.SchedulerConfigFile)
+		if err != nil {
+			return err
+		}
+		if err := validation.ValidateConsistencyWithScheduler(&c.BinderConfig, schedulerCfg).ToAggregate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
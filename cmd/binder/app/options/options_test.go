@@ -22,6 +22,7 @@ import (
 
 	"github.com/kubewharf/godel-scheduler/cmd/binder/app/config"
 	binderconfig "github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config/validation"
 )
 
 func TestLoadProfile(t *testing.T) {
@@ -46,3 +47,61 @@ func TestLoadProfile(t *testing.T) {
 		t.Errorf("expected: %v, but got: %v", expectedPluginConfigs, cfg.BinderConfig.Profile.PreemptionPluginConfigs)
 	}
 }
+
+func TestLoadSubClusterProfiles(t *testing.T) {
+	cfg, err := loadConfigFromFile("../../../../test/static/binder_config_v1beta1_subcluster.yaml")
+	if err != nil {
+		t.Fatalf("fail to load config: %v", err)
+	}
+	if *cfg.SubClusterKey != "nodeLevel" {
+		t.Errorf("expected subClusterKey: nodeLevel, but got: %v", *cfg.SubClusterKey)
+	}
+	expectedSubClusterProfiles := []binderconfig.GodelBinderProfile{
+		{
+			SubClusterName: "subCluster 1",
+			Plugins: &binderconfig.Plugins{
+				VictimChecking: &binderconfig.VictimCheckingPluginSet{},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expectedSubClusterProfiles, cfg.SubClusterProfiles) {
+		t.Errorf("expected: %v, but got: %v", expectedSubClusterProfiles, cfg.SubClusterProfiles)
+	}
+}
+
+func TestValidateConsistencyWithScheduler(t *testing.T) {
+	schedulerCfg, err := loadSchedulerConfigFromFile("../../../../test/static/scheduler_config_v1beta1.yaml")
+	if err != nil {
+		t.Fatalf("fail to load scheduler config: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		configFile  string
+		expectedErr bool
+	}{
+		{
+			name:        "consistent sub-cluster profiles",
+			configFile:  "../../../../test/static/binder_config_v1beta1_subcluster.yaml",
+			expectedErr: false,
+		},
+		{
+			name:        "inconsistent sub-cluster key",
+			configFile:  "../../../../test/static/binder_config_v1beta1.yaml",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadConfigFromFile(tt.configFile)
+			if err != nil {
+				t.Fatalf("fail to load config: %v", err)
+			}
+			errs := validation.ValidateConsistencyWithScheduler(cfg, schedulerCfg)
+			if (len(errs) > 0) != tt.expectedErr {
+				t.Errorf("expected error: %v, but got: %v", tt.expectedErr, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores"
+	binderframework "github.com/kubewharf/godel-scheduler/pkg/binder/framework"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+)
+
+// Option registers out-of-tree extensions, it is used by a downstream `main`
+// to build a binder with extra plugins and stores.
+type Option func(*outOfTreeRegistries) error
+
+type outOfTreeRegistries struct {
+	plugins           binderframework.Registry
+	preemptionPlugins binderframework.Registry
+}
+
+func newOutOfTreeRegistries(opts ...Option) (*outOfTreeRegistries, error) {
+	r := &outOfTreeRegistries{
+		plugins:           binderframework.Registry{},
+		preemptionPlugins: binderframework.Registry{},
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *outOfTreeRegistries) binderOptions() []binder.Option {
+	return []binder.Option{
+		binder.WithFrameworkOutOfTreeRegistry(r.plugins),
+		binder.WithPreemptionOutOfTreeRegistry(r.preemptionPlugins),
+	}
+}
+
+// WithPlugin registers an out-of-tree binder plugin, it is enabled at all the
+// extension points it implements.
+func WithPlugin(name string, factory binderframework.PluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.plugins.Merge(binderframework.Registry{name: factory})
+	}
+}
+
+// WithPreemptionPlugin registers an out-of-tree victim checking plugin.
+func WithPreemptionPlugin(name string, factory binderframework.PluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.preemptionPlugins.Merge(binderframework.Registry{name: factory})
+	}
+}
+
+// WithStore registers an out-of-tree common store of the binder cache.
+func WithStore(name commonstore.StoreName, checker commonstore.FeatureGateChecker, newCache, newSnapshot commonstore.New) Option {
+	return func(_ *outOfTreeRegistries) error {
+		if err := commonstores.GlobalRegistries.RegisterOutOfTree(name, checker, newCache, newSnapshot); err != nil {
+			return fmt.Errorf("failed to register store: %v", err)
+		}
+		return nil
+	}
+}
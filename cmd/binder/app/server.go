@@ -33,6 +33,8 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/binder"
 	godelbinderconfig "github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/controller"
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	cmdutil "github.com/kubewharf/godel-scheduler/pkg/util/cmd"
 	routeutil "github.com/kubewharf/godel-scheduler/pkg/util/route"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
@@ -61,7 +63,9 @@ const (
 	ComponentName = "binder"
 )
 
-func NewGodelBinderCmd() *cobra.Command {
+// NewGodelBinderCmd creates the binder command, registryOptions are used to
+// register out-of-tree plugins and stores.
+func NewGodelBinderCmd(registryOptions ...Option) *cobra.Command {
 	opts, err := options.NewOptions()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "unable to initialize command options: %v\n", err)
@@ -80,7 +84,7 @@ to quickly create a Cobra application.`,
 		// Uncomment the following line if your bare application
 		// has an action associated with it:
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := runCommand(cmd, opts, args); err != nil {
+			if err := runCommand(cmd, opts, args, registryOptions...); err != nil {
 				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
 			}
@@ -111,7 +115,7 @@ to quickly create a Cobra application.`,
 	return godelBinderCmd
 }
 
-func runCommand(cmd *cobra.Command, opts *options.Options, args []string) error {
+func runCommand(cmd *cobra.Command, opts *options.Options, args []string, registryOptions ...Option) error {
 	verflag.PrintAndExitIfRequested()
 	cmdutil.InitKlogV2WithV1Flags(cmd.Flags())
 	if len(args) != 0 {
@@ -148,16 +152,32 @@ func runCommand(cmd *cobra.Command, opts *options.Options, args []string) error
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	return Run(ctx, cc)
+	return Run(ctx, cc, registryOptions...)
 }
 
-func Run(ctx context.Context, cc binderappconfig.CompletedConfig) error {
+func Run(ctx context.Context, cc binderappconfig.CompletedConfig, registryOptions ...Option) error {
 	eventRecorder := getEventRecorder(&cc)
 
 	err := cc.BinderConfig.Tracer.Validate()
 	if err != nil {
 		return err
 	}
+	if err := framework.SetOvercommitPolicies(cc.BinderConfig.OvercommitPolicies); err != nil {
+		return err
+	}
+
+	outOfTreeRegistries, err := newOutOfTreeRegistries(registryOptions...)
+	if err != nil {
+		return err
+	}
+	binderOptions := []binder.Option{
+		binder.WithPluginsAndConfigs(cc.BinderConfig.Profile),
+		binder.WithSubClusterKey(*cc.BinderConfig.SubClusterKey),
+		binder.WithSubClusterProfiles(cc.BinderConfig.SubClusterProfiles),
+		binder.WithCacheComparer(time.Duration(cc.BinderConfig.CacheComparePeriodSeconds)*time.Second, cc.BinderConfig.EnableCacheSelfHealing),
+		binder.WithSchedulingSLO(cc.BinderConfig.SchedulingSLOThresholdSeconds),
+	}
+	binderOptions = append(binderOptions, outOfTreeRegistries.binderOptions()...)
 
 	binder, err := binder.New(
 		cc.Client,
@@ -170,7 +190,7 @@ func Run(ctx context.Context, cc binderappconfig.CompletedConfig) error {
 		cc.BinderConfig.SchedulerName,
 		cc.BinderConfig.VolumeBindingTimeoutSeconds,
 		time.Duration(cc.BinderConfig.ReservationTimeOutSeconds)*time.Second,
-		binder.WithPluginsAndConfigs(cc.BinderConfig.Profile),
+		binderOptions...,
 	)
 	if err != nil {
 		return err
@@ -185,16 +205,18 @@ func Run(ctx context.Context, cc binderappconfig.CompletedConfig) error {
 		checks = append(checks, cc.LeaderElection.WatchDog)
 	}
 
+	inspector := commondebugger.NewCacheInspector(binder.BinderCache)
+
 	// Start up the healthz server.
 	if cc.InsecureServing != nil {
 		separateMetrics := cc.InsecureMetricsServing != nil
-		handler := buildHandlerChain(newHealthzHandler(&cc.BinderConfig, separateMetrics, checks...), nil, nil)
+		handler := buildHandlerChain(newHealthzHandler(&cc.BinderConfig, inspector, separateMetrics, checks...), nil, nil)
 		if err := cc.InsecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start healthz server: %v", err)
 		}
 	}
 	if cc.InsecureMetricsServing != nil {
-		handler := buildHandlerChain(newMetricsHandler(&cc.BinderConfig), nil, nil)
+		handler := buildHandlerChain(newMetricsHandler(&cc.BinderConfig, inspector), nil, nil)
 		if err := cc.InsecureMetricsServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start metrics server: %v", err)
 		}
@@ -285,7 +307,7 @@ func installMetricHandler(pathRecorderMux *mux.PathRecorderMux) {
 }
 
 // newMetricsHandler builds a metrics server from the config.
-func newMetricsHandler(config *godelbinderconfig.GodelBinderConfiguration) http.Handler {
+func newMetricsHandler(config *godelbinderconfig.GodelBinderConfiguration, inspector *commondebugger.CacheInspector) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux(ComponentName)
 	installMetricHandler(pathRecorderMux)
 	if *config.EnableProfiling {
@@ -295,13 +317,16 @@ func newMetricsHandler(config *godelbinderconfig.GodelBinderConfiguration) http.
 		}
 		routeutil.DebugFlags{}.Install(pathRecorderMux, "v", routeutil.StringFlagHandler(routeutil.GlogSetter, routeutil.GlogGetter))
 	}
+	if config.EnableCacheInspection {
+		inspector.Install(pathRecorderMux)
+	}
 	return pathRecorderMux
 }
 
 // newHealthzHandler creates a healthz server from the config, and will also
 // embed the metrics handler if the healthz and metrics address configurations
 // are the same.
-func newHealthzHandler(config *godelbinderconfig.GodelBinderConfiguration, separateMetrics bool, checks ...healthz.HealthChecker) http.Handler {
+func newHealthzHandler(config *godelbinderconfig.GodelBinderConfiguration, inspector *commondebugger.CacheInspector, separateMetrics bool, checks ...healthz.HealthChecker) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux(ComponentName)
 	healthz.InstallHandler(pathRecorderMux, checks...)
 	if !separateMetrics {
@@ -314,6 +339,9 @@ func newHealthzHandler(config *godelbinderconfig.GodelBinderConfiguration, separ
 		}
 		routeutil.DebugFlags{}.Install(pathRecorderMux, "v", routeutil.StringFlagHandler(routeutil.GlogSetter, routeutil.GlogGetter))
 	}
+	if config.EnableCacheInspection {
+		inspector.Install(pathRecorderMux)
+	}
 	return pathRecorderMux
 }
 
@@ -75,7 +75,11 @@ const (
 	ConfigzName           = "godel-controller-manager-config"
 )
 
-var ControllersDisabledByDefault = sets.NewString()
+// ControllersDisabledByDefault is the set of controllers which is disabled by default.
+// PodGroup controller patches workloads of users, so it must be enabled explicitly.
+var ControllersDisabledByDefault = sets.NewString(
+	"podgroup",
+)
 
 func NewGodelControllerCmd() *cobra.Command {
 	opts, err := options.NewGodelControllerManagerOptions()
@@ -438,6 +442,7 @@ func NewControllerInitializers() map[string]InitFunc {
 	}
 
 	register("reservation", startReservationController)
+	register("podgroup", startPodGroupController)
 
 	return controllers
 }
@@ -49,6 +49,7 @@ const DefaultLeaderElectionConfig = "godel-controller-manager"
 type GodelControllerManagerOptions struct {
 	Generic               *GenericControllerManagerConfigurationOptions
 	ReservationController *ReservationControllerOptions
+	PodGroupController    *PodGroupControllerOptions
 	Tracer                *TracerOptions
 
 	SecureServing           *apiserveroptions.SecureServingOptionsWithLoopback
@@ -92,6 +93,9 @@ func NewGodelControllerManagerOptions() (*GodelControllerManagerOptions, error)
 		ReservationController: &ReservationControllerOptions{
 			componentConfig.ReservationController,
 		},
+		PodGroupController: &PodGroupControllerOptions{
+			PodGroupControllerConfiguration: componentConfig.PodGroupController,
+		},
 		Tracer: &TracerOptions{
 			componentConfig.Tracer,
 		},
@@ -136,6 +140,7 @@ func (opt *GodelControllerManagerOptions) Flags(allControllers []string, disable
 
 	opt.Tracer.AddFlags(fss.FlagSet("tracer"))
 	opt.ReservationController.AddFlags(fss.FlagSet("reservation Controller"))
+	opt.PodGroupController.AddFlags(fss.FlagSet("podgroup Controller"))
 
 	fs := fss.FlagSet("misc")
 	fs.StringVar(&opt.Master, "master", opt.Master, "The address of the Kubernetes API server (overrides any value in kubeconfig).")
@@ -153,6 +158,10 @@ func (opt *GodelControllerManagerOptions) ApplyTo(c *cmdconfig.Config) error {
 		return err
 	}
 
+	if err := opt.PodGroupController.ApplyTo(c.ComponentConfig.PodGroupController); err != nil {
+		return err
+	}
+
 	opt.Tracer.ApplyTo(c.ComponentConfig.Tracer)
 
 	if err := opt.SecureServing.ApplyTo(&c.SecureServing, &c.LoopbackClientConfig); err != nil {
@@ -183,6 +192,9 @@ func (opt *GodelControllerManagerOptions) Validate(allControllers []string, disa
 	errs = append(errs, opt.Authentication.Validate()...)
 	errs = append(errs, opt.Authorization.Validate()...)
 	errs = append(errs, opt.Tracer.Validate())
+	if err := opt.PodGroupController.Validate(); err != nil {
+		errs = append(errs, err)
+	}
 
 	return utilerrors.NewAggregate(errs)
 }
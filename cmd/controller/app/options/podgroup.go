@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
+)
+
+type PodGroupControllerOptions struct {
+	*config.PodGroupControllerConfiguration
+
+	// Owners is the list of owner kinds in the format of `Kind.version.group[:key=value;...]`.
+	Owners []string
+}
+
+func (opt *PodGroupControllerOptions) AddFlags(fs *pflag.FlagSet) {
+	if opt == nil {
+		return
+	}
+	fs.StringSliceVar(&opt.Owners, "pod-group-owners", opt.Owners, "The list of owner kinds whose PodGroups will be created automatically, "+
+		"in the format of Kind.version.group[:key=value;...], e.g. Job.v1.batch or TrainJob.v1.example.com:podTemplatePath=spec.worker.template;suspendPath=spec.suspend. "+
+		"Supported keys are podTemplatePath, minMemberPath, suspendPath and immutablePodTemplate. Owners should opt in by annotation, "+
+		"and the controller should be granted get, list, watch and patch on the owner resources.")
+}
+
+func (opt *PodGroupControllerOptions) ApplyTo(cfg *config.PodGroupControllerConfiguration) error {
+	if opt == nil {
+		return nil
+	}
+	cfg.Owners = opt.PodGroupControllerConfiguration.Owners
+	if len(opt.Owners) > 0 {
+		cfg.Owners = nil
+		for _, value := range opt.Owners {
+			owner, err := parseOwner(value)
+			if err != nil {
+				return err
+			}
+			cfg.Owners = append(cfg.Owners, owner)
+		}
+		config.SetDefaultPodGroupController(cfg)
+	}
+	return nil
+}
+
+func (opt *PodGroupControllerOptions) Validate() error {
+	if opt == nil {
+		return nil
+	}
+	for _, value := range opt.Owners {
+		if _, err := parseOwner(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseOwner parses the owner configuration in the format of `Kind.version.group[:key=value;...]`.
+// The configuration of batch/v1 Job is used as base for Job.v1.batch.
+func parseOwner(value string) (config.OwnerConfiguration, error) {
+	kind, options, _ := strings.Cut(value, ":")
+	gvk, _ := schema.ParseKindArg(kind)
+	if gvk == nil {
+		return config.OwnerConfiguration{}, fmt.Errorf("invalid owner kind %q, expected format: Kind.version.group", kind)
+	}
+	owner := config.OwnerConfiguration{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	if owner.Group == config.DefaultJobOwner.Group && owner.Version == config.DefaultJobOwner.Version && owner.Kind == config.DefaultJobOwner.Kind {
+		owner = config.DefaultJobOwner
+	}
+	if len(options) == 0 {
+		return owner, nil
+	}
+	for _, option := range strings.Split(options, ";") {
+		key, val, ok := strings.Cut(option, "=")
+		if !ok || len(val) == 0 {
+			return config.OwnerConfiguration{}, fmt.Errorf("invalid option %q of owner %q, expected format: key=value", option, kind)
+		}
+		switch key {
+		case "podTemplatePath":
+			owner.PodTemplatePath = val
+		case "minMemberPath":
+			owner.MinMemberPath = val
+		case "suspendPath":
+			owner.SuspendPath = val
+		case "immutablePodTemplate":
+			immutable, err := strconv.ParseBool(val)
+			if err != nil {
+				return config.OwnerConfiguration{}, fmt.Errorf("invalid option %q of owner %q: %v", option, kind, err)
+			}
+			owner.ImmutablePodTemplate = immutable
+		default:
+			return config.OwnerConfiguration{}, fmt.Errorf("unknown option %q of owner %q", key, kind)
+		}
+	}
+	return owner, nil
+}
@@ -32,6 +32,7 @@ func (opt *ReservationControllerOptions) AddFlags(fs *pflag.FlagSet) {
 	}
 	fs.Int64Var(&opt.ReservationTTL, "reservation-ttl", opt.ReservationTTL, "how long resources will be reserved (for resource reservation).")
 	fs.Int64Var(&opt.MatchedRequestExtraTTL, "matched-request-cleanup-ttl", opt.MatchedRequestExtraTTL, "how long matched requests will be recycled after reservation ttl")
+	fs.BoolVar(&opt.ProtectReservedNodes, "protect-reserved-nodes-from-scale-down", opt.ProtectReservedNodes, "If true, nodes holding active reservations are annotated so that cluster autoscaler won't scale them down.")
 	fs.StringSliceVar(&opt.IgnoredNamespace, "ignored-namespace-list", opt.IgnoredNamespace, "The list of namespace to be ignored when setup informer.")
 }
 
@@ -43,6 +44,7 @@ func (opt *ReservationControllerOptions) ApplyTo(cfg *config.ReservationControll
 	cfg.ReservationCheckPeriod = opt.ReservationCheckPeriod
 	cfg.MatchedRequestExtraTTL = opt.MatchedRequestExtraTTL
 	cfg.IgnoredNamespace = opt.IgnoredNamespace
+	cfg.ProtectReservedNodes = opt.ProtectReservedNodes
 	return nil
 }
 
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes/scheme"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/kubewharf/godel-scheduler/pkg/controller"
+	"github.com/kubewharf/godel-scheduler/pkg/controller/podgroup"
+)
+
+const podGroupControllerWorkers = 5
+
+func startPodGroupController(ctx context.Context, controllerContext ControllerContext) (controller.Interface, bool, error) {
+	godelClient := controllerContext.GodelClientBuilder.ClientOrDie(podgroup.ControllerName)
+	dynamicClient, err := dynamic.NewForConfig(controllerContext.ClientBuilder.ConfigOrDie(podgroup.ControllerName))
+	if err != nil {
+		return nil, true, err
+	}
+
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, controllerContext.ResyncPeriod())
+	// owners are configured by kind, their resources are discovered from the apiserver.
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(
+		memory.NewMemCacheClient(controllerContext.ClientBuilder.ClientOrDie(podgroup.ControllerName).Discovery()))
+	podGroupInformer := controllerContext.GodelInformerFactory.Scheduling().V1alpha1().PodGroups()
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: controllerContext.ClientBuilder.ClientOrDie(podgroup.ControllerName).CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: podgroup.ControllerName})
+
+	pgc, err := podgroup.NewPodGroupController(godelClient, dynamicClient, podGroupInformer, dynamicInformerFactory, restMapper,
+		controllerContext.ComponentConfig.PodGroupController.Owners, recorder)
+	if err != nil {
+		return nil, true, err
+	}
+	dynamicInformerFactory.Start(ctx.Done())
+	go pgc.Run(ctx, podGroupControllerWorkers, controllerContext.ControllerManagerMetrics)
+	return nil, true, nil
+}
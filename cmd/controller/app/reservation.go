@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 
@@ -48,8 +49,14 @@ func startReservationController(ctx context.Context, controllerContext Controlle
 	reservationTTL := controllerContext.ComponentConfig.ReservationController.ReservationTTL
 	matchedRequestCleanUpTTL := controllerContext.ComponentConfig.ReservationController.MatchedRequestExtraTTL
 
+	// the node informer is only needed to protect reserved nodes from scale down
+	var nodeInformer coreinformers.NodeInformer
+	if controllerContext.ComponentConfig.ReservationController.ProtectReservedNodes {
+		nodeInformer = controllerContext.InformerFactory.Core().V1().Nodes()
+	}
+
 	go podInformer.Informer().Run(ctx.Done())
-	go reservation.NewReservationController(ctx, godelClient, podInformer, deployInformer, reservationInformer,
+	go reservation.NewReservationController(ctx, godelClient, kubeClient, podInformer, deployInformer, reservationInformer, nodeInformer,
 		reservationCheckPeriod, reservationTTL, matchedRequestCleanUpTTL).Run(ctx, controllerContext.ControllerManagerMetrics)
 	return nil, true, nil
 }
@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection"
 
 	dispatcherconfig "github.com/kubewharf/godel-scheduler/pkg/dispatcher/config"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/shard"
 	cmdutil "github.com/kubewharf/godel-scheduler/pkg/util/cmd"
 )
 
@@ -46,6 +47,9 @@ type Config struct {
 
 	LeaderElection *leaderelection.LeaderElectionConfig
 
+	// ShardManager is non-nil if the dispatcher runs in sharding mode.
+	ShardManager *shard.Manager
+
 	InsecureServing        *apiserver.DeprecatedInsecureServingInfo // nil will disable serving on an insecure port
 	InsecureMetricsServing *apiserver.DeprecatedInsecureServingInfo // non-nil if metrics should be served independentl
 
@@ -21,6 +21,7 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	godelclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
@@ -45,6 +46,7 @@ import (
 	dispatcherappconfig "github.com/kubewharf/godel-scheduler/cmd/dispatcher/app/config"
 	dispatcherconfig "github.com/kubewharf/godel-scheduler/pkg/dispatcher/config"
 	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/config/validation"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/shard"
 	cmdutil "github.com/kubewharf/godel-scheduler/pkg/util/cmd"
 )
 
@@ -123,6 +125,12 @@ func (o *Options) Flags() (nfs cliflag.NamedFlagSets) {
 	fs.Int32Var(&o.DispatcherConfig.ClientConnection.Burst, "kube-api-burst", o.DispatcherConfig.ClientConnection.Burst, "burst to use while talking with kubernetes apiserver. This parameter is ignored if a config file is specified in --config.")
 	fs.StringVar(o.DispatcherConfig.SchedulerName, "scheduler-name", *o.DispatcherConfig.SchedulerName, "components will deal with pods that pod.Spec.SchedulerName is equal to scheduler-name / is default-scheduler or empty.")
 
+	shardingFs := nfs.FlagSet("sharding")
+	shardingFs.BoolVar(&o.DispatcherConfig.Sharding.Enabled, "enable-sharding", o.DispatcherConfig.Sharding.Enabled, "If true, multiple dispatcher replicas dispatch pods concurrently, each of them owns a disjoint part of namespaces and units.")
+	shardingFs.StringVar(&o.DispatcherConfig.Sharding.LeaseNamespace, "shard-lease-namespace", o.DispatcherConfig.Sharding.LeaseNamespace, "The namespace of leases by which dispatcher replicas join the shard membership.")
+	shardingFs.Int64Var(&o.DispatcherConfig.Sharding.LeaseDurationSeconds, "shard-lease-duration-seconds", o.DispatcherConfig.Sharding.LeaseDurationSeconds, "The duration after which a dispatcher replica is removed from the shard membership if its lease is not renewed.")
+	shardingFs.Int64Var(&o.DispatcherConfig.Sharding.RenewIntervalSeconds, "shard-renew-interval-seconds", o.DispatcherConfig.Sharding.RenewIntervalSeconds, "The interval of renewing the shard lease and refreshing the shard membership.")
+
 	o.CombinedInsecureServing.AddFlags(nfs.FlagSet("insecure serving"))
 	o.DispatcherConfig.Tracer.AddFlags(nfs.FlagSet("tracer"))
 
@@ -185,6 +193,15 @@ func (o *Options) Config() (*dispatcherappconfig.Config, error) {
 	// c.CoreEventClient = eventClient.CoreV1()
 	c.LeaderElection = leaderElectionConfig
 
+	// Set up shard manager if sharding is enabled.
+	if c.DispatcherConfig.Sharding.Enabled {
+		identity, err := makeShardIdentity()
+		if err != nil {
+			return nil, err
+		}
+		c.ShardManager = shard.NewManager(leaderElectionClient, *c.DispatcherConfig.SchedulerName, identity, c.DispatcherConfig.Sharding)
+	}
+
 	return c, nil
 }
 
@@ -221,6 +238,15 @@ func makeLeaderElectionConfig(config componentbaseconfig.LeaderElectionConfigura
 	}, nil
 }
 
+// makeShardIdentity builds a unique identity for the dispatcher replica, which is also used in the lease name.
+func makeShardIdentity() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("unable to get hostname: %v", err)
+	}
+	return strings.ToLower(hostname) + "-" + string(uuid.NewUUID()), nil
+}
+
 func createClients(config componentbaseconfig.ClientConnectionConfiguration, masterOverride string, timeout time.Duration) (clientset.Interface, clientset.Interface, clientset.Interface, godelclient.Interface, error) {
 	if len(config.Kubeconfig) == 0 && len(masterOverride) == 0 {
 		klog.InfoS("WARN: Neither --kubeconfig nor --master was specified. Using default API client. This might not work")
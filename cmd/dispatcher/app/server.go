@@ -145,6 +145,7 @@ func Run(ctx context.Context, cc config.CompletedConfig) error {
 		cc.InformerFactory.Scheduling().V1().PriorityClasses(),
 		*cc.DispatcherConfig.SchedulerName,
 		getEventRecorder(&cc),
+		cc.ShardManager,
 	)
 
 	// Prepare the event broadcaster.
@@ -188,6 +189,19 @@ func Run(ctx context.Context, cc config.CompletedConfig) error {
 		<-ctx.Done()
 	}
 
+	// In sharding mode, every replica dispatches the pods of its own shards, and leader
+	// election is only used to run the singleton workers.
+	if cc.ShardManager != nil {
+		go cc.ShardManager.Run(ctx.Done())
+		go run(ctx)
+		if cc.LeaderElection == nil {
+			dispatcher.RunSingletonWorkers(ctx)
+			<-ctx.Done()
+			return fmt.Errorf("finished without leader elect")
+		}
+		run = dispatcher.RunSingletonWorkers
+	}
+
 	// If leader election is enabled, runCommand via LeaderElector until done and exit.
 	if cc.LeaderElection != nil {
 		cc.LeaderElection.Callbacks = leaderelection.LeaderCallbacks{
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+	cliflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/logs"
+	_ "k8s.io/component-base/metrics/prometheus/clientgo"
+
+	"github.com/kubewharf/godel-scheduler/cmd/binder/app"
+	"github.com/kubewharf/godel-scheduler/cmd/examples/out-of-tree/drainingnode"
+)
+
+// This is an example of building the binder with out-of-tree plugins and stores.
+func main() {
+	cmd := app.NewGodelBinderCmd(
+		app.WithStore(drainingnode.StoreName, drainingnode.StoreEnabled, drainingnode.NewCache, drainingnode.NewSnapshot),
+		app.WithPlugin(drainingnode.Name, drainingnode.NewBinderPlugin),
+	)
+	pflag.CommandLine.SetNormalizeFunc(cliflag.WordSepNormalizeFunc)
+	logs.InitLogs()
+	defer logs.FlushLogs()
+	if err := cmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
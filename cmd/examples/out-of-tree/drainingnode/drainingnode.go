@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drainingnode is an example of out-of-tree extensions, it keeps pods
+// away from the nodes which are being drained.
+package drainingnode
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	binderhandle "github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "DrainingNode"
+	// DrainingLabel marks the nodes which are being drained.
+	DrainingLabel = "example.godel.io/draining"
+	// ErrReasonDraining is the reason for Filter and CheckConflicts failures.
+	ErrReasonDraining = "node(s) were being drained"
+)
+
+type storeFinder interface {
+	FindStore(commonstore.StoreName) commonstore.Store
+}
+
+// DrainingNode rejects the nodes recorded by DrainingNodeStore.
+type DrainingNode struct {
+	finder storeFinder
+}
+
+var (
+	_ framework.FilterPlugin         = &DrainingNode{}
+	_ framework.CheckConflictsPlugin = &DrainingNode{}
+)
+
+// New initializes the scheduler plugin.
+func New(_ runtime.Object, h handle.PodFrameworkHandle) (framework.Plugin, error) {
+	return &DrainingNode{finder: h}, nil
+}
+
+// NewBinderPlugin initializes the binder plugin.
+func NewBinderPlugin(_ runtime.Object, h binderhandle.BinderFrameworkHandle) (framework.Plugin, error) {
+	return &DrainingNode{finder: h}, nil
+}
+
+func (pl *DrainingNode) Name() string {
+	return Name
+}
+
+func (pl *DrainingNode) Filter(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	return pl.check(nodeInfo)
+}
+
+func (pl *DrainingNode) CheckConflicts(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	return pl.check(nodeInfo)
+}
+
+func (pl *DrainingNode) check(nodeInfo framework.NodeInfo) *framework.Status {
+	store, ok := pl.finder.FindStore(StoreName).(*DrainingNodeStore)
+	if !ok {
+		return nil
+	}
+	if store.IsDraining(nodeInfo.GetNodeName()) {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonDraining)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drainingnode
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+)
+
+// StoreName is the name of the store, it should be different from all in-tree stores.
+const StoreName commonstore.StoreName = "DrainingNodeStore"
+
+// DrainingNodeStore records the nodes which are being drained.
+type DrainingNodeStore struct {
+	commonstore.BaseStore
+	storeType commonstore.StoreType
+
+	nodes sets.String
+}
+
+var _ commonstore.Store = &DrainingNodeStore{}
+
+// StoreEnabled decides whether the store should be built, it is always built in this example.
+func StoreEnabled(_ commoncache.CacheHandler) bool {
+	return true
+}
+
+// NewCache creates the store of cache.
+func NewCache(_ commoncache.CacheHandler) commonstore.Store {
+	return &DrainingNodeStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Cache,
+		nodes:     sets.NewString(),
+	}
+}
+
+// NewSnapshot creates the store of snapshot.
+func NewSnapshot(_ commoncache.CacheHandler) commonstore.Store {
+	return &DrainingNodeStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Snapshot,
+		nodes:     sets.NewString(),
+	}
+}
+
+func (s *DrainingNodeStore) Name() commonstore.StoreName {
+	return StoreName
+}
+
+func (s *DrainingNodeStore) AddNode(node *v1.Node) error {
+	if node.Labels[DrainingLabel] == "true" {
+		s.nodes.Insert(node.Name)
+	} else {
+		s.nodes.Delete(node.Name)
+	}
+	return nil
+}
+
+func (s *DrainingNodeStore) UpdateNode(_, newNode *v1.Node) error {
+	return s.AddNode(newNode)
+}
+
+func (s *DrainingNodeStore) DeleteNode(node *v1.Node) error {
+	s.nodes.Delete(node.Name)
+	return nil
+}
+
+// UpdateSnapshot copies the draining nodes to the store of snapshot.
+func (s *DrainingNodeStore) UpdateSnapshot(store commonstore.Store) error {
+	snapshot := store.(*DrainingNodeStore)
+	snapshot.nodes = sets.NewString(s.nodes.UnsortedList()...)
+	return nil
+}
+
+// IsDraining returns true if the node is being drained.
+func (s *DrainingNodeStore) IsDraining(nodeName string) bool {
+	return s.nodes.Has(nodeName)
+}
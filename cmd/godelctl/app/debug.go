@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
+)
+
+// debugClient reads the cache content of scheduler or binder through the endpoints served by commondebugger.CacheInspector.
+type debugClient struct {
+	component string
+	address   string
+	client    *http.Client
+}
+
+func newDebugClient(component, address string) *debugClient {
+	if len(address) == 0 {
+		return nil
+	}
+	return &debugClient{
+		component: component,
+		address:   strings.TrimSuffix(address, "/"),
+		client:    http.DefaultClient,
+	}
+}
+
+// get decodes the json content of the path into obj, found is false if the content is not found.
+func (c *debugClient) get(ctx context.Context, path string, query url.Values, obj interface{}) (found bool, err error) {
+	u := c.address + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to request %s: %v", c.component, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to request %s %s: %s: %s", c.component, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return false, fmt.Errorf("failed to decode the response of %s %s: %v", c.component, path, err)
+	}
+	return true, nil
+}
+
+// node returns the cached node info, nil if the node is not in cache.
+func (c *debugClient) node(ctx context.Context, name string) (*commondebugger.NodeInfoSummary, error) {
+	summary := &commondebugger.NodeInfoSummary{}
+	found, err := c.get(ctx, commondebugger.NodesPath, url.Values{"name": []string{name}}, summary)
+	if err != nil || !found {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// assumedPods returns the assumed pods in cache keyed by pod uid.
+func (c *debugClient) assumedPods(ctx context.Context) (map[string]*commondebugger.AssumedPodSummary, error) {
+	var summaries []*commondebugger.AssumedPodSummary
+	if _, err := c.get(ctx, commondebugger.AssumedPodsPath, nil, &summaries); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]*commondebugger.AssumedPodSummary, len(summaries))
+	for _, summary := range summaries {
+		ret[summary.UID] = summary
+	}
+	return ret, nil
+}
+
+// store decodes the content of the common store into obj, found is false if the store is not dumpable or not found.
+func (c *debugClient) store(ctx context.Context, name string, obj interface{}) (found bool, err error) {
+	return c.get(ctx, commondebugger.StoresPath, url.Values{"name": []string{name}}, obj)
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	crdclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	frameworkutils "github.com/kubewharf/godel-scheduler/pkg/framework/utils"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+// maxEvents is the max number of the latest events shown in the explanation.
+const maxEvents = 5
+
+func newExplainCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "explain POD",
+		Short: "Explain why a pod is pending",
+		Long: "Explain why a pod is pending. The pod state annotations tell which component is responsible for the pod, " +
+			"then the PodGroup, the scheduler, the events and the caches of scheduler and binder (if their addresses " +
+			"are specified) are checked for the reasons.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runExplain(args[0])
+		},
+	}
+}
+
+func (o *Options) runExplain(name string) error {
+	client, crdClient, err := o.clients()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	pod, err := client.CoreV1().Pods(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %v", o.Namespace, name, err)
+	}
+	stage := getPodStage(pod)
+	reasons, err := o.explain(ctx, client, crdClient, pod, stage)
+	if err != nil {
+		return err
+	}
+	events, err := latestEvents(ctx, client, pod)
+	if err != nil {
+		return err
+	}
+	printExplanation(o.out, pod, stage, reasons, events)
+	return nil
+}
+
+// explain collects the reasons that hold the pod in its current stage.
+func (o *Options) explain(ctx context.Context, client kubernetes.Interface, crdClient crdclient.Interface, pod *v1.Pod, stage podStage) ([]string, error) {
+	switch stage.State {
+	case "Bound":
+		return []string{fmt.Sprintf("The pod is bound to node %s.", stage.Node)}, nil
+	case "Abnormal":
+		return []string{fmt.Sprintf("The pod state annotations are inconsistent, pod-state: %q, selected-scheduler: %q, assumed-node: %q, nominated-node: %q.",
+			pod.Annotations[podutil.PodStateAnnotationKey], pod.Annotations[podutil.SchedulerAnnotationKey],
+			pod.Annotations[podutil.AssumedNodeAnnotationKey], pod.Annotations[podutil.NominatedNodeAnnotationKey])}, nil
+	}
+
+	var reasons []string
+	if !podutil.LegalPodResourceTypeAndLauncher(pod) {
+		reasons = append(reasons, fmt.Sprintf("The pod resource type %q or launcher %q is illegal, the pod is ignored by Godel.",
+			pod.Annotations[podutil.PodResourceTypeAnnotationKey], pod.Annotations[podutil.PodLauncherAnnotationKey]))
+	}
+	if failedSchedulers := podutil.GetFailedSchedulersNames(pod); failedSchedulers.Len() > 0 {
+		reasons = append(reasons, fmt.Sprintf("The schedulers %v have failed to schedule the pod.", failedSchedulers.List()))
+	}
+
+	var err error
+	if pgName := podutil.GetPodGroupName(pod); len(pgName) > 0 {
+		pgReasons, err := o.explainPodGroup(ctx, client, crdClient, pod, pgName, stage)
+		if err != nil {
+			return nil, err
+		}
+		reasons = append(reasons, pgReasons...)
+	}
+
+	var stageReasons []string
+	switch stage.Component {
+	case componentDispatcher:
+		if len(reasons) == 0 {
+			stageReasons = append(stageReasons, "The pod is waiting to be dispatched, no scheduler may be active or the dispatcher is busy.")
+		}
+	case componentScheduler:
+		stageReasons, err = o.explainScheduler(ctx, crdClient, pod, stage)
+	case componentBinder:
+		stageReasons, err = o.explainBinder(ctx, client, pod, stage)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(reasons, stageReasons...), nil
+}
+
+func (o *Options) explainPodGroup(ctx context.Context, client kubernetes.Interface, crdClient crdclient.Interface, pod *v1.Pod, pgName string, stage podStage) ([]string, error) {
+	pg, err := crdClient.SchedulingV1alpha1().PodGroups(pod.Namespace).Get(ctx, pgName, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return []string{fmt.Sprintf("PodGroup %s/%s is not found, the pod is held by dispatcher until it is created.", pod.Namespace, pgName)}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get PodGroup %s/%s: %v", pod.Namespace, pgName, err)
+	}
+
+	var reasons []string
+	switch pg.Status.Phase {
+	case schedulingv1alpha1.PodGroupTimeout, schedulingv1alpha1.PodGroupFailed:
+		reasons = append(reasons, fmt.Sprintf("PodGroup %s/%s is in phase %s, its pods are not scheduled any more.", pod.Namespace, pgName, pg.Status.Phase))
+	}
+	if stage.Component == componentDispatcher {
+		u, err := o.getUnit(ctx, client, crdClient.SchedulingV1alpha1().PodGroups(pod.Namespace).Get, pgName)
+		if err != nil {
+			return nil, err
+		}
+		if len(u.pods) < int(pg.Spec.MinMember) {
+			reasons = append(reasons, fmt.Sprintf("PodGroup %s/%s has %d pods, less than its min member %d.", pod.Namespace, pgName, len(u.pods), pg.Spec.MinMember))
+		}
+		roleMinMember, err := framework.ParseRoleMinMember(pg)
+		if err != nil {
+			reasons = append(reasons, fmt.Sprintf("PodGroup %s/%s has invalid role min member, which is ignored: %v.", pod.Namespace, pgName, err))
+		} else if !framework.MeetRoleMinMember(roleMinMember, u.pods) {
+			reasons = append(reasons, fmt.Sprintf("PodGroup %s/%s does not have enough pods for the role min member %v.", pod.Namespace, pgName, roleMinMember))
+		}
+		reasons = append(reasons, explainDependencies(ctx, crdClient, pod.Namespace, pgName, pg.Annotations)...)
+	}
+	for _, cond := range pg.Status.Conditions {
+		if cond.Status == v1.ConditionFalse && len(cond.Message) > 0 {
+			reasons = append(reasons, fmt.Sprintf("PodGroup condition %s: %s: %s", cond.Phase, cond.Reason, cond.Message))
+		}
+	}
+	return reasons, nil
+}
+
+func explainDependencies(ctx context.Context, crdClient crdclient.Interface, namespace, name string, annotations map[string]string) []string {
+	var getErr error
+	reason, message, ok := unitutil.CheckDependencies(namespace, name, annotations, func(namespace, name string) *schedulingv1alpha1.PodGroup {
+		pg, err := crdClient.SchedulingV1alpha1().PodGroups(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				getErr = err
+			}
+			return nil
+		}
+		return pg
+	})
+	if ok {
+		return nil
+	}
+	if getErr != nil {
+		return []string{fmt.Sprintf("Failed to check the dependencies: %v.", getErr)}
+	}
+	return []string{fmt.Sprintf("The unit is blocked by its dependencies, %s: %s.", reason, message)}
+}
+
+func (o *Options) explainScheduler(ctx context.Context, crdClient crdclient.Interface, pod *v1.Pod, stage podStage) ([]string, error) {
+	scheduler, err := crdClient.SchedulingV1alpha1().Schedulers().Get(ctx, stage.Scheduler, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return []string{fmt.Sprintf("The pod is dispatched to scheduler %s which does not exist, dispatcher will dispatch it again.", stage.Scheduler)}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get scheduler %s: %v", stage.Scheduler, err)
+	}
+	if scheduler.Status.Phase != schedulingv1alpha1.Active {
+		return []string{fmt.Sprintf("The pod is dispatched to scheduler %s which is %s, dispatcher will dispatch it again.", stage.Scheduler, orNone(string(scheduler.Status.Phase)))}, nil
+	}
+	return []string{fmt.Sprintf("The pod is being scheduled by scheduler %s, check the events for the latest scheduling failures.", stage.Scheduler)}, nil
+}
+
+func (o *Options) explainBinder(ctx context.Context, client kubernetes.Interface, pod *v1.Pod, stage podStage) ([]string, error) {
+	var reasons []string
+	if stage.Nominated {
+		reasons = append(reasons, fmt.Sprintf("The pod is nominated to node %s by scheduler %s, waiting for the victims to be preempted.", stage.Node, stage.Scheduler))
+		if nominatedNode, err := frameworkutils.GetPodNominatedNode(pod); err == nil && nominatedNode != nil {
+			for _, victim := range nominatedNode.VictimPods {
+				victimPod, err := client.CoreV1().Pods(victim.Namespace).Get(ctx, victim.Name, metav1.GetOptions{})
+				if err == nil && string(victimPod.UID) == victim.UID {
+					state := "running"
+					if victimPod.DeletionTimestamp != nil {
+						state = "terminating"
+					}
+					reasons = append(reasons, fmt.Sprintf("Victim %s/%s is still %s.", victim.Namespace, victim.Name, state))
+				} else if err != nil && !errors.IsNotFound(err) {
+					return nil, fmt.Errorf("failed to get victim %s/%s: %v", victim.Namespace, victim.Name, err)
+				}
+			}
+		}
+	} else {
+		reasons = append(reasons, fmt.Sprintf("The pod is assumed to node %s by scheduler %s, waiting to be bound by binder.", stage.Node, stage.Scheduler))
+	}
+
+	for _, c := range []*debugClient{newDebugClient(componentScheduler, o.SchedulerAddress), newDebugClient(componentBinder, o.BinderAddress)} {
+		if c == nil {
+			continue
+		}
+		assumedPods, err := c.assumedPods(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := assumedPods[string(pod.UID)]; !ok {
+			if c.component == componentBinder {
+				reasons = append(reasons, "The pod is not assumed in binder cache, it may be still in the binder queue or rejected by binder.")
+			} else {
+				reasons = append(reasons, fmt.Sprintf("The pod is not assumed in scheduler cache at %s, the address may belong to another scheduler.", c.address))
+			}
+		}
+		node, err := c.node(ctx, stage.Node)
+		if err != nil {
+			return nil, err
+		}
+		if node == nil {
+			reasons = append(reasons, fmt.Sprintf("Node %s is not found in %s cache.", stage.Node, c.component))
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("Node %s in %s cache has %d pods, guaranteed requested %s of allocatable %s.",
+			stage.Node, c.component, len(node.Pods), formatResource(node.GuaranteedRequested), formatResource(node.GuaranteedAllocatable)))
+	}
+	return reasons, nil
+}
+
+func formatResource(r *framework.Resource) string {
+	if r == nil {
+		return none
+	}
+	return fmt.Sprintf("{cpu: %dm, memory: %d}", r.MilliCPU, r.Memory)
+}
+
+// latestEvents returns the latest events of the pod, ordered by time.
+func latestEvents(ctx context.Context, client kubernetes.Interface, pod *v1.Pod) ([]v1.Event, error) {
+	events, err := client.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": pod.Name}.AsSelector().String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %v", err)
+	}
+	var ret []v1.Event
+	for _, event := range events.Items {
+		if event.InvolvedObject.UID == pod.UID {
+			ret = append(ret, event)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return eventTime(&ret[i]).Before(eventTime(&ret[j])) })
+	if len(ret) > maxEvents {
+		ret = ret[len(ret)-maxEvents:]
+	}
+	return ret, nil
+}
+
+func eventTime(event *v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func printExplanation(out io.Writer, pod *v1.Pod, stage podStage, reasons []string, events []v1.Event) {
+	fmt.Fprintf(out, "Pod:\t\t%s\n", podutil.GetPodKey(pod))
+	if pgName := podutil.GetPodGroupName(pod); len(pgName) > 0 {
+		fmt.Fprintf(out, "PodGroup:\t%s/%s\n", pod.Namespace, pgName)
+	}
+	fmt.Fprintf(out, "Component:\t%s\n", stage.Component)
+	fmt.Fprintf(out, "State:\t\t%s\n", stage.State)
+	fmt.Fprintln(out, "Reasons:")
+	for _, reason := range reasons {
+		fmt.Fprintf(out, "  - %s\n", reason)
+	}
+	fmt.Fprintln(out, "Events:")
+	if len(events) == 0 {
+		fmt.Fprintln(out, "  "+none)
+		return
+	}
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "  LAST-SEEN\tTYPE\tREASON\tFROM\tMESSAGE")
+	for _, event := range events {
+		t := eventTime(&event)
+		from := event.Source.Component
+		if len(from) == 0 {
+			from = event.ReportingController
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", t.UTC().Format("2006-01-02T15:04:05Z"), event.Type, event.Reason, orNone(from), event.Message)
+	}
+	w.Flush()
+}
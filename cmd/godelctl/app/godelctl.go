@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	crdclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// CommandName is the name of the operator command line tool.
+	CommandName = "godelctl"
+
+	// none is printed for the empty fields.
+	none = "<none>"
+)
+
+// Options holds the global options of godelctl.
+type Options struct {
+	// Kubeconfig is the path of the kubeconfig file, the default loading rules or in-cluster config are used if empty.
+	Kubeconfig string
+	// Context is the kubeconfig context to use.
+	Context string
+	// Namespace is the namespace of the namespaced objects.
+	Namespace string
+	// SchedulerAddress is the base url of the debug endpoints of the scheduler, e.g. http://127.0.0.1:10251.
+	SchedulerAddress string
+	// BinderAddress is the base url of the debug endpoints of the binder, e.g. http://127.0.0.1:10351.
+	BinderAddress string
+	// Timeout is the timeout of each command.
+	Timeout time.Duration
+
+	out io.Writer
+
+	// clients is used to build the clients, it could be replaced in tests.
+	clients func() (kubernetes.Interface, crdclient.Interface, error)
+}
+
+// NewGodelctlCommand creates the godelctl command with all its sub commands.
+func NewGodelctlCommand(out io.Writer) *cobra.Command {
+	o := &Options{
+		Namespace: "default",
+		Timeout:   30 * time.Second,
+		out:       out,
+	}
+	o.clients = o.buildClients
+	return newGodelctlCommand(o)
+}
+
+func newGodelctlCommand(o *Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           CommandName,
+		Short:         "godelctl helps operators to inspect and operate Godel Scheduler",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	fs := cmd.PersistentFlags()
+	fs.StringVar(&o.Kubeconfig, "kubeconfig", o.Kubeconfig, "Path to the kubeconfig file.")
+	fs.StringVar(&o.Context, "context", o.Context, "The name of the kubeconfig context to use.")
+	fs.StringVarP(&o.Namespace, "namespace", "n", o.Namespace, "The namespace of the pods and PodGroups.")
+	fs.StringVar(&o.SchedulerAddress, "scheduler-address", o.SchedulerAddress, "The base url of the scheduler debug endpoints, e.g. http://127.0.0.1:10251.")
+	fs.StringVar(&o.BinderAddress, "binder-address", o.BinderAddress, "The base url of the binder debug endpoints, e.g. http://127.0.0.1:10351.")
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "The timeout of each command.")
+
+	cmd.AddCommand(
+		newSchedulersCommand(o),
+		newUnitCommand(o),
+		newExplainCommand(o),
+		newReservationsCommand(o),
+		newMovementsCommand(o),
+		newRepartitionCommand(o),
+	)
+	return cmd
+}
+
+func (o *Options) buildClients() (kubernetes.Interface, crdclient.Interface, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.Kubeconfig
+	restConfig, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: o.Context}).ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	restConfig.Timeout = o.Timeout
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	crdClient, err := crdclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, crdClient, nil
+}
+
+func (o *Options) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), o.Timeout)
+}
+
+func newTabWriter(out io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+}
+
+func orNone(s string) string {
+	if len(s) == 0 {
+		return none
+	}
+	return s
+}
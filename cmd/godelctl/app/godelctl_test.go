@@ -0,0 +1,363 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	nodev1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/node/v1alpha1"
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	crdclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
+	crdfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/server/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	nodeutil "github.com/kubewharf/godel-scheduler/pkg/util/node"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+func newTestOptions(client kubernetes.Interface, crdClient crdclient.Interface) (*Options, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	return &Options{
+		Namespace: "default",
+		Timeout:   time.Minute,
+		out:       out,
+		clients: func() (kubernetes.Interface, crdclient.Interface, error) {
+			return client, crdClient, nil
+		},
+	}, out
+}
+
+func makeScheduler(name string, phase schedulingv1alpha1.SchedulerPhase) *schedulingv1alpha1.Scheduler {
+	return &schedulingv1alpha1.Scheduler{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     schedulingv1alpha1.SchedulerStatus{Phase: phase},
+	}
+}
+
+func makeNode(name, scheduler string) *v1.Node {
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if len(scheduler) > 0 {
+		node.Annotations = map[string]string{nodeutil.GodelSchedulerNodeAnnotationKey: scheduler}
+	}
+	return node
+}
+
+func makeNMNode(name, scheduler string) *nodev1alpha1.NMNode {
+	nmNode := &nodev1alpha1.NMNode{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if len(scheduler) > 0 {
+		nmNode.Annotations = map[string]string{nodeutil.GodelSchedulerNodeAnnotationKey: scheduler}
+	}
+	return nmNode
+}
+
+func TestSchedulers(t *testing.T) {
+	client := fake.NewSimpleClientset(makeNode("n1", "s1"), makeNode("n2", "s1"), makeNode("n3", "stale"), makeNode("n4", ""))
+	crdClient := crdfake.NewSimpleClientset(makeScheduler("s1", schedulingv1alpha1.Active), makeScheduler("s2", schedulingv1alpha1.Active), makeNMNode("n2", "s1"))
+	o, out := newTestOptions(client, crdClient)
+
+	partitions, err := o.listPartitions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][2][]string{
+		"s1":       {{"n1", "n2"}, {"n2"}},
+		"s2":       {{}, {}},
+		"stale":    {{"n3"}, {}},
+		unassigned: {{"n4"}, {}},
+	}
+	if len(partitions) != len(expected) {
+		t.Fatalf("expected %d partitions, got %d", len(expected), len(partitions))
+	}
+	for name, nodes := range expected {
+		p := partitions[name]
+		if p == nil {
+			t.Fatalf("partition %s not found", name)
+		}
+		if !reflect.DeepEqual(p.nodes.List(), nodes[0]) || !reflect.DeepEqual(p.nmNodes.List(), nodes[1]) {
+			t.Errorf("partition %s: expected nodes %v and nmnodes %v, got %v and %v", name, nodes[0], nodes[1], p.nodes.List(), p.nmNodes.List())
+		}
+	}
+
+	if err := o.runSchedulers(true); err != nil {
+		t.Fatal(err)
+	}
+	for _, expectedLine := range [][]string{
+		{"s1", "Active", none, none, "2", "1"},
+		{"stale", "NotFound", none, none, "1", "0"},
+		{"s1", "n2", "hybrid"},
+		{unassigned, "n4", "node"},
+	} {
+		if !hasLine(out.String(), expectedLine) {
+			t.Errorf("expected line %v in output:\n%s", expectedLine, out.String())
+		}
+	}
+}
+
+func TestGetPodStage(t *testing.T) {
+	tests := []struct {
+		name     string
+		pod      *v1.Pod
+		expected podStage
+	}{
+		{
+			name:     "pending",
+			pod:      testinghelper.MakePod().Name("p").Obj(),
+			expected: podStage{Component: componentDispatcher, State: string(podutil.PodPending)},
+		},
+		{
+			name: "dispatched",
+			pod: testinghelper.MakePod().Name("p").
+				Annotation(podutil.PodStateAnnotationKey, string(podutil.PodDispatched)).
+				Annotation(podutil.SchedulerAnnotationKey, "s1").Obj(),
+			expected: podStage{Component: componentScheduler, State: string(podutil.PodDispatched), Scheduler: "s1"},
+		},
+		{
+			name: "assumed",
+			pod: testinghelper.MakePod().Name("p").
+				Annotation(podutil.PodStateAnnotationKey, string(podutil.PodAssumed)).
+				Annotation(podutil.SchedulerAnnotationKey, "s1").
+				Annotation(podutil.AssumedNodeAnnotationKey, "n1").Obj(),
+			expected: podStage{Component: componentBinder, State: string(podutil.PodAssumed), Scheduler: "s1", Node: "n1"},
+		},
+		{
+			name: "nominated",
+			pod: testinghelper.MakePod().Name("p").
+				Annotation(podutil.PodStateAnnotationKey, string(podutil.PodAssumed)).
+				Annotation(podutil.SchedulerAnnotationKey, "s1").
+				Annotation(podutil.NominatedNodeAnnotationKey, `{"node":"n1","victims":[{"name":"v","namespace":"default","uid":"v"}]}`).Obj(),
+			expected: podStage{Component: componentBinder, State: string(podutil.PodAssumed), Scheduler: "s1", Node: "n1", Nominated: true},
+		},
+		{
+			name: "abnormal",
+			pod: testinghelper.MakePod().Name("p").
+				Annotation(podutil.PodStateAnnotationKey, string(podutil.PodDispatched)).Obj(),
+			expected: podStage{Component: componentNone, State: "Abnormal"},
+		},
+		{
+			name: "bound",
+			pod: testinghelper.MakePod().Name("p").Node("n1").
+				Annotation(podutil.PodStateAnnotationKey, string(podutil.PodAssumed)).
+				Annotation(podutil.SchedulerAnnotationKey, "s1").
+				Annotation(podutil.AssumedNodeAnnotationKey, "n1").Obj(),
+			expected: podStage{Component: componentNone, State: "Bound", Scheduler: "s1", Node: "n1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPodStage(tt.pod); got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
+
+type fakeCache struct {
+	dump *commoncache.Dump
+}
+
+func (c *fakeCache) Dump() *commoncache.Dump {
+	return c.dump
+}
+
+func (c *fakeCache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	return nil
+}
+
+func TestExplain(t *testing.T) {
+	pendingPod := testinghelper.MakePod().Namespace("default").Name("worker-0").UID("worker-0").
+		Annotation(podutil.PodGroupNameAnnotationKey, "worker").Obj()
+	assumedPod := testinghelper.MakePod().Namespace("default").Name("ps-0").UID("ps-0").
+		Annotation(podutil.PodGroupNameAnnotationKey, "ps").
+		Annotation(podutil.PodStateAnnotationKey, string(podutil.PodAssumed)).
+		Annotation(podutil.SchedulerAnnotationKey, "s1").
+		Annotation(podutil.AssumedNodeAnnotationKey, "n1").Obj()
+	singlePod := testinghelper.MakePod().Namespace("default").Name("single").UID("single").
+		Annotation(unitutil.DependsOnAnnotationKey, "ps").Obj()
+	worker := testinghelper.MakePodGroup().Namespace("default").Name("worker").MinMember(2).Obj()
+	worker.Annotations = map[string]string{unitutil.DependsOnAnnotationKey: "ps"}
+	ps := testinghelper.MakePodGroup().Namespace("default").Name("ps").MinMember(1).Phase(schedulingv1alpha1.PodGroupScheduled).Obj()
+
+	// The binder has not assumed the pod yet.
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(testinghelper.MakeNode().Name("n1").Obj())
+	m := mux.NewPathRecorderMux("test")
+	commondebugger.NewCacheInspector(&fakeCache{dump: &commoncache.Dump{Nodes: map[string]framework.NodeInfo{"n1": nodeInfo}}}).Install(m)
+	binder := httptest.NewServer(m)
+	defer binder.Close()
+
+	tests := []struct {
+		name            string
+		pod             *v1.Pod
+		objects         []runtime.Object
+		expectedReasons []string
+	}{
+		{
+			name:    "PodGroup not found",
+			pod:     pendingPod,
+			objects: []runtime.Object{},
+			expectedReasons: []string{
+				"PodGroup default/worker is not found, the pod is held by dispatcher until it is created.",
+			},
+		},
+		{
+			name:    "not enough pods and blocked by dependency",
+			pod:     pendingPod,
+			objects: []runtime.Object{worker},
+			expectedReasons: []string{
+				"PodGroup default/worker has 1 pods, less than its min member 2.",
+				"The unit is blocked by its dependencies, PrerequisiteNotFound: prerequisite PodGroup default/ps is not found.",
+			},
+		},
+		{
+			name:    "dependencies of pods without PodGroup are ignored",
+			pod:     singlePod,
+			objects: []runtime.Object{},
+			expectedReasons: []string{
+				"The pod is waiting to be dispatched, no scheduler may be active or the dispatcher is busy.",
+			},
+		},
+		{
+			name:    "waiting to be bound",
+			pod:     assumedPod,
+			objects: []runtime.Object{ps},
+			expectedReasons: []string{
+				"The pod is assumed to node n1 by scheduler s1, waiting to be bound by binder.",
+				"The pod is not assumed in binder cache, it may be still in the binder queue or rejected by binder.",
+				"Node n1 in binder cache has 0 pods, guaranteed requested {cpu: 0m, memory: 0} of allocatable {cpu: 0m, memory: 0}.",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(tt.pod)
+			crdClient := crdfake.NewSimpleClientset(tt.objects...)
+			o, _ := newTestOptions(client, crdClient)
+			o.BinderAddress = binder.URL
+
+			reasons, err := o.explain(context.Background(), client, crdClient, tt.pod, getPodStage(tt.pod))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(reasons, tt.expectedReasons) {
+				t.Errorf("expected reasons %q, got %q", tt.expectedReasons, reasons)
+			}
+		})
+	}
+}
+
+func TestRepartition(t *testing.T) {
+	tests := []struct {
+		name                string
+		args                []string
+		expectedAnnotations map[string]string
+		expectedErr         string
+	}{
+		{
+			name:                "move nodes between schedulers",
+			args:                []string{"repartition", "--from", "s1", "--to", "s2", "--count", "1"},
+			expectedAnnotations: map[string]string{"n1": "s2", "n2": "s1", "n3": "s2"},
+		},
+		{
+			name:                "release nodes to dispatcher",
+			args:                []string{"repartition", "n1", "n2"},
+			expectedAnnotations: map[string]string{"n1": "", "n2": "", "n3": "s2"},
+		},
+		{
+			name:                "dry run",
+			args:                []string{"repartition", "--from", "s1", "--dry-run"},
+			expectedAnnotations: map[string]string{"n1": "s1", "n2": "s1", "n3": "s2"},
+		},
+		{
+			name:                "refuse to leave partitions unbalanced",
+			args:                []string{"repartition", "--from", "s1", "--to", "s2"},
+			expectedAnnotations: map[string]string{"n1": "s1", "n2": "s1", "n3": "s2"},
+			expectedErr:         "scheduler s2 would have 3 nodes and scheduler s1 would have 0 nodes",
+		},
+		{
+			name:                "force to leave partitions unbalanced",
+			args:                []string{"repartition", "--from", "s1", "--to", "s2", "--force"},
+			expectedAnnotations: map[string]string{"n1": "s2", "n2": "s2", "n3": "s2"},
+		},
+		{
+			name:                "scheduler not found",
+			args:                []string{"repartition", "n1", "--to", "s3"},
+			expectedAnnotations: map[string]string{"n1": "s1", "n2": "s1", "n3": "s2"},
+			expectedErr:         "scheduler s3 is not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(makeNode("n1", "s1"), makeNode("n2", "s1"), makeNode("n3", "s2"))
+			crdClient := crdfake.NewSimpleClientset(makeScheduler("s1", schedulingv1alpha1.Active), makeScheduler("s2", schedulingv1alpha1.Active), makeNMNode("n1", "s1"))
+			o, _ := newTestOptions(client, crdClient)
+			cmd := newGodelctlCommand(o)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			if len(tt.expectedErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			for name, expected := range tt.expectedAnnotations {
+				node, err := client.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := node.Annotations[nodeutil.GodelSchedulerNodeAnnotationKey]; got != expected {
+					t.Errorf("expected node %s in partition %q, got %q", name, expected, got)
+				}
+			}
+			nmNode, err := crdClient.NodeV1alpha1().NMNodes().Get(context.Background(), "n1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := nmNode.Annotations[nodeutil.GodelSchedulerNodeAnnotationKey]; got != tt.expectedAnnotations["n1"] {
+				t.Errorf("expected nmnode n1 in partition %q, got %q", tt.expectedAnnotations["n1"], got)
+			}
+		})
+	}
+}
+
+// hasLine checks whether the output has a line consisting of the fields.
+func hasLine(output string, fields []string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if reflect.DeepEqual(strings.Fields(line), fields) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	movementstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/movement_store"
+)
+
+func newMovementsCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "movements",
+		Short: "List the movements created by the rescheduler",
+		Long: "List the movements created by the rescheduler. The movements held in the scheduler cache are listed " +
+			"as well if the scheduler address is specified.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runMovements()
+		},
+	}
+}
+
+func (o *Options) runMovements() error {
+	_, crdClient, err := o.clients()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	movements, err := crdClient.SchedulingV1alpha1().Movements().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list movements: %v", err)
+	}
+	printMovements(o.out, movements.Items)
+
+	if c := newDebugClient(componentScheduler, o.SchedulerAddress); c != nil {
+		return printCachedMovements(ctx, o.out, c)
+	}
+	return nil
+}
+
+func printMovements(out io.Writer, movements []schedulingv1alpha1.Movement) {
+	sort.Slice(movements, func(i, j int) bool { return movements[i].Name < movements[j].Name })
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "NAME\tCREATOR\tGENERATION\tDELETED-TASKS\tOWNERS\tNOTIFIED-SCHEDULERS")
+	for _, m := range movements {
+		owners := make([]string, 0, len(m.Status.Owners))
+		for _, owner := range m.Status.Owners {
+			if owner.Owner != nil {
+				owners = append(owners, fmt.Sprintf("%s/%s/%s", owner.Owner.Type, owner.Owner.Namespace, owner.Owner.Name))
+			}
+		}
+		sort.Strings(owners)
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", m.Name, orNone(m.Spec.Creator), m.Spec.Generation, len(m.Spec.DeletedTasks),
+			orNone(strings.Join(owners, ",")), orNone(strings.Join(m.Status.NotifiedSchedulers, ",")))
+	}
+	w.Flush()
+}
+
+func printCachedMovements(ctx context.Context, out io.Writer, c *debugClient) error {
+	var dump map[string]*movementstore.MovementDump
+	found, err := c.store(ctx, string(movementstore.Name), &dump)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nMovements in %s cache:\n", c.component)
+	if !found || len(dump) == 0 {
+		fmt.Fprintln(out, "  "+none)
+		return nil
+	}
+	names := make([]string, 0, len(dump))
+	for name := range dump {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "  NAME\tALGORITHM\tOWNERS\tDELETED-PODS")
+	for _, name := range names {
+		m := dump[name]
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%d\n", name, orNone(m.Algorithm), orNone(strings.Join(m.Owners, ",")), len(m.DeletedPods))
+	}
+	w.Flush()
+	return nil
+}
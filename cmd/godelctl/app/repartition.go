@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	crdclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	nodeutil "github.com/kubewharf/godel-scheduler/pkg/util/node"
+)
+
+// repartitionOptions holds the options of the repartition command.
+type repartitionOptions struct {
+	// from is the scheduler whose nodes are moved.
+	from string
+	// to is the scheduler the nodes are moved to, the nodes are released to dispatcher if it is empty.
+	to string
+	// count is the max number of nodes moved from the partition of `from`, all nodes are moved if it is not positive.
+	count int
+	// dryRun only prints the nodes to be moved.
+	dryRun bool
+	// force moves the nodes even if the partitions are left unbalanced.
+	force bool
+}
+
+func newRepartitionCommand(o *Options) *cobra.Command {
+	ro := &repartitionOptions{}
+	cmd := &cobra.Command{
+		Use:   "repartition [NODE...]",
+		Short: "Move nodes between the partitions of schedulers",
+		Long: "Move nodes between the partitions of schedulers by updating the scheduler name annotation of both Node " +
+			"and NMNode. The nodes are specified by name, or taken from the partition of --from. If --to is not " +
+			"specified, the annotation is removed and dispatcher assigns the nodes to the active scheduler with the " +
+			"least nodes. Moves that leave the partitions unbalanced are refused unless --force is specified, since " +
+			"dispatcher moves nodes back from the largest partition once it has more than twice the nodes of the smallest one.",
+		Example: "  # Move 10 nodes from scheduler-a to scheduler-b.\n" +
+			"  godelctl repartition --from scheduler-a --to scheduler-b --count 10\n\n" +
+			"  # Let dispatcher re-assign node-1 and node-2.\n" +
+			"  godelctl repartition node-1 node-2",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runRepartition(ro, args)
+		},
+	}
+	fs := cmd.Flags()
+	fs.StringVar(&ro.from, "from", ro.from, "Move the nodes in the partition of this scheduler.")
+	fs.StringVar(&ro.to, "to", ro.to, "The scheduler to move the nodes to. The nodes are re-assigned by dispatcher if it is empty.")
+	fs.IntVar(&ro.count, "count", ro.count, "The max number of nodes moved from the partition of --from, all of them if it is not positive.")
+	fs.BoolVar(&ro.dryRun, "dry-run", ro.dryRun, "Only print the nodes to be moved.")
+	fs.BoolVar(&ro.force, "force", ro.force, "Move the nodes even if the partitions are left unbalanced, dispatcher may move them back.")
+	return cmd
+}
+
+func (o *Options) runRepartition(ro *repartitionOptions, nodes []string) error {
+	if len(nodes) == 0 && len(ro.from) == 0 {
+		return fmt.Errorf("either nodes or --from should be specified")
+	}
+	if len(nodes) > 0 && len(ro.from) > 0 {
+		return fmt.Errorf("nodes and --from can not be specified at the same time")
+	}
+	if len(ro.from) > 0 && ro.from == ro.to {
+		return fmt.Errorf("--from and --to should be different schedulers")
+	}
+
+	partitions, err := o.listPartitions()
+	if err != nil {
+		return err
+	}
+	if len(ro.to) > 0 {
+		if p, ok := partitions[ro.to]; !ok || p.scheduler == nil {
+			return fmt.Errorf("scheduler %s is not found", ro.to)
+		}
+	}
+	if len(ro.from) > 0 {
+		p, ok := partitions[ro.from]
+		if !ok {
+			return fmt.Errorf("scheduler %s does not have any node", ro.from)
+		}
+		nodes = p.nodes.Union(p.nmNodes).List()
+		if ro.count > 0 && len(nodes) > ro.count {
+			nodes = nodes[:ro.count]
+		}
+	}
+
+	client, crdClient, err := o.clients()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+	return repartition(ctx, o.out, client, crdClient, partitions, nodes, ro.to, ro.dryRun, ro.force)
+}
+
+// repartition updates the scheduler name annotation of the nodes and nmnodes to the target scheduler, or removes
+// it if the target is empty, so that node shuffler of dispatcher assigns them again.
+func repartition(ctx context.Context, out io.Writer, client kubernetes.Interface, crdClient crdclient.Interface,
+	partitions map[string]*partition, nodes []string, to string, dryRun, force bool,
+) error {
+	owners := make(map[string]string)
+	for name, p := range partitions {
+		for _, node := range p.nodes.Union(p.nmNodes).UnsortedList() {
+			owners[node] = name
+		}
+	}
+
+	target := to
+	var value interface{} = to
+	if len(to) == 0 {
+		// A null value removes the annotation in json merge patch.
+		target, value = unassigned, nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{nodeutil.GodelSchedulerNodeAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	moves := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		from, ok := owners[node]
+		if !ok {
+			return fmt.Errorf("node %s is not found", node)
+		}
+		if from != target {
+			moves[node] = from
+		}
+	}
+	// The released nodes are assigned to the scheduler with the least nodes by dispatcher, only the moves to a
+	// specific scheduler may leave the partitions unbalanced.
+	if len(to) > 0 {
+		if msg := checkBalance(partitions, moves, to); len(msg) > 0 {
+			if !force && !dryRun {
+				return fmt.Errorf("%s, use --force to move the nodes anyway", msg)
+			}
+			fmt.Fprintf(out, "warning: %s\n", msg)
+		}
+	}
+
+	moved := 0
+	for _, node := range nodes {
+		from, ok := moves[node]
+		if !ok {
+			fmt.Fprintf(out, "node %s is already in partition %s\n", node, target)
+			continue
+		}
+		if !dryRun {
+			p := partitions[from]
+			if p.nodes.Has(node) {
+				if _, err := client.CoreV1().Nodes().Patch(ctx, node, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !errors.IsNotFound(err) {
+					return fmt.Errorf("failed to update node %s: %v", node, err)
+				}
+			}
+			if p.nmNodes.Has(node) {
+				if _, err := crdClient.NodeV1alpha1().NMNodes().Patch(ctx, node, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil && !errors.IsNotFound(err) {
+					return fmt.Errorf("failed to update nmnode %s: %v", node, err)
+				}
+			}
+		}
+		moved++
+		fmt.Fprintf(out, "node %s: %s -> %s\n", node, from, target)
+	}
+	if dryRun {
+		fmt.Fprintf(out, "%d nodes would be moved (dry run)\n", moved)
+	} else {
+		fmt.Fprintf(out, "%d nodes moved\n", moved)
+	}
+	return nil
+}
+
+// checkBalance returns a message if the partitions of active schedulers are left unbalanced by the moves, in the
+// same way as the re-balancing of node shuffler in dispatcher, which moves the nodes back in that case.
+func checkBalance(partitions map[string]*partition, moves map[string]string, to string) string {
+	sizes := make(map[string]int)
+	for name, p := range partitions {
+		if p.scheduler != nil && p.scheduler.Status.Phase == schedulingv1alpha1.Active {
+			sizes[name] = p.nodes.Union(p.nmNodes).Len()
+		}
+	}
+	for _, from := range moves {
+		if _, ok := sizes[from]; ok {
+			sizes[from]--
+		}
+	}
+	if _, ok := sizes[to]; ok {
+		sizes[to] += len(moves)
+	}
+
+	var most, least string
+	for name, size := range sizes {
+		if len(most) == 0 || size > sizes[most] || (size == sizes[most] && name < most) {
+			most = name
+		}
+		if len(least) == 0 || size < sizes[least] || (size == sizes[least] && name < least) {
+			least = name
+		}
+	}
+	if len(most) == 0 || sizes[most] <= sizes[least]*2 || sizes[most] <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("scheduler %s would have %d nodes and scheduler %s would have %d nodes, dispatcher moves nodes back "+
+		"once the largest partition has more than twice the nodes of the smallest one", most, sizes[most], least, sizes[least])
+}
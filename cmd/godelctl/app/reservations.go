@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	reservationstorage "github.com/kubewharf/godel-scheduler/pkg/common/storage/reservation"
+	reservationstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/reservation_store"
+)
+
+func newReservationsCommand(o *Options) *cobra.Command {
+	var allNamespaces bool
+	cmd := &cobra.Command{
+		Use:   "reservations",
+		Short: "List the active reservations",
+		Long: "List the active reservations. The Reservation objects are listed, and the reservations held in the " +
+			"caches of scheduler and binder are listed as well if their addresses are specified.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runReservations(allNamespaces)
+		},
+	}
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", allNamespaces, "List the reservations across all namespaces.")
+	return cmd
+}
+
+func (o *Options) runReservations(allNamespaces bool) error {
+	_, crdClient, err := o.clients()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	namespace := o.Namespace
+	if allNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+	reservations, err := crdClient.SchedulingV1alpha1().Reservations(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list reservations: %v", err)
+	}
+	printReservations(o.out, activeReservations(reservations.Items))
+
+	for _, c := range []*debugClient{newDebugClient(componentScheduler, o.SchedulerAddress), newDebugClient(componentBinder, o.BinderAddress)} {
+		if c == nil {
+			continue
+		}
+		if err := printCachedReservations(ctx, o.out, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// activeReservations filters out the reservations that are timeout or matched, which no longer hold resources.
+func activeReservations(reservations []schedulingv1alpha1.Reservation) []schedulingv1alpha1.Reservation {
+	var ret []schedulingv1alpha1.Reservation
+	for _, r := range reservations {
+		switch r.Status.Phase {
+		case schedulingv1alpha1.ReservationTimeOut, schedulingv1alpha1.ReservationMatched:
+			continue
+		}
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func printReservations(out io.Writer, reservations []schedulingv1alpha1.Reservation) {
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tNODE\tPHASE\tTTL\tCREATED")
+	for _, r := range reservations {
+		ttl := none
+		if r.Spec.TimeToLive != nil {
+			ttl = strconv.FormatInt(*r.Spec.TimeToLive, 10) + "s"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.Namespace, r.Name, orNone(r.Spec.NodeName), orNone(string(r.Status.Phase)), ttl,
+			r.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+	w.Flush()
+}
+
+func printCachedReservations(ctx context.Context, out io.Writer, c *debugClient) error {
+	var dump map[string][]reservationstorage.ReservationInfoDump
+	found, err := c.store(ctx, string(reservationstore.Name), &dump)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nReservations in %s cache:\n", c.component)
+	if !found {
+		fmt.Fprintln(out, "  "+none)
+		return nil
+	}
+	nodes := make([]string, 0, len(dump))
+	for node := range dump {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "  NODE\tPLACEHOLDER\tPLACEHOLDER-POD\tMATCHED-POD\tCREATED")
+	for _, node := range nodes {
+		for _, info := range dump[node] {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", node, info.Placeholder, info.PlaceholderPod, orNone(info.MatchedPod),
+				info.CreateTime.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+	}
+	w.Flush()
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	nodev1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/node/v1alpha1"
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	nodeutil "github.com/kubewharf/godel-scheduler/pkg/util/node"
+)
+
+// unassigned is the partition of the nodes without scheduler name annotation.
+const unassigned = "<unassigned>"
+
+// partition is the nodes in the partition of one scheduler.
+type partition struct {
+	scheduler *schedulingv1alpha1.Scheduler
+	nodes     sets.String
+	nmNodes   sets.String
+}
+
+func newSchedulersCommand(o *Options) *cobra.Command {
+	var showNodes bool
+	cmd := &cobra.Command{
+		Use:   "schedulers",
+		Short: "List the schedulers and their node partitions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runSchedulers(showNodes)
+		},
+	}
+	cmd.Flags().BoolVar(&showNodes, "show-nodes", showNodes, "Also list the nodes in each partition.")
+	return cmd
+}
+
+func (o *Options) runSchedulers(showNodes bool) error {
+	partitions, err := o.listPartitions()
+	if err != nil {
+		return err
+	}
+	printPartitions(o.out, partitions, showNodes)
+	return nil
+}
+
+// listPartitions lists the schedulers and groups the nodes and nmnodes by their scheduler name annotations.
+func (o *Options) listPartitions() (map[string]*partition, error) {
+	client, crdClient, err := o.clients()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	schedulers, err := crdClient.SchedulingV1alpha1().Schedulers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedulers: %v", err)
+	}
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
+	}
+	nmNodes, err := crdClient.NodeV1alpha1().NMNodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nmnodes: %v", err)
+	}
+	return groupPartitions(schedulers.Items, nodes.Items, nmNodes.Items), nil
+}
+
+// groupPartitions groups the nodes by scheduler, the nodes of the schedulers that do not exist are still
+// grouped by their scheduler names so that the stale partitions are visible.
+func groupPartitions(schedulers []schedulingv1alpha1.Scheduler, nodes []v1.Node, nmNodes []nodev1alpha1.NMNode) map[string]*partition {
+	partitions := make(map[string]*partition, len(schedulers)+1)
+	getPartition := func(name string) *partition {
+		if len(name) == 0 {
+			name = unassigned
+		}
+		p, ok := partitions[name]
+		if !ok {
+			p = &partition{nodes: sets.NewString(), nmNodes: sets.NewString()}
+			partitions[name] = p
+		}
+		return p
+	}
+	for i := range schedulers {
+		getPartition(schedulers[i].Name).scheduler = &schedulers[i]
+	}
+	for _, node := range nodes {
+		getPartition(node.Annotations[nodeutil.GodelSchedulerNodeAnnotationKey]).nodes.Insert(node.Name)
+	}
+	for _, nmNode := range nmNodes {
+		getPartition(nmNode.Annotations[nodeutil.GodelSchedulerNodeAnnotationKey]).nmNodes.Insert(nmNode.Name)
+	}
+	return partitions
+}
+
+func printPartitions(out io.Writer, partitions map[string]*partition, showNodes bool) {
+	names := make([]string, 0, len(partitions))
+	for name := range partitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := newTabWriter(out)
+	fmt.Fprintln(w, "NAME\tPHASE\tHOST\tLAST-UPDATE\tNODES\tNMNODES")
+	for _, name := range names {
+		p := partitions[name]
+		phase, host, lastUpdate := none, none, none
+		if p.scheduler != nil {
+			phase, host = orNone(string(p.scheduler.Status.Phase)), orNone(p.scheduler.Status.CurrentHost)
+			if t := p.scheduler.Status.LastUpdateTime; t != nil {
+				lastUpdate = t.UTC().Format("2006-01-02T15:04:05Z")
+			}
+		} else if name != unassigned {
+			phase = "NotFound"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\n", name, phase, host, lastUpdate, p.nodes.Len(), p.nmNodes.Len())
+	}
+	w.Flush()
+
+	if !showNodes {
+		return
+	}
+	fmt.Fprintln(out)
+	w = newTabWriter(out)
+	fmt.Fprintln(w, "SCHEDULER\tNODE\tKIND")
+	for _, name := range names {
+		p := partitions[name]
+		for _, node := range p.nodes.Union(p.nmNodes).List() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", name, node, nodeKind(p, node))
+		}
+	}
+	w.Flush()
+}
+
+// nodeKind is consistent with the kinds of the node partition size metrics of dispatcher.
+func nodeKind(p *partition, node string) string {
+	switch {
+	case p.nodes.Has(node) && p.nmNodes.Has(node):
+		return "hybrid"
+	case p.nmNodes.Has(node):
+		return "nmnode"
+	default:
+		return "node"
+	}
+}
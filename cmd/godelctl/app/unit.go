@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
+	frameworkutils "github.com/kubewharf/godel-scheduler/pkg/framework/utils"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+// The components that a pod goes through, the pod is handled by one of them according to its pod state.
+const (
+	componentDispatcher = "dispatcher"
+	componentScheduler  = "scheduler"
+	componentBinder     = "binder"
+	componentNone       = "-"
+)
+
+// podStage is the lifecycle stage of a pod.
+type podStage struct {
+	// Component is the component that is responsible for the pod.
+	Component string
+	// State is the pod state, it is Bound if the pod is bound and Abnormal if the annotations are inconsistent.
+	State string
+	// Scheduler is the scheduler that the pod is dispatched to.
+	Scheduler string
+	// Node is the node the pod is bound, assumed or nominated to.
+	Node string
+	// Nominated means the pod is waiting for the victims on the nominated node to be preempted.
+	Nominated bool
+}
+
+// getPodStage derives the lifecycle stage from the pod state annotations, which are the protocol of
+// dispatcher, scheduler and binder to hand over pods.
+func getPodStage(pod *v1.Pod) podStage {
+	stage := podStage{Scheduler: podutil.GetSchedulerNameForPod(pod)}
+	switch {
+	case podutil.BoundPod(pod):
+		stage.Component, stage.State, stage.Node = componentNone, "Bound", pod.Spec.NodeName
+	case podutil.AbnormalPodState(pod):
+		stage.Component, stage.State = componentNone, "Abnormal"
+	case podutil.AssumedPod(pod):
+		stage.Component, stage.State = componentBinder, string(podutil.PodAssumed)
+		if stage.Node = pod.Annotations[podutil.AssumedNodeAnnotationKey]; len(stage.Node) == 0 {
+			stage.Node, stage.Nominated = nominatedNodeName(pod), true
+		}
+	case podutil.DispatchedPod(pod):
+		stage.Component, stage.State = componentScheduler, string(podutil.PodDispatched)
+	default:
+		stage.Component, stage.State = componentDispatcher, string(podutil.PodPending)
+	}
+	return stage
+}
+
+// nominatedNodeName returns the node name in the nominated node annotation, or the raw value if it can not be parsed.
+func nominatedNodeName(pod *v1.Pod) string {
+	nominatedNode, err := frameworkutils.GetPodNominatedNode(pod)
+	if err != nil || nominatedNode == nil {
+		return pod.Annotations[podutil.NominatedNodeAnnotationKey]
+	}
+	return nominatedNode.NodeName
+}
+
+// unit is a PodGroup with its pods, or a single pod without PodGroup.
+type unit struct {
+	podGroup *schedulingv1alpha1.PodGroup
+	pods     []*v1.Pod
+}
+
+func (u *unit) key() string {
+	if u.podGroup != nil {
+		return u.podGroup.Namespace + "/" + u.podGroup.Name
+	}
+	return podutil.GetPodKey(u.pods[0])
+}
+
+func (u *unit) annotations() map[string]string {
+	if u.podGroup != nil {
+		return u.podGroup.Annotations
+	}
+	return u.pods[0].Annotations
+}
+
+func newUnitCommand(o *Options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unit NAME",
+		Short: "Show the lifecycle state of a unit across dispatcher, scheduler and binder",
+		Long: "Show the lifecycle state of a unit across dispatcher, scheduler and binder. NAME is the name of a PodGroup, " +
+			"or a pod which is shown together with the other pods of its PodGroup. The scheduler and binder caches are " +
+			"checked as well if their addresses are specified.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.runUnit(args[0])
+		},
+	}
+}
+
+func (o *Options) runUnit(name string) error {
+	client, crdClient, err := o.clients()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := o.context()
+	defer cancel()
+
+	u, err := o.getUnit(ctx, client, crdClient.SchedulingV1alpha1().PodGroups(o.Namespace).Get, name)
+	if err != nil {
+		return err
+	}
+	caches, err := o.getAssumedPods(ctx)
+	if err != nil {
+		return err
+	}
+	printUnit(o.out, u, caches)
+	return nil
+}
+
+type getPodGroupFunc func(ctx context.Context, name string, opts metav1.GetOptions) (*schedulingv1alpha1.PodGroup, error)
+
+// getUnit gets the PodGroup of the name, or the unit of the pod of the name if the PodGroup is not found.
+func (o *Options) getUnit(ctx context.Context, client kubernetes.Interface, getPodGroup getPodGroupFunc, name string) (*unit, error) {
+	pg, err := getPodGroup(ctx, name, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get PodGroup %s/%s: %v", o.Namespace, name, err)
+	}
+	if err != nil {
+		pod, podErr := client.CoreV1().Pods(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(podErr) {
+			return nil, fmt.Errorf("neither PodGroup nor pod %s/%s is found", o.Namespace, name)
+		} else if podErr != nil {
+			return nil, fmt.Errorf("failed to get pod %s/%s: %v", o.Namespace, name, podErr)
+		}
+		pgName := podutil.GetPodGroupName(pod)
+		if len(pgName) == 0 {
+			return &unit{pods: []*v1.Pod{pod}}, nil
+		}
+		if pg, err = getPodGroup(ctx, pgName, metav1.GetOptions{}); errors.IsNotFound(err) {
+			// The pods are held by dispatcher until the PodGroup is created.
+			return &unit{pods: []*v1.Pod{pod}}, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get PodGroup %s/%s: %v", o.Namespace, pgName, err)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(o.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	u := &unit{podGroup: pg}
+	for i := range pods.Items {
+		if podutil.GetPodGroupName(&pods.Items[i]) == pg.Name {
+			u.pods = append(u.pods, &pods.Items[i])
+		}
+	}
+	sort.Slice(u.pods, func(i, j int) bool { return u.pods[i].Name < u.pods[j].Name })
+	return u, nil
+}
+
+// assumedPodsInCaches is the assumed pods in the caches of components keyed by pod uid, a component is absent
+// if its address is not specified.
+type assumedPodsInCaches map[string]map[string]*commondebugger.AssumedPodSummary
+
+func (o *Options) getAssumedPods(ctx context.Context) (assumedPodsInCaches, error) {
+	caches := assumedPodsInCaches{}
+	for _, c := range []*debugClient{newDebugClient(componentScheduler, o.SchedulerAddress), newDebugClient(componentBinder, o.BinderAddress)} {
+		if c == nil {
+			continue
+		}
+		assumedPods, err := c.assumedPods(ctx)
+		if err != nil {
+			return nil, err
+		}
+		caches[c.component] = assumedPods
+	}
+	return caches, nil
+}
+
+func printUnit(out io.Writer, u *unit, caches assumedPodsInCaches) {
+	if pg := u.podGroup; pg != nil {
+		fmt.Fprintf(out, "PodGroup:\t%s\n", u.key())
+		fmt.Fprintf(out, "Phase:\t\t%s\n", orNone(string(pg.Status.Phase)))
+		fmt.Fprintf(out, "MinMember:\t%d\n", pg.Spec.MinMember)
+		if roleMinMember := pg.Annotations[podutil.RoleMinMemberAnnotationKey]; len(roleMinMember) > 0 {
+			fmt.Fprintf(out, "RoleMinMember:\t%s\n", roleMinMember)
+		}
+		if dependsOn := pg.Annotations[unitutil.DependsOnAnnotationKey]; len(dependsOn) > 0 {
+			fmt.Fprintf(out, "DependsOn:\t%s\n", dependsOn)
+		}
+		if len(pg.Status.Conditions) > 0 {
+			fmt.Fprintln(out, "Conditions:")
+			w := newTabWriter(out)
+			fmt.Fprintln(w, "  PHASE\tSTATUS\tREASON\tMESSAGE\tLAST-TRANSITION")
+			for _, cond := range pg.Status.Conditions {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", cond.Phase, cond.Status, orNone(cond.Reason), orNone(cond.Message),
+					cond.LastTransitionTime.UTC().Format("2006-01-02T15:04:05Z"))
+			}
+			w.Flush()
+		}
+	} else {
+		fmt.Fprintf(out, "Pod:\t\t%s (without PodGroup)\n", u.key())
+	}
+
+	fmt.Fprintln(out, "Pods:")
+	if len(u.pods) == 0 {
+		fmt.Fprintln(out, "  "+none)
+		return
+	}
+	components := make([]string, 0, len(caches))
+	for _, component := range []string{componentScheduler, componentBinder} {
+		if _, ok := caches[component]; ok {
+			components = append(components, component)
+		}
+	}
+	w := newTabWriter(out)
+	header := "  NAME\tCOMPONENT\tSTATE\tSCHEDULER\tNODE\tFAILED-SCHEDULERS"
+	for _, component := range components {
+		header += "\t" + strings.ToUpper(component) + "-CACHE"
+	}
+	fmt.Fprintln(w, header)
+	for _, pod := range u.pods {
+		stage := getPodStage(pod)
+		node := orNone(stage.Node)
+		if stage.Nominated {
+			node += " (nominated)"
+		}
+		line := fmt.Sprintf("  %s\t%s\t%s\t%s\t%s\t%s", pod.Name, stage.Component, stage.State, orNone(stage.Scheduler), node,
+			orNone(pod.Annotations[podutil.FailedSchedulersAnnotationKey]))
+		for _, component := range components {
+			if _, ok := caches[component][string(pod.UID)]; ok {
+				line += "\tassumed"
+			} else {
+				line += "\t" + none
+			}
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+}
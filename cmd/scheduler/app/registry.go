@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	godelscheduler "github.com/kubewharf/godel-scheduler/pkg/scheduler"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores"
+	schedulerframework "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework"
+	godelqueue "github.com/kubewharf/godel-scheduler/pkg/scheduler/queue"
+)
+
+// Option registers out-of-tree extensions, it is used by a downstream `main`
+// to build a scheduler with extra plugins and stores.
+type Option func(*outOfTreeRegistries) error
+
+type outOfTreeRegistries struct {
+	plugins           schedulerframework.Registry
+	unitPlugins       schedulerframework.UnitRegistry
+	preemptionPlugins schedulerframework.Registry
+	queueSortPlugins  godelqueue.UnitSortPluginRegistry
+}
+
+func newOutOfTreeRegistries(opts ...Option) (*outOfTreeRegistries, error) {
+	r := &outOfTreeRegistries{
+		plugins:           schedulerframework.Registry{},
+		unitPlugins:       schedulerframework.UnitRegistry{},
+		preemptionPlugins: schedulerframework.Registry{},
+		queueSortPlugins:  godelqueue.UnitSortPluginRegistry{},
+	}
+	for _, opt := range opts {
+		if err := opt(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func (r *outOfTreeRegistries) schedulerOptions() []godelscheduler.Option {
+	return []godelscheduler.Option{
+		godelscheduler.WithFrameworkOutOfTreeRegistry(r.plugins),
+		godelscheduler.WithUnitFrameworkOutOfTreeRegistry(r.unitPlugins),
+		godelscheduler.WithPreemptionOutOfTreeRegistry(r.preemptionPlugins),
+		godelscheduler.WithUnitQueueSortOutOfTreeRegistry(r.queueSortPlugins),
+	}
+}
+
+// WithPlugin registers an out-of-tree pod plugin.
+func WithPlugin(name string, factory schedulerframework.PluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.plugins.Merge(schedulerframework.Registry{name: factory})
+	}
+}
+
+// WithUnitPlugin registers an out-of-tree unit plugin.
+func WithUnitPlugin(name string, factory schedulerframework.UnitPluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.unitPlugins.Merge(schedulerframework.UnitRegistry{name: factory})
+	}
+}
+
+// WithPreemptionPlugin registers an out-of-tree preemption plugin.
+func WithPreemptionPlugin(name string, factory schedulerframework.PluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.preemptionPlugins.Merge(schedulerframework.Registry{name: factory})
+	}
+}
+
+// WithQueueSortPlugin registers an out-of-tree unit queue sort plugin.
+func WithQueueSortPlugin(name string, factory godelqueue.SortPluginFactory) Option {
+	return func(r *outOfTreeRegistries) error {
+		return r.queueSortPlugins.Merge(godelqueue.UnitSortPluginRegistry{name: factory})
+	}
+}
+
+// WithStore registers an out-of-tree common store of the scheduler cache.
+func WithStore(name commonstore.StoreName, checker commonstore.FeatureGateChecker, newCache, newSnapshot commonstore.New) Option {
+	return func(_ *outOfTreeRegistries) error {
+		if err := commonstores.GlobalRegistries.RegisterOutOfTree(name, checker, newCache, newSnapshot); err != nil {
+			return fmt.Errorf("failed to register store: %v", err)
+		}
+		return nil
+	}
+}
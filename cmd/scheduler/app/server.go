@@ -30,9 +30,12 @@ import (
 	schedulerserverconfig "github.com/kubewharf/godel-scheduler/cmd/scheduler/app/config"
 	"github.com/kubewharf/godel-scheduler/cmd/scheduler/app/options"
 	"github.com/kubewharf/godel-scheduler/cmd/scheduler/app/util/configz"
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	godelscheduler "github.com/kubewharf/godel-scheduler/pkg/scheduler"
 	godelschedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	cmdutil "github.com/kubewharf/godel-scheduler/pkg/util/cmd"
+	"github.com/kubewharf/godel-scheduler/pkg/util/podmetrics"
 	routeutil "github.com/kubewharf/godel-scheduler/pkg/util/route"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 	"github.com/kubewharf/godel-scheduler/pkg/version/verflag"
@@ -57,7 +60,9 @@ import (
 
 const ComponentName = "scheduler"
 
-func NewGodelSchedulerCmd() *cobra.Command {
+// NewGodelSchedulerCmd creates the scheduler command, registryOptions are used to
+// register out-of-tree plugins and stores.
+func NewGodelSchedulerCmd(registryOptions ...Option) *cobra.Command {
 	opts, err := options.NewOptions()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "unable to initialize command options: %v\n", err)
@@ -77,7 +82,7 @@ of that hardware is not par during off-peak load. Primary goal for godel
 scheduler is to harvest the underutilized resources from the online and 
 streaming workloads by collocating the batch workloads.`,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := runCommand(cmd, opts, args); err != nil {
+			if err := runCommand(cmd, opts, args, registryOptions...); err != nil {
 				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
 			}
@@ -108,7 +113,7 @@ streaming workloads by collocating the batch workloads.`,
 	return godelSchedulerCmd
 }
 
-func runCommand(cmd *cobra.Command, opts *options.Options, args []string) error {
+func runCommand(cmd *cobra.Command, opts *options.Options, args []string, registryOptions ...Option) error {
 	cmdutil.InitKlogV2WithV1Flags(cmd.Flags())
 	verflag.PrintAndExitIfRequested()
 	if len(args) != 0 {
@@ -149,18 +154,38 @@ func runCommand(cmd *cobra.Command, opts *options.Options, args []string) error
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	return Run(ctx, cc)
+	return Run(ctx, cc, registryOptions...)
 }
 
-func Run(ctx context.Context, cc schedulerserverconfig.CompletedConfig) error {
+func Run(ctx context.Context, cc schedulerserverconfig.CompletedConfig, registryOptions ...Option) error {
 	err := cc.ComponentConfig.Tracer.Validate()
 	if err != nil {
 		return err
 	}
+	if err := framework.SetOvercommitPolicies(cc.ComponentConfig.OvercommitPolicies); err != nil {
+		return err
+	}
+
+	outOfTreeRegistries, err := newOutOfTreeRegistries(registryOptions...)
+	if err != nil {
+		return err
+	}
 
 	eventRecorder := getEventRecorder(&cc)
 
 	// Create the scheduler.
+	schedulerOptions := []godelscheduler.Option{
+		godelscheduler.WithDefaultProfile(cc.ComponentConfig.DefaultProfile),
+		godelscheduler.WithSubClusterProfiles(cc.ComponentConfig.SubClusterProfiles),
+		godelscheduler.WithRenewInterval(cc.ComponentConfig.SchedulerRenewIntervalSeconds),
+		godelscheduler.WithSubClusterKey(*cc.ComponentConfig.SubClusterKey),
+		godelscheduler.WithCacheComparer(time.Duration(cc.ComponentConfig.CacheComparePeriodSeconds)*time.Second, cc.ComponentConfig.EnableCacheSelfHealing),
+	}
+	if cc.ComponentConfig.PodMetricsSamplePeriodSeconds > 0 {
+		schedulerOptions = append(schedulerOptions, godelscheduler.WithPodMetricsLister(
+			podmetrics.NewLister(cc.Client.Discovery().RESTClient()), time.Duration(cc.ComponentConfig.PodMetricsSamplePeriodSeconds)*time.Second))
+	}
+	schedulerOptions = append(schedulerOptions, outOfTreeRegistries.schedulerOptions()...)
 	sched, err := godelscheduler.New(
 		cc.ComponentConfig.GodelSchedulerName,
 		cc.ComponentConfig.SchedulerName,
@@ -172,10 +197,7 @@ func Run(ctx context.Context, cc schedulerserverconfig.CompletedConfig) error {
 		ctx.Done(),
 		eventRecorder,
 		time.Duration(cc.ComponentConfig.ReservationTimeOutSeconds)*time.Second,
-		godelscheduler.WithDefaultProfile(cc.ComponentConfig.DefaultProfile),
-		godelscheduler.WithSubClusterProfiles(cc.ComponentConfig.SubClusterProfiles),
-		godelscheduler.WithRenewInterval(cc.ComponentConfig.SchedulerRenewIntervalSeconds),
-		godelscheduler.WithSubClusterKey(*cc.ComponentConfig.SubClusterKey),
+		schedulerOptions...,
 	)
 	if err != nil {
 		return err
@@ -193,19 +215,19 @@ func Run(ctx context.Context, cc schedulerserverconfig.CompletedConfig) error {
 	// Start up the healthz server.
 	if cc.InsecureServing != nil {
 		separateMetrics := cc.InsecureMetricsServing != nil
-		handler := buildHandlerChain(newHealthzHandler(&cc.ComponentConfig, separateMetrics, checks...), nil, nil)
+		handler := buildHandlerChain(newHealthzHandler(&cc.ComponentConfig, sched.CacheInspector(), separateMetrics, checks...), nil, nil)
 		if err := cc.InsecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start healthz server: %v", err)
 		}
 	}
 	if cc.InsecureMetricsServing != nil {
-		handler := buildHandlerChain(newMetricsHandler(&cc.ComponentConfig), nil, nil)
+		handler := buildHandlerChain(newMetricsHandler(&cc.ComponentConfig, sched.CacheInspector()), nil, nil)
 		if err := cc.InsecureMetricsServing.Serve(handler, 0, ctx.Done()); err != nil {
 			return fmt.Errorf("failed to start metrics server: %v", err)
 		}
 	}
 	if cc.SecureServing != nil {
-		handler := buildHandlerChain(newHealthzHandler(&cc.ComponentConfig, false, checks...), cc.Authentication.Authenticator, cc.Authorization.Authorizer)
+		handler := buildHandlerChain(newHealthzHandler(&cc.ComponentConfig, sched.CacheInspector(), false, checks...), cc.Authentication.Authenticator, cc.Authorization.Authorizer)
 		// TODO: handle stoppedCh returned by c.SecureServing.Serve
 		if _, _, err := cc.SecureServing.Serve(handler, 0, ctx.Done()); err != nil {
 			// fail early for secure handlers, removing the old error loop from above
@@ -296,7 +318,7 @@ func installMetricHandler(pathRecorderMux *mux.PathRecorderMux) {
 }
 
 // newMetricsHandler builds a metrics server from the config.
-func newMetricsHandler(config *godelschedulerconfig.GodelSchedulerConfiguration) http.Handler {
+func newMetricsHandler(config *godelschedulerconfig.GodelSchedulerConfiguration, inspector *commondebugger.CacheInspector) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux(ComponentName)
 	installMetricHandler(pathRecorderMux)
 	if *config.EnableProfiling {
@@ -306,13 +328,16 @@ func newMetricsHandler(config *godelschedulerconfig.GodelSchedulerConfiguration)
 		}
 		routeutil.DebugFlags{}.Install(pathRecorderMux, "v", routeutil.StringFlagHandler(routeutil.GlogSetter, routeutil.GlogGetter))
 	}
+	if config.EnableCacheInspection {
+		inspector.Install(pathRecorderMux)
+	}
 	return pathRecorderMux
 }
 
 // newHealthzHandler creates a healthz server from the config, and will also
 // embed the metrics handler if the healthz and metrics address configurations
 // are the same.
-func newHealthzHandler(config *godelschedulerconfig.GodelSchedulerConfiguration, separateMetrics bool, checks ...healthz.HealthChecker) http.Handler {
+func newHealthzHandler(config *godelschedulerconfig.GodelSchedulerConfiguration, inspector *commondebugger.CacheInspector, separateMetrics bool, checks ...healthz.HealthChecker) http.Handler {
 	pathRecorderMux := mux.NewPathRecorderMux(ComponentName)
 	healthz.InstallHandler(pathRecorderMux, checks...)
 	if !separateMetrics {
@@ -325,6 +350,9 @@ func newHealthzHandler(config *godelschedulerconfig.GodelSchedulerConfiguration,
 		}
 		routeutil.DebugFlags{}.Install(pathRecorderMux, "v", routeutil.StringFlagHandler(routeutil.GlogSetter, routeutil.GlogGetter))
 	}
+	if config.EnableCacheInspection {
+		inspector.Install(pathRecorderMux)
+	}
 	return pathRecorderMux
 }
 
@@ -27,6 +27,12 @@ import (
 const (
 	DefaultPreemptMinIntervalSeconds int64 = 0
 
+	// DefaultPreemptionBudgetWindowSeconds is the default time window of preemption budget.
+	DefaultPreemptionBudgetWindowSeconds int64 = 600
+	// MaxPreemptionBudgetWindowSeconds is the max time window of preemption budget, preemption records
+	// older than it will be cleaned up.
+	MaxPreemptionBudgetWindowSeconds int64 = 3600
+
 	// NamespaceSystem is the system namespace where we place godel components.
 	NamespaceSystem = "godel-system"
 
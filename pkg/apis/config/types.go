@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// OvercommitPolicy describes how the allocatable taken from Node/NMNode/CNR is transformed. The policies are
+// configured by name in both scheduler and binder, and selected by the label of Node/NMNode.
+// +k8s:deepcopy-gen=true
+type OvercommitPolicy struct {
+	// Name is the unique name of the policy, it is the value of the node label selecting the policy.
+	Name string `json:"name"`
+	// GuaranteedRatios scales the guaranteed allocatable by resource name. Missing resources are not scaled.
+	GuaranteedRatios map[v1.ResourceName]float64 `json:"guaranteedRatios,omitempty"`
+	// BestEffortRatios scales the best-effort allocatable by resource name. Missing resources are not scaled.
+	BestEffortRatios map[v1.ResourceName]float64 `json:"bestEffortRatios,omitempty"`
+	// BestEffortFromSlack derives the best-effort cpu/memory allocatable from the slack between the guaranteed
+	// allocatable and the guaranteed usage reported by CNR, instead of the CNR allocatable.
+	BestEffortFromSlack bool `json:"bestEffortFromSlack,omitempty"`
+}
+
+// ValidateOvercommitPolicies validates the names and the ratios of the overcommit policies.
+func ValidateOvercommitPolicies(path *field.Path, policies []OvercommitPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, policy := range policies {
+		policyPath := path.Index(i)
+		if len(policy.Name) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("name"), "overcommit policy name must be set"))
+		} else if names.Has(policy.Name) {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), policy.Name))
+		}
+		names.Insert(policy.Name)
+		for name, ratio := range policy.GuaranteedRatios {
+			if ratio <= 0 {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("guaranteedRatios").Key(string(name)), ratio, "must be greater than 0"))
+			}
+		}
+		for name, ratio := range policy.BestEffortRatios {
+			if ratio <= 0 {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("bestEffortRatios").Key(string(name)), ratio, "must be greater than 0"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// PreemptionClass is a group of pods in the preemption policy matrix, it is configured in both scheduler and binder.
+// +k8s:deepcopy-gen=true
+type PreemptionClass struct {
+	// Name is the unique name of the class.
+	Name string `json:"name"`
+	// PriorityClassNames are the PriorityClasses of the pods belonging to this class.
+	PriorityClassNames []string `json:"priorityClassNames,omitempty"`
+	// WorkloadClasses are the values of pod annotation `godel.bytedance.com/workload-class`
+	// of the pods belonging to this class. The annotation is only used for the pods whose
+	// PriorityClass does not belong to any class, since it could be set by any pod author.
+	WorkloadClasses []string `json:"workloadClasses,omitempty"`
+}
+
+// PreemptionRule declares which classes the pods of the preemptor class could preempt.
+// +k8s:deepcopy-gen=true
+type PreemptionRule struct {
+	// Preemptor is the name of the preemptor class.
+	Preemptor string `json:"preemptor"`
+	// Victims are the names of classes which could be preempted by the preemptor class.
+	Victims []string `json:"victims,omitempty"`
+	// MaxPriorityGap is the max difference between the priority of preemptor and victim.
+	// Nil means no limitation.
+	MaxPriorityGap *int64 `json:"maxPriorityGap,omitempty"`
+	// AllowCrossResourceType indicates whether the preemptor could preempt victims of a
+	// different resource type (guaranteed vs best-effort).
+	AllowCrossResourceType bool `json:"allowCrossResourceType,omitempty"`
+}
@@ -25,6 +25,7 @@ import (
 	componentbaseconfig "k8s.io/component-base/config/v1alpha1"
 	"sigs.k8s.io/yaml"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
 
@@ -64,7 +65,35 @@ type GodelBinderConfiguration struct {
 	// reserved resources will be released after a period of time.
 	ReservationTimeOutSeconds int64
 
+	// CacheComparePeriodSeconds is the period for comparing the binder cache with informers in
+	// the background, the differences will be reported as metrics and events. 0 disables it.
+	CacheComparePeriodSeconds int64
+	// EnableCacheSelfHealing resyncs the objects missed or left behind by the binder cache
+	// when they are found by the background cache comparer.
+	EnableCacheSelfHealing bool
+	// EnableCacheInspection exposes the cache inspection endpoints, e.g. /debug/cache/nodes, on the
+	// healthz and metrics servers regardless of profiling.
+	EnableCacheInspection bool
+
+	// SchedulingSLOThresholdSeconds are the SLO thresholds of pod lifecycle stages keyed by stage name,
+	// a pod whose stage latency exceeds the threshold will be reported as SLO breach by metrics and events.
+	// Pending pods are also checked periodically against the threshold of their current stage.
+	// Stages without threshold are not checked.
+	SchedulingSLOThresholdSeconds map[string]int64
+
+	// OvercommitPolicies are the named policies transforming the allocatable of nodes, a node selects its policy
+	// by the label godel.bytedance.com/overcommit-policy. They should be the same as the ones of the scheduler.
+	OvercommitPolicies []defaultsconfig.OvercommitPolicy
+
+	// SubClusterKey is the label key of nodes which partitions the cluster into sub-clusters,
+	// the sub-cluster of a pod is the value of this key in pod.Spec.NodeSelector.
+	SubClusterKey *string
+
+	// Profile is the default binder profile.
 	Profile *GodelBinderProfile `json:"profile"`
+	// SubClusterProfiles are binder profiles of specific sub-clusters, a sub-cluster without its
+	// own profile uses the default profile.
+	SubClusterProfiles []GodelBinderProfile `json:"subClusterProfiles,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -73,6 +102,9 @@ type GodelBinderConfiguration struct {
 type GodelBinderProfile struct {
 	metav1.TypeMeta `json:",inline"`
 
+	// SubClusterName associates the profile to a sub-cluster, it is only used by SubClusterProfiles.
+	SubClusterName string `json:"subClusterName,omitempty"`
+
 	Plugins *Plugins `json:"plugins"`
 
 	// PluginConfigs is an optional set of custom plugin arguments for each plugin.
@@ -82,6 +114,27 @@ type GodelBinderProfile struct {
 	PluginConfigs           []PluginConfig `json:"pluginConfigs,omitempty"`
 }
 
+// Pod lifecycle stages tracked by binder, they are the valid keys of SchedulingSLOThresholdSeconds.
+const (
+	// PendingStage is from pod creation to the time the pod is first handled by Godel Scheduler.
+	PendingStage = "pending"
+	// DispatchingStage is from the time the pod is first handled to the time it is dispatched to a scheduler.
+	DispatchingStage = "dispatching"
+	// QueueingStage is from dispatching to the start of the successful scheduling attempt.
+	QueueingStage = "queueing"
+	// SchedulingStage is the successful scheduling attempt until the pod is assumed by scheduler.
+	SchedulingStage = "scheduling"
+	// PreemptionWaitStage is from the first preemption attempt in binder to the pod is bound.
+	PreemptionWaitStage = "preemption_wait"
+	// BindingStage is from the pod is assumed by scheduler to the pod is bound, excluding preemption wait.
+	BindingStage = "binding"
+	// E2EStage is from the time the pod is first handled by Godel Scheduler to the pod is bound.
+	E2EStage = "e2e"
+)
+
+// LifecycleStages lists all pod lifecycle stages tracked by binder.
+var LifecycleStages = []string{PendingStage, DispatchingStage, QueueingStage, SchedulingStage, PreemptionWaitStage, BindingStage, E2EStage}
+
 type Plugins struct {
 	// Searching is a list of plugins that should be invoked in preemption phase
 	VictimChecking *VictimCheckingPluginSet `json:"victimChecking,omitempty"`
@@ -135,6 +188,11 @@ func (in *GodelBinderConfiguration) DecodeNestedObjects(d runtime.Decoder) error
 	if err := decodeProfile(in.Profile); err != nil {
 		return err
 	}
+	for i := range in.SubClusterProfiles {
+		if err := decodeProfile(&in.SubClusterProfiles[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -162,6 +220,11 @@ func (in *GodelBinderConfiguration) EncodeNestedObjects(e runtime.Encoder) error
 	if err := encodeProfile(in.Profile); err != nil {
 		return err
 	}
+	for i := range in.SubClusterProfiles {
+		if err := encodeProfile(&in.SubClusterProfiles[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -38,6 +38,8 @@ const (
 	BinderDefaultLockObjectName      = "binder"
 	DefaultReservationTimeOutSeconds = 60
 
+	DefaultSubClusterKey = ""
+
 	// DefaultGodelBinderAddress is the default address for the scheduler status server.
 	// May be overridden by a flag at startup.
 	DefaultGodelBinderAddress = "0.0.0.0"
@@ -67,6 +69,11 @@ func SetDefaults_GodelBinderConfiguration(cfg *GodelBinderConfiguration) {
 		cfg.Tracer = tracing.DefaultNoopOptions()
 	}
 
+	if cfg.SubClusterKey == nil {
+		defaultValue := DefaultSubClusterKey
+		cfg.SubClusterKey = &defaultValue
+	}
+
 	// Scheduler has an opinion about QPS/Burst, setting specific defaults for itself, instead of generic settings.
 	if cfg.ClientConnection.QPS == 0.0 {
 		cfg.ClientConnection.QPS = DefaultClientConnectionQPS
@@ -46,6 +46,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceAffinityArgs{},
 		&NodeResourcesLeastAllocatedArgs{},
 		&NodeResourcesMostAllocatedArgs{},
+		&PreemptionBudgetCheckerArgs{},
+		&PreemptionPolicyMatrixArgs{},
+		&LoadAwareArgs{},
+		&NodeManagerBinderArgs{},
+		&NodePoolArgs{},
 	)
 	return nil
 }
@@ -19,6 +19,8 @@ package config
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -169,3 +171,86 @@ type NodeLoadArgs struct {
 	// LoadThreshold is the threshold to consider for node load limitation
 	LoadThreshold float64 `json:"loadThreshold"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PreemptionBudgetCheckerArgs holds arguments used to configure the PreemptionBudgetChecker plugin.
+// Zero value of each limit means no limitation.
+type PreemptionBudgetCheckerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// WindowSeconds is the sliding time window in which the victims are counted.
+	// If this value is zero, the default value will be used.
+	WindowSeconds int64 `json:"windowSeconds,omitempty"`
+	// MaxVictimsPerOwner is the max number of victims belonging to the same owner in the window.
+	MaxVictimsPerOwner int64 `json:"maxVictimsPerOwner,omitempty"`
+	// MaxVictimsPerNamespace is the max number of victims in the same namespace in the window.
+	MaxVictimsPerNamespace int64 `json:"maxVictimsPerNamespace,omitempty"`
+	// MinIntervalSecondsPerOwner is the min interval between two preemptions of the same owner.
+	MinIntervalSecondsPerOwner int64 `json:"minIntervalSecondsPerOwner,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadAwareArgs holds arguments used to configure the LoadAware conflict check plugin.
+type LoadAwareArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// FilterExpiredNodeMetrics indicates whether to reject nodes whose metrics are expired.
+	FilterExpiredNodeMetrics bool `json:"filterExpiredNodeMetrics,omitempty"`
+	// NodeMetricExpirationSeconds is the max age of node metrics. If this value is zero, the default value will be used.
+	NodeMetricExpirationSeconds int64 `json:"nodeMetricExpirationSeconds,omitempty"`
+	// UsageThresholds is the max usage of each resource in percentage of the node allocatable.
+	UsageThresholds map[v1.ResourceName]int64 `json:"usageThresholds,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PreemptionPolicyMatrixArgs holds arguments used to configure the PreemptionPolicyMatrix plugin.
+// Preemptors which do not belong to any class are not governed by the matrix.
+type PreemptionPolicyMatrixArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Classes groups pods by their PriorityClass names or workload classes.
+	Classes []defaultsconfig.PreemptionClass `json:"classes,omitempty"`
+	// Rules declares which classes each class could preempt. Pods of a class without rule
+	// could not preempt any pods.
+	Rules []defaultsconfig.PreemptionRule `json:"rules,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeManagerBinderArgs holds arguments used to configure the NodeManagerBinder plugin.
+type NodeManagerBinderArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Launcher is the in-tree launcher handing node manager pods over, only Annotation is supported.
+	// If this value is empty, node manager pods are left to DefaultBinder.
+	Launcher string `json:"launcher,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePoolArgs holds arguments used to configure the NodePool plugin, the NodePoolChecker preemption
+// plugin takes the same args. The pools should be the same as the ones of the scheduler.
+type NodePoolArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Pools are the node pools, a node belongs to the first pool whose node selector matches its labels.
+	// Nodes not belonging to any pool can be used by all pods.
+	Pools []NodePool `json:"pools,omitempty"`
+}
+
+// NodePool maps the tenants, which are namespaces or applications selected by pod labels, to a set of nodes.
+type NodePool struct {
+	// Name is the unique name of the node pool.
+	Name string `json:"name"`
+	// Mode is the mode of the node pool, one of Dedicated, Shared and Borrowable.
+	Mode string `json:"mode"`
+	// NodeSelector selects the nodes of the pool by node labels.
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Namespaces are the tenant namespaces of the pool.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodSelector selects the tenant applications of the pool by pod labels.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
@@ -38,6 +38,8 @@ const (
 	DefaultReservationTimeOutSeconds = 60
 
 	BinderDefaultLockObjectName = "godel-binder"
+
+	DefaultSubClusterKey = ""
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -58,6 +60,11 @@ func SetDefaults_GodelBinderConfiguration(cfg *GodelBinderConfiguration) {
 		cfg.Tracer = tracing.DefaultNoopOptions()
 	}
 
+	if cfg.SubClusterKey == nil {
+		defaultValue := DefaultSubClusterKey
+		cfg.SubClusterKey = &defaultValue
+	}
+
 	// Scheduler has an opinion about QPS/Burst, setting specific defaults for itself, instead of generic settings.
 	if cfg.ClientConnection.QPS == 0.0 {
 		cfg.ClientConnection.QPS = DefaultClientConnectionQPS
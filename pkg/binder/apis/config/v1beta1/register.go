@@ -48,6 +48,11 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&config.ServiceAffinityArgs{},
 		&config.NodeResourcesLeastAllocatedArgs{},
 		&config.NodeResourcesMostAllocatedArgs{},
+		&config.PreemptionBudgetCheckerArgs{},
+		&config.PreemptionPolicyMatrixArgs{},
+		&config.LoadAwareArgs{},
+		&config.NodeManagerBinderArgs{},
+		&config.NodePoolArgs{},
 	)
 
 	return nil
@@ -25,6 +25,7 @@ import (
 	componentbaseconfig "k8s.io/component-base/config/v1alpha1"
 	"sigs.k8s.io/yaml"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
 
@@ -65,7 +66,35 @@ type GodelBinderConfiguration struct {
 	// reserved resources will be released after a period of time.
 	ReservationTimeOutSeconds int64 `json:"reservationTimeOutSeconds,omitempty"`
 
+	// CacheComparePeriodSeconds is the period for comparing the binder cache with informers in
+	// the background, the differences will be reported as metrics and events. 0 disables it.
+	CacheComparePeriodSeconds int64 `json:"cacheComparePeriodSeconds,omitempty"`
+	// EnableCacheSelfHealing resyncs the objects missed or left behind by the binder cache
+	// when they are found by the background cache comparer.
+	EnableCacheSelfHealing bool `json:"enableCacheSelfHealing,omitempty"`
+	// EnableCacheInspection exposes the cache inspection endpoints, e.g. /debug/cache/nodes, on the
+	// healthz and metrics servers regardless of profiling.
+	EnableCacheInspection bool `json:"enableCacheInspection,omitempty"`
+
+	// SchedulingSLOThresholdSeconds are the SLO thresholds of pod lifecycle stages keyed by stage name,
+	// a pod whose stage latency exceeds the threshold will be reported as SLO breach by metrics and events.
+	// Pending pods are also checked periodically against the threshold of their current stage.
+	// Stages without threshold are not checked.
+	SchedulingSLOThresholdSeconds map[string]int64 `json:"schedulingSLOThresholdSeconds,omitempty"`
+
+	// OvercommitPolicies are the named policies transforming the allocatable of nodes, a node selects its policy
+	// by the label godel.bytedance.com/overcommit-policy. They should be the same as the ones of the scheduler.
+	OvercommitPolicies []defaultsconfig.OvercommitPolicy `json:"overcommitPolicies,omitempty"`
+
+	// SubClusterKey is the label key of nodes which partitions the cluster into sub-clusters,
+	// the sub-cluster of a pod is the value of this key in pod.Spec.NodeSelector.
+	SubClusterKey *string `json:"subClusterKey,omitempty"`
+
+	// Profile is the default binder profile.
 	Profile *GodelBinderProfile `json:"profile"`
+	// SubClusterProfiles are binder profiles of specific sub-clusters, a sub-cluster without its
+	// own profile uses the default profile.
+	SubClusterProfiles []GodelBinderProfile `json:"subClusterProfiles,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -74,6 +103,9 @@ type GodelBinderConfiguration struct {
 type GodelBinderProfile struct {
 	metav1.TypeMeta `json:",inline"`
 
+	// SubClusterName associates the profile to a sub-cluster, it is only used by SubClusterProfiles.
+	SubClusterName string `json:"subClusterName,omitempty"`
+
 	Plugins *Plugins `json:"plugins"`
 
 	// PluginConfigs is an optional set of custom plugin arguments for each plugin.
@@ -136,6 +168,11 @@ func (in *GodelBinderConfiguration) DecodeNestedObjects(d runtime.Decoder) error
 	if err := decodeProfile(in.Profile); err != nil {
 		return err
 	}
+	for i := range in.SubClusterProfiles {
+		if err := decodeProfile(&in.SubClusterProfiles[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -163,6 +200,11 @@ func (in *GodelBinderConfiguration) EncodeNestedObjects(e runtime.Encoder) error
 	if err := encodeProfile(in.Profile); err != nil {
 		return err
 	}
+	for i := range in.SubClusterProfiles {
+		if err := encodeProfile(&in.SubClusterProfiles[i]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
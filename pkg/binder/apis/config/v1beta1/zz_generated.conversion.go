@@ -24,6 +24,7 @@ package v1beta1
 import (
 	unsafe "unsafe"
 
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	config "github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
 	tracing "github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 	conversion "k8s.io/apimachinery/pkg/conversion"
@@ -120,7 +121,14 @@ func autoConvert_v1beta1_GodelBinderConfiguration_To_config_GodelBinderConfigura
 	out.VolumeBindingTimeoutSeconds = in.VolumeBindingTimeoutSeconds
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
 	out.ReservationTimeOutSeconds = in.ReservationTimeOutSeconds
+	out.CacheComparePeriodSeconds = in.CacheComparePeriodSeconds
+	out.EnableCacheSelfHealing = in.EnableCacheSelfHealing
+	out.EnableCacheInspection = in.EnableCacheInspection
+	out.SchedulingSLOThresholdSeconds = *(*map[string]int64)(unsafe.Pointer(&in.SchedulingSLOThresholdSeconds))
+	out.OvercommitPolicies = *(*[]apisconfig.OvercommitPolicy)(unsafe.Pointer(&in.OvercommitPolicies))
+	out.SubClusterKey = (*string)(unsafe.Pointer(in.SubClusterKey))
 	out.Profile = (*config.GodelBinderProfile)(unsafe.Pointer(in.Profile))
+	out.SubClusterProfiles = *(*[]config.GodelBinderProfile)(unsafe.Pointer(&in.SubClusterProfiles))
 	return nil
 }
 
@@ -139,7 +147,14 @@ func autoConvert_config_GodelBinderConfiguration_To_v1beta1_GodelBinderConfigura
 	out.VolumeBindingTimeoutSeconds = in.VolumeBindingTimeoutSeconds
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
 	out.ReservationTimeOutSeconds = in.ReservationTimeOutSeconds
+	out.CacheComparePeriodSeconds = in.CacheComparePeriodSeconds
+	out.EnableCacheSelfHealing = in.EnableCacheSelfHealing
+	out.EnableCacheInspection = in.EnableCacheInspection
+	out.SchedulingSLOThresholdSeconds = *(*map[string]int64)(unsafe.Pointer(&in.SchedulingSLOThresholdSeconds))
+	out.OvercommitPolicies = *(*[]apisconfig.OvercommitPolicy)(unsafe.Pointer(&in.OvercommitPolicies))
+	out.SubClusterKey = (*string)(unsafe.Pointer(in.SubClusterKey))
 	out.Profile = (*GodelBinderProfile)(unsafe.Pointer(in.Profile))
+	out.SubClusterProfiles = *(*[]GodelBinderProfile)(unsafe.Pointer(&in.SubClusterProfiles))
 	return nil
 }
 
@@ -149,6 +164,7 @@ func Convert_config_GodelBinderConfiguration_To_v1beta1_GodelBinderConfiguration
 }
 
 func autoConvert_v1beta1_GodelBinderProfile_To_config_GodelBinderProfile(in *GodelBinderProfile, out *config.GodelBinderProfile, s conversion.Scope) error {
+	out.SubClusterName = in.SubClusterName
 	out.Plugins = (*config.Plugins)(unsafe.Pointer(in.Plugins))
 	out.PreemptionPluginConfigs = *(*[]config.PluginConfig)(unsafe.Pointer(&in.PreemptionPluginConfigs))
 	out.PluginConfigs = *(*[]config.PluginConfig)(unsafe.Pointer(&in.PluginConfigs))
@@ -161,6 +177,7 @@ func Convert_v1beta1_GodelBinderProfile_To_config_GodelBinderProfile(in *GodelBi
 }
 
 func autoConvert_config_GodelBinderProfile_To_v1beta1_GodelBinderProfile(in *config.GodelBinderProfile, out *GodelBinderProfile, s conversion.Scope) error {
+	out.SubClusterName = in.SubClusterName
 	out.Plugins = (*Plugins)(unsafe.Pointer(in.Plugins))
 	out.PreemptionPluginConfigs = *(*[]PluginConfig)(unsafe.Pointer(&in.PreemptionPluginConfigs))
 	out.PluginConfigs = *(*[]PluginConfig)(unsafe.Pointer(&in.PluginConfigs))
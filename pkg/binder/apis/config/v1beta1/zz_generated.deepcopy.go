@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -41,11 +42,37 @@ func (in *GodelBinderConfiguration) DeepCopyInto(out *GodelBinderConfiguration)
 		in, out := &in.Tracer, &out.Tracer
 		*out = (*in).DeepCopy()
 	}
+	if in.SchedulingSLOThresholdSeconds != nil {
+		in, out := &in.SchedulingSLOThresholdSeconds, &out.SchedulingSLOThresholdSeconds
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OvercommitPolicies != nil {
+		in, out := &in.OvercommitPolicies, &out.OvercommitPolicies
+		*out = make([]apisconfig.OvercommitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SubClusterKey != nil {
+		in, out := &in.SubClusterKey, &out.SubClusterKey
+		*out = new(string)
+		**out = **in
+	}
 	if in.Profile != nil {
 		in, out := &in.Profile, &out.Profile
 		*out = new(GodelBinderProfile)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SubClusterProfiles != nil {
+		in, out := &in.SubClusterProfiles, &out.SubClusterProfiles
+		*out = make([]GodelBinderProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
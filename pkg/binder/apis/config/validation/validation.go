@@ -17,10 +17,13 @@ limitations under the License.
 package validation
 
 import (
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	godelvalidation "github.com/kubewharf/godel-scheduler/pkg/util/validation"
 )
 
@@ -46,5 +49,85 @@ func ValidateGodelBinderConfiguration(cc *config.GodelBinderConfiguration) field
 			cc.VolumeBindingTimeoutSeconds, "must be greater than 0"))
 	}
 
+	if cc.CacheComparePeriodSeconds < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("cacheComparePeriodSeconds"),
+			cc.CacheComparePeriodSeconds, "must be non-negative"))
+	}
+
+	errs = append(errs, validateSchedulingSLOThresholds(cc.SchedulingSLOThresholdSeconds, field.NewPath("schedulingSLOThresholdSeconds"))...)
+	errs = append(errs, defaultsconfig.ValidateOvercommitPolicies(field.NewPath("overcommitPolicies"), cc.OvercommitPolicies)...)
+	errs = append(errs, validateSubClusterProfiles(cc, field.NewPath("subClusterProfiles"))...)
+
+	return errs
+}
+
+func validateSchedulingSLOThresholds(thresholds map[string]int64, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	stages := sets.NewString(config.LifecycleStages...)
+	for stage, seconds := range thresholds {
+		if !stages.Has(stage) {
+			errs = append(errs, field.NotSupported(fldPath, stage, config.LifecycleStages))
+			continue
+		}
+		if seconds <= 0 {
+			errs = append(errs, field.Invalid(fldPath.Key(stage), seconds, "must be greater than 0"))
+		}
+	}
+	return errs
+}
+
+func validateSubClusterProfiles(cc *config.GodelBinderConfiguration, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if len(cc.SubClusterProfiles) == 0 {
+		return errs
+	}
+	if cc.SubClusterKey == nil || len(*cc.SubClusterKey) == 0 {
+		errs = append(errs, field.Required(field.NewPath("subClusterKey"), "must be set when subClusterProfiles are specified"))
+	}
+	names := sets.NewString()
+	for i, profile := range cc.SubClusterProfiles {
+		path := fldPath.Index(i).Child("subClusterName")
+		if len(profile.SubClusterName) == 0 {
+			errs = append(errs, field.Required(path, ""))
+		} else if names.Has(profile.SubClusterName) {
+			errs = append(errs, field.Duplicate(path, profile.SubClusterName))
+		} else {
+			names.Insert(profile.SubClusterName)
+		}
+	}
+	return errs
+}
+
+// ValidateConsistencyWithScheduler ensures the binder configuration matches the scheduler configuration
+// of the same scheduling system: both of them should partition sub-clusters by the same key, and each
+// sub-cluster profile of binder should have a counterpart in scheduler.
+func ValidateConsistencyWithScheduler(cc *config.GodelBinderConfiguration, sc *schedulerconfig.GodelSchedulerConfiguration) field.ErrorList {
+	errs := field.ErrorList{}
+	if cc.SchedulerName != nil && sc.SchedulerName != nil && *cc.SchedulerName != *sc.SchedulerName {
+		errs = append(errs, field.Invalid(field.NewPath("schedulerName"), *cc.SchedulerName,
+			"must be the same as schedulerName of scheduler: "+*sc.SchedulerName))
+	}
+
+	binderKey, schedulerKey := "", ""
+	if cc.SubClusterKey != nil {
+		binderKey = *cc.SubClusterKey
+	}
+	if sc.SubClusterKey != nil {
+		schedulerKey = *sc.SubClusterKey
+	}
+	if binderKey != schedulerKey {
+		errs = append(errs, field.Invalid(field.NewPath("subClusterKey"), binderKey,
+			"must be the same as subClusterKey of scheduler: "+schedulerKey))
+	}
+
+	schedulerSubClusters := sets.NewString()
+	for _, profile := range sc.SubClusterProfiles {
+		schedulerSubClusters.Insert(profile.SubClusterName)
+	}
+	for i, profile := range cc.SubClusterProfiles {
+		if !schedulerSubClusters.Has(profile.SubClusterName) {
+			errs = append(errs, field.NotFound(field.NewPath("subClusterProfiles").Index(i).Child("subClusterName"), profile.SubClusterName))
+		}
+	}
 	return errs
 }
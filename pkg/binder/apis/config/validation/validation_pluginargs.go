@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+)
+
+// ValidatePreemptionBudgetCheckerArgs validates that PreemptionBudgetCheckerArgs are correct.
+func ValidatePreemptionBudgetCheckerArgs(args *config.PreemptionBudgetCheckerArgs) error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validatePreemptionBudgetSeconds(field.NewPath("windowSeconds"), args.WindowSeconds)...)
+	allErrs = append(allErrs, validatePreemptionBudgetSeconds(field.NewPath("minIntervalSecondsPerOwner"), args.MinIntervalSecondsPerOwner)...)
+	if args.MaxVictimsPerOwner < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxVictimsPerOwner"), args.MaxVictimsPerOwner, "must be non-negative"))
+	}
+	if args.MaxVictimsPerNamespace < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxVictimsPerNamespace"), args.MaxVictimsPerNamespace, "must be non-negative"))
+	}
+	return allErrs.ToAggregate()
+}
+
+func validatePreemptionBudgetSeconds(path *field.Path, seconds int64) field.ErrorList {
+	if seconds < 0 || seconds > defaultsconfig.MaxPreemptionBudgetWindowSeconds {
+		msg := fmt.Sprintf("not in valid range [0-%d]", defaultsconfig.MaxPreemptionBudgetWindowSeconds)
+		return field.ErrorList{field.Invalid(path, seconds, msg)}
+	}
+	return nil
+}
+
+// ValidateLoadAwareArgs validates that LoadAwareArgs are correct.
+func ValidateLoadAwareArgs(args *config.LoadAwareArgs) error {
+	var allErrs field.ErrorList
+	if args.NodeMetricExpirationSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("nodeMetricExpirationSeconds"), args.NodeMetricExpirationSeconds, "must be non-negative"))
+	}
+	for resourceName, threshold := range args.UsageThresholds {
+		if threshold <= 0 || threshold > 100 {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("usageThresholds").Key(string(resourceName)), threshold, "not in valid range (0-100]"))
+		}
+	}
+	return allErrs.ToAggregate()
+}
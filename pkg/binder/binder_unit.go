@@ -141,7 +141,8 @@ func (unitInfo *bindingUnitInfo) IsUnitFailed() bool {
 	defer unitInfo.mu.Unlock()
 
 	if unitInfo.queuedUnitInfo.Type() == framework.PodGroupUnitType {
-		if !unitInfo.everScheduled && unitInfo.allMember-len(unitInfo.failedTasks)-len(unitInfo.ignoredTasks) < unitInfo.minMember {
+		if !unitInfo.everScheduled && (unitInfo.allMember-len(unitInfo.failedTasks)-len(unitInfo.ignoredTasks) < unitInfo.minMember ||
+			!unitInfo.queuedUnitInfo.ValidatePodRoles(unitInfo.getRemainingPods())) {
 			// unit is not ever scheduled, and break mim member semantic (too many tasks failed)
 			return true
 		}
@@ -160,7 +161,7 @@ func (unitInfo *bindingUnitInfo) IsAbleToBindReadyTasks() bool {
 	defer unitInfo.mu.Unlock()
 
 	if unitInfo.queuedUnitInfo.Type() == framework.PodGroupUnitType {
-		if unitInfo.everScheduled || len(unitInfo.readyTasks) >= unitInfo.minMember {
+		if unitInfo.everScheduled || (len(unitInfo.readyTasks) >= unitInfo.minMember && unitInfo.queuedUnitInfo.ValidatePodRoles(unitInfo.getReadyPods())) {
 			// unit is not ever scheduled, and break mim member semantic (too many tasks failed)
 			return true
 		} else {
@@ -171,6 +172,35 @@ func (unitInfo *bindingUnitInfo) IsAbleToBindReadyTasks() bool {
 	return true
 }
 
+// getRemainingPods returns the pods which are neither failed nor ignored, the caller should hold the lock.
+func (unitInfo *bindingUnitInfo) getRemainingPods() []*v1.Pod {
+	excluded := make(map[types.UID]bool, len(unitInfo.failedTasks)+len(unitInfo.ignoredTasks))
+	for uid := range unitInfo.failedTasks {
+		excluded[uid] = true
+	}
+	for _, qpi := range unitInfo.ignoredTasks {
+		excluded[qpi.Pod.UID] = true
+	}
+	pods := make([]*v1.Pod, 0, unitInfo.allMember)
+	for _, qpi := range unitInfo.queuedUnitInfo.GetPods() {
+		if qpi.Pod != nil && !excluded[qpi.Pod.UID] {
+			pods = append(pods, qpi.Pod)
+		}
+	}
+	return pods
+}
+
+// getReadyPods returns the pods of the ready tasks, the caller should hold the lock.
+func (unitInfo *bindingUnitInfo) getReadyPods() []*v1.Pod {
+	pods := make([]*v1.Pod, 0, len(unitInfo.readyTasks))
+	for _, cr := range unitInfo.readyTasks {
+		if cr.runningUnit != nil && cr.runningUnit.queuedPodInfo != nil {
+			pods = append(pods, cr.runningUnit.queuedPodInfo.Pod)
+		}
+	}
+	return pods
+}
+
 func (unitInfo *bindingUnitInfo) HasVictim(uid types.UID) bool {
 	unitInfo.mu.Lock()
 	defer unitInfo.mu.Unlock()
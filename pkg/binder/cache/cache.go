@@ -57,7 +57,7 @@ type binderCache struct {
 
 func newBinderCache(handler commoncache.CacheHandler) *binderCache {
 	bc := &binderCache{
-		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Cache, commonstores.GlobalRegistries, orderedStoreNames),
+		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Cache, commonstores.GlobalRegistries, storeNames()),
 
 		handler: handler,
 		mu:      handler.Mutex(),
@@ -110,6 +110,16 @@ func (cache *binderCache) updateMetrics() {
 	metrics.CacheSize.WithLabelValues("assumed_pods").Set(float64(len(podStore.AssumedPods)))
 	metrics.CacheSize.WithLabelValues("pods").Set(float64(len(podStore.PodStates)))
 	metrics.CacheSize.WithLabelValues("nodes").Set(float64(nodeStore.Len() - nodeStore.Deleted.Len()))
+
+	metrics.OvercommitNodes.Reset()
+	metrics.OvercommitAllocatable.Reset()
+	for profile, summary := range nodeStore.OvercommitSummaries() {
+		metrics.OvercommitNodes.WithLabelValues(profile).Set(float64(summary.Nodes))
+		for qos, allocatable := range map[string]*framework.Resource{"guaranteed": summary.GuaranteedAllocatable, "besteffort": summary.BestEffortAllocatable} {
+			metrics.OvercommitAllocatable.WithLabelValues(profile, qos, "cpu").Set(float64(allocatable.MilliCPU))
+			metrics.OvercommitAllocatable.WithLabelValues(profile, qos, "memory").Set(float64(allocatable.Memory))
+		}
+	}
 }
 
 // -------------------------------------- Other Interface --------------------------------------
@@ -144,6 +154,13 @@ func (cache *binderCache) Dump() *commoncache.Dump {
 	}
 }
 
+func (cache *binderCache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	return commonstore.DumpStores(cache.CommonStoresSwitch, names...)
+}
+
 func (cache *binderCache) IsAssumedPod(pod *v1.Pod) (bool, error) {
 	cache.mu.RLock()
 	defer cache.mu.RUnlock()
@@ -0,0 +1,96 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepoolstore
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	fakelisters "github.com/kubewharf/godel-scheduler/pkg/framework/api/fake"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func makePod(name, nodeName string, claims ...string) *v1.Pod {
+	pod := testinghelper.MakePod().Namespace("default").Name(name).UID(name).Node(nodeName).Obj()
+	for _, claim := range claims {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name:         claim,
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+		})
+	}
+	return pod
+}
+
+func makeClaim(name, storage, volumeName string) v1.PersistentVolumeClaim {
+	storageClass := "ssd"
+	return v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(storage)}},
+			VolumeName:       volumeName,
+		},
+	}
+}
+
+func TestLocalStoragePoolStore(t *testing.T) {
+	pvcLister := fakelisters.PersistentVolumeClaimLister{
+		makeClaim("c1", "10", ""),
+		makeClaim("c2", "20", ""),
+		makeClaim("bound", "40", "pv"),
+	}
+	cache := NewCache(commoncache.MakeCacheHandlerWrapper().PVCLister(pvcLister).Obj()).(*LocalStoragePoolStore)
+
+	node := testinghelper.MakeNode().Name("n").Obj()
+	node.Status.Allocatable = v1.ResourceList{framework.LocalStoragePoolResourcePrefix + "ssd": resource.MustParse("100")}
+	cache.AddNode(node)
+
+	checkRequested := func(expected int64) {
+		t.Helper()
+		capacity, requested := cache.GetNodeLocalStoragePools("n")
+		if capacity["ssd"] != 100 || requested["ssd"] != expected {
+			t.Errorf("expected capacity 100 and requested %d, but got %v and %v", expected, capacity, requested)
+		}
+	}
+
+	// The bound claim is still used by the pod and should be counted.
+	p1 := makePod("p1", "n", "c1", "bound")
+	cache.AddPod(p1)
+	checkRequested(50)
+
+	// Pods are assumed by binder before they are bound.
+	p2 := makePod("p2", "n", "c2")
+	cache.AssumePod(&framework.CachePodInfo{Pod: p2})
+	checkRequested(70)
+	// The assumed pod will not be counted repeatedly once it is bound.
+	cache.AddPod(p2)
+	checkRequested(70)
+	cache.ForgetPod(&framework.CachePodInfo{Pod: p2})
+	checkRequested(50)
+
+	cache.DeletePod(p1)
+	checkRequested(0)
+	cache.DeleteNode(node)
+	if cache.IsLocalStorageClass("ssd") {
+		t.Errorf("expected ssd not to be local storage class")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionbudgetstore
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestRebuildRecordsFromBoundPreemptors(t *testing.T) {
+	now := time.Now()
+	controller := true
+	ownerRef := metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs", UID: "rs", Controller: &controller}
+	victim := testing_helper.MakePod().Namespace("ns").Name("v1").UID("v1").Node("n").ControllerRef(ownerRef).Obj()
+	ownerKey := podutil.GetPodOwner(victim)
+
+	makePreemptor := func(name, victimName string, nodeName string, scheduledAt time.Time) *v1.Pod {
+		pod := testing_helper.MakePod().Namespace("ns").Name(name).UID(name).Node(nodeName).
+			Annotation(podutil.NominatedNodeAnnotationKey, "{\"node\":\"n\",\"victims\":[{\"name\":\""+victimName+"\",\"namespace\":\"ns\",\"uid\":\""+victimName+"\"}]}").Obj()
+		pod.Status.Conditions = []v1.PodCondition{
+			{Type: v1.PodScheduled, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(scheduledAt)},
+		}
+		return pod
+	}
+
+	handler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(make(chan struct{})).
+		ComponentName("godel-binder").Obj()
+	handler.SetPodHandler(func(key string) (*framework.CachePodState, bool) {
+		if key == string(victim.UID) {
+			return &framework.CachePodState{Pod: victim}, true
+		}
+		return nil, false
+	})
+	store := NewCache(handler).(*PreemptionBudgetStore)
+
+	// The preemptor bound 5 minutes ago, whose victim is still known.
+	bound := makePreemptor("p1", "v1", "n", now.Add(-5*time.Minute))
+	if err := store.AddPod(bound); err != nil {
+		t.Fatal(err)
+	}
+	// Re-adding the same preemptor should not count the victim twice.
+	if err := store.UpdatePod(bound, bound); err != nil {
+		t.Fatal(err)
+	}
+	// The preemptor which is not bound yet will be recorded when it's assumed by binder.
+	if err := store.AddPod(makePreemptor("p2", "v2", "", now)); err != nil {
+		t.Fatal(err)
+	}
+	// The preemption beyond the retention is skipped.
+	if err := store.AddPod(makePreemptor("p3", "v3", "n", now.Add(-2*PreemptionRecordsRetention))); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := store.GetPreemptionCountOfOwner(ownerKey, now.Add(-10*time.Minute)); got != 1 {
+		t.Errorf("expected 1 preemption of owner in 10 minutes, got %d", got)
+	}
+	if got := store.GetPreemptionCountOfOwner(ownerKey, now.Add(-time.Minute)); got != 0 {
+		t.Errorf("expected 0 preemption of owner in 1 minute, got %d", got)
+	}
+	if got := store.GetPreemptionCountOfNamespace("ns", now.Add(-3*PreemptionRecordsRetention)); got != 1 {
+		t.Errorf("expected 1 preemption of namespace, got %d", got)
+	}
+	if got := store.GetLastPreemptionTimeOfOwner(ownerKey); !got.Equal(now.Add(-5 * time.Minute)) {
+		t.Errorf("unexpected last preemption time %v", got)
+	}
+}
@@ -624,3 +624,10 @@ func (s *ReservationStore) GetAvailablePlaceholderPod(pod *v1.Pod) (*v1.Pod, err
 	}
 	return reservationInfo.PlaceholderPod, nil
 }
+
+var _ commonstore.Dumpable = &ReservationStore{}
+
+// Dump returns the reservation infos grouped by node name.
+func (s *ReservationStore) Dump() interface{} {
+	return s.reservations.Dump()
+}
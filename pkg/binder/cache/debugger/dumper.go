@@ -84,6 +84,11 @@ func (d *CacheDumper) printNodeInfo(name string, n api.NodeInfo) string {
 		n.GetGuaranteedRequested(), n.GetGuaranteedAllocatable(),
 		n.GetBestEffortRequested(), n.GetBestEffortAllocatable(), n.NumPods()))
 
+	// Dump node's overcommit policy, the allocatable above has been transformed by it.
+	if policy := n.GetOvercommitPolicy(); policy != nil {
+		nodeData.WriteString(fmt.Sprintf("Overcommit Policy: %+v\n", *policy))
+	}
+
 	// Dump node's numa topology information
 	numaTopologyStatus := n.GetNumaTopologyStatus()
 	if numaTopologyStatus != nil {
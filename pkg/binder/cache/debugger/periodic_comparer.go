@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/klog/v2"
+
+	godelcache "github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/metrics"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	driftResourceNode  = "node"
+	driftResourcePod   = "pod"
+	driftTypeMissed    = "missed"
+	driftTypeRedundant = "redundant"
+
+	cacheDriftReason = "CacheDrift"
+	cacheDriftAction = "CompareCache"
+)
+
+// CacheDrift holds the objects which are inconsistent between informers and cache.
+type CacheDrift struct {
+	// MissedNodes exist in informer but not in cache.
+	MissedNodes []*v1.Node
+	// RedundantNodes exist in cache but not in informer.
+	RedundantNodes []*v1.Node
+	// MissedPods exist in informer but not in cache.
+	MissedPods []*v1.Pod
+	// RedundantPods exist in cache but not in informer.
+	RedundantPods []*v1.Pod
+}
+
+// PeriodicComparer compares the cache with informers periodically, reports the drift as metrics
+// and events, and resyncs the drifted objects into the cache if self-healing is enabled.
+// Since the cache is updated asynchronously by event handlers, an object is regarded as drifted
+// only if it has been found in two consecutive rounds.
+type PeriodicComparer struct {
+	nodeLister  corelisters.NodeLister
+	podLister   corelisters.PodLister
+	cache       godelcache.BinderCache
+	recorder    events.EventRecorder
+	selfHealing bool
+
+	// suspects holds the keys of drifted objects found in the last round.
+	suspects sets.String
+}
+
+// NewPeriodicComparer creates a PeriodicComparer.
+func NewPeriodicComparer(
+	nodeLister corelisters.NodeLister,
+	podLister corelisters.PodLister,
+	cache godelcache.BinderCache,
+	recorder events.EventRecorder,
+	selfHealing bool,
+) *PeriodicComparer {
+	return &PeriodicComparer{
+		nodeLister:  nodeLister,
+		podLister:   podLister,
+		cache:       cache,
+		recorder:    recorder,
+		selfHealing: selfHealing,
+		suspects:    sets.NewString(),
+	}
+}
+
+// Run compares the cache with informers every period until stopCh is closed.
+func (c *PeriodicComparer) Run(period time.Duration, stopCh <-chan struct{}) {
+	klog.InfoS("Started periodic cache comparer", "period", period, "selfHealing", c.selfHealing)
+	go wait.Until(c.CompareAndHeal, period, stopCh)
+}
+
+// CompareAndHeal runs one round of comparison.
+func (c *PeriodicComparer) CompareAndHeal() {
+	drift, err := c.Compare()
+	if err != nil {
+		klog.ErrorS(err, "Failed to compare the binder cache with informers")
+		return
+	}
+
+	metrics.CacheDrift.WithLabelValues(driftResourceNode, driftTypeMissed).Set(float64(len(drift.MissedNodes)))
+	metrics.CacheDrift.WithLabelValues(driftResourceNode, driftTypeRedundant).Set(float64(len(drift.RedundantNodes)))
+	metrics.CacheDrift.WithLabelValues(driftResourcePod, driftTypeMissed).Set(float64(len(drift.MissedPods)))
+	metrics.CacheDrift.WithLabelValues(driftResourcePod, driftTypeRedundant).Set(float64(len(drift.RedundantPods)))
+
+	for _, node := range drift.MissedNodes {
+		c.report(node, driftResourceNode, driftTypeMissed, node.Name, func() error { return c.cache.AddNode(node) })
+	}
+	for _, node := range drift.RedundantNodes {
+		c.report(node, driftResourceNode, driftTypeRedundant, node.Name, func() error { return c.cache.DeleteNode(node) })
+	}
+	for _, pod := range drift.MissedPods {
+		c.report(pod, driftResourcePod, driftTypeMissed, podutil.GetPodKey(pod), func() error { return c.cache.AddPod(pod) })
+	}
+	for _, pod := range drift.RedundantPods {
+		c.report(pod, driftResourcePod, driftTypeRedundant, podutil.GetPodKey(pod), func() error { return c.cache.DeletePod(pod) })
+	}
+}
+
+// Compare returns the objects that have drifted in two consecutive rounds.
+func (c *PeriodicComparer) Compare() (*CacheDrift, error) {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	dump := c.cache.Dump()
+
+	current := sets.NewString()
+	drift := &CacheDrift{}
+	confirmed := func(resource, driftType, name string) bool {
+		key := resource + "/" + driftType + "/" + name
+		current.Insert(key)
+		return c.suspects.Has(key)
+	}
+
+	actualNodes := make(map[string]*v1.Node, len(nodes))
+	for _, node := range nodes {
+		actualNodes[node.Name] = node
+		if nodeInfo, ok := dump.Nodes[node.Name]; (!ok || nodeInfo.GetNode() == nil) && confirmed(driftResourceNode, driftTypeMissed, node.Name) {
+			drift.MissedNodes = append(drift.MissedNodes, node)
+		}
+	}
+	cachedPods := make(map[string]*v1.Pod)
+	for name, nodeInfo := range dump.Nodes {
+		if node := nodeInfo.GetNode(); node != nil {
+			if _, ok := actualNodes[name]; !ok && confirmed(driftResourceNode, driftTypeRedundant, name) {
+				drift.RedundantNodes = append(drift.RedundantNodes, node)
+			}
+		}
+		for _, p := range nodeInfo.GetPods() {
+			// Pods assumed in memory are not visible to informers yet.
+			if !dump.AssumedPods[string(p.Pod.UID)] {
+				cachedPods[string(p.Pod.UID)] = p.Pod
+			}
+		}
+	}
+
+	actualPods := make(map[string]*v1.Pod, len(pods))
+	for _, pod := range pods {
+		// Only bound pods are stored in binder cache.
+		if !podutil.BoundPod(pod) || dump.AssumedPods[string(pod.UID)] {
+			continue
+		}
+		actualPods[string(pod.UID)] = pod
+		if _, ok := cachedPods[string(pod.UID)]; !ok && confirmed(driftResourcePod, driftTypeMissed, string(pod.UID)) {
+			drift.MissedPods = append(drift.MissedPods, pod)
+		}
+	}
+	for uid, pod := range cachedPods {
+		if _, ok := actualPods[uid]; !ok && confirmed(driftResourcePod, driftTypeRedundant, uid) {
+			drift.RedundantPods = append(drift.RedundantPods, pod)
+		}
+	}
+
+	c.suspects = current
+	return drift, nil
+}
+
+func (c *PeriodicComparer) report(regarding runtime.Object, resource, driftType, name string, heal func() error) {
+	klog.InfoS("WARN: cache drift detected", "resource", resource, "type", driftType, "name", name, "selfHealing", c.selfHealing)
+	c.recorder.Eventf(regarding, nil, v1.EventTypeWarning, cacheDriftReason, cacheDriftAction,
+		"The %s %s is %s in the cache of binder", resource, name, driftType)
+
+	if !c.selfHealing {
+		return
+	}
+	if err := heal(); err != nil {
+		klog.ErrorS(err, "Failed to resync the drifted object into cache", "resource", resource, "type", driftType, "name", name)
+		return
+	}
+	metrics.CacheResyncs.WithLabelValues(resource, driftType).Inc()
+}
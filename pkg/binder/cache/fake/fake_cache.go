@@ -117,6 +117,10 @@ func (c *Cache) Dump() *commoncache.Dump {
 	return &commoncache.Dump{}
 }
 
+func (c *Cache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	return nil
+}
+
 // AddCNR adds custom resource information about node
 func (c *Cache) AddCNR(cnr *katalystv1alpha1.CustomNodeResource) error {
 	return nil
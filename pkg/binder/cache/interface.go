@@ -67,6 +67,10 @@ type BinderCache interface {
 	// This method is expensive, and should be only used in non-critical path.
 	Dump() *commoncache.Dump
 
+	// DumpStores returns the content of the common stores which implement commonstore.Dumpable,
+	// keyed by store name. All the dumpable stores will be returned if names is empty.
+	DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{}
+
 	// GetPod returns the pod from the cache with the same namespace and the
 	// same name of the specified pod.
 	GetPod(pod *v1.Pod) (*v1.Pod, error)
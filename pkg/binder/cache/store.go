@@ -17,10 +17,13 @@ limitations under the License.
 package cache
 
 import (
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores"
 	deletedmarkerstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/deleted_marker_store"
+	localstoragepoolstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/local_storage_pool_store"
 	nodestore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/node_store"
 	pdbstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/pdb_store"
 	podstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/pod_store"
+	preemptionbudgetstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/preemption_budget_store"
 	reservationstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/reservation_store"
 	unitstatusstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/unit_status_store"
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
@@ -35,7 +38,23 @@ var orderedStoreNames = []commonstore.StoreName{
 	reservationstore.Name,
 	unitstatusstore.Name,
 	deletedmarkerstore.Name,
+	preemptionbudgetstore.Name,
+	localstoragepoolstore.Name,
 
 	nodestore.Name, // NodeStore be placed second to last.
 	podstore.Name,  // PodStore must be placed at the end.
 }
+
+// storeNames returns the ordered in-tree stores with the out-of-tree stores inserted
+// before NodeStore and PodStore.
+func storeNames() []commonstore.StoreName {
+	outOfTree := commonstores.GlobalRegistries.OutOfTreeStoreNames()
+	if len(outOfTree) == 0 {
+		return orderedStoreNames
+	}
+	tail := len(orderedStoreNames) - 2
+	names := make([]commonstore.StoreName, 0, len(orderedStoreNames)+len(outOfTree))
+	names = append(names, orderedStoreNames[:tail]...)
+	names = append(names, outOfTree...)
+	return append(names, orderedStoreNames[tail:]...)
+}
@@ -64,6 +64,11 @@ const (
 	DeletePodEventFmt = "DeletePodEvent(%v)"
 
 	EventReason = "PGController"
+
+	GangDegradedReason  = "GangDegraded"
+	GangRecoveredReason = "GangRecovered"
+	GangRestartedReason = "GangRestarted"
+	GangFailedReason    = "GangFailed"
 )
 
 // PodGroupController is a controller that process pod groups using provided Handler interface
@@ -75,6 +80,7 @@ type PodGroupController struct {
 	pgListerSynced  cache.InformerSynced
 	podListerSynced cache.InformerSynced
 	pgClient        pgclientset.Interface
+	client          kubernetes.Interface
 }
 
 // SetupPodGroupController returns a new *PodGroupController
@@ -113,6 +119,7 @@ func SetupPodGroupController(
 	ctrl.pgListerSynced = pgInformer.Informer().HasSynced
 	ctrl.podListerSynced = podInformer.Informer().HasSynced
 	ctrl.pgClient = pgClient
+	ctrl.client = client
 
 	go podInformer.Informer().Run(ctx.Done())
 	go ctrl.Run(PodGroupWorkers, ctx.Done())
@@ -138,7 +145,7 @@ func (ctrl *PodGroupController) Run(workers int, stopCh <-chan struct{}) {
 }
 
 func (ctrl *PodGroupController) pgAdded(pg *schedv1alpha1.PodGroup, event string) {
-	if unitutil.PodGroupFinalState(pg.Status.Phase) {
+	if unitutil.PodGroupFinalState(pg.Status.Phase) && !podGroupRecoverable(pg) || podGroupRecoveryFailed(pg) {
 		return
 	}
 	if key := unitutil.GetPodGroupKey(pg); len(key) > 0 {
@@ -236,6 +243,13 @@ func (ctrl *PodGroupController) syncHandler(key string) bool {
 		klog.InfoS("Failed to retrieve pod group from informer local store", "podGroupKey", key, "err", err)
 		return true
 	}
+	if podGroupRecoverable(pg) {
+		return ctrl.recoverPodGroup(key, pg)
+	}
+	if podGroupRecoveryFailed(pg) {
+		klog.V(4).InfoS("PodGroup has been marked as failed by the recovery policy, shouldn't change any more", "podGroupKey", key)
+		return false
+	}
 	// Quick check.
 	if unitutil.PodGroupFinalState(pg.Status.Phase) {
 		klog.V(4).InfoS("PodGroup has already ever reached the final state, shouldn't change any more", "podGroupKey", key, "phase", pg.Status.Phase)
@@ -253,6 +267,9 @@ func (ctrl *PodGroupController) syncHandler(key string) bool {
 			klog.InfoS("Failed to list pods for podGroup", "podGroupKey", key, "err", err)
 			return true
 		}
+		if unitutil.GetPodGroupRecoveryPolicy(pgCopy) != unitutil.PodGroupRecoveryNone {
+			pods = filterStaleMembers(pgCopy, pods)
+		}
 
 		if len(pods) > 0 {
 			fillOccupiedObj(pgCopy, pods[0])
@@ -400,7 +417,12 @@ func podGroupTimeout(pgCopy *schedv1alpha1.PodGroup) bool {
 		timeoutDuration = frameworkruntime.DefaultGangTimeout
 	}
 
-	if time.Since(pgCopy.CreationTimestamp.Time) > timeoutDuration {
+	startTime := pgCopy.CreationTimestamp.Time
+	if restartedAt, ok := unitutil.GetPodGroupTimeAnnotation(pgCopy, unitutil.PodGroupRestartedAtAnnotationKey); ok {
+		// The gang has been restarted by the recovery policy, count the timeout from the restart.
+		startTime = restartedAt
+	}
+	if time.Since(startTime) > timeoutDuration {
 		klog.V(5).InfoS("Pod group timeout", "podGroupKey", unitutil.GetPodGroupKey(pgCopy), "timeout period", timeoutDuration)
 		return true
 	}
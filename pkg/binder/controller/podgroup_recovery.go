@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	schedv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+// podGroupRecoverable returns true if the PodGroup has been scheduled and is configured with a recovery policy,
+// such PodGroups still need to be watched so that member failures can be handled.
+func podGroupRecoverable(pg *schedv1alpha1.PodGroup) bool {
+	return pg.Status.Phase == schedv1alpha1.PodGroupScheduled && unitutil.GetPodGroupRecoveryPolicy(pg) != unitutil.PodGroupRecoveryNone
+}
+
+// podGroupRecoveryFailed returns true if the PodGroup has been marked as Failed by the recovery policy.
+// Different from the legacy Failed phase, this is a final state.
+func podGroupRecoveryFailed(pg *schedv1alpha1.PodGroup) bool {
+	return pg.Status.Phase == schedv1alpha1.PodGroupFailed && unitutil.GetPodGroupRecoveryPolicy(pg) != unitutil.PodGroupRecoveryNone
+}
+
+// filterStaleMembers drops pods which are being deleted or were created before the latest gang restart,
+// these pods belong to the previous incarnation of the gang and should not be taken into account any more.
+func filterStaleMembers(pg *schedv1alpha1.PodGroup, pods []*v1.Pod) []*v1.Pod {
+	restartedAt, restarted := unitutil.GetPodGroupTimeAnnotation(pg, unitutil.PodGroupRestartedAtAnnotationKey)
+	// CreationTimestamp of pods only has second precision.
+	restartedAt = restartedAt.Truncate(time.Second)
+	ret := make([]*v1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if restarted && pod.CreationTimestamp.Time.Before(restartedAt) {
+			continue
+		}
+		ret = append(ret, pod)
+	}
+	return ret
+}
+
+// healthyMember returns true if the pod is still a valid member of a scheduled gang.
+func healthyMember(pod *v1.Pod) bool {
+	return pod.Status.Phase != v1.PodFailed && podutil.BoundPod(pod)
+}
+
+// recoverPodGroup handles a scheduled PodGroup with recovery policy. If the number of healthy members drops below
+// minMember, the PodGroup is restarted, waits for replacements or is marked as Failed based on the policy.
+// It returns true if the key should be re-enqueued with rate limit.
+func (ctrl *PodGroupController) recoverPodGroup(key string, pg *schedv1alpha1.PodGroup) bool {
+	pods, err := GetAllPods(ctrl.podLister, pg.Namespace, pg.Name)
+	if err != nil {
+		klog.InfoS("Failed to list pods for podGroup", "podGroupKey", key, "err", err)
+		return true
+	}
+
+	members := filterStaleMembers(pg, pods)
+	var healthy int32
+	for _, pod := range members {
+		if healthyMember(pod) {
+			healthy++
+		}
+	}
+	policy := unitutil.GetPodGroupRecoveryPolicy(pg)
+	eventMsg := fmt.Sprintf("policy=%v;created=%v,healthy=%v,minMember=%v", policy, len(members), healthy, pg.Spec.MinMember)
+	klog.V(4).InfoS("Analyzed members for scheduled PodGroup", "podGroupKey", key, "eventMsg", eventMsg)
+
+	pgCopy := pg.DeepCopy()
+	if healthy >= pg.Spec.MinMember {
+		if _, degraded := pgCopy.Annotations[unitutil.PodGroupDegradedSinceAnnotationKey]; !degraded {
+			return false
+		}
+		delete(pgCopy.Annotations, unitutil.PodGroupDegradedSinceAnnotationKey)
+		if _, err := ctrl.updatePodGroup(pg, pgCopy, eventMsg); err != nil {
+			return true
+		}
+		ctrl.recordEvent(pg, v1.EventTypeNormal, GangRecoveredReason, "PodGroup recovered, %v", eventMsg)
+		return false
+	}
+
+	now := time.Now()
+	if pgCopy.Annotations == nil {
+		pgCopy.Annotations = make(map[string]string)
+	}
+	switch policy {
+	case unitutil.PodGroupRecoveryFail:
+		return ctrl.failPodGroup(key, pg, pgCopy, fmt.Sprintf("Only %v healthy members left, less than minMember %v", healthy, pg.Spec.MinMember), eventMsg)
+	case unitutil.PodGroupRecoveryRestart:
+		return ctrl.restartPodGroup(key, pg, pgCopy, pods, eventMsg)
+	case unitutil.PodGroupRecoveryWaitForReplacement:
+		timeout := unitutil.GetPodGroupRecoveryTimeout(pg)
+		degradedSince, ok := unitutil.GetPodGroupTimeAnnotation(pg, unitutil.PodGroupDegradedSinceAnnotationKey)
+		if !ok {
+			pgCopy.Annotations[unitutil.PodGroupDegradedSinceAnnotationKey] = now.Format(time.RFC3339)
+			if _, err := ctrl.updatePodGroup(pg, pgCopy, eventMsg); err != nil {
+				return true
+			}
+			ctrl.recordEvent(pg, v1.EventTypeWarning, GangDegradedReason, "PodGroup degraded, waiting %v for replacement members, %v", timeout, eventMsg)
+			ctrl.pgQueue.AddAfter(key, timeout)
+			return false
+		}
+		if waited := now.Sub(degradedSince); waited < timeout {
+			ctrl.pgQueue.AddAfter(key, timeout-waited)
+			return false
+		}
+		return ctrl.failPodGroup(key, pg, pgCopy, fmt.Sprintf("Replacement members were not scheduled within %v", timeout), eventMsg)
+	}
+	return false
+}
+
+// failPodGroup moves the PodGroup to Failed, which is a final state for PodGroups with recovery policy.
+func (ctrl *PodGroupController) failPodGroup(key string, pg, pgCopy *schedv1alpha1.PodGroup, reason, eventMsg string) bool {
+	if _, ok := pgCopy.Annotations[unitutil.PodGroupDegradedSinceAnnotationKey]; !ok {
+		pgCopy.Annotations[unitutil.PodGroupDegradedSinceAnnotationKey] = time.Now().Format(time.RFC3339)
+	}
+	updatePodGroupCondition(pgCopy, schedv1alpha1.PodGroupFailed, reason)
+	pgCopy.Status.Phase = schedv1alpha1.PodGroupFailed
+	if _, err := ctrl.updatePodGroup(pg, pgCopy, eventMsg); err != nil {
+		return true
+	}
+	klog.InfoS("Marked PodGroup as failed by recovery policy", "podGroupKey", key, "reason", reason)
+	ctrl.recordEvent(pg, v1.EventTypeWarning, GangFailedReason, "PodGroup failed: %v, %v", reason, eventMsg)
+	return false
+}
+
+// restartPodGroup deletes all remaining members and moves the PodGroup back to Pending, so that the
+// whole gang will be recreated by its workload controller and scheduled again.
+func (ctrl *PodGroupController) restartPodGroup(key string, pg, pgCopy *schedv1alpha1.PodGroup, pods []*v1.Pod, eventMsg string) bool {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if err := ctrl.client.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			klog.InfoS("Failed to delete member pod for gang restart", "podGroupKey", key, "pod", podutil.GetPodKey(pod), "err", err)
+			return true
+		}
+	}
+
+	restartCount, _ := strconv.Atoi(pgCopy.Annotations[unitutil.PodGroupRestartCountAnnotationKey])
+	pgCopy.Annotations[unitutil.PodGroupRestartCountAnnotationKey] = strconv.Itoa(restartCount + 1)
+	pgCopy.Annotations[unitutil.PodGroupRestartedAtAnnotationKey] = time.Now().Format(time.RFC3339)
+	delete(pgCopy.Annotations, unitutil.PodGroupDegradedSinceAnnotationKey)
+	// The gang is going to be scheduled again, so the final operation could be taken once more.
+	delete(pgCopy.Annotations, unitutil.PodGroupFinalOpLock)
+	pgCopy.Status.ScheduleStartTime = nil
+	updatePodGroupCondition(pgCopy, schedv1alpha1.PodGroupPending, "Restart the gang since members failed")
+	pgCopy.Status.Phase = schedv1alpha1.PodGroupPending
+	if _, err := ctrl.updatePodGroup(pg, pgCopy, eventMsg); err != nil {
+		return true
+	}
+	klog.InfoS("Restarted PodGroup by recovery policy", "podGroupKey", key, "restartCount", restartCount+1)
+	ctrl.recordEvent(pg, v1.EventTypeWarning, GangRestartedReason, "PodGroup restarted, deleted %v members, %v", len(pods), eventMsg)
+	return false
+}
+
+func (ctrl *PodGroupController) recordEvent(pg *schedv1alpha1.PodGroup, eventType, reason, messageFmt string, args ...interface{}) {
+	if ctrl.eventRecorder != nil {
+		ctrl.eventRecorder.Eventf(pg, eventType, reason, messageFmt, args...)
+	}
+}
@@ -34,6 +34,7 @@ import (
 	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
 	"github.com/kubewharf/godel-scheduler/pkg/util/controller"
 	podAnnotations "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
 )
 
 const (
@@ -320,6 +321,130 @@ func Test_PodGroupRun(t *testing.T) {
 	}
 }
 
+func Test_PodGroupRecovery(t *testing.T) {
+	ctx := context.TODO()
+	longAgo := time.Now().Add(-10 * time.Minute).Format(time.RFC3339)
+
+	cases := []struct {
+		name                string
+		annotations         map[string]string
+		failedPods          int
+		desiredGroupPhase   v1alpha1.PodGroupPhase
+		desiredAnnotations  map[string]string
+		absentAnnotations   []string
+		desiredRemainedPods int
+	}{
+		{
+			name:                "no recovery policy, keep scheduled",
+			failedPods:          1,
+			desiredGroupPhase:   v1alpha1.PodGroupScheduled,
+			desiredRemainedPods: 2,
+		},
+		{
+			name:                "fail policy, mark failed",
+			annotations:         map[string]string{unitutil.PodGroupRecoveryPolicyAnnotationKey: string(unitutil.PodGroupRecoveryFail)},
+			failedPods:          1,
+			desiredGroupPhase:   v1alpha1.PodGroupFailed,
+			desiredRemainedPods: 2,
+		},
+		{
+			name:                "restart policy, delete all members and move back to pending",
+			annotations:         map[string]string{unitutil.PodGroupRecoveryPolicyAnnotationKey: string(unitutil.PodGroupRecoveryRestart)},
+			failedPods:          1,
+			desiredGroupPhase:   v1alpha1.PodGroupPending,
+			desiredAnnotations:  map[string]string{unitutil.PodGroupRestartCountAnnotationKey: "1"},
+			desiredRemainedPods: 0,
+		},
+		{
+			name:                "wait for replacement policy, mark degraded",
+			annotations:         map[string]string{unitutil.PodGroupRecoveryPolicyAnnotationKey: string(unitutil.PodGroupRecoveryWaitForReplacement)},
+			failedPods:          1,
+			desiredGroupPhase:   v1alpha1.PodGroupScheduled,
+			desiredAnnotations:  map[string]string{unitutil.PodGroupDegradedSinceAnnotationKey: ""},
+			desiredRemainedPods: 2,
+		},
+		{
+			name: "wait for replacement policy, timeout and mark failed",
+			annotations: map[string]string{
+				unitutil.PodGroupRecoveryPolicyAnnotationKey:         string(unitutil.PodGroupRecoveryWaitForReplacement),
+				unitutil.PodGroupRecoveryTimeoutSecondsAnnotationKey: "60",
+				unitutil.PodGroupDegradedSinceAnnotationKey:          longAgo,
+			},
+			failedPods:          1,
+			desiredGroupPhase:   v1alpha1.PodGroupFailed,
+			desiredRemainedPods: 2,
+		},
+		{
+			name: "wait for replacement policy, recovered",
+			annotations: map[string]string{
+				unitutil.PodGroupRecoveryPolicyAnnotationKey: string(unitutil.PodGroupRecoveryWaitForReplacement),
+				unitutil.PodGroupDegradedSinceAnnotationKey:  longAgo,
+			},
+			desiredGroupPhase:   v1alpha1.PodGroupScheduled,
+			absentAnnotations:   []string{unitutil.PodGroupDegradedSinceAnnotationKey},
+			desiredRemainedPods: 2,
+		},
+	}
+	for i, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pgName := fmt.Sprintf("pg-recovery-%d", i)
+			ps := makePods([]string{"pod1", "pod2"}, pgName, "n")
+			for j, pod := range ps {
+				pod.Status.Phase = v1.PodRunning
+				if j < c.failedPods {
+					pod.Status.Phase = v1.PodFailed
+				}
+			}
+			kubeClient := fake.NewSimpleClientset(ps[0], ps[1])
+			pg := makePG(pgName, 2, v1alpha1.PodGroupScheduled, nil, nil)
+			pg.Annotations = c.annotations
+			pgClient := pgfake.NewSimpleClientset(pg)
+
+			pgInformerFactory := crdinformers.NewSharedInformerFactory(pgClient, controller.NoResyncPeriodFunc())
+			pgInformer := pgInformerFactory.Scheduling().V1alpha1().PodGroups()
+			SetupPodGroupController(ctx, kubeClient, pgClient, pgInformer)
+			pgInformerFactory.Start(ctx.Done())
+
+			var lastErr error
+			err := wait.Poll(100*time.Millisecond, 3*time.Second, func() (done bool, err error) {
+				newPg, err := pgClient.SchedulingV1alpha1().PodGroups("default").Get(ctx, pgName, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				if newPg.Status.Phase != c.desiredGroupPhase {
+					lastErr = fmt.Errorf("want %v, got %v", c.desiredGroupPhase, newPg.Status.Phase)
+					return false, nil
+				}
+				for k, v := range c.desiredAnnotations {
+					got, ok := newPg.Annotations[k]
+					if !ok || len(v) > 0 && got != v {
+						lastErr = fmt.Errorf("want annotation %v=%v, got %v", k, v, got)
+						return false, nil
+					}
+				}
+				for _, k := range c.absentAnnotations {
+					if _, ok := newPg.Annotations[k]; ok {
+						lastErr = fmt.Errorf("want annotation %v removed", k)
+						return false, nil
+					}
+				}
+				pods, err := kubeClient.CoreV1().Pods("default").List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return false, err
+				}
+				if len(pods.Items) != c.desiredRemainedPods {
+					lastErr = fmt.Errorf("want %v pods, got %v", c.desiredRemainedPods, len(pods.Items))
+					return false, nil
+				}
+				return true, nil
+			})
+			if err != nil {
+				t.Fatal("Unexpected error", err, lastErr)
+			}
+		})
+	}
+}
+
 func makePods(podNames []string, pgName string, nodeName string) []*v1.Pod {
 	pds := make([]*v1.Pod, 0)
 	trueP := true
@@ -19,6 +19,7 @@ package binder
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,11 +31,19 @@ import (
 
 	"github.com/kubewharf/godel-scheduler/pkg/binder/apis"
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	binderframework "github.com/kubewharf/godel-scheduler/pkg/binder/framework"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultbinder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/loadaware"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/localstoragepool"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeaffinity"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodemanagerbinder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeports"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/noderesources"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeunschedulable"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodevolumelimits"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nonnativeresource"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/tainttoleration"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/volumebinding"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/queue"
 	"github.com/kubewharf/godel-scheduler/pkg/features"
@@ -69,13 +78,22 @@ func NewBasePlugins(victimsCheckingPlugins []*framework.VictimCheckingPluginColl
 	basicPlugins := apis.BinderPluginCollection{
 		CheckTopology: []string{},
 		CheckConflicts: []string{
+			// Pod count limits are also re-checked by NodeResourcesCheck.
 			noderesources.ConflictCheckName,
+			nodeunschedulable.Name,
+			tainttoleration.Name,
+			nodeaffinity.Name,
+			nodepool.Name,
+			loadaware.Name,
 			nodevolumelimits.CSIName,
 			volumebinding.Name,
 			nodeports.Name,
+			nodemanagerbinder.Name,
 		},
 		Permits: []string{},
 		Binds: []string{
+			// NodeManagerBinder checks the NMNode of node manager pods, and leaves them to DefaultBinder unless a launcher is injected.
+			nodemanagerbinder.Name,
 			defaultbinder.Name,
 		},
 		VictimCheckings: victimsCheckingPlugins,
@@ -83,10 +101,54 @@ func NewBasePlugins(victimsCheckingPlugins []*framework.VictimCheckingPluginColl
 	if utilfeature.DefaultFeatureGate.Enabled(features.NonNativeResourceSchedulingSupport) {
 		basicPlugins.CheckConflicts = append(basicPlugins.CheckConflicts, nonnativeresource.Name)
 	}
+	if utilfeature.DefaultFeatureGate.Enabled(features.LocalStoragePool) {
+		basicPlugins.CheckConflicts = append(basicPlugins.CheckConflicts, localstoragepool.Name)
+	}
 
 	return &basicPlugins
 }
 
+// appendOutOfTreePlugins enables the out-of-tree plugins at the extension points they implement.
+// Out-of-tree bind plugins are placed before the default binder so that they can take over binding.
+func appendOutOfTreePlugins(basePlugins *apis.BinderPluginCollection, registry binderframework.Registry, pluginMap framework.PluginMap) {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var binds []string
+	for _, name := range names {
+		pl, ok := pluginMap[name]
+		if !ok {
+			continue
+		}
+		if _, ok := pl.(framework.CheckTopologyPlugin); ok {
+			basePlugins.CheckTopology = append(basePlugins.CheckTopology, name)
+		}
+		if _, ok := pl.(framework.CheckConflictsPlugin); ok {
+			basePlugins.CheckConflicts = append(basePlugins.CheckConflicts, name)
+		}
+		if _, ok := pl.(framework.ReservePlugin); ok {
+			basePlugins.Reserves = append(basePlugins.Reserves, name)
+		}
+		if _, ok := pl.(framework.PermitPlugin); ok {
+			basePlugins.Permits = append(basePlugins.Permits, name)
+		}
+		if _, ok := pl.(framework.PreBindPlugin); ok {
+			basePlugins.PreBinds = append(basePlugins.PreBinds, name)
+		}
+		if _, ok := pl.(framework.BindPlugin); ok {
+			binds = append(binds, name)
+		}
+		if _, ok := pl.(framework.PostBindPlugin); ok {
+			basePlugins.PostBinds = append(basePlugins.PostBinds, name)
+		}
+		klog.InfoS("Enabled out-of-tree binder plugin", "plugin", name)
+	}
+	basePlugins.Binds = append(binds, basePlugins.Binds...)
+}
+
 // MakeDefaultErrorFunc construct a function to handle pod scheduler error
 func MakeDefaultErrorFunc(client clientset.Interface, podLister corelisters.PodLister, podQueue queue.BinderQueue, binderCache godelcache.BinderCache) func(*framework.QueuedPodInfo, error) {
 	return func(podInfo *framework.QueuedPodInfo, err error) {
@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	nodepoolplugin "github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodepool"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+)
+
+const NodePoolCheckerName = nodepool.NodePoolCheckerName
+
+// NodePoolChecker re-checks the victims against the node pools in binder, so that the tenants of
+// borrowable node pools reclaim the borrowed capacity regardless of the following checkers, and the
+// borrowers could not preempt the tenants. It takes the args of the NodePool plugin.
+type NodePoolChecker struct {
+	handle handle.BinderFrameworkHandle
+	pools  nodepool.Pools
+}
+
+var _ framework.VictimCheckingPlugin = &NodePoolChecker{}
+
+// NewNodePoolChecker initializes a new NodePoolChecker plugin and returns it.
+func NewNodePoolChecker(plArgs runtime.Object, handle handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := nodepoolplugin.GetArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new NodePoolChecker plugin: %v", err)
+	}
+	pools, err := nodepoolplugin.NewPools(args)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePoolChecker{handle: handle, pools: pools}, nil
+}
+
+func (npc *NodePoolChecker) Name() string {
+	return NodePoolCheckerName
+}
+
+func (npc *NodePoolChecker) VictimChecking(preemptor, pod *v1.Pod, _, _ *framework.CycleState) (framework.Code, string) {
+	if len(npc.pools) == 0 {
+		return framework.PreemptionNotSure, ""
+	}
+	return nodepool.CheckVictim(npc.pools.PoolOfNode(npc.handle.GetNodeInfo(pod.Spec.NodeName)), preemptor, pod)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"testing"
+	"time"
+
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	pt "github.com/kubewharf/godel-scheduler/pkg/binder/testing"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func TestNodePoolChecker(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdClient := godelclientfake.NewSimpleClientset()
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	cacheHandler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(make(chan struct{})).
+		ComponentName("godel-binder").Obj()
+	binderCache := cache.New(cacheHandler)
+	for _, node := range []*v1.Node{
+		testing_helper.MakeNode().Name("borrowable").Label("pool", "borrowable").Obj(),
+		testing_helper.MakeNode().Name("other").Obj(),
+	} {
+		if err := binderCache.AddNode(node); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fh, err := pt.NewBinderFrameworkHandle(client, crdClient, informerFactory, crdInformerFactory, binderCache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pl, err := NewNodePoolChecker(&config.NodePoolArgs{
+		Pools: []config.NodePool{
+			{
+				Name:         "borrowable",
+				Mode:         "Borrowable",
+				NodeSelector: map[string]string{"pool": "borrowable"},
+				Namespaces:   []string{"tenant"},
+			},
+		},
+	}, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := pl.(*NodePoolChecker)
+
+	tenant := testing_helper.MakePod().Namespace("tenant").Name("tenant").Obj()
+	borrower := testing_helper.MakePod().Namespace("default").Name("borrower").Obj()
+	tests := []struct {
+		name       string
+		preemptor  *v1.Pod
+		victim     *v1.Pod
+		wantCode   framework.Code
+		wantReason string
+	}{
+		{
+			name:      "tenant reclaims borrowed capacity",
+			preemptor: tenant,
+			victim:    testing_helper.MakePod().Namespace("default").Name("victim").Node("borrowable").Obj(),
+			wantCode:  framework.PreemptionSucceed,
+		},
+		{
+			name:       "borrower preempts tenant",
+			preemptor:  borrower,
+			victim:     testing_helper.MakePod().Namespace("tenant").Name("victim").Node("borrowable").Obj(),
+			wantCode:   framework.PreemptionFail,
+			wantReason: nodepool.ErrReasonBorrowerPreempt,
+		},
+		{
+			name:      "victim not in any pool",
+			preemptor: tenant,
+			victim:    testing_helper.MakePod().Namespace("default").Name("victim").Node("other").Obj(),
+			wantCode:  framework.PreemptionNotSure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, reason := checker.VictimChecking(tt.preemptor, tt.victim, framework.NewCycleState(), framework.NewCycleState())
+			if code != tt.wantCode || reason != tt.wantReason {
+				t.Errorf("expected %v %q, got %v %q", tt.wantCode, tt.wantReason, code, reason)
+			}
+		})
+	}
+}
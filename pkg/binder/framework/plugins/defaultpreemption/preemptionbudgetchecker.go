@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config/validation"
+	preemptionbudgetstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/preemption_budget_store"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/preempting/budgetchecker"
+)
+
+const (
+	PreemptionBudgetCheckerName      = budgetchecker.PreemptionBudgetCheckerName
+	CheckingPreemptionBudgetCheckKey = "Checking-" + PreemptionBudgetCheckerName
+)
+
+// PreemptionBudgetChecker re-checks the preemption budget in binder, since the victims chosen by
+// different schedulers concurrently are only visible here.
+type PreemptionBudgetChecker struct {
+	budget       *budgetchecker.Budget
+	pluginHandle preemptionbudgetstore.StoreHandle
+}
+
+var (
+	_ framework.ClusterPrePreemptingPlugin = &PreemptionBudgetChecker{}
+	_ framework.VictimCheckingPlugin       = &PreemptionBudgetChecker{}
+	_ framework.PostVictimCheckingPlugin   = &PreemptionBudgetChecker{}
+)
+
+// NewPreemptionBudgetChecker initializes a new PreemptionBudgetChecker plugin and returns it.
+func NewPreemptionBudgetChecker(plArgs runtime.Object, handle handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := getPreemptionBudgetCheckerArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new PreemptionBudgetChecker plugin: %v", err)
+	}
+	if err := validation.ValidatePreemptionBudgetCheckerArgs(args); err != nil {
+		return nil, err
+	}
+
+	checker := &PreemptionBudgetChecker{
+		budget: budgetchecker.NewBudget(args.WindowSeconds, args.MaxVictimsPerOwner, args.MaxVictimsPerNamespace, args.MinIntervalSecondsPerOwner),
+	}
+	if store := handle.FindStore(preemptionbudgetstore.Name); store != nil {
+		checker.pluginHandle = store.(preemptionbudgetstore.StoreHandle)
+	}
+	return checker, nil
+}
+
+func (pbc *PreemptionBudgetChecker) Name() string {
+	return PreemptionBudgetCheckerName
+}
+
+func (pbc *PreemptionBudgetChecker) ClusterPrePreempting(_ *v1.Pod, _, commonState *framework.CycleState) *framework.Status {
+	// only need to exec once in a unit
+	if _, err := getCheckingBudgetUsage(commonState); err == nil {
+		return nil
+	}
+	commonState.Write(CheckingPreemptionBudgetCheckKey, budgetchecker.NewBudgetUsage())
+	return nil
+}
+
+func (pbc *PreemptionBudgetChecker) VictimChecking(_, pod *v1.Pod, _, commonState *framework.CycleState) (framework.Code, string) {
+	if pbc.pluginHandle == nil {
+		return framework.PreemptionNotSure, ""
+	}
+	usage, err := getCheckingBudgetUsage(commonState)
+	if err != nil {
+		return framework.Error, err.Error()
+	}
+	return budgetchecker.CheckBudget(pbc.budget, pbc.pluginHandle, usage, pod, time.Now())
+}
+
+func (pbc *PreemptionBudgetChecker) PostVictimChecking(_, pod *v1.Pod, _, commonState *framework.CycleState) *framework.Status {
+	usage, err := getCheckingBudgetUsage(commonState)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	usage.Add(pod)
+	return nil
+}
+
+func getCheckingBudgetUsage(state *framework.CycleState) (*budgetchecker.BudgetUsage, error) {
+	data, err := state.Read(CheckingPreemptionBudgetCheckKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", CheckingPreemptionBudgetCheckKey, err)
+	}
+	usage, ok := data.(*budgetchecker.BudgetUsage)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to PreemptionBudgetChecker.BudgetUsage error", data)
+	}
+	return usage, nil
+}
+
+func getPreemptionBudgetCheckerArgs(obj runtime.Object) (*config.PreemptionBudgetCheckerArgs, error) {
+	if obj == nil {
+		return &config.PreemptionBudgetCheckerArgs{}, nil
+	}
+	ptr, ok := obj.(*config.PreemptionBudgetCheckerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PreemptionBudgetCheckerArgs, got %T", obj)
+	}
+	return ptr, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"testing"
+	"time"
+
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	pt "github.com/kubewharf/godel-scheduler/pkg/binder/testing"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestPreemptionBudgetChecker(t *testing.T) {
+	controller := true
+	ownerRef := metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs", UID: "rs", Controller: &controller}
+	victims := []*v1.Pod{
+		testing_helper.MakePod().Namespace("ns").Name("p1").UID("p1").Node("n").ControllerRef(ownerRef).Obj(),
+		testing_helper.MakePod().Namespace("ns").Name("p2").UID("p2").Node("n").ControllerRef(ownerRef).Obj(),
+		testing_helper.MakePod().Namespace("ns").Name("p3").UID("p3").Node("n").ControllerRef(ownerRef).Obj(),
+	}
+	preemptor := testing_helper.MakePod().Namespace("ns").Name("preemptor").UID("preemptor").Node("n").
+		Annotation(podutil.NominatedNodeAnnotationKey, "{\"node\":\"n\",\"victims\":[{\"name\":\"p1\",\"namespace\":\"ns\",\"uid\":\"p1\"}]}").Obj()
+
+	client := fake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdClient := godelclientfake.NewSimpleClientset()
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	cacheHandler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(make(chan struct{})).
+		ComponentName("godel-binder").Obj()
+	binderCache := cache.New(cacheHandler)
+	fh, err := pt.NewBinderFrameworkHandle(client, crdClient, informerFactory, crdInformerFactory, binderCache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, victim := range victims {
+		if err := binderCache.AddPod(victim); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := binderCache.AssumePod(framework.MakeCachePodInfoWrapper().Pod(preemptor).Obj()); err != nil {
+		t.Fatal(err)
+	}
+
+	pl, err := NewPreemptionBudgetChecker(&config.PreemptionBudgetCheckerArgs{MaxVictimsPerOwner: 2}, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := pl.(*PreemptionBudgetChecker)
+
+	commonState := framework.NewCycleState()
+	if status := checker.ClusterPrePreempting(nil, nil, commonState); status != nil {
+		t.Errorf("failed to prepare preemption: %v", status)
+	}
+	expectedCodes := []framework.Code{framework.PreemptionNotSure, framework.PreemptionFail}
+	for i, victim := range victims[1:] {
+		gotCode, gotMsg := checker.VictimChecking(nil, victim, nil, commonState)
+		if gotCode != expectedCodes[i] {
+			t.Errorf("index %d, expected code %v, but got %v: %v", i, expectedCodes[i], gotCode, gotMsg)
+		}
+		if gotCode == framework.PreemptionFail {
+			continue
+		}
+		if status := checker.PostVictimChecking(nil, victim, nil, commonState); status != nil {
+			t.Errorf("index %d, get post preemption result error: %v", i, status)
+		}
+	}
+
+	// Victims will be counted again after the preemptor is forgotten.
+	if err := binderCache.ForgetPod(framework.MakeCachePodInfoWrapper().Pod(preemptor).Obj()); err != nil {
+		t.Fatal(err)
+	}
+	if gotCode, gotMsg := checker.VictimChecking(nil, victims[2], nil, framework.NewCycleState()); gotCode != framework.Error {
+		t.Errorf("expected error without preparing, but got %v: %v", gotCode, gotMsg)
+	}
+	if got := checker.pluginHandle.GetPreemptionCountOfOwner(podutil.GetPodOwner(victims[0]), time.Now().Add(-time.Hour)); got != 0 {
+		t.Errorf("expected no preemption record after preemptor is forgotten, but got %d", got)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/preempting/policymatrix"
+)
+
+const PreemptionPolicyMatrixName = policymatrix.PreemptionPolicyMatrixName
+
+// PreemptionPolicyMatrix re-checks the victims against the policy matrix in binder, since the
+// victims may be nominated by schedulers running with a different configuration.
+type PreemptionPolicyMatrix struct {
+	matrix *policymatrix.Matrix
+}
+
+var _ framework.VictimCheckingPlugin = &PreemptionPolicyMatrix{}
+
+// NewPreemptionPolicyMatrix initializes a new PreemptionPolicyMatrix plugin and returns it.
+func NewPreemptionPolicyMatrix(plArgs runtime.Object, _ handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := getPreemptionPolicyMatrixArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new PreemptionPolicyMatrix plugin: %v", err)
+	}
+	matrix, err := policymatrix.NewMatrix(args.Classes, args.Rules)
+	if err != nil {
+		return nil, err
+	}
+	return &PreemptionPolicyMatrix{matrix: matrix}, nil
+}
+
+func (ppm *PreemptionPolicyMatrix) Name() string {
+	return PreemptionPolicyMatrixName
+}
+
+func (ppm *PreemptionPolicyMatrix) VictimChecking(preemptor, pod *v1.Pod, _, _ *framework.CycleState) (framework.Code, string) {
+	return policymatrix.CheckPolicy(ppm.matrix, preemptor, pod)
+}
+
+func getPreemptionPolicyMatrixArgs(obj runtime.Object) (*config.PreemptionPolicyMatrixArgs, error) {
+	if obj == nil {
+		return &config.PreemptionPolicyMatrixArgs{}, nil
+	}
+	ptr, ok := obj.(*config.PreemptionPolicyMatrixArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PreemptionPolicyMatrixArgs, got %T", obj)
+	}
+	return ptr, nil
+}
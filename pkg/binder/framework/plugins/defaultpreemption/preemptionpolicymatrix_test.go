@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultpreemption
+
+import (
+	"testing"
+
+	"k8s.io/utils/pointer"
+
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func TestPreemptionPolicyMatrix(t *testing.T) {
+	args := &config.PreemptionPolicyMatrixArgs{
+		Classes: []apisconfig.PreemptionClass{
+			{Name: "online", PriorityClassNames: []string{"online"}},
+			{Name: "batch", PriorityClassNames: []string{"batch"}},
+		},
+		Rules: []apisconfig.PreemptionRule{
+			{Preemptor: "online", Victims: []string{"batch"}},
+		},
+	}
+	pl, err := NewPreemptionPolicyMatrix(args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := pl.(*PreemptionPolicyMatrix)
+
+	online := testing_helper.MakePod().Name("online").PriorityClassName("online").Priority(100).Obj()
+	batch := testing_helper.MakePod().Name("batch").PriorityClassName("batch").Priority(10).Obj()
+	if code, msg := checker.VictimChecking(online, batch, nil, nil); code != framework.PreemptionSucceed {
+		t.Errorf("expected online to preempt batch, got %v: %s", code, msg)
+	}
+	if code, _ := checker.VictimChecking(batch, online, nil, nil); code != framework.PreemptionFail {
+		t.Errorf("expected batch not to preempt online, got %v", code)
+	}
+	if code, _ := checker.VictimChecking(online, online, nil, nil); code != framework.PreemptionFail {
+		t.Errorf("expected online not to preempt online, got %v", code)
+	}
+
+	invalid := &config.PreemptionPolicyMatrixArgs{
+		Classes: []apisconfig.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+		Rules:   []apisconfig.PreemptionRule{{Preemptor: "online", Victims: []string{"unknown"}, MaxPriorityGap: pointer.Int64(-1)}},
+	}
+	if _, err := NewPreemptionPolicyMatrix(invalid, nil); err == nil {
+		t.Errorf("expected error for invalid args")
+	}
+}
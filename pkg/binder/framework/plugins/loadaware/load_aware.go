@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config/validation"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/loadaware"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = utils.Name
+
+	DefaultNodeMetricExpirationSeconds = 30
+)
+
+// LoadAware is a plugin that re-checks if the usage of the node reported by CNR exceeds the thresholds,
+// since the node usage may grow after the scheduling decision. Nothing will be checked without thresholds.
+type LoadAware struct {
+	expirationSeconds int64
+	usageThresholds   map[v1.ResourceName]int64
+}
+
+var _ framework.CheckConflictsPlugin = &LoadAware{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *LoadAware) Name() string {
+	return Name
+}
+
+// CheckConflicts invoked at the CheckConflicts extension point.
+func (pl *LoadAware) CheckConflicts(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	if pl.expirationSeconds <= 0 && len(pl.usageThresholds) == 0 {
+		return nil
+	}
+	resourceType, err := podutil.GetPodResourceType(pod)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	nodeMetricInfo := utils.NodeMetricInfoFromCNR(nodeInfo.GetCNR(), resourceType)
+	if nodeMetricInfo == nil {
+		// The node usage is unknown, leave it to the scheduler.
+		return nil
+	}
+	return utils.ValidateNodeMetric(nodeMetricInfo, nodeInfo, resourceType, pl.expirationSeconds, pl.usageThresholds)
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, _ handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := getLoadAwareArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new LoadAware plugin: %v", err)
+	}
+	if err := validation.ValidateLoadAwareArgs(args); err != nil {
+		return nil, err
+	}
+
+	pl := &LoadAware{usageThresholds: args.UsageThresholds}
+	if args.FilterExpiredNodeMetrics {
+		pl.expirationSeconds = args.NodeMetricExpirationSeconds
+		if pl.expirationSeconds == 0 {
+			pl.expirationSeconds = DefaultNodeMetricExpirationSeconds
+		}
+	}
+	return pl, nil
+}
+
+func getLoadAwareArgs(obj runtime.Object) (*config.LoadAwareArgs, error) {
+	if obj == nil {
+		return &config.LoadAwareArgs{}, nil
+	}
+	args, ok := obj.(*config.LoadAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type LoadAwareArgs, got %T", obj)
+	}
+	return args, nil
+}
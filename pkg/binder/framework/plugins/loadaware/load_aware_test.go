@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/loadaware"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+)
+
+func makeCNR(cpuUsage string, updateTime time.Time) *katalystv1alpha1.CustomNodeResource {
+	usage := resource.MustParse(cpuUsage)
+	return &katalystv1alpha1.CustomNodeResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "n"},
+		Status: katalystv1alpha1.CustomNodeResourceStatus{
+			NodeMetricStatus: &katalystv1alpha1.NodeMetricStatus{
+				UpdateTime: metav1.NewTime(updateTime),
+				GroupMetric: []katalystv1alpha1.GroupMetricInfo{
+					{
+						QoSLevel: string(util.SharedCores),
+						ResourceUsage: katalystv1alpha1.ResourceUsage{
+							GenericUsage: &katalystv1alpha1.ResourceMetric{
+								CPU:    &usage,
+								Memory: resource.NewQuantity(0, resource.BinarySI),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestLoadAwareCheckConflicts(t *testing.T) {
+	node := testinghelper.MakeNode().Name("n").Capacity(map[v1.ResourceName]string{v1.ResourceCPU: "10", v1.ResourceMemory: "10Gi"}).Obj()
+	pod := testinghelper.MakePod().Namespace("default").Name("p").Obj()
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		args       *config.LoadAwareArgs
+		cnr        *katalystv1alpha1.CustomNodeResource
+		wantStatus *framework.Status
+	}{
+		{
+			name: "nothing is checked without args",
+			cnr:  makeCNR("9", now),
+		},
+		{
+			name: "usage is under threshold",
+			args: &config.LoadAwareArgs{UsageThresholds: map[v1.ResourceName]int64{v1.ResourceCPU: 80}},
+			cnr:  makeCNR("7", now),
+		},
+		{
+			name:       "usage exceeds threshold after scheduling",
+			args:       &config.LoadAwareArgs{UsageThresholds: map[v1.ResourceName]int64{v1.ResourceCPU: 80}},
+			cnr:        makeCNR("9", now),
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, utils.ErrReasonUsageExceedsThreshold),
+		},
+		{
+			name:       "node metric expired",
+			args:       &config.LoadAwareArgs{FilterExpiredNodeMetrics: true},
+			cnr:        makeCNR("1", now.Add(-time.Minute)),
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, utils.ErrReasonNodeMetricExpired),
+		},
+		{
+			name: "node without cnr is skipped",
+			args: &config.LoadAwareArgs{UsageThresholds: map[v1.ResourceName]int64{v1.ResourceCPU: 80}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+			if test.cnr != nil {
+				nodeInfo.SetCNR(test.cnr)
+			}
+			var args runtime.Object
+			if test.args != nil {
+				args = test.args
+			}
+			p, err := New(args, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotStatus := p.(framework.CheckConflictsPlugin).CheckConflicts(context.Background(), framework.NewCycleState(), pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("expected status %v, but got %v", test.wantStatus, gotStatus)
+			}
+		})
+	}
+}
+
+func TestValidateLoadAwareArgs(t *testing.T) {
+	if _, err := New(&config.LoadAwareArgs{UsageThresholds: map[v1.ResourceName]int64{v1.ResourceCPU: 120}}, nil); err == nil {
+		t.Errorf("expected error for invalid usage threshold")
+	}
+	if _, err := New(&config.LoadAwareArgs{NodeMetricExpirationSeconds: -1}, nil); err == nil {
+		t.Errorf("expected error for negative expiration seconds")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepool
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	localstoragepoolstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/local_storage_pool_store"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/localstoragepool"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = utils.Name
+
+// LocalStoragePoolChecker is a plugin that re-checks the local storage pools of the node, since pods
+// from different schedulers may be placed on the same pool concurrently.
+// Nothing will be checked if LocalStoragePoolStore is disabled.
+type LocalStoragePoolChecker struct {
+	pluginHandle localstoragepoolstore.StoreHandle
+}
+
+var _ framework.CheckConflictsPlugin = &LocalStoragePoolChecker{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *LocalStoragePoolChecker) Name() string {
+	return Name
+}
+
+// CheckConflicts invoked at the CheckConflicts extension point.
+func (pl *LocalStoragePoolChecker) CheckConflicts(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	if pl.pluginHandle == nil {
+		return nil
+	}
+	requests := utils.GetPodLocalStorageRequests(pl.pluginHandle, pl.pluginHandle.PvcLister(), pod)
+	return utils.Fits(pl.pluginHandle, nodeInfo.GetNodeName(), requests)
+}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, handle handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	var pluginHandle localstoragepoolstore.StoreHandle
+	if ins := handle.FindStore(localstoragepoolstore.Name); ins != nil {
+		pluginHandle = ins.(localstoragepoolstore.StoreHandle)
+	}
+	return &LocalStoragePoolChecker{pluginHandle: pluginHandle}, nil
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepool
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	localstoragepoolstore "github.com/kubewharf/godel-scheduler/pkg/binder/cache/commonstores/local_storage_pool_store"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	fakelisters "github.com/kubewharf/godel-scheduler/pkg/framework/api/fake"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func makePodWithLocalVolume(name, nodeName, storageClass, size string) *v1.Pod {
+	pod := testinghelper.MakePod().Namespace("default").Name(name).UID(name).Node(nodeName).Obj()
+	pod.Spec.Volumes = []v1.Volume{
+		{
+			Name: "data",
+			VolumeSource: v1.VolumeSource{
+				Ephemeral: &v1.EphemeralVolumeSource{
+					VolumeClaimTemplate: &v1.PersistentVolumeClaimTemplate{
+						Spec: v1.PersistentVolumeClaimSpec{
+							StorageClassName: &storageClass,
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(size)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return pod
+}
+
+func makePodWithClaim(name, nodeName, claimName string) *v1.Pod {
+	pod := testinghelper.MakePod().Namespace("default").Name(name).UID(name).Node(nodeName).Obj()
+	pod.Spec.Volumes = []v1.Volume{
+		{
+			Name:         "data",
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claimName}},
+		},
+	}
+	return pod
+}
+
+func TestLocalStoragePoolCheckConflicts(t *testing.T) {
+	ssd := "ssd"
+	boundClaim := v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "bound", Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &ssd,
+			Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("50Gi")}},
+			VolumeName:       "pv",
+		},
+	}
+	pvcLister := fakelisters.PersistentVolumeClaimLister{boundClaim}
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{framework.LocalStoragePoolResourcePrefix + "ssd": resource.MustParse("100Gi")},
+		},
+	}
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+
+	tests := []struct {
+		name         string
+		existingPods []*v1.Pod
+		assumedPods  []*v1.Pod
+		pod          *v1.Pod
+		wantCode     framework.Code
+	}{
+		{
+			name:     "pool has enough storage",
+			pod:      makePodWithLocalVolume("p", "", "ssd", "60Gi"),
+			wantCode: framework.Success,
+		},
+		{
+			name:     "non-local storage class is ignored",
+			pod:      makePodWithLocalVolume("p", "", "nas", "1Ti"),
+			wantCode: framework.Success,
+		},
+		{
+			name:         "pool is occupied by bound pods",
+			existingPods: []*v1.Pod{makePodWithLocalVolume("e", "n", "ssd", "50Gi")},
+			pod:          makePodWithLocalVolume("p", "", "ssd", "60Gi"),
+			wantCode:     framework.Unschedulable,
+		},
+		{
+			name:        "pool is occupied by pods assumed by binder",
+			assumedPods: []*v1.Pod{makePodWithLocalVolume("a", "n", "ssd", "50Gi")},
+			pod:         makePodWithLocalVolume("p", "", "ssd", "60Gi"),
+			wantCode:    framework.Unschedulable,
+		},
+		{
+			name:         "pool is occupied by pods with bound claims",
+			existingPods: []*v1.Pod{makePodWithClaim("e", "n", "bound")},
+			pod:          makePodWithLocalVolume("p", "", "ssd", "60Gi"),
+			wantCode:     framework.Unschedulable,
+		},
+		{
+			name:         "bound claims of the pod are not requested again",
+			existingPods: []*v1.Pod{makePodWithLocalVolume("e", "n", "ssd", "60Gi")},
+			pod:          makePodWithClaim("p", "", "bound"),
+			wantCode:     framework.Success,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := localstoragepoolstore.NewCache(commoncache.MakeCacheHandlerWrapper().PVCLister(pvcLister).Obj())
+			store.AddNode(node)
+			for _, p := range tt.existingPods {
+				store.AddPod(p)
+			}
+			for _, p := range tt.assumedPods {
+				store.AssumePod(framework.MakeCachePodInfoWrapper().Pod(p).Obj())
+			}
+
+			pl := &LocalStoragePoolChecker{pluginHandle: store.(localstoragepoolstore.StoreHandle)}
+			status := pl.CheckConflicts(context.Background(), framework.NewCycleState(), tt.pod, nodeInfo)
+			if status.Code() != tt.wantCode {
+				t.Errorf("expected code %v, but got %v", tt.wantCode, status)
+			}
+		})
+	}
+}
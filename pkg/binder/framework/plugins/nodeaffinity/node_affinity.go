@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeaffinity
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/nodeaffinity"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = utils.Name
+
+// NodeAffinity is a plugin that re-checks if the node still matches the nodeSelector and
+// required node affinity of the pod, since node labels may be changed after the scheduling decision.
+type NodeAffinity struct{}
+
+var _ framework.CheckConflictsPlugin = &NodeAffinity{}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodeAffinity) Name() string {
+	return Name
+}
+
+// CheckConflicts invoked at the CheckConflicts extension point.
+func (pl *NodeAffinity) CheckConflicts(_ context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	return utils.Fits(state, pod, nodeInfo)
+}
+
+// New initializes a new plugin and returns it.
+func New(_ runtime.Object, _ handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	return &NodeAffinity{}, nil
+}
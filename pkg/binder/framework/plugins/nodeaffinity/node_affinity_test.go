@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeaffinity
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/nodeaffinity"
+)
+
+func TestNodeAffinityCheckConflicts(t *testing.T) {
+	affinityPod := &v1.Pod{Spec: v1.PodSpec{Affinity: &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{{
+				MatchExpressions: []v1.NodeSelectorRequirement{{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"a"}}},
+			}},
+		},
+	}}}}
+
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		labels     map[string]string
+		wantStatus *framework.Status
+	}{
+		{
+			name:   "node matches node selector",
+			pod:    &v1.Pod{Spec: v1.PodSpec{NodeSelector: map[string]string{"pool": "x"}}},
+			labels: map[string]string{"pool": "x"},
+		},
+		{
+			name:       "node is relabeled after scheduling",
+			pod:        &v1.Pod{Spec: v1.PodSpec{NodeSelector: map[string]string{"pool": "x"}}},
+			labels:     map[string]string{"pool": "y"},
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, utils.ErrNodeSelectorNotMatching.Error()),
+		},
+		{
+			name:   "node matches required node affinity",
+			pod:    affinityPod,
+			labels: map[string]string{"zone": "a"},
+		},
+		{
+			name:       "node doesn't match required node affinity",
+			pod:        affinityPod,
+			labels:     map[string]string{"zone": "b"},
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, utils.ErrNodeAffinityNotMatching.Error()),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: test.labels}})
+			p, _ := New(nil, nil)
+			gotStatus := p.(framework.CheckConflictsPlugin).CheckConflicts(context.Background(), framework.NewCycleState(), test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("expected status %v, but got %v", test.wantStatus, gotStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemanagerbinder
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// AnnotationLauncherName is the name of the in-tree AnnotationLauncher in the plugin args.
+const AnnotationLauncherName = "Annotation"
+
+// AnnotationLauncher hands pods over by annotating them with the node before binding them with
+// the Binding API, so that node managers can tell the pods they should launch from the others
+// bound to the node.
+type AnnotationLauncher struct {
+	client kubernetes.Interface
+}
+
+var _ Launcher = &AnnotationLauncher{}
+
+// NewAnnotationLauncher creates an AnnotationLauncher.
+func NewAnnotationLauncher(client kubernetes.Interface) *AnnotationLauncher {
+	return &AnnotationLauncher{client: client}
+}
+
+// Launch annotates the pod with the node, and binds the pod to the node.
+func (l *AnnotationLauncher) Launch(ctx context.Context, pod *v1.Pod, nodeName string) error {
+	if pod.Annotations[podutil.NodeManagerNodeAnnotationKey] != nodeName {
+		newPod := pod.DeepCopy()
+		if newPod.Annotations == nil {
+			newPod.Annotations = make(map[string]string)
+		}
+		newPod.Annotations[podutil.NodeManagerNodeAnnotationKey] = nodeName
+		if err := util.PatchPod(l.client, pod, newPod); err != nil {
+			return fmt.Errorf("failed to annotate pod with node %s: %v", nodeName, err)
+		}
+	}
+
+	binding := &v1.Binding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name, UID: pod.UID},
+		Target:     v1.ObjectReference{Kind: "Node", Name: nodeName},
+	}
+	return l.client.CoreV1().Pods(pod.Namespace).Bind(ctx, binding, metav1.CreateOptions{})
+}
+
+func newLauncher(name string, client kubernetes.Interface) (Launcher, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case AnnotationLauncherName:
+		return NewAnnotationLauncher(client), nil
+	default:
+		return nil, fmt.Errorf("unknown launcher %q, only %s is supported", name, AnnotationLauncherName)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemanagerbinder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	pt "github.com/kubewharf/godel-scheduler/pkg/binder/testing"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestAnnotationLauncher(t *testing.T) {
+	tests := []struct {
+		name           string
+		bindErr        error
+		wantBound      bool
+		wantAnnotation string
+		wantErr        bool
+	}{
+		{
+			name:           "pod is annotated and bound",
+			wantBound:      true,
+			wantAnnotation: "nm-ready",
+		},
+		{
+			name:           "binding error",
+			bindErr:        errors.New("binding error"),
+			wantAnnotation: "nm-ready",
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := makePod("nm-pod", podutil.NodeManager)
+			client := fake.NewSimpleClientset(pod)
+			var gotBinding *v1.Binding
+			client.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "binding" {
+					return false, nil, nil
+				}
+				if tt.bindErr != nil {
+					return true, nil, tt.bindErr
+				}
+				gotBinding = action.(clienttesting.CreateAction).GetObject().(*v1.Binding)
+				return true, gotBinding, nil
+			})
+
+			err := NewAnnotationLauncher(client).Launch(context.Background(), pod, "nm-ready")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if bound := gotBinding != nil && gotBinding.Target.Name == "nm-ready"; bound != tt.wantBound {
+				t.Errorf("expected bound %v, got binding %v", tt.wantBound, gotBinding)
+			}
+			got, err := client.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if annotation := got.Annotations[podutil.NodeManagerNodeAnnotationKey]; annotation != tt.wantAnnotation {
+				t.Errorf("expected annotation %q, got %q", tt.wantAnnotation, annotation)
+			}
+		})
+	}
+}
+
+func TestNewWithArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         runtime.Object
+		wantLauncher bool
+		wantErr      bool
+	}{
+		{
+			name: "no args",
+		},
+		{
+			name: "no launcher",
+			args: &config.NodeManagerBinderArgs{},
+		},
+		{
+			name:         "annotation launcher",
+			args:         &config.NodeManagerBinderArgs{Launcher: AnnotationLauncherName},
+			wantLauncher: true,
+		},
+		{
+			name:    "unknown launcher",
+			args:    &config.NodeManagerBinderArgs{Launcher: "Status"},
+			wantErr: true,
+		},
+		{
+			name:    "unexpected args",
+			args:    &config.NodePoolArgs{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := makePod("nm-pod", podutil.NodeManager)
+			client := fake.NewSimpleClientset(pod)
+			informerFactory := informers.NewSharedInformerFactory(client, 0)
+			cacheHandler := commoncache.MakeCacheHandlerWrapper().
+				Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(make(chan struct{})).
+				ComponentName("godel-binder").Obj()
+			binderCache := cache.New(cacheHandler)
+			binderCache.AddNMNode(makeNMNode("nm-ready", v1.ConditionTrue))
+			fh, err := pt.NewBinderFrameworkHandle(client, godelclientfake.NewSimpleClientset(), informerFactory, nil, binderCache)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pl, err := New(tt.args, fh)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+
+			// pods are left to DefaultBinder without launcher, and bound by the launcher otherwise.
+			status := pl.(*NodeManagerBinder).Bind(context.Background(), framework.NewCycleState(), pod, "nm-ready")
+			wantCode := framework.Skip
+			if tt.wantLauncher {
+				wantCode = framework.Success
+			}
+			if got := status.Code(); got != wantCode {
+				t.Errorf("expected code %v, got %v: %v", wantCode, got, status.Message())
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemanagerbinder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/metrics"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// Name of the plugin used in the plugin registry and configurations.
+const Name = "NodeManagerBinder"
+
+const (
+	// ErrReasonNMNodeNotFound is used when the node has no NMNode to launch the pod.
+	ErrReasonNMNodeNotFound = "node(s) didn't have NMNode for node-manager pods"
+	// ErrReasonNMNodeNotReady is used when the NMNode reports it is not ready.
+	ErrReasonNMNodeNotReady = "node(s) had NMNode not ready"
+)
+
+// Launcher hands a pod over to the node manager of the node, the pod must be visible as bound to the node
+// once Launch returns so that the caches of scheduler and binder can confirm the assumed pod.
+type Launcher interface {
+	Launch(ctx context.Context, pod *v1.Pod, nodeName string) error
+}
+
+// NodeManagerBinder checks the NMNode view of the node for the pods launched by node manager and hands them over
+// through a Launcher. The pods launched by kubelet are skipped so that they are bound by the following bind plugins.
+//
+// There is no in-tree launcher, since how pods are handed over depends on the node manager. Without a launcher,
+// which is the case of the in-tree plugin, the node manager pods are skipped after the NMNode check as well and
+// bound by DefaultBinder with the Binding API. A launcher must be injected via NewWithLauncher otherwise.
+type NodeManagerBinder struct {
+	handle   handle.BinderFrameworkHandle
+	launcher Launcher
+}
+
+var (
+	_ framework.CheckConflictsPlugin = &NodeManagerBinder{}
+	_ framework.BindPlugin           = &NodeManagerBinder{}
+)
+
+// New creates a NodeManagerBinder without launcher, which leaves the binding of node manager pods to DefaultBinder.
+func New(plArgs runtime.Object, handle handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := GetArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	launcher, err := newLauncher(args.Launcher, handle.ClientSet())
+	if err != nil {
+		return nil, err
+	}
+	return &NodeManagerBinder{handle: handle, launcher: launcher}, nil
+}
+
+// GetArgs returns the NodeManagerBinderArgs of the plugin args.
+func GetArgs(obj runtime.Object) (*config.NodeManagerBinderArgs, error) {
+	if obj == nil {
+		return &config.NodeManagerBinderArgs{}, nil
+	}
+	ptr, ok := obj.(*config.NodeManagerBinderArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NodeManagerBinderArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// NewWithLauncher returns the factory of a NodeManagerBinder using the given launcher, it can be used to register
+// an out-of-tree plugin handing pods over to node manager in another way, e.g. through a CRD.
+func NewWithLauncher(launcher Launcher) func(runtime.Object, handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	return func(_ runtime.Object, handle handle.BinderFrameworkHandle) (framework.Plugin, error) {
+		return &NodeManagerBinder{handle: handle, launcher: launcher}, nil
+	}
+}
+
+// Name returns the name of the plugin.
+func (b *NodeManagerBinder) Name() string {
+	return Name
+}
+
+// CheckConflicts re-checks the NMNode view of the node for the pods launched by node manager, since the NMNode
+// may be removed or become not ready after the scheduling decision.
+func (b *NodeManagerBinder) CheckConflicts(_ context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	if launcher, _ := podutil.GetPodLauncher(pod); launcher != podutil.NodeManager {
+		return nil
+	}
+	return checkNMNode(state, pod, nodeInfo)
+}
+
+// Bind hands the pods launched by node manager over to the launcher, or skips them if there is no launcher.
+func (b *NodeManagerBinder) Bind(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	if launcher, _ := podutil.GetPodLauncher(pod); launcher != podutil.NodeManager {
+		return framework.NewStatus(framework.Skip, "")
+	}
+	// the conflicts were checked on a snapshot of the node, check the latest NMNode again before handing over.
+	if status := checkNMNode(state, pod, b.handle.GetNodeInfo(nodeName)); !status.IsSuccess() {
+		return status
+	}
+	if b.launcher == nil {
+		return framework.NewStatus(framework.Skip, "")
+	}
+
+	klog.V(3).InfoS("Started to hand pod over to node manager", "pod", klog.KObj(pod), "nodeName", nodeName)
+	startTime := time.Now()
+	if err := b.launcher.Launch(ctx, pod, nodeName); err != nil {
+		metrics.PodOperatingLatencyObserve(framework.ExtractPodProperty(pod), metrics.FailureResult, metrics.BindPod, metrics.SinceInSeconds(startTime))
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	metrics.PodOperatingLatencyObserve(framework.ExtractPodProperty(pod), metrics.SuccessResult, metrics.BindPod, metrics.SinceInSeconds(startTime))
+	return nil
+}
+
+func checkNMNode(state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	if nodeInfo == nil || nodeInfo.GetNMNode() == nil {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonNMNodeNotFound)
+	}
+	if _, status := podlauncher.NodeFits(state, pod, nodeInfo); status != nil {
+		return status
+	}
+	for _, condition := range nodeInfo.GetNMNode().Status.NodeCondition {
+		if condition != nil && condition.Type == v1.NodeReady && condition.Status != v1.ConditionTrue {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("%s: %s", ErrReasonNMNodeNotReady, condition.Reason))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodemanagerbinder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+
+	nodev1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/node/v1alpha1"
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	pt "github.com/kubewharf/godel-scheduler/pkg/binder/testing"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func makePod(name string, launcher podutil.PodLauncher) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			UID:       types.UID("uid-" + name),
+			Annotations: map[string]string{
+				podutil.PodLauncherAnnotationKey:     string(launcher),
+				podutil.PodResourceTypeAnnotationKey: string(podutil.BestEffortPod),
+			},
+		},
+	}
+}
+
+func makeNMNode(name string, ready v1.ConditionStatus) *nodev1alpha1.NMNode {
+	return &nodev1alpha1.NMNode{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: nodev1alpha1.NMNodeStatus{
+			NodeCondition: []*v1.NodeCondition{{Type: v1.NodeReady, Status: ready, Reason: "NodeManagerReport"}},
+		},
+	}
+}
+
+func TestNodeManagerBinder(t *testing.T) {
+	tests := []struct {
+		name         string
+		pod          *v1.Pod
+		nodeName     string
+		launchErr    error
+		noLauncher   bool
+		wantCode     framework.Code
+		wantLaunched bool
+	}{
+		{
+			name:     "kubelet pods are left to the following binders",
+			pod:      makePod("kubelet-pod", podutil.Kubelet),
+			nodeName: "nm-ready",
+			wantCode: framework.Skip,
+		},
+		{
+			name:         "node manager pods are handed over",
+			pod:          makePod("nm-pod", podutil.NodeManager),
+			nodeName:     "nm-ready",
+			wantCode:     framework.Success,
+			wantLaunched: true,
+		},
+		{
+			name:       "node manager pods are left to the following binders without launcher",
+			pod:        makePod("nm-pod", podutil.NodeManager),
+			nodeName:   "nm-ready",
+			noLauncher: true,
+			wantCode:   framework.Skip,
+		},
+		{
+			name:       "NMNode is still checked without launcher",
+			pod:        makePod("nm-pod", podutil.NodeManager),
+			nodeName:   "nm-not-ready",
+			noLauncher: true,
+			wantCode:   framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:     "node without NMNode",
+			pod:      makePod("nm-pod", podutil.NodeManager),
+			nodeName: "kubelet-only",
+			wantCode: framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:     "unknown node",
+			pod:      makePod("nm-pod", podutil.NodeManager),
+			nodeName: "unknown",
+			wantCode: framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:     "NMNode not ready",
+			pod:      makePod("nm-pod", podutil.NodeManager),
+			nodeName: "nm-not-ready",
+			wantCode: framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:      "launch failure",
+			pod:       makePod("nm-pod", podutil.NodeManager),
+			nodeName:  "nm-ready",
+			launchErr: errors.New("node manager unavailable"),
+			wantCode:  framework.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			informerFactory := informers.NewSharedInformerFactory(client, 0)
+			cacheHandler := commoncache.MakeCacheHandlerWrapper().
+				Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(make(chan struct{})).
+				ComponentName("godel-binder").Obj()
+			binderCache := cache.New(cacheHandler)
+			binderCache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "kubelet-only"}})
+			binderCache.AddNMNode(makeNMNode("nm-ready", v1.ConditionTrue))
+			binderCache.AddNMNode(makeNMNode("nm-not-ready", v1.ConditionFalse))
+			fh, err := pt.NewBinderFrameworkHandle(client, godelclientfake.NewSimpleClientset(), informerFactory, nil, binderCache)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			launcher := pt.NewFakeLauncher()
+			launcher.Err = tt.launchErr
+			pl, _ := NewWithLauncher(launcher)(nil, fh)
+			if tt.noLauncher {
+				pl, _ = New(nil, fh)
+			}
+			binder := pl.(*NodeManagerBinder)
+
+			status := binder.Bind(context.Background(), framework.NewCycleState(), tt.pod, tt.nodeName)
+			if got := status.Code(); got != tt.wantCode {
+				t.Errorf("expected code %v, got %v: %v", tt.wantCode, got, status.Message())
+			}
+			nodeName, launched := launcher.LaunchedNode(podutil.GetPodKey(tt.pod))
+			if launched != tt.wantLaunched || (launched && nodeName != tt.nodeName) {
+				t.Errorf("expected launched %v on %s, got %v on %s", tt.wantLaunched, tt.nodeName, launched, nodeName)
+			}
+
+			// conflicts are checked against the same NMNode view, and kubelet pods are not affected.
+			conflictStatus := binder.CheckConflicts(context.Background(), framework.NewCycleState(), tt.pod, binderCache.GetNodeInfo(tt.nodeName))
+			wantConflict := tt.wantCode == framework.UnschedulableAndUnresolvable
+			if conflictStatus.IsSuccess() == wantConflict {
+				t.Errorf("expected conflict %v, got status %v", wantConflict, conflictStatus)
+			}
+		})
+	}
+}
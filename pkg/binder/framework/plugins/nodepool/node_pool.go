@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = nodepool.Name
+
+// NewPools converts the node pools of the plugin args and compiles them, the args are shared by
+// the NodePool plugin and the NodePoolChecker preemption plugin.
+func NewPools(args *config.NodePoolArgs) (nodepool.Pools, error) {
+	specs := make([]nodepool.Spec, len(args.Pools))
+	for i, pool := range args.Pools {
+		specs[i] = nodepool.Spec{
+			Name:         pool.Name,
+			Mode:         nodepool.Mode(pool.Mode),
+			NodeSelector: pool.NodeSelector,
+			Namespaces:   pool.Namespaces,
+			PodSelector:  pool.PodSelector,
+		}
+	}
+	return nodepool.NewPools(specs)
+}
+
+// NodePool is a plugin that re-checks if the node belongs to the dedicated node pool of other tenants,
+// since the pod may be scheduled by a scheduler with different node pools.
+type NodePool struct {
+	pools nodepool.Pools
+}
+
+var _ framework.CheckConflictsPlugin = &NodePool{}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, _ handle.BinderFrameworkHandle) (framework.Plugin, error) {
+	args, err := GetArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := NewPools(args)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePool{pools: pools}, nil
+}
+
+// GetArgs returns the NodePoolArgs of the plugin args.
+func GetArgs(obj runtime.Object) (*config.NodePoolArgs, error) {
+	if obj == nil {
+		return &config.NodePoolArgs{}, nil
+	}
+	ptr, ok := obj.(*config.NodePoolArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NodePoolArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodePool) Name() string {
+	return Name
+}
+
+// CheckConflicts invoked at the CheckConflicts extension point.
+func (pl *NodePool) CheckConflicts(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	return pl.pools.Fits(pod, nodeInfo)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func TestNodePoolCheckConflicts(t *testing.T) {
+	args := &config.NodePoolArgs{
+		Pools: []config.NodePool{
+			{
+				Name:         "dedicated",
+				Mode:         "Dedicated",
+				NodeSelector: map[string]string{"pool": "dedicated"},
+				Namespaces:   []string{"tenant-a"},
+			},
+		},
+	}
+	tests := []struct {
+		name      string
+		pod       *v1.Pod
+		nodeLabel string
+		wantCode  framework.Code
+	}{
+		{
+			name:      "tenant on dedicated pool",
+			pod:       testinghelper.MakePod().Namespace("tenant-a").Name("p").Obj(),
+			nodeLabel: "dedicated",
+			wantCode:  framework.Success,
+		},
+		{
+			name:      "other pod on dedicated pool",
+			pod:       testinghelper.MakePod().Namespace("default").Name("p").Obj(),
+			nodeLabel: "dedicated",
+			wantCode:  framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:      "node not in any pool",
+			pod:       testinghelper.MakePod().Namespace("default").Name("p").Obj(),
+			nodeLabel: "none",
+			wantCode:  framework.Success,
+		},
+	}
+
+	pl, err := New(args, nil)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(testinghelper.MakeNode().Name("n").Label("pool", tt.nodeLabel).Obj())
+
+			status := pl.(framework.CheckConflictsPlugin).CheckConflicts(context.Background(), framework.NewCycleState(), tt.pod, nodeInfo)
+			if status.Code() != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, status.Code())
+			}
+		})
+	}
+
+	args.Pools[0].Mode = "Unknown"
+	if _, err := New(args, nil); err == nil {
+		t.Errorf("expected error for unknown node pool mode")
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeunschedulable
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/nodeunschedulable"
+)
+
+func TestNodeUnschedulableCheckConflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		pod           *v1.Pod
+		unschedulable bool
+		wantStatus    *framework.Status
+	}{
+		{
+			name: "node is schedulable",
+			pod:  &v1.Pod{},
+		},
+		{
+			name:          "node is cordoned after scheduling",
+			pod:           &v1.Pod{},
+			unschedulable: true,
+			wantStatus:    framework.NewStatus(framework.UnschedulableAndUnresolvable, utils.ErrReasonUnschedulable),
+		},
+		{
+			name: "pod tolerates the unschedulable taint",
+			pod: &v1.Pod{Spec: v1.PodSpec{Tolerations: []v1.Toleration{
+				{Key: v1.TaintNodeUnschedulable, Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+			}}},
+			unschedulable: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(&v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "n"},
+				Spec:       v1.NodeSpec{Unschedulable: test.unschedulable},
+			})
+			p, _ := New(nil, nil)
+			gotStatus := p.(framework.CheckConflictsPlugin).CheckConflicts(context.Background(), framework.NewCycleState(), test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("expected status %v, but got %v", test.wantStatus, gotStatus)
+			}
+		})
+	}
+}
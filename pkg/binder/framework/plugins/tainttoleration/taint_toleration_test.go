@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tainttoleration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+)
+
+func TestTaintTolerationCheckConflicts(t *testing.T) {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n"},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{Key: "dedicated", Value: "user1", Effect: v1.TaintEffectNoSchedule}},
+		},
+	}
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		wantStatus *framework.Status
+	}{
+		{
+			name:       "pod doesn't tolerate the taint added after scheduling",
+			pod:        &v1.Pod{},
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, "node(s) had taint {dedicated: user1}, that the pod didn't tolerate"),
+		},
+		{
+			name: "pod tolerates the taint",
+			pod: &v1.Pod{Spec: v1.PodSpec{Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "user1", Effect: v1.TaintEffectNoSchedule},
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(node)
+			p, _ := New(nil, nil)
+			gotStatus := p.(framework.CheckConflictsPlugin).CheckConflicts(context.Background(), framework.NewCycleState(), test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("expected status %v, but got %v", test.wantStatus, gotStatus)
+			}
+		})
+	}
+}
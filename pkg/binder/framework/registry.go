@@ -25,10 +25,17 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultbinder"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultpreemption"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/loadaware"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/localstoragepool"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeaffinity"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodemanagerbinder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeports"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/noderesources"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodeunschedulable"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodevolumelimits"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nonnativeresource"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/tainttoleration"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/volumebinding"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 )
@@ -41,6 +48,18 @@ type PluginFactory = func(configuration runtime.Object, pfh handle.BinderFramewo
 // All plugins must be in the registry before initializing the framework.
 type Registry map[string]PluginFactory
 
+// Merge merges the provided registry to the current one, it returns an error
+// if a plugin in the provided registry is already registered.
+func (r Registry) Merge(in Registry) error {
+	for name, factory := range in {
+		if _, ok := r[name]; ok {
+			return fmt.Errorf("a plugin named %v already exists", name)
+		}
+		r[name] = factory
+	}
+	return nil
+}
+
 // NewInTreeRegistry builds the registry with all the in-tree plugins.
 // A scheduler that runs out of tree plugins can register additional plugins
 // through the WithFrameworkOutOfTreeRegistry option.
@@ -48,6 +67,7 @@ type Registry map[string]PluginFactory
 func NewInTreeRegistry() Registry {
 	return Registry{
 		defaultbinder.Name:              defaultbinder.New,
+		nodemanagerbinder.Name:          nodemanagerbinder.New,
 		noderesources.ConflictCheckName: noderesources.NewConflictCheck,
 		nodevolumelimits.CSIName:        nodevolumelimits.NewCSI,
 		nodevolumelimits.CinderName:     nodevolumelimits.NewCinder,
@@ -57,13 +77,22 @@ func NewInTreeRegistry() Registry {
 		volumebinding.Name:              volumebinding.New,
 		nodeports.Name:                  nodeports.New,
 		nonnativeresource.Name:          nonnativeresource.New,
+		tainttoleration.Name:            tainttoleration.New,
+		nodeaffinity.Name:               nodeaffinity.New,
+		nodeunschedulable.Name:          nodeunschedulable.New,
+		loadaware.Name:                  loadaware.New,
+		localstoragepool.Name:           localstoragepool.New,
+		nodepool.Name:                   nodepool.New,
 	}
 }
 
 func NewInTreePreemptionRegistry() Registry {
 	return Registry{
 		// preemption plugins
-		defaultpreemption.PDBCheckerName: defaultpreemption.NewPDBChecker,
+		defaultpreemption.PDBCheckerName:              defaultpreemption.NewPDBChecker,
+		defaultpreemption.PreemptionBudgetCheckerName: defaultpreemption.NewPreemptionBudgetChecker,
+		defaultpreemption.PreemptionPolicyMatrixName:  defaultpreemption.NewPreemptionPolicyMatrix,
+		defaultpreemption.NodePoolCheckerName:         defaultpreemption.NewNodePoolChecker,
 	}
 }
 
@@ -25,6 +25,7 @@ import (
 	"golang.org/x/time/rate"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -63,6 +64,8 @@ import (
 const (
 	MaxPreemptionBackoffPeriodInSeconds = 600
 	MaxRetryAttempts                    = 3 // TODO: 5 will cause a timeout in UT (30s)
+
+	pendingLifecycleScanPeriod = 30 * time.Second
 )
 
 // Binder watches for assumed pods from multiple schedulers,
@@ -106,6 +109,11 @@ type Binder struct {
 	// schedulerInfo *SchedulerInfo
 
 	movementController controller.CommonController
+
+	cacheComparer      *cachedebugger.PeriodicComparer
+	cacheComparePeriod time.Duration
+
+	lifecycleTracker *metrics.LifecycleTracker
 }
 
 // New returns a Binder
@@ -166,6 +174,8 @@ func New(
 
 		podLister: informerFactory.Core().V1().Pods().Lister(),
 		pgLister:  crdInformerFactory.Scheduling().V1alpha1().PodGroups().Lister(),
+
+		lifecycleTracker: metrics.NewLifecycleTracker(options.schedulingSLOThresholdSeconds),
 	}
 
 	// Setup cache debugger.
@@ -176,6 +186,16 @@ func New(
 		binderQueue,
 	)
 	debugger.ListenForSignal(stopEverything)
+	if options.cacheComparePeriod > 0 {
+		binder.cacheComparer = cachedebugger.NewPeriodicComparer(
+			informerFactory.Core().V1().Nodes().Lister(),
+			informerFactory.Core().V1().Pods().Lister(),
+			binderCache,
+			recorder,
+			options.enableCacheSelfHealing,
+		)
+		binder.cacheComparePeriod = options.cacheComparePeriod
+	}
 	binder.initializeReschedulingModule(crdInformerFactory, stopEverything, crdClient)
 
 	if utilfeature.DefaultFeatureGate.Enabled(features.ResourceReservation) {
@@ -227,6 +247,14 @@ func (binder *Binder) Run(ctx context.Context) {
 		binder.movementController.Run()
 	}
 
+	if binder.cacheComparer != nil {
+		binder.cacheComparer.Run(binder.cacheComparePeriod, ctx.Done())
+	}
+
+	if binder.lifecycleTracker.Enabled() {
+		go wait.Until(binder.scanPendingPodLifecycle, pendingLifecycleScanPeriod, ctx.Done())
+	}
+
 	<-ctx.Done()
 }
 
@@ -1146,11 +1174,42 @@ func (binder *Binder) bindTasks(ctx context.Context, unitInfo *bindingUnitInfo)
 		if success {
 			metrics.ObservePodBinderE2ELatency(task.queuedPodInfo)
 			metrics.ObservePodGodelE2E(task.queuedPodInfo)
+			binder.observePodLifecycle(task.queuedPodInfo)
 		}
 	})
 	return failedTaskToError
 }
 
+// observePodLifecycle records the lifecycle stage latencies of the bound pod and
+// emits warning events for the stages breaching the SLO thresholds.
+func (binder *Binder) observePodLifecycle(podInfo *framework.QueuedPodInfo) {
+	breaches := binder.lifecycleTracker.ObservePodLifecycle(podInfo, time.Now())
+	for _, breach := range breaches {
+		klog.V(4).InfoS("Pod lifecycle stage breached SLO", "pod", klog.KObj(podInfo.Pod), "stage", breach.Stage, "duration", breach.Duration, "threshold", breach.Threshold)
+		if binder.recorder != nil {
+			binder.recorder.Eventf(podInfo.Pod, nil, v1.EventTypeWarning, "SchedulingSLOBreached", "Binding",
+				"Stage %s took %v, exceeding the SLO threshold %v", breach.Stage, breach.Duration, breach.Threshold)
+		}
+	}
+}
+
+// scanPendingPodLifecycle checks the pods which are not bound yet against the SLO thresholds, so that
+// the pods stuck in dispatching, queueing, scheduling or binding are reported before they are bound.
+func (binder *Binder) scanPendingPodLifecycle() {
+	pods, err := binder.podLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Failed to list pods for lifecycle SLO")
+		return
+	}
+	for _, breach := range binder.lifecycleTracker.ScanPendingPods(pods, time.Now()) {
+		klog.V(4).InfoS("Pending pod lifecycle stage breached SLO", "pod", klog.KObj(breach.Pod), "stage", breach.Stage, "duration", breach.Duration, "threshold", breach.Threshold)
+		if binder.recorder != nil {
+			binder.recorder.Eventf(breach.Pod, nil, v1.EventTypeWarning, "SchedulingSLOBreached", "Pending",
+				"Pod has been in stage %s for %v, exceeding the SLO threshold %v", breach.Stage, breach.Duration, breach.Threshold)
+		}
+	}
+}
+
 func deleteVictimsForTask(cli clientset.Interface, task *runningUnitInfo) (returnErr error) {
 	podTrace := task.getSchedulingTrace()
 	traceContext := podTrace.NewTraceContext(tracing.RootSpan, tracing.BinderDeleteVictimsSpan)
@@ -27,9 +27,12 @@ import (
 	godelclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
 	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/apis"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/binder/cache"
 	binderframework "github.com/kubewharf/godel-scheduler/pkg/binder/framework"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultpreemption"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/runtime"
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
@@ -46,6 +49,16 @@ type frameworkHandleImpl struct {
 	// VolumeBinder handles PVC/PV binding for the pod.
 	volumeBinder scheduling.GodelVolumeBinder
 
+	// defaultPlugins are used by pods that do not belong to any sub-cluster with its own profile.
+	defaultPlugins *binderPlugins
+	// subClusterKey is used to get the sub-cluster of a pod from its node selector.
+	subClusterKey string
+	// subClusterPlugins are keyed by sub-cluster name.
+	subClusterPlugins map[string]*binderPlugins
+}
+
+// binderPlugins holds the plugins of a binder profile.
+type binderPlugins struct {
 	// basePlugins is the collection of all plugins supposed to run when a pod is scheduled
 	basePlugins *apis.BinderPluginCollection
 	// pluginRegistry is the collection of all enabled plugins
@@ -76,11 +89,36 @@ func NewFrameworkHandle(
 			informerFactory.Core().V1().PersistentVolumeClaims(),
 			informerFactory.Core().V1().PersistentVolumes(),
 			informerFactory.Storage().V1().StorageClasses(),
+			scheduling.NewCapacityCheck(informerFactory),
 			time.Duration(volumeBindingTimeoutSeconds)*time.Second,
 		),
 	}
 
-	pluginMaps, err := binderframework.NewPluginsRegistry(binderframework.NewInTreeRegistry(), options.pluginConfigs, h)
+	h.defaultPlugins = newBinderPlugins(options, h)
+	h.subClusterKey = options.subClusterKey
+	h.subClusterPlugins = make(map[string]*binderPlugins, len(options.subClusterProfiles))
+	for subCluster, profile := range options.subClusterProfiles {
+		profile := profile
+		klog.InfoS("Initialized binder profile for sub-cluster", "subCluster", subCluster)
+		h.subClusterPlugins[subCluster] = newBinderPlugins(options.subClusterOptions(&profile), h)
+	}
+
+	return h
+}
+
+func newBinderPlugins(options binderOptions, h handle.BinderFrameworkHandle) *binderPlugins {
+	registry := binderframework.NewInTreeRegistry()
+	if err := registry.Merge(options.outOfTreeRegistry); err != nil {
+		klog.ErrorS(err, "Failed to merge out-of-tree binder plugins")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	preemptionRegistry := binderframework.NewInTreePreemptionRegistry()
+	if err := preemptionRegistry.Merge(options.outOfTreePreemptionRegistry); err != nil {
+		klog.ErrorS(err, "Failed to merge out-of-tree preemption plugins")
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+
+	pluginMaps, err := binderframework.NewPluginsRegistry(registry, options.pluginConfigs, h)
 	if err != nil {
 		klog.ErrorS(err, "Failed to initialize GodelBinder")
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
@@ -89,25 +127,51 @@ func NewFrameworkHandle(
 		klog.ErrorS(nil, "Failed to initialize GodelBinder as plugins registry is not defined")
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 	}
-	preemptionPluginsMaps, err := binderframework.NewPluginsRegistry(binderframework.NewInTreePreemptionRegistry(), options.preemptionPluginConfigs, h)
+	// NodePoolChecker honors the same node pools as NodePool, so that they can't drift apart.
+	preemptionPluginConfigs := make(map[string]*config.PluginConfig, len(options.preemptionPluginConfigs)+1)
+	for name, pluginConfig := range options.preemptionPluginConfigs {
+		if name != defaultpreemption.NodePoolCheckerName {
+			preemptionPluginConfigs[name] = pluginConfig
+		}
+	}
+	if nodePoolConfig, ok := options.pluginConfigs[nodepool.Name]; ok {
+		preemptionPluginConfigs[defaultpreemption.NodePoolCheckerName] = nodePoolConfig
+	}
+	preemptionPluginsMaps, err := binderframework.NewPluginsRegistry(preemptionRegistry, preemptionPluginConfigs, h)
 	if err != nil {
 		klog.ErrorS(err, "Failed to initialize GodelBinder")
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
 	}
 
-	h.pluginRegistry = pluginMaps
-	h.preemptionPluginRegistry = preemptionPluginsMaps
-	h.basePlugins = NewBasePlugins(options.victimCheckingPluginSet)
-
-	return h
+	basePlugins := NewBasePlugins(options.victimCheckingPluginSet)
+	appendOutOfTreePlugins(basePlugins, options.outOfTreeRegistry, pluginMaps)
+	return &binderPlugins{
+		basePlugins:              basePlugins,
+		pluginRegistry:           pluginMaps,
+		preemptionPluginRegistry: preemptionPluginsMaps,
+	}
 }
 
+// GetFrameworkForPod returns the framework of the sub-cluster which the pod belongs to, all pods of
+// a unit share the same sub-cluster so that the unit is bound with the same profile.
 func (h *frameworkHandleImpl) GetFrameworkForPod(pod *v1.Pod) (framework.BinderFramework, error) {
-	// TODO: construct according to pod.Annotation ?
-	f := runtime.New(h.pluginRegistry, h.preemptionPluginRegistry, h.basePlugins) //, binder.waitingTasksManager)
+	plugins := h.getPluginsForPod(pod)
+	f := runtime.New(plugins.pluginRegistry, plugins.preemptionPluginRegistry, plugins.basePlugins) //, binder.waitingTasksManager)
 	return f, nil
 }
 
+func (h *frameworkHandleImpl) getPluginsForPod(pod *v1.Pod) *binderPlugins {
+	if len(h.subClusterKey) == 0 {
+		return h.defaultPlugins
+	}
+	if subCluster, ok := pod.Spec.NodeSelector[h.subClusterKey]; ok {
+		if plugins, ok := h.subClusterPlugins[subCluster]; ok {
+			return plugins
+		}
+	}
+	return h.defaultPlugins
+}
+
 func (h *frameworkHandleImpl) ClientSet() clientset.Interface {
 	return h.client
 }
@@ -0,0 +1,250 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	godelcache "github.com/kubewharf/godel-scheduler/pkg/binder/cache"
+	binderframework "github.com/kubewharf/godel-scheduler/pkg/binder/framework"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultbinder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultpreemption"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodemanagerbinder"
+	"github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/nodepool"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func TestGetPluginsForPodWithSubClusterProfiles(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	client := clientsetfake.NewSimpleClientset()
+	crdClient := godelclientfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	cacheHandler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(stop).
+		ComponentName("binder").Obj()
+
+	options := renderOptions(
+		WithPluginsAndConfigs(&config.GodelBinderProfile{
+			Plugins: &config.Plugins{
+				VictimChecking: &config.VictimCheckingPluginSet{
+					PluginCollections: []config.VictimCheckingPluginCollection{
+						{Plugins: []config.Plugin{{Name: defaultpreemption.PDBCheckerName}}},
+					},
+				},
+			},
+		}),
+		WithSubClusterKey("subCluster"),
+		WithSubClusterProfiles([]config.GodelBinderProfile{
+			{
+				SubClusterName: "training",
+				Plugins: &config.Plugins{
+					VictimChecking: &config.VictimCheckingPluginSet{},
+				},
+			},
+			{
+				// inherits the plugins of default profile.
+				SubClusterName: "online",
+			},
+		}),
+	)
+	h := NewFrameworkHandle(client, crdClient, informerFactory, crdInformerFactory, options, godelcache.New(cacheHandler), volumeBindingTimeoutSeconds).(*frameworkHandleImpl)
+
+	tests := []struct {
+		name                   string
+		nodeSelector           map[string]string
+		expectedVictimCheckers int
+		expectedSubCluster     string
+	}{
+		{
+			name:                   "pod without sub-cluster uses default profile",
+			expectedVictimCheckers: 1,
+		},
+		{
+			name:                   "pod in sub-cluster without profile uses default profile",
+			nodeSelector:           map[string]string{"subCluster": "unknown"},
+			expectedVictimCheckers: 1,
+		},
+		{
+			name:                   "pod in sub-cluster uses its own profile",
+			nodeSelector:           map[string]string{"subCluster": "training"},
+			expectedVictimCheckers: 0,
+			expectedSubCluster:     "training",
+		},
+		{
+			name:                   "sub-cluster profile inherits default plugins",
+			nodeSelector:           map[string]string{"subCluster": "online"},
+			expectedVictimCheckers: 1,
+			expectedSubCluster:     "online",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := testinghelper.MakePod().Namespace("default").Name("p").UID("p").NodeSelector(tt.nodeSelector).Obj()
+			plugins := h.getPluginsForPod(pod)
+
+			expectedPlugins := h.defaultPlugins
+			if len(tt.expectedSubCluster) > 0 {
+				expectedPlugins = h.subClusterPlugins[tt.expectedSubCluster]
+			}
+			if plugins != expectedPlugins {
+				t.Errorf("expected plugins of sub-cluster %q, but got others", tt.expectedSubCluster)
+			}
+			if got := len(plugins.basePlugins.VictimCheckings); got != tt.expectedVictimCheckers {
+				t.Errorf("expected %d victim checking collections, but got %d", tt.expectedVictimCheckers, got)
+			}
+			if _, err := h.GetFrameworkForPod(pod); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+type fakeOutOfTreePlugin struct{}
+
+func (pl *fakeOutOfTreePlugin) Name() string { return "FakeOutOfTree" }
+
+func (pl *fakeOutOfTreePlugin) CheckConflicts(_ context.Context, _ *framework.CycleState, _ *v1.Pod, _ framework.NodeInfo) *framework.Status {
+	return nil
+}
+
+func (pl *fakeOutOfTreePlugin) Bind(_ context.Context, _ *framework.CycleState, _ *v1.Pod, _ string) *framework.Status {
+	return framework.NewStatus(framework.Skip)
+}
+
+func TestNewBinderPluginsWithOutOfTreeRegistry(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	client := clientsetfake.NewSimpleClientset()
+	crdClient := godelclientfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	cacheHandler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(stop).
+		ComponentName("binder").Obj()
+
+	options := renderOptions(
+		WithFrameworkOutOfTreeRegistry(binderframework.Registry{
+			"FakeOutOfTree": func(_ runtime.Object, _ handle.BinderFrameworkHandle) (framework.Plugin, error) {
+				return &fakeOutOfTreePlugin{}, nil
+			},
+		}),
+	)
+	h := NewFrameworkHandle(client, crdClient, informerFactory, crdInformerFactory, options, godelcache.New(cacheHandler), volumeBindingTimeoutSeconds).(*frameworkHandleImpl)
+
+	plugins := h.defaultPlugins
+	if _, ok := plugins.pluginRegistry["FakeOutOfTree"]; !ok {
+		t.Fatalf("expected out-of-tree plugin to be initialized")
+	}
+	conflicts := plugins.basePlugins.CheckConflicts
+	if conflicts[len(conflicts)-1] != "FakeOutOfTree" {
+		t.Errorf("expected out-of-tree plugin at the end of CheckConflicts, but got %v", conflicts)
+	}
+	if binds := plugins.basePlugins.Binds; len(binds) != 3 || binds[0] != "FakeOutOfTree" || binds[1] != nodemanagerbinder.Name || binds[2] != defaultbinder.Name {
+		t.Errorf("expected out-of-tree plugin before the in-tree binders, but got %v", binds)
+	}
+	if len(plugins.basePlugins.Permits) != 0 {
+		t.Errorf("expected no permit plugins, but got %v", plugins.basePlugins.Permits)
+	}
+}
+
+func TestDefaultVictimCheckingRespectsPDBWhenReclaiming(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	client := clientsetfake.NewSimpleClientset()
+	crdClient := godelclientfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+	cacheHandler := commoncache.MakeCacheHandlerWrapper().
+		Period(10 * time.Second).PodAssumedTTL(30 * time.Second).StopCh(stop).
+		ComponentName("binder").Obj()
+	binderCache := godelcache.New(cacheHandler)
+	binderCache.AddNode(testinghelper.MakeNode().Name("n").Label("pool", "borrowable").Obj())
+	binderCache.AddPDB(testinghelper.MakePdb().Namespace("default").Name("pdb").Label("app", "protected").DisruptionsAllowed(0).Obj())
+
+	options := binderOptions{
+		victimCheckingPluginSet: defaultBinderOptions.victimCheckingPluginSet,
+		preemptionPluginConfigs: map[string]*config.PluginConfig{},
+		pluginConfigs: map[string]*config.PluginConfig{
+			nodepool.Name: {
+				Name: nodepool.Name,
+				Args: runtime.RawExtension{Object: &config.NodePoolArgs{
+					Pools: []config.NodePool{
+						{
+							Name:         "borrowable",
+							Mode:         "Borrowable",
+							NodeSelector: map[string]string{"pool": "borrowable"},
+							Namespaces:   []string{"tenant"},
+						},
+					},
+				}},
+			},
+		},
+	}
+	h := NewFrameworkHandle(client, crdClient, informerFactory, crdInformerFactory, options, binderCache, volumeBindingTimeoutSeconds)
+
+	tenant := testinghelper.MakePod().Namespace("tenant").Name("tenant").UID("tenant").Priority(10).Obj()
+	tests := []struct {
+		name         string
+		victim       *v1.Pod
+		expectedCode framework.Code
+	}{
+		{
+			name:         "tenant reclaims borrowed capacity",
+			victim:       testinghelper.MakePod().Namespace("default").Name("borrower").UID("borrower").Priority(100).Node("n").Obj(),
+			expectedCode: framework.PreemptionSucceed,
+		},
+		{
+			name:         "tenant could not reclaim borrowed capacity protected by pdb",
+			victim:       testinghelper.MakePod().Namespace("default").Name("protected").UID("protected").Priority(100).Node("n").Label("app", "protected").Obj(),
+			expectedCode: framework.PreemptionFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fwk, err := h.GetFrameworkForPod(tenant)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			commonState := framework.NewCycleState()
+			if status := fwk.RunClusterPrePreemptingPlugins(tenant, nil, commonState); status != nil {
+				t.Fatalf("failed to prepare preemption: %v", status)
+			}
+			if got := fwk.RunVictimCheckingPlugins(tenant, tt.victim, nil, commonState).Code(); got != tt.expectedCode {
+				t.Errorf("expected code %v, but got %v", tt.expectedCode, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-base/metrics"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	pkgmetrics "github.com/kubewharf/godel-scheduler/pkg/common/metrics"
+	"github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+var (
+	podLifecycleStageLatency = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem: "godel",
+			Name:      "pod_lifecycle_stage_duration_seconds",
+			Help: "Latency of each stage in the pod lifecycle, in seconds. Stages are calculated from the timestamp " +
+				"annotations set by dispatcher, scheduler and binder, starting from the initial handled timestamp.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 20),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.StageLabel, pkgmetrics.PriorityClassLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.SubClusterLabel})
+
+	podLifecycleSLOBreaches = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      "godel",
+			Name:           "pod_lifecycle_slo_breaches_total",
+			Help:           "Number of pods whose lifecycle stage latency exceeds the configured SLO threshold, including the pods still pending in that stage.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.StageLabel, pkgmetrics.PriorityClassLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.SubClusterLabel})
+)
+
+// StageLatency is the latency of a pod lifecycle stage.
+type StageLatency struct {
+	Stage    string
+	Duration time.Duration
+}
+
+// SLOBreach records a pod lifecycle stage whose latency exceeds the SLO threshold.
+type SLOBreach struct {
+	Stage     string
+	Duration  time.Duration
+	Threshold time.Duration
+}
+
+// PodSLOBreach is a SLOBreach of a pending pod.
+type PodSLOBreach struct {
+	SLOBreach
+	Pod *v1.Pod
+}
+
+// LifecycleTracker observes the lifecycle of pods and checks the stage latencies against SLO thresholds.
+// Each stage of a pod is reported as SLO breach at most once, whether it's found by scanning the
+// pending pods or when the pod is bound.
+type LifecycleTracker struct {
+	thresholds map[string]time.Duration
+
+	mu sync.Mutex
+	// reported records the breached stages of pods which have been reported, keyed by pod UID.
+	reported map[types.UID]sets.String
+}
+
+// NewLifecycleTracker returns a LifecycleTracker with the thresholds keyed by stage name.
+func NewLifecycleTracker(thresholdSeconds map[string]int64) *LifecycleTracker {
+	thresholds := make(map[string]time.Duration, len(thresholdSeconds))
+	for stage, seconds := range thresholdSeconds {
+		if seconds > 0 {
+			thresholds[stage] = time.Duration(seconds) * time.Second
+		}
+	}
+	return &LifecycleTracker{thresholds: thresholds, reported: make(map[types.UID]sets.String)}
+}
+
+// Enabled returns true if any SLO threshold is configured.
+func (t *LifecycleTracker) Enabled() bool {
+	return t != nil && len(t.thresholds) > 0
+}
+
+// ObservePodLifecycle records the stage latencies of a pod bound at boundTime and returns the SLO breaches.
+// Pods without the initial handled timestamp annotation are ignored.
+func (t *LifecycleTracker) ObservePodLifecycle(podInfo *api.QueuedPodInfo, boundTime time.Time) []SLOBreach {
+	if podInfo == nil || podInfo.Pod == nil {
+		return nil
+	}
+	stages := PodLifecycleStages(podInfo, boundTime)
+	if len(stages) == 0 {
+		return nil
+	}
+
+	labels := lifecycleLabels(podInfo.Pod)
+	for _, stage := range stages {
+		labels[pkgmetrics.StageLabel] = stage.Stage
+		podLifecycleStageLatency.With(labels).Observe(stage.Duration.Seconds())
+	}
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	breaches := t.checkBreaches(podInfo.Pod, stages)
+	// The pod is bound, the stages reported while it was pending will not be checked again.
+	delete(t.reported, podInfo.Pod.UID)
+	return breaches
+}
+
+// ScanPendingPods checks how long the pods which are not bound yet have been in their current stage,
+// and returns the SLO breaches not reported before. So that the pods stuck before binding can breach
+// the SLO, which are exactly the pods the alert exists for.
+func (t *LifecycleTracker) ScanPendingPods(pods []*v1.Pod, now time.Time) []PodSLOBreach {
+	if !t.Enabled() {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var breaches []PodSLOBreach
+	pending := make(map[types.UID]bool, len(pods))
+	for _, pod := range pods {
+		if len(pod.Spec.NodeName) > 0 || pod.DeletionTimestamp != nil {
+			continue
+		}
+		pending[pod.UID] = true
+		for _, breach := range t.checkBreaches(pod, PendingPodLifecycleStages(pod, now)) {
+			breaches = append(breaches, PodSLOBreach{SLOBreach: breach, Pod: pod})
+		}
+	}
+	for uid := range t.reported {
+		if !pending[uid] {
+			delete(t.reported, uid)
+		}
+	}
+	return breaches
+}
+
+// checkBreaches counts the stages exceeding the thresholds which are not reported before.
+// ATTENTION: t.mu must be held.
+func (t *LifecycleTracker) checkBreaches(pod *v1.Pod, stages []StageLatency) []SLOBreach {
+	var breaches []SLOBreach
+	labels := lifecycleLabels(pod)
+	for _, stage := range stages {
+		threshold, ok := t.thresholds[stage.Stage]
+		if !ok || stage.Duration <= threshold || t.reported[pod.UID].Has(stage.Stage) {
+			continue
+		}
+		if t.reported[pod.UID] == nil {
+			t.reported[pod.UID] = sets.NewString()
+		}
+		t.reported[pod.UID].Insert(stage.Stage)
+		labels[pkgmetrics.StageLabel] = stage.Stage
+		podLifecycleSLOBreaches.With(labels).Inc()
+		breaches = append(breaches, SLOBreach{Stage: stage.Stage, Duration: stage.Duration, Threshold: threshold})
+	}
+	return breaches
+}
+
+// PodLifecycleStages calculates the latencies of the lifecycle stages of a pod bound at boundTime.
+// Stages whose boundary timestamps are missing are skipped.
+func PodLifecycleStages(podInfo *api.QueuedPodInfo, boundTime time.Time) []StageLatency {
+	pod := podInfo.Pod
+	initialHandled, ok := parseTimestampAnnotation(pod, podutil.InitialHandledTimestampAnnotationKey)
+	if !ok {
+		return nil
+	}
+	dispatched, dispatchedOk := parseTimestampAnnotation(pod, podutil.DispatchedTimestampAnnotationKey)
+	started, startedOk := parseTimestampAnnotation(pod, podutil.ScheduleStartedTimestampAnnotationKey)
+	scheduled, scheduledOk := parseTimestampAnnotation(pod, podutil.ScheduledTimestampAnnotationKey)
+
+	var stages []StageLatency
+	addStage := func(stage string, start, end time.Time) {
+		if end.Before(start) {
+			return
+		}
+		stages = append(stages, StageLatency{Stage: stage, Duration: end.Sub(start)})
+	}
+
+	if !pod.CreationTimestamp.IsZero() {
+		addStage(config.PendingStage, pod.CreationTimestamp.Time, initialHandled)
+	}
+	if dispatchedOk {
+		addStage(config.DispatchingStage, initialHandled, dispatched)
+		if startedOk {
+			addStage(config.QueueingStage, dispatched, started)
+		}
+	}
+	if startedOk && scheduledOk {
+		addStage(config.SchedulingStage, started, scheduled)
+	}
+
+	var preemptionWait time.Duration
+	if preemptStart := podInfo.InitialPreemptAttemptTimestamp; !preemptStart.IsZero() && !boundTime.Before(preemptStart) {
+		preemptionWait = boundTime.Sub(preemptStart)
+		stages = append(stages, StageLatency{Stage: config.PreemptionWaitStage, Duration: preemptionWait})
+	}
+	if scheduledOk && !boundTime.Before(scheduled) {
+		binding := boundTime.Sub(scheduled) - preemptionWait
+		if binding < 0 {
+			binding = 0
+		}
+		stages = append(stages, StageLatency{Stage: config.BindingStage, Duration: binding})
+	}
+	addStage(config.E2EStage, initialHandled, boundTime)
+	return stages
+}
+
+// PendingPodLifecycleStages calculates how long a pod which is not bound yet has been in its current
+// stage, and the e2e latency until now. The scheduling attempts are only annotated on success, so a
+// pod failing to be scheduled stays in the queueing stage.
+func PendingPodLifecycleStages(pod *v1.Pod, now time.Time) []StageLatency {
+	initialHandled, ok := parseTimestampAnnotation(pod, podutil.InitialHandledTimestampAnnotationKey)
+	if !ok {
+		return nil
+	}
+
+	var stages []StageLatency
+	addStage := func(stage string, start time.Time) {
+		if now.Before(start) {
+			return
+		}
+		stages = append(stages, StageLatency{Stage: stage, Duration: now.Sub(start)})
+	}
+	if scheduled, ok := parseTimestampAnnotation(pod, podutil.ScheduledTimestampAnnotationKey); ok {
+		addStage(config.BindingStage, scheduled)
+	} else if started, ok := parseTimestampAnnotation(pod, podutil.ScheduleStartedTimestampAnnotationKey); ok {
+		addStage(config.SchedulingStage, started)
+	} else if dispatched, ok := parseTimestampAnnotation(pod, podutil.DispatchedTimestampAnnotationKey); ok {
+		addStage(config.QueueingStage, dispatched)
+	} else {
+		addStage(config.DispatchingStage, initialHandled)
+	}
+	addStage(config.E2EStage, initialHandled)
+	return stages
+}
+
+func parseTimestampAnnotation(pod *v1.Pod, key string) (time.Time, bool) {
+	value := pod.Annotations[key]
+	if len(value) == 0 {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse(helper.TimestampLayout, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
+
+// lifecycleLabels uses the priority class instead of the priority value as label to bound the cardinality.
+func lifecycleLabels(pod *v1.Pod) metrics.Labels {
+	labels := metrics.Labels{
+		pkgmetrics.PriorityClassLabel: pkgmetrics.UndefinedLabelValue,
+		pkgmetrics.SubClusterLabel:    pkgmetrics.UndefinedLabelValue,
+		pkgmetrics.UnitTypeLabel:      string(api.SinglePodUnitType),
+	}
+	if len(pod.Spec.PriorityClassName) > 0 {
+		labels[pkgmetrics.PriorityClassLabel] = pod.Spec.PriorityClassName
+	}
+	if subCluster := api.GetPodSubCluster(pod); len(subCluster) > 0 {
+		labels[pkgmetrics.SubClusterLabel] = subCluster
+	}
+	if len(podutil.GetPodGroupName(pod)) > 0 {
+		labels[pkgmetrics.UnitTypeLabel] = string(api.PodGroupUnitType)
+	}
+	return labels
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestPodLifecycleStages(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return base.Add(time.Duration(seconds) * time.Second)
+	}
+	newPodInfo := func(annotations map[string]int, preemptAt int) *api.QueuedPodInfo {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              "p",
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(base),
+			Annotations:       map[string]string{},
+		}}
+		for key, seconds := range annotations {
+			pod.Annotations[key] = at(seconds).Format(helper.TimestampLayout)
+		}
+		podInfo := &api.QueuedPodInfo{Pod: pod}
+		if preemptAt > 0 {
+			podInfo.InitialPreemptAttemptTimestamp = at(preemptAt)
+		}
+		return podInfo
+	}
+
+	tests := []struct {
+		name    string
+		podInfo *api.QueuedPodInfo
+		bound   time.Time
+		want    []StageLatency
+	}{
+		{
+			name:    "without initial handled timestamp",
+			podInfo: newPodInfo(map[string]int{podutil.DispatchedTimestampAnnotationKey: 1}, 0),
+			bound:   at(10),
+			want:    nil,
+		},
+		{
+			name: "all stages",
+			podInfo: newPodInfo(map[string]int{
+				podutil.InitialHandledTimestampAnnotationKey:  1,
+				podutil.DispatchedTimestampAnnotationKey:      3,
+				podutil.ScheduleStartedTimestampAnnotationKey: 6,
+				podutil.ScheduledTimestampAnnotationKey:       10,
+			}, 12),
+			bound: at(20),
+			want: []StageLatency{
+				{Stage: config.PendingStage, Duration: 1 * time.Second},
+				{Stage: config.DispatchingStage, Duration: 2 * time.Second},
+				{Stage: config.QueueingStage, Duration: 3 * time.Second},
+				{Stage: config.SchedulingStage, Duration: 4 * time.Second},
+				{Stage: config.PreemptionWaitStage, Duration: 8 * time.Second},
+				{Stage: config.BindingStage, Duration: 2 * time.Second},
+				{Stage: config.E2EStage, Duration: 19 * time.Second},
+			},
+		},
+		{
+			name: "missing dispatched timestamp",
+			podInfo: newPodInfo(map[string]int{
+				podutil.InitialHandledTimestampAnnotationKey:  1,
+				podutil.ScheduleStartedTimestampAnnotationKey: 6,
+				podutil.ScheduledTimestampAnnotationKey:       10,
+			}, 0),
+			bound: at(11),
+			want: []StageLatency{
+				{Stage: config.PendingStage, Duration: 1 * time.Second},
+				{Stage: config.SchedulingStage, Duration: 4 * time.Second},
+				{Stage: config.BindingStage, Duration: 1 * time.Second},
+				{Stage: config.E2EStage, Duration: 10 * time.Second},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodLifecycleStages(tt.podInfo, tt.bound); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PodLifecycleStages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObservePodLifecycle(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:              "p",
+		Namespace:         "default",
+		CreationTimestamp: metav1.NewTime(base),
+		Annotations: map[string]string{
+			podutil.InitialHandledTimestampAnnotationKey:  base.Format(helper.TimestampLayout),
+			podutil.DispatchedTimestampAnnotationKey:      base.Add(time.Second).Format(helper.TimestampLayout),
+			podutil.ScheduleStartedTimestampAnnotationKey: base.Add(2 * time.Second).Format(helper.TimestampLayout),
+			podutil.ScheduledTimestampAnnotationKey:       base.Add(30 * time.Second).Format(helper.TimestampLayout),
+		},
+	}}
+	tracker := NewLifecycleTracker(map[string]int64{
+		config.SchedulingStage: 10,
+		config.QueueingStage:   10,
+		config.E2EStage:        60,
+	})
+
+	got := tracker.ObservePodLifecycle(&api.QueuedPodInfo{Pod: pod}, base.Add(31*time.Second))
+	want := []SLOBreach{{Stage: config.SchedulingStage, Duration: 28 * time.Second, Threshold: 10 * time.Second}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ObservePodLifecycle() = %v, want %v", got, want)
+	}
+
+	var nilTracker *LifecycleTracker
+	if got := nilTracker.ObservePodLifecycle(&api.QueuedPodInfo{Pod: pod}, base.Add(31*time.Second)); got != nil {
+		t.Errorf("expected no breaches for nil tracker, got %v", got)
+	}
+}
+
+func TestScanPendingPods(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newPod := func(name string, annotations map[string]int) *v1.Pod {
+		pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(base),
+			Annotations:       map[string]string{},
+		}}
+		for key, seconds := range annotations {
+			pod.Annotations[key] = base.Add(time.Duration(seconds) * time.Second).Format(helper.TimestampLayout)
+		}
+		return pod
+	}
+	dispatching := newPod("dispatching", map[string]int{podutil.InitialHandledTimestampAnnotationKey: 0})
+	queueing := newPod("queueing", map[string]int{
+		podutil.InitialHandledTimestampAnnotationKey: 0,
+		podutil.DispatchedTimestampAnnotationKey:     1,
+	})
+	binding := newPod("binding", map[string]int{
+		podutil.InitialHandledTimestampAnnotationKey:  0,
+		podutil.DispatchedTimestampAnnotationKey:      1,
+		podutil.ScheduleStartedTimestampAnnotationKey: 2,
+		podutil.ScheduledTimestampAnnotationKey:       3,
+	})
+	bound := newPod("bound", map[string]int{podutil.InitialHandledTimestampAnnotationKey: 0})
+	bound.Spec.NodeName = "n"
+
+	tracker := NewLifecycleTracker(map[string]int64{
+		config.DispatchingStage: 10,
+		config.QueueingStage:    10,
+		config.BindingStage:     30,
+	})
+	pods := []*v1.Pod{dispatching, queueing, binding, bound}
+
+	got := tracker.ScanPendingPods(pods, base.Add(20*time.Second))
+	want := []PodSLOBreach{
+		{SLOBreach: SLOBreach{Stage: config.DispatchingStage, Duration: 20 * time.Second, Threshold: 10 * time.Second}, Pod: dispatching},
+		{SLOBreach: SLOBreach{Stage: config.QueueingStage, Duration: 19 * time.Second, Threshold: 10 * time.Second}, Pod: queueing},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanPendingPods() = %v, want %v", got, want)
+	}
+
+	// The reported stages should not be reported again.
+	got = tracker.ScanPendingPods(pods, base.Add(40*time.Second))
+	want = []PodSLOBreach{
+		{SLOBreach: SLOBreach{Stage: config.BindingStage, Duration: 37 * time.Second, Threshold: 30 * time.Second}, Pod: binding},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScanPendingPods() = %v, want %v", got, want)
+	}
+
+	// Neither when the pod is bound.
+	if got := tracker.ObservePodLifecycle(&api.QueuedPodInfo{Pod: binding}, base.Add(50*time.Second)); got != nil {
+		t.Errorf("expected no new breaches for bound pod, got %v", got)
+	}
+	if _, ok := tracker.reported[binding.UID]; ok {
+		t.Errorf("expected reported stages of bound pod to be removed")
+	}
+
+	// Pods which are no longer pending are forgotten.
+	tracker.ScanPendingPods([]*v1.Pod{queueing}, base.Add(60*time.Second))
+	if _, ok := tracker.reported[dispatching.UID]; ok {
+		t.Errorf("expected reported stages of pod %v to be removed", dispatching.Name)
+	}
+}
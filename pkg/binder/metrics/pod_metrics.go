@@ -34,6 +34,38 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"type"})
 
+	CacheDrift = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      BinderSubsystem,
+			Name:           "cache_drift",
+			Help:           "Number of nodes and pods missed or redundant in the binder cache compared with informers.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ResourceLabel, pkgmetrics.TypeLabel})
+
+	CacheResyncs = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      BinderSubsystem,
+			Name:           "cache_resync_total",
+			Help:           "Number of nodes and pods resynced into the binder cache by cache self-healing.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ResourceLabel, pkgmetrics.TypeLabel})
+
+	OvercommitNodes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      BinderSubsystem,
+			Name:           "overcommit_nodes",
+			Help:           "Number of nodes whose allocatable is transformed by each overcommit profile.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ProfileLabel})
+
+	OvercommitAllocatable = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      BinderSubsystem,
+			Name:           "overcommit_allocatable",
+			Help:           "The allocatable of nodes transformed by each overcommit profile.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ProfileLabel, pkgmetrics.QosLabel, pkgmetrics.ResourceLabel})
+
 	buildInfo = metrics.NewGaugeVec(
 		&metrics.GaugeOpts{
 			Subsystem:      BinderSubsystem,
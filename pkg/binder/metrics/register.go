@@ -32,6 +32,10 @@ var metricsList = []metrics.Registerable{
 	pendingUnits,
 	binderGoroutines,
 	CacheSize,
+	CacheDrift,
+	CacheResyncs,
+	OvercommitNodes,
+	OvercommitAllocatable,
 
 	podRejection,
 	podBindingFailure,
@@ -54,6 +58,8 @@ var metricsList = []metrics.Registerable{
 	podE2ELatency,
 	podE2ELatencyQuantile,
 	podGroupE2ELatency,
+	podLifecycleStageLatency,
+	podLifecycleSLOBreaches,
 
 	movementUpdateAttempts,
 
@@ -17,13 +17,17 @@ limitations under the License.
 package binder
 
 import (
+	"time"
+
 	"github.com/kubewharf/godel-scheduler/pkg/binder/apis/config"
+	binderframework "github.com/kubewharf/godel-scheduler/pkg/binder/framework"
 	plugins "github.com/kubewharf/godel-scheduler/pkg/binder/framework/plugins/defaultpreemption"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 )
 
 var defaultBinderOptions = binderOptions{
 	victimCheckingPluginSet: []*framework.VictimCheckingPluginCollectionSpec{
+		// pdbs are respected even when the tenants reclaim the borrowed capacity.
 		framework.NewVictimCheckingPluginCollectionSpec(
 			[]config.Plugin{
 				{Name: plugins.PDBCheckerName},
@@ -31,15 +35,41 @@ var defaultBinderOptions = binderOptions{
 			false,
 			false,
 		),
+		// the tenants of borrowable node pools reclaim the borrowed capacity regardless of the following checkers,
+		// so the checkers that must not be bypassed have to be placed before it.
+		framework.NewVictimCheckingPluginCollectionSpec(
+			[]config.Plugin{
+				{Name: plugins.NodePoolCheckerName},
+			},
+			false,
+			true,
+		),
 	},
 	preemptionPluginConfigs: map[string]*config.PluginConfig{},
 	pluginConfigs:           map[string]*config.PluginConfig{},
+	subClusterKey:           config.DefaultSubClusterKey,
 }
 
 type binderOptions struct {
 	victimCheckingPluginSet []*framework.VictimCheckingPluginCollectionSpec
 	preemptionPluginConfigs map[string]*config.PluginConfig
 	pluginConfigs           map[string]*config.PluginConfig
+
+	// subClusterKey is used to get the sub-cluster of a pod from its node selector.
+	subClusterKey string
+	// subClusterProfiles are keyed by sub-cluster name, a sub-cluster profile overrides
+	// the plugins and plugin configs of the default profile.
+	subClusterProfiles map[string]config.GodelBinderProfile
+
+	cacheComparePeriod     time.Duration
+	enableCacheSelfHealing bool
+
+	// schedulingSLOThresholdSeconds are the SLO thresholds of pod lifecycle stages.
+	schedulingSLOThresholdSeconds map[string]int64
+
+	// out-of-tree registries are merged into the in-tree ones when building the plugins.
+	outOfTreeRegistry           binderframework.Registry
+	outOfTreePreemptionRegistry binderframework.Registry
 }
 
 // Option configures a Scheduler
@@ -48,24 +78,96 @@ type Option func(*binderOptions)
 // WithPluginsAndConfigs sets Preemption Plugins and Configs, the default value is nil
 func WithPluginsAndConfigs(profile *config.GodelBinderProfile) Option {
 	return func(o *binderOptions) {
-		if profile == nil {
-			return
-		}
-		if profile.Plugins != nil && profile.Plugins.VictimChecking != nil {
-			o.victimCheckingPluginSet = make([]*framework.VictimCheckingPluginCollectionSpec, len(profile.Plugins.VictimChecking.PluginCollections))
-			for i, collection := range profile.Plugins.VictimChecking.PluginCollections {
-				o.victimCheckingPluginSet[i] = framework.NewVictimCheckingPluginCollectionSpec(collection.Plugins, collection.EnableQuickPass, collection.ForceQuickPass)
-			}
-		}
-		for index := range profile.PreemptionPluginConfigs {
-			plugin := profile.PreemptionPluginConfigs[index]
-			o.preemptionPluginConfigs[plugin.Name] = &plugin
+		o.applyProfile(profile)
+	}
+}
+
+// WithSubClusterKey sets the key to determine the sub-cluster of a pod.
+func WithSubClusterKey(key string) Option {
+	return func(o *binderOptions) {
+		o.subClusterKey = key
+	}
+}
+
+// WithSubClusterProfiles sets the binder profiles of sub-clusters.
+func WithSubClusterProfiles(profiles []config.GodelBinderProfile) Option {
+	return func(o *binderOptions) {
+		subClusterProfiles := make(map[string]config.GodelBinderProfile, len(profiles))
+		for _, profile := range profiles {
+			subClusterProfiles[profile.SubClusterName] = profile
 		}
-		for index := range profile.PluginConfigs {
-			config := profile.PluginConfigs[index]
-			o.pluginConfigs[config.Name] = &config
+		o.subClusterProfiles = subClusterProfiles
+	}
+}
+
+// WithCacheComparer enables the periodic comparison between cache and informers, 0 period disables it.
+func WithCacheComparer(period time.Duration, selfHealing bool) Option {
+	return func(o *binderOptions) {
+		o.cacheComparePeriod = period
+		o.enableCacheSelfHealing = selfHealing
+	}
+}
+
+// WithSchedulingSLO sets the SLO thresholds of pod lifecycle stages keyed by stage name.
+func WithSchedulingSLO(thresholdSeconds map[string]int64) Option {
+	return func(o *binderOptions) {
+		o.schedulingSLOThresholdSeconds = thresholdSeconds
+	}
+}
+
+// WithFrameworkOutOfTreeRegistry sets the registry of out-of-tree binder plugins.
+func WithFrameworkOutOfTreeRegistry(registry binderframework.Registry) Option {
+	return func(o *binderOptions) {
+		o.outOfTreeRegistry = registry
+	}
+}
+
+// WithPreemptionOutOfTreeRegistry sets the registry of out-of-tree preemption plugins.
+func WithPreemptionOutOfTreeRegistry(registry binderframework.Registry) Option {
+	return func(o *binderOptions) {
+		o.outOfTreePreemptionRegistry = registry
+	}
+}
+
+func (o *binderOptions) applyProfile(profile *config.GodelBinderProfile) {
+	if profile == nil {
+		return
+	}
+	if profile.Plugins != nil && profile.Plugins.VictimChecking != nil {
+		o.victimCheckingPluginSet = make([]*framework.VictimCheckingPluginCollectionSpec, len(profile.Plugins.VictimChecking.PluginCollections))
+		for i, collection := range profile.Plugins.VictimChecking.PluginCollections {
+			o.victimCheckingPluginSet[i] = framework.NewVictimCheckingPluginCollectionSpec(collection.Plugins, collection.EnableQuickPass, collection.ForceQuickPass)
 		}
 	}
+	for index := range profile.PreemptionPluginConfigs {
+		plugin := profile.PreemptionPluginConfigs[index]
+		o.preemptionPluginConfigs[plugin.Name] = &plugin
+	}
+	for index := range profile.PluginConfigs {
+		config := profile.PluginConfigs[index]
+		o.pluginConfigs[config.Name] = &config
+	}
+}
+
+// subClusterOptions returns the options of a sub-cluster, which inherits the default profile
+// and is overridden by the profile of the sub-cluster.
+func (o *binderOptions) subClusterOptions(profile *config.GodelBinderProfile) binderOptions {
+	options := binderOptions{
+		victimCheckingPluginSet: o.victimCheckingPluginSet,
+		preemptionPluginConfigs: make(map[string]*config.PluginConfig, len(o.preemptionPluginConfigs)),
+		pluginConfigs:           make(map[string]*config.PluginConfig, len(o.pluginConfigs)),
+
+		outOfTreeRegistry:           o.outOfTreeRegistry,
+		outOfTreePreemptionRegistry: o.outOfTreePreemptionRegistry,
+	}
+	for name, pluginConfig := range o.preemptionPluginConfigs {
+		options.preemptionPluginConfigs[name] = pluginConfig
+	}
+	for name, pluginConfig := range o.pluginConfigs {
+		options.pluginConfigs[name] = pluginConfig
+	}
+	options.applyProfile(profile)
+	return options
 }
 
 func renderOptions(opts ...Option) binderOptions {
@@ -0,0 +1,59 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"context"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// FakeLauncher records the pods handed over to node manager instead of launching them, it fails the launches
+// with Err if it is set.
+type FakeLauncher struct {
+	Err error
+
+	mu       sync.Mutex
+	launched map[string]string
+}
+
+// NewFakeLauncher returns a FakeLauncher with no launched pods.
+func NewFakeLauncher() *FakeLauncher {
+	return &FakeLauncher{launched: make(map[string]string)}
+}
+
+// Launch records the node of the pod.
+func (l *FakeLauncher) Launch(_ context.Context, pod *v1.Pod, nodeName string) error {
+	if l.Err != nil {
+		return l.Err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.launched[podutil.GetPodKey(pod)] = nodeName
+	return nil
+}
+
+// LaunchedNode returns the node the pod was handed over to.
+func (l *FakeLauncher) LaunchedNode(podKey string) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	nodeName, ok := l.launched[podKey]
+	return nodeName, ok
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"k8s.io/apiserver/pkg/server/mux"
+	"k8s.io/klog/v2"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// NodesPath serves the node infos in cache, a single node could be specified by `?name=<node>`.
+	NodesPath = "/debug/cache/nodes"
+	// AssumedPodsPath serves the pods assumed in cache.
+	AssumedPodsPath = "/debug/cache/assumedpods"
+	// StoresPath serves the content of common stores, a single store could be specified by `?name=<store>`.
+	StoresPath = "/debug/cache/stores"
+)
+
+// Cache is the cache that could be inspected, both scheduler cache and binder cache implement it.
+type Cache interface {
+	Dump() *commoncache.Dump
+	DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{}
+}
+
+// NodeInfoSummary is the json view of a NodeInfo.
+type NodeInfoSummary struct {
+	Name                  string              `json:"name"`
+	HasNode               bool                `json:"hasNode"`
+	HasNMNode             bool                `json:"hasNMNode"`
+	GuaranteedRequested   *framework.Resource `json:"guaranteedRequested"`
+	GuaranteedAllocatable *framework.Resource `json:"guaranteedAllocatable"`
+	BestEffortRequested   *framework.Resource `json:"bestEffortRequested"`
+	BestEffortAllocatable *framework.Resource `json:"bestEffortAllocatable"`
+	Pods                  []string            `json:"pods"`
+}
+
+// AssumedPodSummary is the json view of an assumed pod.
+type AssumedPodSummary struct {
+	UID      string `json:"uid"`
+	Pod      string `json:"pod,omitempty"`
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// CacheInspector exposes the content of cache through http endpoints in json format.
+type CacheInspector struct {
+	cache Cache
+}
+
+// NewCacheInspector creates a CacheInspector.
+func NewCacheInspector(cache Cache) *CacheInspector {
+	return &CacheInspector{cache: cache}
+}
+
+// Install registers the http endpoints of CacheInspector.
+func (i *CacheInspector) Install(m *mux.PathRecorderMux) {
+	m.HandleFunc(NodesPath, i.serveNodes)
+	m.HandleFunc(AssumedPodsPath, i.serveAssumedPods)
+	m.HandleFunc(StoresPath, i.serveStores)
+}
+
+func (i *CacheInspector) serveNodes(w http.ResponseWriter, req *http.Request) {
+	dump := i.cache.Dump()
+	if name := req.URL.Query().Get("name"); len(name) > 0 {
+		nodeInfo, ok := dump.Nodes[name]
+		if !ok {
+			http.Error(w, "node "+name+" not found in cache", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, summarizeNodeInfo(name, nodeInfo))
+		return
+	}
+
+	summaries := make([]*NodeInfoSummary, 0, len(dump.Nodes))
+	for name, nodeInfo := range dump.Nodes {
+		summaries = append(summaries, summarizeNodeInfo(name, nodeInfo))
+	}
+	sort.Slice(summaries, func(a, b int) bool { return summaries[a].Name < summaries[b].Name })
+	writeJSON(w, summaries)
+}
+
+func (i *CacheInspector) serveAssumedPods(w http.ResponseWriter, _ *http.Request) {
+	dump := i.cache.Dump()
+	summaries := make(map[string]*AssumedPodSummary, len(dump.AssumedPods))
+	for uid, assumed := range dump.AssumedPods {
+		if assumed {
+			summaries[uid] = &AssumedPodSummary{UID: uid}
+		}
+	}
+	// Fill in the pod keys and node names of the assumed pods placed on nodes.
+	for name, nodeInfo := range dump.Nodes {
+		for _, p := range nodeInfo.GetPods() {
+			if summary, ok := summaries[string(p.Pod.UID)]; ok {
+				summary.Pod = podutil.GetPodKey(p.Pod)
+				summary.NodeName = name
+			}
+		}
+	}
+
+	ret := make([]*AssumedPodSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		ret = append(ret, summary)
+	}
+	sort.Slice(ret, func(a, b int) bool { return ret[a].UID < ret[b].UID })
+	writeJSON(w, ret)
+}
+
+func (i *CacheInspector) serveStores(w http.ResponseWriter, req *http.Request) {
+	var names []commonstore.StoreName
+	if name := req.URL.Query().Get("name"); len(name) > 0 {
+		names = append(names, commonstore.StoreName(name))
+	}
+	stores := i.cache.DumpStores(names...)
+	if len(names) > 0 {
+		store, ok := stores[names[0]]
+		if !ok {
+			http.Error(w, "store "+string(names[0])+" not found or not dumpable", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, store)
+		return
+	}
+	writeJSON(w, stores)
+}
+
+func summarizeNodeInfo(name string, nodeInfo framework.NodeInfo) *NodeInfoSummary {
+	pods := make([]string, 0, nodeInfo.NumPods())
+	for _, p := range nodeInfo.GetPods() {
+		pods = append(pods, podutil.GetPodKey(p.Pod))
+	}
+	sort.Strings(pods)
+	return &NodeInfoSummary{
+		Name:                  name,
+		HasNode:               nodeInfo.GetNode() != nil,
+		HasNMNode:             nodeInfo.GetNMNode() != nil,
+		GuaranteedRequested:   nodeInfo.GetGuaranteedRequested(),
+		GuaranteedAllocatable: nodeInfo.GetGuaranteedAllocatable(),
+		BestEffortRequested:   nodeInfo.GetBestEffortRequested(),
+		BestEffortAllocatable: nodeInfo.GetBestEffortAllocatable(),
+		Pods:                  pods,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, obj interface{}) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal cache content")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
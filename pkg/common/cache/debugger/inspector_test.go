@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apiserver/pkg/server/mux"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+type fakeCache struct {
+	dump   *commoncache.Dump
+	stores map[commonstore.StoreName]interface{}
+}
+
+func (c *fakeCache) Dump() *commoncache.Dump {
+	return c.dump
+}
+
+func (c *fakeCache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	if len(names) == 0 {
+		return c.stores
+	}
+	ret := make(map[commonstore.StoreName]interface{})
+	for _, name := range names {
+		if store, ok := c.stores[name]; ok {
+			ret[name] = store
+		}
+	}
+	return ret
+}
+
+func TestCacheInspector(t *testing.T) {
+	nodeInfo := framework.NewNodeInfo(
+		testinghelper.MakePod().Namespace("default").Name("p1").UID("p1").Node("n1").Obj(),
+		testinghelper.MakePod().Namespace("default").Name("p2").UID("p2").Node("n1").Obj(),
+	)
+	nodeInfo.SetNode(testinghelper.MakeNode().Name("n1").Obj())
+
+	c := &fakeCache{
+		dump: &commoncache.Dump{
+			AssumedPods: map[string]bool{"p2": true},
+			Nodes:       map[string]framework.NodeInfo{"n1": nodeInfo},
+		},
+		stores: map[commonstore.StoreName]interface{}{
+			"PreemptionStore": map[string]map[string][]string{"n1": {"default/p1": {"default/p3"}}},
+		},
+	}
+	m := mux.NewPathRecorderMux("test")
+	NewCacheInspector(c).Install(m)
+
+	tests := []struct {
+		name         string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "list nodes",
+			path:         NodesPath,
+			expectedCode: http.StatusOK,
+			expectedBody: `"name":"n1","hasNode":true,"hasNMNode":false`,
+		},
+		{
+			name:         "get node",
+			path:         NodesPath + "?name=n1",
+			expectedCode: http.StatusOK,
+			expectedBody: `"pods":["default/p1","default/p2"]`,
+		},
+		{
+			name:         "get missing node",
+			path:         NodesPath + "?name=n2",
+			expectedCode: http.StatusNotFound,
+			expectedBody: "node n2 not found in cache",
+		},
+		{
+			name:         "list assumed pods",
+			path:         AssumedPodsPath,
+			expectedCode: http.StatusOK,
+			expectedBody: `[{"uid":"p2","pod":"default/p2","nodeName":"n1"}]`,
+		},
+		{
+			name:         "list stores",
+			path:         StoresPath,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"PreemptionStore":{"n1":{"default/p1":["default/p3"]}}}`,
+		},
+		{
+			name:         "get store",
+			path:         StoresPath + "?name=PreemptionStore",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"n1":{"default/p1":["default/p3"]}}`,
+		},
+		{
+			name:         "get missing store",
+			path:         StoresPath + "?name=PdbStore",
+			expectedCode: http.StatusNotFound,
+			expectedBody: "store PdbStore not found or not dumpable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.expectedCode {
+				t.Errorf("expected code %d, but got %d", tt.expectedCode, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.expectedBody) {
+				t.Errorf("expected body containing %s, but got %s", tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
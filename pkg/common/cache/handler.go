@@ -17,6 +17,7 @@ limitations under the License.
 package cache
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -24,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	corelister "k8s.io/client-go/listers/core/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 )
@@ -35,6 +37,11 @@ type (
 	PodOpFunc func(pod *v1.Pod, isAdd bool, skippedStores sets.String) error
 )
 
+// PodMetricsLister lists the resource usage of running pods, e.g. from metrics.k8s.io.
+type PodMetricsLister interface {
+	List(ctx context.Context) ([]metricsv1beta1.PodMetrics, error)
+}
+
 type CacheHandler interface {
 	ComponentName() string
 	SchedulerType() string
@@ -53,6 +60,9 @@ type CacheHandler interface {
 	PodLister() corelister.PodLister
 	PvcLister() corelister.PersistentVolumeClaimLister
 	PodInformer() coreinformers.PodInformer
+	// PodMetricsLister returns nil if sampling the usage of pods is disabled.
+	PodMetricsLister() PodMetricsLister
+	PodMetricsSamplePeriod() time.Duration
 
 	// GetNodeInfo return the NodeInfo before NodeStore handle the event.
 	GetNodeInfo(string) framework.NodeInfo
@@ -84,6 +94,9 @@ type handler struct {
 	podInformer coreinformers.PodInformer
 	pvcLister   corelister.PersistentVolumeClaimLister
 
+	podMetricsLister       PodMetricsLister
+	podMetricsSamplePeriod time.Duration
+
 	nodeHandler NodeHandler
 	podHandler  PodHandler
 
@@ -106,6 +119,8 @@ func (h *handler) Mutex() *sync.RWMutex                              { return h.
 func (h *handler) PodLister() corelister.PodLister                   { return h.podLister }
 func (h *handler) PvcLister() corelister.PersistentVolumeClaimLister { return h.pvcLister }
 func (h *handler) PodInformer() coreinformers.PodInformer            { return h.podInformer }
+func (h *handler) PodMetricsLister() PodMetricsLister                { return h.podMetricsLister }
+func (h *handler) PodMetricsSamplePeriod() time.Duration             { return h.podMetricsSamplePeriod }
 
 func (h *handler) GetNodeInfo(nodeName string) framework.NodeInfo          { return h.nodeHandler(nodeName) }
 func (h *handler) GetPodState(key string) (*framework.CachePodState, bool) { return h.podHandler(key) }
@@ -194,6 +209,12 @@ func (w *handlerWrapper) PodInformer(podInformer coreinformers.PodInformer) *han
 	return w
 }
 
+func (w *handlerWrapper) PodMetricsLister(lister PodMetricsLister, period time.Duration) *handlerWrapper {
+	w.obj.podMetricsLister = lister
+	w.obj.podMetricsSamplePeriod = period
+	return w
+}
+
 func (w *handlerWrapper) NodeHandler(h NodeHandler) *handlerWrapper {
 	w.obj.nodeHandler = h
 	return w
@@ -33,6 +33,7 @@ const (
 
 	QosLabel             = "qos"
 	PriorityLabel        = "priority"
+	PriorityClassLabel   = "priority_class"
 	SubClusterLabel      = "sub_cluster"
 	ResultLabel          = "result"
 	AttemptsLabel        = "attempts"
@@ -48,6 +49,7 @@ const (
 	ReasonLabel          = "reason"
 	UnitTypeLabel        = "unit_type"
 	StageLabel           = "stage"
+	ProfileLabel         = "profile"
 )
 
 const (
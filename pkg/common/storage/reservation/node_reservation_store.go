@@ -18,10 +18,13 @@ package reservation
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 
 	v1 "k8s.io/api/core/v1"
 )
@@ -282,3 +285,41 @@ func (r *NodeReservationStore) GetAvailablePlaceholderPods(placeholder string) (
 
 	return placeholders, nil
 }
+
+// ReservationInfoDump is the debugging view of a ReservationInfo.
+type ReservationInfoDump struct {
+	Placeholder    string    `json:"placeholder"`
+	PlaceholderPod string    `json:"placeholderPod"`
+	MatchedPod     string    `json:"matchedPod,omitempty"`
+	CreateTime     time.Time `json:"createTime"`
+}
+
+// Dump returns all the reservation infos grouped by node name. It is used for debugging only.
+func (r *NodeReservationStore) Dump() map[string][]ReservationInfoDump {
+	ret := make(map[string][]ReservationInfoDump, r.nodeInfos.Len())
+	r.nodeInfos.Range(func(nodeName string, obj generationstore.StoredObj) {
+		nodeInfo := obj.(*NodeReservationInfo)
+		var infos []ReservationInfoDump
+		for _, placeholder := range nodeInfo.Keys() {
+			for _, res := range nodeInfo.listReservationInfos(placeholder) {
+				info := ReservationInfoDump{
+					Placeholder:    res.placeholder,
+					PlaceholderPod: res.placeholderPodKey,
+					CreateTime:     res.CreateTime,
+				}
+				if res.MatchedPod != nil {
+					info.MatchedPod = podutil.GetPodKey(res.MatchedPod)
+				}
+				infos = append(infos, info)
+			}
+		}
+		sort.Slice(infos, func(i, j int) bool {
+			if infos[i].Placeholder != infos[j].Placeholder {
+				return infos[i].Placeholder < infos[j].Placeholder
+			}
+			return infos[i].PlaceholderPod < infos[j].PlaceholderPod
+		})
+		ret[nodeName] = infos
+	})
+	return ret
+}
@@ -51,6 +51,33 @@ type Store interface {
 	UpdateSnapshot(Store) error
 }
 
+// Dumpable is implemented by stores whose content can be exposed for debugging.
+// The returned object should be JSON serializable and must not share memory with the store.
+type Dumpable interface {
+	Dump() interface{}
+}
+
+// DumpStores dumps the dumpable stores in the switch, keyed by store name. All of them will be
+// dumped if names is empty. The caller is responsible for holding the lock of cache.
+func DumpStores(s CommonStoresSwitch, names ...StoreName) map[StoreName]interface{} {
+	ret := make(map[StoreName]interface{})
+	if len(names) == 0 {
+		s.Range(func(store Store) error {
+			if d, ok := store.(Dumpable); ok {
+				ret[store.Name()] = d.Dump()
+			}
+			return nil
+		})
+		return ret
+	}
+	for _, name := range names {
+		if d, ok := s.Find(name).(Dumpable); ok {
+			ret[name] = d.Dump()
+		}
+	}
+	return ret
+}
+
 type BaseStoreImpl struct{}
 
 var _ BaseStore = &BaseStoreImpl{}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package store
 
-import commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+import (
+	"fmt"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+)
 
 type (
 	StoreName string
@@ -29,16 +33,21 @@ type (
 
 type Registries interface {
 	Register(name StoreName, checker FeatureGateChecker, newCache, newSnapshot New)
+	// RegisterOutOfTree registers a store that is not built in the repo, out-of-tree stores
+	// will be called in registration order after the in-tree stores except NodeStore and PodStore.
+	RegisterOutOfTree(name StoreName, checker FeatureGateChecker, newCache, newSnapshot New) error
 
 	CacheRegistry() Registry
 	SnapshotRegistry() Registry
 	FeatureGateCheckers() map[StoreName]FeatureGateChecker
+	OutOfTreeStoreNames() []StoreName
 }
 
 type registriesImpl struct {
 	cacheRegistry       map[StoreName]New
 	snapshotRegistry    map[StoreName]New
 	featureGateCheckers map[StoreName]FeatureGateChecker
+	outOfTreeStoreNames []StoreName
 }
 
 func NewRegistries() Registries {
@@ -55,6 +64,18 @@ func (r *registriesImpl) Register(name StoreName, checker FeatureGateChecker, ne
 	r.featureGateCheckers[name] = checker
 }
 
+func (r *registriesImpl) RegisterOutOfTree(name StoreName, checker FeatureGateChecker, newCache, newSnapshot New) error {
+	if _, ok := r.featureGateCheckers[name]; ok {
+		return fmt.Errorf("a store named %v already exists", name)
+	}
+	if checker == nil || newCache == nil || newSnapshot == nil {
+		return fmt.Errorf("store %v must provide the checker and the new functions", name)
+	}
+	r.Register(name, checker, newCache, newSnapshot)
+	r.outOfTreeStoreNames = append(r.outOfTreeStoreNames, name)
+	return nil
+}
+
 func (r *registriesImpl) CacheRegistry() Registry {
 	return r.cacheRegistry
 }
@@ -66,3 +87,7 @@ func (r *registriesImpl) SnapshotRegistry() Registry {
 func (r *registriesImpl) FeatureGateCheckers() map[StoreName]FeatureGateChecker {
 	return r.featureGateCheckers
 }
+
+func (r *registriesImpl) OutOfTreeStoreNames() []StoreName {
+	return r.outOfTreeStoreNames
+}
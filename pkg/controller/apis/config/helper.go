@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	podgroupconfig "github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
 	reservationconfig "github.com/kubewharf/godel-scheduler/pkg/controller/reservation/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -25,6 +26,7 @@ func NewEmptyGodelControllerManagerConfiguration() *GodelControllerManagerConfig
 	return &GodelControllerManagerConfiguration{
 		Generic:               &GenericControllerManagerConfiguration{},
 		ReservationController: &reservationconfig.ReservationControllerConfiguration{},
+		PodGroupController:    &podgroupconfig.PodGroupControllerConfiguration{},
 		Tracer:                &tracing.TracerConfiguration{},
 	}
 }
@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfig "k8s.io/component-base/config"
 
+	podgroupconfig "github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
 	reservationconfig "github.com/kubewharf/godel-scheduler/pkg/controller/reservation/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -30,6 +31,7 @@ type GodelControllerManagerConfiguration struct {
 
 	Generic               *GenericControllerManagerConfiguration
 	ReservationController *reservationconfig.ReservationControllerConfiguration
+	PodGroupController    *podgroupconfig.PodGroupControllerConfiguration
 	// HealthzBindAddress is the IP address and port for the health check server to serve on,
 	// defaulting to 0.0.0.0:10251
 	HealthzBindAddress string
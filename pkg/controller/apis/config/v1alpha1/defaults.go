@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config/v1alpha1"
 
+	podgroupconfig "github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
 	reservationconfig "github.com/kubewharf/godel-scheduler/pkg/controller/reservation/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -119,6 +120,11 @@ func SetDefaults_GodelControllerManagerConfiguration(obj *GodelControllerManager
 	}
 	reservationconfig.SetDefaultReservationController(obj.ReservationController)
 
+	if obj.PodGroupController == nil {
+		obj.PodGroupController = podgroupconfig.NewPodGroupControllerConfiguration()
+	}
+	podgroupconfig.SetDefaultPodGroupController(obj.PodGroupController)
+
 	if obj.Tracer == nil {
 		obj.Tracer = tracing.DefaultNoopOptions()
 	}
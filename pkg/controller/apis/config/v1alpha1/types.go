@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
 
+	podgroupconfig "github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
 	reservationconfig "github.com/kubewharf/godel-scheduler/pkg/controller/reservation/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -31,6 +32,7 @@ type GodelControllerManagerConfiguration struct {
 
 	Generic               *GenericControllerManagerConfiguration
 	ReservationController *reservationconfig.ReservationControllerConfiguration
+	PodGroupController    *podgroupconfig.PodGroupControllerConfiguration
 	// defaulting to 0.0.0.0:10651
 	HealthzBindAddress string
 	// MetricsBindAddress is the IP address and port for the metrics       server to
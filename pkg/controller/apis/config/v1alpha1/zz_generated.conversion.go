@@ -28,6 +28,7 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 
 	config "github.com/kubewharf/godel-scheduler/pkg/controller/apis/config"
+	podgroupconfig "github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
 	reservationconfig "github.com/kubewharf/godel-scheduler/pkg/controller/reservation/config"
 	tracing "github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -157,6 +158,7 @@ func autoConvert_v1alpha1_GodelControllerManagerConfiguration_To_config_GodelCon
 		out.Generic = nil
 	}
 	out.ReservationController = (*reservationconfig.ReservationControllerConfiguration)(unsafe.Pointer(in.ReservationController))
+	out.PodGroupController = (*podgroupconfig.PodGroupControllerConfiguration)(unsafe.Pointer(in.PodGroupController))
 	out.HealthzBindAddress = in.HealthzBindAddress
 	out.MetricsBindAddress = in.MetricsBindAddress
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
@@ -179,6 +181,7 @@ func autoConvert_config_GodelControllerManagerConfiguration_To_v1alpha1_GodelCon
 		out.Generic = nil
 	}
 	out.ReservationController = (*reservationconfig.ReservationControllerConfiguration)(unsafe.Pointer(in.ReservationController))
+	out.PodGroupController = (*podgroupconfig.PodGroupControllerConfiguration)(unsafe.Pointer(in.PodGroupController))
 	out.HealthzBindAddress = in.HealthzBindAddress
 	out.MetricsBindAddress = in.MetricsBindAddress
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
@@ -80,6 +80,10 @@ func (in *GodelControllerManagerConfiguration) DeepCopyInto(out *GodelController
 		in, out := &in.ReservationController, &out.ReservationController
 		*out = (*in).DeepCopy()
 	}
+	if in.PodGroupController != nil {
+		in, out := &in.PodGroupController, &out.PodGroupController
+		*out = (*in).DeepCopy()
+	}
 	if in.Tracer != nil {
 		in, out := &in.Tracer, &out.Tracer
 		*out = (*in).DeepCopy()
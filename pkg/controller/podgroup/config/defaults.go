@@ -0,0 +1,60 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+const (
+	DefaultMinMemberAnnotation              = "godel.bytedance.com/pod-group-min-member"
+	DefaultPriorityClassNameAnnotation      = "godel.bytedance.com/pod-group-priority-class-name"
+	DefaultScheduleTimeoutSecondsAnnotation = "godel.bytedance.com/pod-group-schedule-timeout-seconds"
+
+	DefaultPodTemplatePath = "spec.template"
+)
+
+// DefaultJobOwner is the owner configuration of batch/v1 Job. Jobs are expected to be created
+// suspended, so that no pod is created before the PodGroup name is injected into the pod template,
+// and to be resumed by their creators once the PodGroup name is injected.
+var DefaultJobOwner = OwnerConfiguration{
+	Group:           "batch",
+	Version:         "v1",
+	Kind:            "Job",
+	PodTemplatePath: DefaultPodTemplatePath,
+	MinMemberPath:   "spec.parallelism",
+	SuspendPath:     "spec.suspend",
+
+	ImmutablePodTemplate: true,
+}
+
+func SetDefaultPodGroupController(obj *PodGroupControllerConfiguration) {
+	if len(obj.Owners) == 0 {
+		obj.Owners = []OwnerConfiguration{DefaultJobOwner}
+	}
+	for i := range obj.Owners {
+		owner := &obj.Owners[i]
+		if len(owner.PodTemplatePath) == 0 {
+			owner.PodTemplatePath = DefaultPodTemplatePath
+		}
+		if len(owner.MinMemberAnnotation) == 0 {
+			owner.MinMemberAnnotation = DefaultMinMemberAnnotation
+		}
+		if len(owner.PriorityClassNameAnnotation) == 0 {
+			owner.PriorityClassNameAnnotation = DefaultPriorityClassNameAnnotation
+		}
+		if len(owner.ScheduleTimeoutSecondsAnnotation) == 0 {
+			owner.ScheduleTimeoutSecondsAnnotation = DefaultScheduleTimeoutSecondsAnnotation
+		}
+	}
+}
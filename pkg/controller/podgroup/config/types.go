@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// PodGroupControllerConfiguration holds the configuration of the PodGroup auto-creation controller.
+type PodGroupControllerConfiguration struct {
+	// Owners is the list of owner kinds whose PodGroups will be created automatically.
+	// Only owner objects opted in by annotation will be handled.
+	Owners []OwnerConfiguration
+}
+
+// OwnerConfiguration describes how to build the PodGroup for an owner kind.
+type OwnerConfiguration struct {
+	// Group, Version and Kind identify the owner kind, e.g. batch/v1 Job.
+	Group   string
+	Version string
+	Kind    string
+	// PodTemplatePath is the dot-separated path of the pod template in the owner object,
+	// the PodGroup name will be injected into the annotations of the pod template.
+	PodTemplatePath string
+	// MinMemberPath is the dot-separated path of the integer field used as minMember if
+	// the owner doesn't have the MinMemberAnnotation.
+	MinMemberPath string
+	// SuspendPath is the dot-separated path of the boolean field which suspends the owner.
+	// The owner is never resumed by the controller, but by whoever suspended it, e.g. the user
+	// or a queueing controller, since the controller can't tell why the owner is suspended.
+	SuspendPath string
+	// ImmutablePodTemplate indicates the pod template of the owner could only be changed while the
+	// owner is suspended, e.g. batch/v1 Job. Owners neither suspended nor mutable will be skipped.
+	ImmutablePodTemplate bool
+	// MinMemberAnnotation, PriorityClassNameAnnotation and ScheduleTimeoutSecondsAnnotation are
+	// the owner annotations mapped to the fields of the PodGroup spec.
+	MinMemberAnnotation              string
+	PriorityClassNameAnnotation      string
+	ScheduleTimeoutSecondsAnnotation string
+}
+
+func NewPodGroupControllerConfiguration() *PodGroupControllerConfiguration {
+	return &PodGroupControllerConfiguration{}
+}
+
+func (c *PodGroupControllerConfiguration) DeepCopyInto(in *PodGroupControllerConfiguration) {
+	if in.Owners != nil {
+		c.Owners = make([]OwnerConfiguration, len(in.Owners))
+		copy(c.Owners, in.Owners)
+	}
+}
+
+func (c *PodGroupControllerConfiguration) DeepCopy() (out *PodGroupControllerConfiguration) {
+	out = new(PodGroupControllerConfiguration)
+	out.DeepCopyInto(c)
+	return out
+}
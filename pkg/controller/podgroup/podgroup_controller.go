@@ -0,0 +1,417 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+
+	schedulingv1a1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	godelclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
+	pginformer "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions/scheduling/v1alpha1"
+	pglister "github.com/kubewharf/godel-scheduler-api/pkg/client/listers/scheduling/v1alpha1"
+	controllersmetrics "github.com/kubewharf/godel-scheduler/pkg/controller/metrics"
+	"github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// AutoCreatePodGroupAnnotationKey is the owner annotation to opt in PodGroup auto-creation.
+	AutoCreatePodGroupAnnotationKey = "godel.bytedance.com/auto-create-pod-group"
+	// ManagedByLabelKey is the label of PodGroups created by this controller.
+	ManagedByLabelKey = "godel.bytedance.com/pod-group-managed-by"
+	// OwnerResourceAnnotationKey is the PodGroup annotation recording the resource of its owner.
+	OwnerResourceAnnotationKey = "godel.bytedance.com/pod-group-owner-resource"
+
+	ControllerName = "podgroup-controller"
+
+	// PodTemplateImmutableReason is the event reason of owners whose pod template can't be changed.
+	PodTemplateImmutableReason = "PodTemplateImmutable"
+)
+
+type owner struct {
+	config.OwnerConfiguration
+	gvr      schema.GroupVersionResource
+	informer cache.SharedIndexInformer
+	lister   cache.GenericLister
+}
+
+type ownerKey struct {
+	resource  string
+	namespace string
+	name      string
+}
+
+// PodGroupController creates PodGroups for the opted-in owners, injects the PodGroup name into
+// the pod template of owners, and deletes the PodGroups once the owners are gone or opted out.
+type PodGroupController struct {
+	godelClient          godelclient.Interface
+	dynamicClient        dynamic.Interface
+	podGroupLister       pglister.PodGroupLister
+	podGroupListerSynced cache.InformerSynced
+	owners               map[string]*owner
+	queue                workqueue.RateLimitingInterface
+	recorder             record.EventRecorder
+}
+
+func NewPodGroupController(
+	godelClient godelclient.Interface,
+	dynamicClient dynamic.Interface,
+	podGroupInformer pginformer.PodGroupInformer,
+	dynamicInformerFactory dynamicinformer.DynamicSharedInformerFactory,
+	restMapper meta.RESTMapper,
+	owners []config.OwnerConfiguration,
+	recorder record.EventRecorder,
+) (*PodGroupController, error) {
+	c := &PodGroupController{
+		godelClient:          godelClient,
+		dynamicClient:        dynamicClient,
+		podGroupLister:       podGroupInformer.Lister(),
+		podGroupListerSynced: podGroupInformer.Informer().HasSynced,
+		owners:               make(map[string]*owner, len(owners)),
+		queue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "pod_group_auto_creation"),
+		recorder:             recorder,
+	}
+
+	for _, cfg := range owners {
+		mapping, err := restMapper.RESTMapping(schema.GroupKind{Group: cfg.Group, Kind: cfg.Kind}, cfg.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the resource of owner kind %s: %v", schema.GroupVersionKind{Group: cfg.Group, Version: cfg.Version, Kind: cfg.Kind}, err)
+		}
+		gvr := mapping.Resource
+		informer := dynamicInformerFactory.ForResource(gvr)
+		o := &owner{OwnerConfiguration: cfg, gvr: gvr, informer: informer.Informer(), lister: informer.Lister()}
+		c.owners[resourceKey(gvr)] = o
+
+		enqueue := func(obj interface{}) { c.enqueueOwner(o, obj) }
+		o.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    enqueue,
+			UpdateFunc: func(_, newObj interface{}) { enqueue(newObj) },
+			DeleteFunc: enqueue,
+		})
+	}
+
+	podGroupInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			pg, ok := obj.(*schedulingv1a1.PodGroup)
+			return ok && isManaged(pg)
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(_, newObj interface{}) { c.enqueuePodGroupOwner(newObj) },
+			DeleteFunc: c.enqueuePodGroupOwner,
+		},
+	})
+	return c, nil
+}
+
+func (c *PodGroupController) Run(ctx context.Context, workers int, controllerManagerMetrics *controllersmetrics.ControllerManagerMetrics) {
+	defer utilruntime.HandleCrash()
+	controllerManagerMetrics.ControllerStarted(ControllerName)
+	defer controllerManagerMetrics.ControllerStopped(ControllerName)
+
+	klog.V(3).InfoS("Starting PodGroup Controller")
+	defer c.queue.ShutDown()
+	defer klog.V(3).InfoS("Shutting down PodGroup Controller")
+
+	synced := []cache.InformerSynced{c.podGroupListerSynced}
+	for _, o := range c.owners {
+		synced = append(synced, o.informer.HasSynced)
+	}
+	if !cache.WaitForNamedCacheSync("PodGroup", ctx.Done(), synced...) {
+		return
+	}
+
+	for i := 0; i < workers; i++ {
+		go wait.UntilWithContext(ctx, c.worker, time.Second)
+	}
+	<-ctx.Done()
+}
+
+func (c *PodGroupController) worker(ctx context.Context) {
+	for c.processNextWorkItem(ctx) {
+	}
+}
+
+func (c *PodGroupController) processNextWorkItem(ctx context.Context) bool {
+	item, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(item)
+
+	key := item.(ownerKey)
+	if err := c.sync(ctx, key); err != nil {
+		klog.ErrorS(err, "Failed to sync the PodGroup of owner", "resource", key.resource, "owner", klog.KRef(key.namespace, key.name))
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+func (c *PodGroupController) enqueueOwner(o *owner, obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		utilruntime.HandleError(err)
+		return
+	}
+	c.queue.Add(ownerKey{resource: resourceKey(o.gvr), namespace: namespace, name: name})
+}
+
+func (c *PodGroupController) enqueuePodGroupOwner(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	pg, ok := obj.(*schedulingv1a1.PodGroup)
+	if !ok {
+		return
+	}
+	ref := metav1.GetControllerOf(pg)
+	resource := pg.Annotations[OwnerResourceAnnotationKey]
+	if ref == nil || c.owners[resource] == nil {
+		return
+	}
+	c.queue.Add(ownerKey{resource: resource, namespace: pg.Namespace, name: ref.Name})
+}
+
+func (c *PodGroupController) sync(ctx context.Context, key ownerKey) error {
+	o := c.owners[key.resource]
+	if o == nil {
+		return nil
+	}
+	pgName := podGroupName(o.gvr, key.name)
+
+	obj, err := o.lister.ByNamespace(key.namespace).Get(key.name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	var u *unstructured.Unstructured
+	if err == nil {
+		u, _ = obj.(*unstructured.Unstructured)
+	}
+	if u == nil || u.GetDeletionTimestamp() != nil || u.GetAnnotations()[AutoCreatePodGroupAnnotationKey] != "true" {
+		return c.deletePodGroup(ctx, key, pgName)
+	}
+
+	templatePath := splitPath(o.PodTemplatePath)
+	if existing, _, _ := unstructured.NestedString(u.Object, append(templatePath, "metadata", "annotations", podutil.PodGroupNameAnnotationKey)...); len(existing) > 0 && existing != pgName {
+		klog.V(4).InfoS("Skipped the owner whose PodGroup is managed by user", "resource", key.resource, "owner", klog.KObj(u), "podGroup", existing)
+		return nil
+	}
+
+	desired, err := buildPodGroup(o, u, pgName)
+	if err != nil {
+		// Invalid annotations won't be fixed by retrying.
+		klog.ErrorS(err, "Failed to build the PodGroup of owner", "resource", key.resource, "owner", klog.KObj(u))
+		return nil
+	}
+	if err := c.createOrUpdatePodGroup(ctx, desired); err != nil {
+		return err
+	}
+	return c.injectPodGroup(ctx, o, u, pgName)
+}
+
+func (c *PodGroupController) createOrUpdatePodGroup(ctx context.Context, desired *schedulingv1a1.PodGroup) error {
+	pg, err := c.podGroupLister.PodGroups(desired.Namespace).Get(desired.Name)
+	if apierrors.IsNotFound(err) {
+		_, err = c.godelClient.SchedulingV1alpha1().PodGroups(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err == nil {
+			klog.V(4).InfoS("Created the PodGroup for owner", "podGroup", klog.KObj(desired))
+		}
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if !isManaged(pg) {
+		return fmt.Errorf("podGroup %s/%s exists but isn't managed by %s", pg.Namespace, pg.Name, ControllerName)
+	}
+	if pg.Spec.MinMember == desired.Spec.MinMember && pg.Spec.PriorityClassName == desired.Spec.PriorityClassName &&
+		reflect.DeepEqual(pg.Spec.ScheduleTimeoutSeconds, desired.Spec.ScheduleTimeoutSeconds) {
+		return nil
+	}
+	pg = pg.DeepCopy()
+	pg.Spec.MinMember = desired.Spec.MinMember
+	pg.Spec.PriorityClassName = desired.Spec.PriorityClassName
+	pg.Spec.ScheduleTimeoutSeconds = desired.Spec.ScheduleTimeoutSeconds
+	_, err = c.godelClient.SchedulingV1alpha1().PodGroups(pg.Namespace).Update(ctx, pg, metav1.UpdateOptions{})
+	return err
+}
+
+// injectPodGroup patches the PodGroup name into the pod template of the owner. Suspended owners are
+// left suspended, since they may be suspended by users or queueing controllers, e.g. Kueue, which
+// should decide when to resume them. Owners whose pod template can't be changed are skipped with
+// an event, since the patch would be rejected on every retry.
+func (c *PodGroupController) injectPodGroup(ctx context.Context, o *owner, u *unstructured.Unstructured, pgName string) error {
+	annotationPath := append(splitPath(o.PodTemplatePath), "metadata", "annotations", podutil.PodGroupNameAnnotationKey)
+	if existing, _, _ := unstructured.NestedString(u.Object, annotationPath...); existing == pgName {
+		return nil
+	}
+
+	var suspended bool
+	if len(o.SuspendPath) > 0 {
+		suspended, _, _ = unstructured.NestedBool(u.Object, splitPath(o.SuspendPath)...)
+	}
+	if o.ImmutablePodTemplate && !suspended {
+		klog.V(4).InfoS("Skipped injecting the PodGroup into owner whose pod template is immutable", "resource", resourceKey(o.gvr), "owner", klog.KObj(u), "podGroup", pgName)
+		if c.recorder != nil {
+			c.recorder.Eventf(u, v1.EventTypeWarning, PodTemplateImmutableReason,
+				"PodGroup %s can't be injected into the pod template unless the owner is created suspended", pgName)
+		}
+		return nil
+	}
+
+	patch := map[string]interface{}{}
+	if err := unstructured.SetNestedField(patch, pgName, annotationPath...); err != nil {
+		return err
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	if _, err := c.dynamicClient.Resource(o.gvr).Namespace(u.GetNamespace()).Patch(ctx, u.GetName(), types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return err
+	}
+	klog.V(4).InfoS("Injected the PodGroup into owner", "resource", resourceKey(o.gvr), "owner", klog.KObj(u), "podGroup", pgName)
+	return nil
+}
+
+func (c *PodGroupController) deletePodGroup(ctx context.Context, key ownerKey, pgName string) error {
+	pg, err := c.podGroupLister.PodGroups(key.namespace).Get(pgName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !isManaged(pg) || pg.Annotations[OwnerResourceAnnotationKey] != key.resource {
+		return nil
+	}
+	err = c.godelClient.SchedulingV1alpha1().PodGroups(pg.Namespace).Delete(ctx, pg.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	klog.V(4).InfoS("Deleted the PodGroup of owner", "podGroup", klog.KObj(pg))
+	return nil
+}
+
+func buildPodGroup(o *owner, u *unstructured.Unstructured, pgName string) (*schedulingv1a1.PodGroup, error) {
+	annotations := u.GetAnnotations()
+	templatePath := splitPath(o.PodTemplatePath)
+
+	minMember := int64(1)
+	if v, ok := annotations[o.MinMemberAnnotation]; ok {
+		parsed, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q", o.MinMemberAnnotation, v)
+		}
+		minMember = parsed
+	} else if len(o.MinMemberPath) > 0 {
+		if v, found, _ := unstructured.NestedInt64(u.Object, splitPath(o.MinMemberPath)...); found && v > 0 {
+			minMember = v
+		}
+	}
+
+	priorityClassName, ok := annotations[o.PriorityClassNameAnnotation]
+	if !ok {
+		priorityClassName, _, _ = unstructured.NestedString(u.Object, append(templatePath, "spec", "priorityClassName")...)
+	}
+
+	var timeout *int32
+	if v, ok := annotations[o.ScheduleTimeoutSecondsAnnotation]; ok {
+		parsed, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q", o.ScheduleTimeoutSecondsAnnotation, v)
+		}
+		t := int32(parsed)
+		timeout = &t
+	}
+
+	isController := true
+	return &schedulingv1a1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   u.GetNamespace(),
+			Name:        pgName,
+			Labels:      map[string]string{ManagedByLabelKey: ControllerName},
+			Annotations: map[string]string{OwnerResourceAnnotationKey: resourceKey(o.gvr)},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: u.GetAPIVersion(),
+				Kind:       u.GetKind(),
+				Name:       u.GetName(),
+				UID:        u.GetUID(),
+				Controller: &isController,
+			}},
+		},
+		Spec: schedulingv1a1.PodGroupSpec{
+			MinMember:              int32(minMember),
+			PriorityClassName:      priorityClassName,
+			ScheduleTimeoutSeconds: timeout,
+		},
+	}, nil
+}
+
+func isManaged(pg *schedulingv1a1.PodGroup) bool {
+	return pg.Labels[ManagedByLabelKey] == ControllerName
+}
+
+// podGroupName returns the name of the PodGroup created for the owner, e.g. `jobs-foo`.
+func podGroupName(gvr schema.GroupVersionResource, name string) string {
+	return gvr.Resource + "-" + name
+}
+
+// resourceKey returns the key of resource in the format of `resource.version.group`, e.g. `jobs.v1.batch`.
+func resourceKey(gvr schema.GroupVersionResource) string {
+	return strings.TrimSuffix(gvr.Resource+"."+gvr.Version+"."+gvr.Group, ".")
+}
+
+func splitPath(path string) []string {
+	if len(path) == 0 {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podgroup
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/record"
+
+	godelfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
+	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
+	"github.com/kubewharf/godel-scheduler/pkg/controller/podgroup/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+var (
+	jobGVR = schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}
+	jobGVK = schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+)
+
+func makeJob(name string, annotations map[string]string, parallelism int64, suspend bool) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]interface{}{
+			"namespace": "default",
+			"name":      name,
+			"uid":       name + "-uid",
+		},
+		"spec": map[string]interface{}{
+			"parallelism": parallelism,
+			"suspend":     suspend,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{"priorityClassName": "high"},
+			},
+		},
+	}}
+	job.SetAnnotations(annotations)
+	return job
+}
+
+type testController struct {
+	*PodGroupController
+	dynamicClient   *dynamicfake.FakeDynamicClient
+	godelClient     *godelfake.Clientset
+	informerFactory dynamicinformer.DynamicSharedInformerFactory
+	godelFactory    crdinformers.SharedInformerFactory
+	recorder        *record.FakeRecorder
+}
+
+func newTestController(objs ...runtime.Object) *testController {
+	scheme := runtime.NewScheme()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{jobGVR: "JobList"}, objs...)
+	godelClient := godelfake.NewSimpleClientset()
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	godelFactory := crdinformers.NewSharedInformerFactory(godelClient, 0)
+
+	cfg := config.NewPodGroupControllerConfiguration()
+	config.SetDefaultPodGroupController(cfg)
+	recorder := record.NewFakeRecorder(10)
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(jobGVK, meta.RESTScopeNamespace)
+	c, err := NewPodGroupController(godelClient, dynamicClient, godelFactory.Scheduling().V1alpha1().PodGroups(), informerFactory, restMapper, cfg.Owners, recorder)
+	if err != nil {
+		panic(err)
+	}
+	for _, obj := range objs {
+		informerFactory.ForResource(jobGVR).Informer().GetIndexer().Add(obj)
+	}
+	return &testController{c, dynamicClient, godelClient, informerFactory, godelFactory, recorder}
+}
+
+// syncPodGroups copies the PodGroups from the client to the informer.
+func (c *testController) syncPodGroups(t *testing.T) {
+	pgs, err := c.godelClient.SchedulingV1alpha1().PodGroups("default").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexer := c.godelFactory.Scheduling().V1alpha1().PodGroups().Informer().GetIndexer()
+	for _, obj := range indexer.List() {
+		indexer.Delete(obj)
+	}
+	for i := range pgs.Items {
+		indexer.Add(&pgs.Items[i])
+	}
+}
+
+func TestSyncCreatesPodGroup(t *testing.T) {
+	job := makeJob("train", map[string]string{
+		AutoCreatePodGroupAnnotationKey:                "true",
+		config.DefaultScheduleTimeoutSecondsAnnotation: "300",
+	}, 4, true)
+	c := newTestController(job)
+
+	key := ownerKey{resource: "jobs.v1.batch", namespace: "default", name: "train"}
+	if err := c.sync(context.TODO(), key); err != nil {
+		t.Fatal(err)
+	}
+
+	pg, err := c.godelClient.SchedulingV1alpha1().PodGroups("default").Get(context.TODO(), "jobs-train", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected PodGroup created, but got error: %v", err)
+	}
+	if pg.Spec.MinMember != 4 || pg.Spec.PriorityClassName != "high" || pg.Spec.ScheduleTimeoutSeconds == nil || *pg.Spec.ScheduleTimeoutSeconds != 300 {
+		t.Errorf("unexpected PodGroup spec: %+v", pg.Spec)
+	}
+	if ref := metav1.GetControllerOf(pg); ref == nil || ref.Kind != "Job" || ref.Name != "train" {
+		t.Errorf("unexpected owner of PodGroup: %v", pg.OwnerReferences)
+	}
+
+	patched, err := c.dynamicClient.Resource(jobGVR).Namespace("default").Get(context.TODO(), "train", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name, _, _ := unstructured.NestedString(patched.Object, "spec", "template", "metadata", "annotations", podutil.PodGroupNameAnnotationKey); name != "jobs-train" {
+		t.Errorf("expected PodGroup name injected into pod template, but got %q", name)
+	}
+	if suspended, _, _ := unstructured.NestedBool(patched.Object, "spec", "suspend"); !suspended {
+		t.Errorf("expected job left suspended")
+	}
+}
+
+func TestSyncUpdatesAndDeletesPodGroup(t *testing.T) {
+	job := makeJob("train", map[string]string{AutoCreatePodGroupAnnotationKey: "true"}, 2, false)
+	c := newTestController(job)
+	key := ownerKey{resource: "jobs.v1.batch", namespace: "default", name: "train"}
+	if err := c.sync(context.TODO(), key); err != nil {
+		t.Fatal(err)
+	}
+	c.syncPodGroups(t)
+
+	// minMember annotation overrides parallelism.
+	job = job.DeepCopy()
+	job.SetAnnotations(map[string]string{AutoCreatePodGroupAnnotationKey: "true", config.DefaultMinMemberAnnotation: "3"})
+	c.informerFactory.ForResource(jobGVR).Informer().GetIndexer().Update(job)
+	if err := c.sync(context.TODO(), key); err != nil {
+		t.Fatal(err)
+	}
+	pg, _ := c.godelClient.SchedulingV1alpha1().PodGroups("default").Get(context.TODO(), "jobs-train", metav1.GetOptions{})
+	if pg.Spec.MinMember != 3 {
+		t.Errorf("expected minMember 3, but got %d", pg.Spec.MinMember)
+	}
+	c.syncPodGroups(t)
+
+	// the PodGroup is deleted once the job is gone.
+	c.informerFactory.ForResource(jobGVR).Informer().GetIndexer().Delete(job)
+	if err := c.sync(context.TODO(), key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.godelClient.SchedulingV1alpha1().PodGroups("default").Get(context.TODO(), "jobs-train", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected PodGroup deleted, but got %v", err)
+	}
+}
+
+func TestSyncSkipsOwners(t *testing.T) {
+	userManaged := makeJob("user", map[string]string{AutoCreatePodGroupAnnotationKey: "true"}, 2, false)
+	unstructured.SetNestedField(userManaged.Object, "pg", "spec", "template", "metadata", "annotations", podutil.PodGroupNameAnnotationKey)
+	c := newTestController(makeJob("plain", nil, 2, false), userManaged)
+
+	for _, name := range []string{"plain", "user"} {
+		if err := c.sync(context.TODO(), ownerKey{resource: "jobs.v1.batch", namespace: "default", name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pgs, _ := c.godelClient.SchedulingV1alpha1().PodGroups("default").List(context.TODO(), metav1.ListOptions{})
+	if len(pgs.Items) != 0 {
+		t.Errorf("expected no PodGroup created, but got %d", len(pgs.Items))
+	}
+}
+
+func TestSyncSkipsImmutablePodTemplate(t *testing.T) {
+	job := makeJob("train", map[string]string{AutoCreatePodGroupAnnotationKey: "true"}, 2, false)
+	c := newTestController(job)
+	if err := c.sync(context.TODO(), ownerKey{resource: "jobs.v1.batch", namespace: "default", name: "train"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, action := range c.dynamicClient.Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("expected no patch for the running job, but got %v", action)
+		}
+	}
+	select {
+	case event := <-c.recorder.Events:
+		if !strings.Contains(event, PodTemplateImmutableReason) {
+			t.Errorf("unexpected event: %s", event)
+		}
+	default:
+		t.Errorf("expected an event for the running job")
+	}
+}
+
+func TestNewPodGroupControllerWithUnknownOwnerKind(t *testing.T) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	godelClient := godelfake.NewSimpleClientset()
+	informerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, 0)
+	godelFactory := crdinformers.NewSharedInformerFactory(godelClient, 0)
+
+	owners := []config.OwnerConfiguration{{Group: "example.com", Version: "v1", Kind: "TrainJob"}}
+	if _, err := NewPodGroupController(godelClient, dynamicClient, godelFactory.Scheduling().V1alpha1().PodGroups(), informerFactory,
+		meta.NewDefaultRESTMapper(nil), owners, record.NewFakeRecorder(10)); err == nil {
+		t.Errorf("expected error for the owner kind without resource")
+	}
+}
@@ -23,6 +23,8 @@ type ReservationControllerConfiguration struct {
 	// IgnoredNamespace is the list of namespace to be ignored when
 	// setup informer.
 	IgnoredNamespace []string
+	// ProtectReservedNodes disables the scale down of nodes holding active reservations by cluster autoscaler.
+	ProtectReservedNodes bool
 }
 
 func NewReservationControllerConfiguration() *ReservationControllerConfiguration {
@@ -33,6 +35,7 @@ func (c *ReservationControllerConfiguration) DeepCopyInto(in *ReservationControl
 	c.ReservationTTL = in.ReservationTTL
 	c.MatchedRequestExtraTTL = in.MatchedRequestExtraTTL
 	c.ReservationCheckPeriod = in.ReservationCheckPeriod
+	c.ProtectReservedNodes = in.ProtectReservedNodes
 
 	if in.IgnoredNamespace != nil {
 		c.IgnoredNamespace = make([]string, len(in.IgnoredNamespace))
@@ -45,6 +48,7 @@ func (c *ReservationControllerConfiguration) DeepCopy() (out *ReservationControl
 	out.ReservationTTL = c.ReservationTTL
 	out.MatchedRequestExtraTTL = c.MatchedRequestExtraTTL
 	out.ReservationCheckPeriod = c.ReservationCheckPeriod
+	out.ProtectReservedNodes = c.ProtectReservedNodes
 	if c.IgnoredNamespace != nil {
 		out.IgnoredNamespace = make([]string, len(c.IgnoredNamespace))
 		copy(out.IgnoredNamespace, c.IgnoredNamespace)
@@ -23,10 +23,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	appsinformers "k8s.io/client-go/informers/apps/v1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
+	clientset "k8s.io/client-go/kubernetes"
 	appslister "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
@@ -50,6 +53,7 @@ const (
 
 type ReservationController struct {
 	godelClient godelclient.Interface
+	kubeClient  clientset.Interface
 	// eventRecorder   record.EventRecorder
 	podReservationLister       reservationlister.ReservationLister
 	podReservationListerSynced cache.InformerSynced
@@ -58,6 +62,9 @@ type ReservationController struct {
 	reservationCheckPeriod     int64
 	reservationTTL             int64
 	matchedPodExtraTTL         int64
+	// nodeLister is nil if reserved nodes are not protected from scale down.
+	nodeLister       corelisters.NodeLister
+	nodeListerSynced cache.InformerSynced
 	// TODO: deal with fault node, remove reservation CRD on the node.
 }
 
@@ -69,15 +76,19 @@ func init() {
 func NewReservationController(
 	ctx context.Context,
 	godelClient godelclient.Interface,
+	kubeClient clientset.Interface,
 	podInformer coreinformers.PodInformer,
 	deployInformer appsinformers.DeploymentInformer,
 	podReservationInformer reservationinformer.ReservationInformer,
+	// nodeInformer is optional, reserved nodes are protected from scale down if it is specified.
+	nodeInformer coreinformers.NodeInformer,
 	reservationCheckPeriod int64,
 	reservationTTL int64,
 	matchedPodExtraTTL int64,
 ) *ReservationController {
 	rc := &ReservationController{
 		godelClient:                godelClient,
+		kubeClient:                 kubeClient,
 		podReservationLister:       podReservationInformer.Lister(),
 		podReservationListerSynced: podReservationInformer.Informer().HasSynced,
 		podReservationQueue:        workqueue.NewNamedDelayingQueue("pod_reservation_request"),
@@ -86,6 +97,10 @@ func NewReservationController(
 		reservationTTL:             reservationTTL,
 		matchedPodExtraTTL:         matchedPodExtraTTL,
 	}
+	if nodeInformer != nil {
+		rc.nodeLister = nodeInformer.Lister()
+		rc.nodeListerSynced = nodeInformer.Informer().HasSynced
+	}
 
 	podInformer.Informer().AddEventHandler(
 		cache.ResourceEventHandlerFuncs{
@@ -107,6 +122,9 @@ func (rc *ReservationController) Run(ctx context.Context, controllerManagerMetri
 	if !cache.WaitForNamedCacheSync("Reservation", ctx.Done(), rc.podReservationListerSynced) {
 		return
 	}
+	if rc.nodeLister != nil && !cache.WaitForNamedCacheSync("Reservation", ctx.Done(), rc.nodeListerSynced) {
+		return
+	}
 
 	checkPeriod := time.Duration(rc.reservationCheckPeriod) * time.Second
 
@@ -185,8 +203,9 @@ func (rc *ReservationController) gcReservations(
 	}
 
 	var (
-		active    = 0
-		waitForGC = make([]gcObject, 0)
+		active        = 0
+		waitForGC     = make([]gcObject, 0)
+		reservedNodes = sets.NewString()
 	)
 
 	for _, prr := range reservations {
@@ -202,6 +221,9 @@ func (rc *ReservationController) gcReservations(
 			})
 		} else {
 			active += 1
+			if len(prr.Spec.NodeName) > 0 {
+				reservedNodes.Insert(prr.Spec.NodeName)
+			}
 		}
 	}
 
@@ -217,6 +239,10 @@ func (rc *ReservationController) gcReservations(
 	}
 
 	reservationmetrics.SetExistingReservationCount(active)
+
+	if rc.nodeLister != nil {
+		rc.protectReservedNodes(ctx, reservedNodes)
+	}
 }
 
 func (rc *ReservationController) isReservationTimeout(prr *schedulingv1a1.Reservation) bool {
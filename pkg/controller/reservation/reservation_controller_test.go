@@ -24,6 +24,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 
@@ -32,6 +33,7 @@ import (
 	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
 	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
 	"github.com/kubewharf/godel-scheduler/pkg/util/controller"
+	nodeutil "github.com/kubewharf/godel-scheduler/pkg/util/node"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
@@ -97,9 +99,11 @@ func TestCreateReservationCrdOnPodDeletion(t *testing.T) {
 			rc := NewReservationController(
 				context.TODO(),
 				godelClient,
+				kubeClient,
 				podInformer,
 				deployInformer,
 				podReservationInformer,
+				nil,
 				1,
 				60,
 				60,
@@ -149,9 +153,11 @@ func TestHandleReservation(t *testing.T) {
 			rc := NewReservationController(
 				context.TODO(),
 				godelClient,
+				kubeClient,
 				podInformer,
 				deployInformer,
 				podReservationInformer,
+				nil,
 				1,
 				60,
 				60,
@@ -172,3 +178,94 @@ func TestHandleReservation(t *testing.T) {
 		})
 	}
 }
+
+func TestProtectReservedNodes(t *testing.T) {
+	reservationAnnotations := map[string]string{
+		podutil.PodResourceReservationAnnotationForGodel: podutil.PodHasReservationRequirement,
+	}
+	makeNode := func(name string, annotations map[string]string) *v1.Node {
+		node := testinghelper.MakeNode().Name(name).Obj()
+		node.Annotations = annotations
+		return node
+	}
+	protected := map[string]string{
+		nodeutil.ClusterAutoscalerScaleDownDisabledAnnotationKey: "true",
+		nodeutil.ScaleDownDisabledByReservationAnnotationKey:     "true",
+	}
+	disabledByOthers := map[string]string{
+		nodeutil.ClusterAutoscalerScaleDownDisabledAnnotationKey: "true",
+	}
+
+	nodes := []*v1.Node{
+		makeNode("n1", nil),
+		makeNode("n2", protected),
+		makeNode("n3", disabledByOthers),
+		makeNode("n4", disabledByOthers),
+		makeNode("n5", protected),
+	}
+	crds := []*schedulingv1a1.Reservation{
+		// active reservations on n1 and n3
+		testinghelper.WrapReservation(makeReservationCrd(makePod("p1", reservationAnnotations, "n1"))).CreateTime(metav1.Now()).Obj(),
+		testinghelper.WrapReservation(makeReservationCrd(makePod("p3", reservationAnnotations, "n3"))).CreateTime(metav1.Now()).Obj(),
+		// matched reservation on n2 no longer protects the node
+		testinghelper.WrapReservation(makeReservationCrd(makePod("p2", reservationAnnotations, "n2"))).CreateTime(metav1.Now()).
+			SetStatus(schedulingv1a1.ReservationStatus{Phase: schedulingv1a1.ReservationMatched}).Obj(),
+		// active reservation on n5
+		testinghelper.WrapReservation(makeReservationCrd(makePod("p5", reservationAnnotations, "n5"))).CreateTime(metav1.Now()).Obj(),
+	}
+	expected := map[string]map[string]string{
+		"n1": protected,
+		"n2": nil,
+		"n3": disabledByOthers,
+		"n4": disabledByOthers,
+		"n5": protected,
+	}
+
+	var kubeObjs []runtime.Object
+	for _, node := range nodes {
+		kubeObjs = append(kubeObjs, node)
+	}
+	var godelObjs []runtime.Object
+	for _, crd := range crds {
+		godelObjs = append(godelObjs, crd)
+	}
+	kubeClient := fake.NewSimpleClientset(kubeObjs...)
+	godelClient := godelfake.NewSimpleClientset(godelObjs...)
+	informerFactory := informers.NewSharedInformerFactory(kubeClient, controller.NoResyncPeriodFunc())
+	godelInformerFactory := crdinformers.NewSharedInformerFactory(godelClient, controller.NoResyncPeriodFunc())
+	podReservationInformer := godelInformerFactory.Scheduling().V1alpha1().Reservations()
+	nodeInformer := informerFactory.Core().V1().Nodes()
+
+	rc := NewReservationController(
+		context.TODO(),
+		godelClient,
+		kubeClient,
+		informerFactory.Core().V1().Pods(),
+		informerFactory.Apps().V1().Deployments(),
+		podReservationInformer,
+		nodeInformer,
+		1,
+		60,
+		60,
+	)
+	for _, node := range nodes {
+		nodeInformer.Informer().GetIndexer().Add(node)
+	}
+	for _, crd := range crds {
+		podReservationInformer.Informer().GetIndexer().Add(crd)
+	}
+	rc.handleReservation(context.TODO())
+
+	for name, annotations := range expected {
+		node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get node %s: %v", name, err)
+		}
+		if len(annotations) == 0 && len(node.Annotations) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(annotations, node.Annotations) {
+			t.Errorf("node %s: expected annotations %v, got %v", name, annotations, node.Annotations)
+		}
+	}
+}
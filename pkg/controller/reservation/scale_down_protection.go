@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"context"
+	"encoding/json"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	nodeutil "github.com/kubewharf/godel-scheduler/pkg/util/node"
+)
+
+// protectReservedNodes disables the scale down of the nodes holding active reservations, and enables it again
+// once the reservations are gone. Only the nodes marked by this controller are re-enabled, so the annotations
+// set by others are left alone.
+func (rc *ReservationController) protectReservedNodes(ctx context.Context, reservedNodes sets.String) {
+	nodes, err := rc.nodeLister.List(labels.Everything())
+	if err != nil {
+		klog.ErrorS(err, "Error while listing nodes to protect from scale down")
+		return
+	}
+	for _, node := range nodes {
+		_, marked := node.Annotations[nodeutil.ScaleDownDisabledByReservationAnnotationKey]
+		reserved := reservedNodes.Has(node.Name)
+		switch {
+		case reserved && !marked && node.Annotations[nodeutil.ClusterAutoscalerScaleDownDisabledAnnotationKey] != "true":
+			rc.patchScaleDownDisabled(ctx, node, true)
+		case !reserved && marked:
+			rc.patchScaleDownDisabled(ctx, node, false)
+		}
+	}
+}
+
+func (rc *ReservationController) patchScaleDownDisabled(ctx context.Context, node *v1.Node, disabled bool) {
+	// a null value removes the annotation in merge patch
+	var value *string
+	if disabled {
+		trueValue := "true"
+		value = &trueValue
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				nodeutil.ClusterAutoscalerScaleDownDisabledAnnotationKey: value,
+				nodeutil.ScaleDownDisabledByReservationAnnotationKey:     value,
+			},
+		},
+	})
+	if err != nil {
+		klog.ErrorS(err, "Failed to build node patch", "node", klog.KObj(node))
+		return
+	}
+	if _, err := rc.kubeClient.CoreV1().Nodes().Patch(ctx, node.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.ErrorS(err, "Failed to update scale down protection of node", "node", klog.KObj(node), "scaleDownDisabled", disabled)
+		return
+	}
+	klog.V(4).InfoS("Updated scale down protection of node", "node", klog.KObj(node), "scaleDownDisabled", disabled)
+}
@@ -49,4 +49,29 @@ type GodelDispatcherConfiguration struct {
 
 	// Tracer defines the configuration of tracer
 	Tracer *tracing.TracerConfiguration `json:"tracer,omitempty" yaml:"tracer,omitempty"`
+
+	// Sharding defines the configuration of active-active sharding mode.
+	Sharding *ShardingConfiguration `json:"sharding,omitempty" yaml:"sharding,omitempty"`
+}
+
+// ShardingConfiguration configures the active-active sharding mode, in which multiple dispatcher
+// replicas dispatch pods concurrently and each of them owns a disjoint part of namespaces and units.
+type ShardingConfiguration struct {
+	// Enabled enables the sharding mode. Replicas join the shard membership through leases and
+	// leader election is only used for singleton workers (e.g. node shuffling).
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+
+	// LeaseNamespace is the namespace of the membership leases, defaulting to godel-system.
+	LeaseNamespace string `json:"leaseNamespace,omitempty" yaml:"leaseNamespace,omitempty"`
+
+	// LeaseDurationSeconds is the duration after which a replica is removed from the membership
+	// if its lease is not renewed, defaulting to 15.
+	LeaseDurationSeconds int64 `json:"leaseDurationSeconds,omitempty" yaml:"leaseDurationSeconds,omitempty"`
+
+	// RenewIntervalSeconds is the interval of renewing the lease and refreshing the membership, defaulting to 5.
+	RenewIntervalSeconds int64 `json:"renewIntervalSeconds,omitempty" yaml:"renewIntervalSeconds,omitempty"`
+
+	// VirtualNodes is the number of virtual nodes of each replica on the hash ring, defaulting to 128.
+	// It must be the same for all replicas, since the rings acknowledged by other replicas are rebuilt locally.
+	VirtualNodes int `json:"virtualNodes,omitempty" yaml:"virtualNodes,omitempty"`
 }
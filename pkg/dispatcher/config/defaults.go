@@ -32,6 +32,10 @@ const (
 	DefaultInsecureBinderPort          = 10351
 
 	DispatcherDefaultLockObjectName = "dispatcher"
+
+	DefaultShardLeaseDurationSeconds = 15
+	DefaultShardRenewIntervalSeconds = 5
+	DefaultShardVirtualNodes         = 128
 )
 
 func SetDefaults(cfg *GodelDispatcherConfiguration) {
@@ -102,6 +106,22 @@ func SetDefaults(cfg *GodelDispatcherConfiguration) {
 		}
 	}
 
+	if cfg.Sharding == nil {
+		cfg.Sharding = &ShardingConfiguration{}
+	}
+	if len(cfg.Sharding.LeaseNamespace) == 0 {
+		cfg.Sharding.LeaseNamespace = defaultsconfig.NamespaceSystem
+	}
+	if cfg.Sharding.LeaseDurationSeconds == 0 {
+		cfg.Sharding.LeaseDurationSeconds = DefaultShardLeaseDurationSeconds
+	}
+	if cfg.Sharding.RenewIntervalSeconds == 0 {
+		cfg.Sharding.RenewIntervalSeconds = DefaultShardRenewIntervalSeconds
+	}
+	if cfg.Sharding.VirtualNodes == 0 {
+		cfg.Sharding.VirtualNodes = DefaultShardVirtualNodes
+	}
+
 	// Use the default LeaderElectionConfiguration options
 	defaultsconfig.SetDefaultLeaderElectionConfiguration(&cfg.LeaderElection)
 	if len(cfg.LeaderElection.ResourceName) == 0 {
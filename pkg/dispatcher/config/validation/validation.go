@@ -41,5 +41,27 @@ func ValidateGodelDispatcherConfiguration(cc *config.GodelDispatcherConfiguratio
 		errs = append(errs, field.Invalid(field.NewPath("metricsBindAddress"), cc.MetricsBindAddress, msg))
 	}
 
+	errs = append(errs, validateShardingConfiguration(cc.Sharding, field.NewPath("sharding"))...)
+
+	return errs
+}
+
+func validateShardingConfiguration(cfg *config.ShardingConfiguration, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if cfg == nil || !cfg.Enabled {
+		return errs
+	}
+	if len(cfg.LeaseNamespace) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("leaseNamespace"), ""))
+	}
+	if cfg.RenewIntervalSeconds <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("renewIntervalSeconds"), cfg.RenewIntervalSeconds, "must be greater than zero"))
+	}
+	if cfg.LeaseDurationSeconds <= cfg.RenewIntervalSeconds {
+		errs = append(errs, field.Invalid(fldPath.Child("leaseDurationSeconds"), cfg.LeaseDurationSeconds, "must be greater than renewIntervalSeconds"))
+	}
+	if cfg.VirtualNodes <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("virtualNodes"), cfg.VirtualNodes, "must be greater than zero"))
+	}
 	return errs
 }
@@ -47,6 +47,7 @@ import (
 	nodeshuffler "github.com/kubewharf/godel-scheduler/pkg/dispatcher/node-shuffler"
 	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/reconciler"
 	schemaintainer "github.com/kubewharf/godel-scheduler/pkg/dispatcher/scheduler-maintainer"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/shard"
 	"github.com/kubewharf/godel-scheduler/pkg/features"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
@@ -98,6 +99,10 @@ type Dispatcher struct {
 	// that godel schedulers should be responsible for and filter out irrelevant pods.
 	SchedulerName string
 
+	// shardManager decides which pods this dispatcher is responsible for in sharding mode,
+	// it is nil if sharding is disabled and then all pods are owned.
+	shardManager *shard.Manager
+
 	recorder events.EventRecorder
 }
 
@@ -113,6 +118,7 @@ func New(
 	priorityClassInformer schedinformers.PriorityClassInformer,
 	schedulerName string,
 	recorder events.EventRecorder,
+	shardManager *shard.Manager,
 ) *Dispatcher {
 	metrics.Register()
 
@@ -123,7 +129,7 @@ func New(
 		StopEverything:       stopCh,
 		client:               client,
 		podLister:            podInformer.Lister(),
-		UnitInfos:            queue.NewUnitInfos(recorder),
+		UnitInfos:            queue.NewUnitInfos(recorder, crdClient),
 		OwnerInfos:           store.NewOwnerInfo(),
 		FIFOPendingPodsQueue: queue.NewPendingFIFO(metrics.NewPendingPodsRecorder("pending")),
 		SortedPodsQueue:      queue.NewSortedFIFO(metrics.NewPendingPodsRecorder("ready")),
@@ -139,7 +145,8 @@ func New(
 		PodGroupLister:      podGroupInformer.Lister(),
 		PriorityClassLister: priorityClassInformer.Lister(),
 
-		recorder: recorder,
+		shardManager: shardManager,
+		recorder:     recorder,
 	}
 
 	reconciler := reconciler.NewPodStateReconciler(client, podInformer.Lister(), nodeInformer.Lister(),
@@ -148,6 +155,9 @@ func New(
 	dispatcher.reconciler = reconciler
 
 	AddAllEventHandlers(dispatcher, podInformer, schedulerInformer, nodeInformer, nmNodeInformer, podGroupInformer)
+	if shardManager != nil {
+		shardManager.AddMembershipChangeHandler(dispatcher.rebalance)
+	}
 	go func() {
 		<-dispatcher.StopEverything
 		dispatcher.FIFOPendingPodsQueue.Close()
@@ -171,8 +181,9 @@ func (d *Dispatcher) Run(ctx context.Context) {
 
 	go d.maintainer.Run(d.StopEverything)
 
-	if utilfeature.DefaultFeatureGate.Enabled(features.DispatcherNodeShuffle) {
-		go d.shuffler.Run(d.StopEverything)
+	// in sharding mode, singleton workers are started by the leader replica only.
+	if d.shardManager == nil {
+		d.RunSingletonWorkers(ctx)
 	}
 
 	go wait.UntilWithContext(ctx, d.pendingLoop, 0)
@@ -181,6 +192,13 @@ func (d *Dispatcher) Run(ctx context.Context) {
 	go d.reconciler.Run(d.StopEverything)
 }
 
+// RunSingletonWorkers runs the workers which must not run in multiple dispatcher replicas concurrently.
+func (d *Dispatcher) RunSingletonWorkers(ctx context.Context) {
+	if utilfeature.DefaultFeatureGate.Enabled(features.DispatcherNodeShuffle) {
+		go d.shuffler.Run(ctx.Done())
+	}
+}
+
 // pendingUnitPodsLoop adds pods belonging to dispatchable units to the policy
 // manager or the FIFOPendingPodsQueue.
 func (d *Dispatcher) pendingUnitPodsLoop(ctx context.Context) {
@@ -252,6 +270,11 @@ func (d *Dispatcher) dispatchingPod(ctx context.Context, podInfo *queue.QueuedPo
 		// return directly without re-enqueuing the podInfo
 		return
 	}
+	if !d.ownsPod(pod) {
+		// the shard has been handed over to another dispatcher after the pod was enqueued
+		klog.V(4).InfoS("Skipped dispatching the pod owned by another dispatcher", "pod", klog.KObj(pod))
+		return
+	}
 
 	// get pod labels, which is used in metrics
 	podProperty := podInfo.GetPodProperty()
@@ -467,6 +490,7 @@ func (d *Dispatcher) sendPodToScheduler(pod *v1.Pod, podInfo *queue.QueuedPodInf
 	if _, ok := podCopy.Annotations[podutil.InitialHandledTimestampAnnotationKey]; !ok {
 		podCopy.Annotations[podutil.InitialHandledTimestampAnnotationKey] = podInfo.InitialAddedTimestamp.Format(helper.TimestampLayout)
 	}
+	podCopy.Annotations[podutil.DispatchedTimestampAnnotationKey] = time.Now().Format(helper.TimestampLayout)
 	if podCopy.Annotations[podutil.TraceContext] == "" {
 		tracing.SetSpanContextForPod(podCopy, podInfo.SpanContext)
 	}
@@ -217,7 +217,7 @@ func TestSelectSchedulerAccordingToOwner(t *testing.T) {
 			informerFactory.Start(stopCh)
 			cache.WaitForCacheSync(stopCh, podSharedInformer.HasSynced, schedulerSharedInformer.HasSynced)
 
-			dispatcher := New(stopCh, client, crdClient, podInformer, nodeInformer, schedulerInformer, nmNodeInformer, podGroupInformer, pcInformer, schedulerName, nil)
+			dispatcher := New(stopCh, client, crdClient, podInformer, nodeInformer, schedulerInformer, nmNodeInformer, podGroupInformer, pcInformer, schedulerName, nil, nil)
 
 			for _, p := range tt.pods {
 				dispatcher.addPodToPendingOrSortedQueue(p)
@@ -239,10 +239,10 @@ func AddAllEventHandlers(
 			FilterFunc: func(obj interface{}) bool {
 				switch t := obj.(type) {
 				case *v1.Pod:
-					return podutil.PendingPodOfGodel(t, dispatcher.SchedulerName)
+					return podutil.PendingPodOfGodel(t, dispatcher.SchedulerName) && dispatcher.ownsPod(t)
 				case cache.DeletedFinalStateUnknown:
 					if pod, ok := t.Obj.(*v1.Pod); ok {
-						return podutil.PendingPodOfGodel(pod, dispatcher.SchedulerName)
+						return podutil.PendingPodOfGodel(pod, dispatcher.SchedulerName) && dispatcher.ownsPod(pod)
 					}
 					klog.InfoS("Failed to convert object to *v1.Pod", "object", obj, "component", dispatcher)
 					return false
@@ -600,7 +600,7 @@ func (d *Dispatcher) addPodToAbnormalQueue(obj interface{}) {
 		return
 	}
 
-	if abnormal := podutil.AbnormalPodStateOfGodel(pod, d.SchedulerName); abnormal {
+	if abnormal := podutil.AbnormalPodStateOfGodel(pod, d.SchedulerName); abnormal && d.ownsPod(pod) {
 		podKey, err := cache.MetaNamespaceKeyFunc(pod)
 		if err == nil {
 			d.reconciler.AbnormalPodsEnqueue(podKey)
@@ -616,7 +616,7 @@ func (d *Dispatcher) updatePodInAbnormalQueue(_, newObj interface{}) {
 		klog.InfoS("Failed to add pod to dispatched", "err", err)
 		return
 	}
-	if abnormal := podutil.AbnormalPodStateOfGodel(newPod, d.SchedulerName); abnormal {
+	if abnormal := podutil.AbnormalPodStateOfGodel(newPod, d.SchedulerName); abnormal && d.ownsPod(newPod) {
 		podKey, err := cache.MetaNamespaceKeyFunc(newPod)
 		if err == nil {
 			d.reconciler.AbnormalPodsEnqueue(podKey)
@@ -18,19 +18,26 @@ package queue
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	crdclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
 	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/metrics"
 	"github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
+	"github.com/kubewharf/godel-scheduler/pkg/util/interpretabity"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
 )
 
 type UnitInfos interface {
@@ -45,6 +52,7 @@ type UnitInfos interface {
 	Enqueue(podInfo *QueuedPodInfo)
 	GetAssignedSchedulerForPodGroupUnit(pg *v1alpha1.PodGroup) string
 	AssignSchedulerToPodGroupUnit(pg *v1alpha1.PodGroup, schedName string, forceUpdate bool) error
+	ReleaseUnit(unitKey string)
 	Run(stop <-chan struct{})
 }
 
@@ -55,16 +63,26 @@ type unitInfos struct {
 
 	readyUnitPods *cache.FIFO
 
+	// dependents indexes the keys of the units by the keys of their prerequisite PodGroups.
+	dependents map[string]sets.String
+
 	recorder events.EventRecorder
+	// crdClient is used to expose the blocked reason of the units in PodGroup conditions, it can be nil.
+	crdClient crdclient.Interface
+	// blockedUnitsQueue holds the keys of the units whose blocked reason should be exposed in PodGroup conditions.
+	blockedUnitsQueue workqueue.RateLimitingInterface
 }
 
 var _ UnitInfos = &unitInfos{}
 
-func NewUnitInfos(recorder events.EventRecorder) UnitInfos {
+func NewUnitInfos(recorder events.EventRecorder, crdClient crdclient.Interface) UnitInfos {
 	return &unitInfos{
-		units:         make(map[string]*unitInfo),
-		readyUnitPods: cache.NewFIFO(simpleKeyFunc),
-		recorder:      recorder,
+		units:             make(map[string]*unitInfo),
+		readyUnitPods:     cache.NewFIFO(simpleKeyFunc),
+		dependents:        make(map[string]sets.String),
+		recorder:          recorder,
+		crdClient:         crdClient,
+		blockedUnitsQueue: workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 5*time.Second), "blocked-units-queue"),
 	}
 }
 
@@ -74,6 +92,11 @@ func simpleKeyFunc(obj interface{}) (string, error) {
 
 func (uis *unitInfos) Run(stop <-chan struct{}) {
 	go wait.Until(uis.populate, 30*time.Second, stop)
+	go wait.Until(uis.blockedConditionWorker, time.Second, stop)
+	go func() {
+		<-stop
+		uis.blockedUnitsQueue.ShutDown()
+	}()
 }
 
 func syncPendingMetricsFactory() func(ui *unitInfo) {
@@ -121,7 +144,7 @@ func (uis *unitInfos) populate() {
 	// TODO: remove this func after refactor dispatcher Queue unit
 	syncPendingMetrics := syncPendingMetricsFactory()
 	for _, ui := range uis.units {
-		message, isReady := ui.readyToBeDispatched()
+		message, isReady := uis.readyToBeDispatched(ui)
 		if isReady {
 			uis.movePodsToReadyQueue(ui)
 		} else {
@@ -174,8 +197,10 @@ func (uis *unitInfos) addPodGroup(pg *v1alpha1.PodGroup) {
 		uis.units[unitKey] = ui
 	}
 	ui.podGroup = pg
+	uis.setPrerequisites(unitKey, ui, pg)
+	uis.setRoleMinMemberError(ui, pg)
 
-	message, isReady := uis.units[unitKey].readyToBeDispatched()
+	message, isReady := uis.readyToBeDispatched(uis.units[unitKey])
 	if isReady {
 		uis.movePodsToReadyQueue(uis.units[unitKey])
 	} else {
@@ -185,6 +210,7 @@ func (uis *unitInfos) addPodGroup(pg *v1alpha1.PodGroup) {
 				podGroup, nil, v1.EventTypeNormal, "AddOrUpdatePodGroup", "CheckDispatchReadiness", message)
 		}
 	}
+	uis.activateDependents(pg)
 }
 
 func (uis *unitInfos) UpdatePodGroup(oldPG, newPG *v1alpha1.PodGroup) {
@@ -206,6 +232,7 @@ func (uis *unitInfos) DeletePodGroup(pg *v1alpha1.PodGroup) {
 	}
 
 	ui.podGroup = nil
+	uis.setPrerequisites(unitKey, ui, nil)
 	if len(ui.pods) == 0 {
 		delete(uis.units, unitKey)
 	}
@@ -221,7 +248,7 @@ func (uis *unitInfos) AddPod(unitKey string, podKey string) {
 		}
 		uis.units[unitKey].pods[podKey] = struct{}{}
 
-		message, isReady := uis.units[unitKey].readyToBeDispatched()
+		message, isReady := uis.readyToBeDispatched(uis.units[unitKey])
 		if isReady {
 			uis.movePodsToReadyQueue(uis.units[unitKey])
 		} else {
@@ -245,7 +272,7 @@ func (uis *unitInfos) AddUnSortedPodInfo(unitKey string, podInfo *QueuedPodInfo)
 		uis.units[unitKey].unSortedPods[podInfo.PodKey] = podInfo
 		uis.units[unitKey].pods[podInfo.PodKey] = struct{}{}
 
-		message, isReady := uis.units[unitKey].readyToBeDispatched()
+		message, isReady := uis.readyToBeDispatched(uis.units[unitKey])
 		if isReady {
 			klog.V(5).InfoS("DEBUG: scheduling unit is ready to be dispatched", "unitKey", unitKey)
 			uis.movePodsToReadyQueue(uis.units[unitKey])
@@ -327,6 +354,208 @@ func (uis *unitInfos) AssignSchedulerToPodGroupUnit(pg *v1alpha1.PodGroup, sched
 	return nil
 }
 
+// ReleaseUnit drops the unsorted pods and the cached scheduler of the unit when the unit is
+// handed over to another dispatcher, the pods and podGroup are kept for readiness checking.
+func (uis *unitInfos) ReleaseUnit(unitKey string) {
+	uis.Lock()
+	defer uis.Unlock()
+
+	if ui := uis.units[unitKey]; ui != nil {
+		ui.unSortedPods = make(map[string]*QueuedPodInfo)
+		ui.scheduler = ""
+	}
+}
+
+// readyToBeDispatched checks the readiness of the unit itself and then the dependencies of the unit.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) readyToBeDispatched(ui *unitInfo) (string, bool) {
+	if message, isReady := ui.readyToBeDispatched(); !isReady {
+		return message, false
+	}
+
+	reason, message, ok := unitutil.CheckDependencies(ui.podGroup.Namespace, ui.podGroup.Name, ui.podGroup.Annotations, uis.getPodGroup)
+	if !ok && reason != unitutil.DependencyInvalid {
+		// the units in a cycle wait for each other forever, so the cycle is reported instead of the blocking prerequisite.
+		if cycle := uis.dependencyCycle(generateUnitKeyFromPodGroup(ui.podGroup)); len(cycle) > 0 {
+			reason, message = unitutil.DependencyInvalid, fmt.Sprintf("dependency cycle %s", strings.Join(cycle, " -> "))
+		}
+	}
+	uis.setBlockedReason(ui, reason, message)
+	if !ok {
+		formattedMsg := fmt.Sprintf(MsgPodGroupBlockedByDependency, message)
+		klog.V(5).InfoS(formattedMsg, "podGroup", klog.KObj(ui.podGroup))
+		return formattedMsg, false
+	}
+	return "", true
+}
+
+// dependencyCycle returns the keys of the units in the dependency cycle through the unit, in the order of the
+// dependencies and starting and ending with the unit, nil is returned if there is no cycle.
+// The dependents index is searched breadth first, so the shortest cycle is returned.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) dependencyCycle(unitKey string) []string {
+	// dependencyOf records which unit each visited unit is a prerequisite of on the search path.
+	dependencyOf := map[string]string{}
+	queue := []string{unitKey}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+		for dependent := range uis.dependents[key] {
+			if dependent == unitKey {
+				cycle := []string{unitKey}
+				for cur := key; cur != unitKey; cur = dependencyOf[cur] {
+					cycle = append(cycle, cur)
+				}
+				return append(cycle, unitKey)
+			}
+			if _, visited := dependencyOf[dependent]; !visited {
+				dependencyOf[dependent] = key
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	return nil
+}
+
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) getPodGroup(namespace, name string) *v1alpha1.PodGroup {
+	if ui := uis.units[namespace+"/"+name]; ui != nil {
+		return ui.podGroup
+	}
+	return nil
+}
+
+// setBlockedReason records the blocked reason of the unit in metrics and PodGroup conditions when it changes.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) setBlockedReason(ui *unitInfo, reason, message string) {
+	if message == ui.blockedMessage {
+		return
+	}
+	if len(reason) == 0 {
+		metrics.UnitDependencyWaitDurationObserve(ui.GetUnitProperty(), helper.SinceInSeconds(ui.blockedTimestamp))
+	} else {
+		if len(ui.blockedReason) == 0 {
+			ui.blockedTimestamp = time.Now()
+		}
+		if reason != ui.blockedReason {
+			metrics.UnitDependencyBlockedInc(ui.GetUnitProperty(), reason)
+		}
+	}
+	ui.blockedReason, ui.blockedMessage = reason, message
+	if (len(reason) > 0 || len(ui.roleMinMemberError) > 0) && uis.crdClient != nil {
+		uis.blockedUnitsQueue.Add(generateUnitKeyFromPodGroup(ui.podGroup))
+	}
+}
+
+// setRoleMinMemberError records the error of parsing the role min member of the PodGroup when it changes.
+// The malformed annotation is ignored in scheduling, so it is only exposed in the Pending condition of the
+// PodGroup when the unit is not blocked by its dependencies.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) setRoleMinMemberError(ui *unitInfo, pg *v1alpha1.PodGroup) {
+	var message string
+	if _, err := api.ParseRoleMinMember(pg); err != nil {
+		message = err.Error()
+	}
+	if message == ui.roleMinMemberError {
+		return
+	}
+	ui.roleMinMemberError = message
+	if len(message) > 0 && uis.crdClient != nil {
+		uis.blockedUnitsQueue.Add(generateUnitKeyFromPodGroup(pg))
+	}
+}
+
+// blockedConditionWorker exposes the latest blocked reason of the units in the Pending condition of their PodGroups,
+// or the error of the role min member if they are not blocked.
+// The scheduler owns the PreScheduling condition, so it is left untouched.
+func (uis *unitInfos) blockedConditionWorker() {
+	for uis.processNextBlockedUnit() {
+	}
+}
+
+func (uis *unitInfos) processNextBlockedUnit() bool {
+	obj, quit := uis.blockedUnitsQueue.Get()
+	if quit {
+		return false
+	}
+	defer uis.blockedUnitsQueue.Done(obj)
+
+	unitKey := obj.(string)
+	uis.Lock()
+	ui := uis.units[unitKey]
+	if ui == nil || ui.podGroup == nil || (len(ui.blockedReason) == 0 && len(ui.roleMinMemberError) == 0) {
+		uis.Unlock()
+		uis.blockedUnitsQueue.Forget(obj)
+		return true
+	}
+	pg := ui.podGroup
+	cond := v1alpha1.PodGroupCondition{
+		Phase:              v1alpha1.PodGroupPending,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(ui.blockedTimestamp),
+		Reason:             ui.blockedReason,
+		Message:            ui.blockedMessage,
+	}
+	if len(ui.blockedReason) == 0 {
+		cond.LastTransitionTime = metav1.Now()
+		cond.Reason = ReasonInvalidRoleMinMember
+		cond.Message = fmt.Sprintf(MsgInvalidRoleMinMember, ui.roleMinMemberError)
+	}
+	uis.Unlock()
+
+	if err := interpretabity.UpdatePendingCondition(uis.crdClient, pg, cond); err != nil {
+		if uis.blockedUnitsQueue.NumRequeues(obj) < maxBlockedConditionRetries {
+			klog.V(4).InfoS("Failed to update the blocked reason in PodGroup condition, will retry", "podGroup", klog.KObj(pg), "err", err)
+			uis.blockedUnitsQueue.AddRateLimited(obj)
+			return true
+		}
+		klog.InfoS("Failed to update the blocked reason in PodGroup condition", "podGroup", klog.KObj(pg), "err", err)
+	}
+	uis.blockedUnitsQueue.Forget(obj)
+	return true
+}
+
+// setPrerequisites refreshes the dependents index according to the dependencies declared by the PodGroup,
+// nil PodGroup means the unit no longer depends on anything.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) setPrerequisites(unitKey string, ui *unitInfo, pg *v1alpha1.PodGroup) {
+	var prerequisites []string
+	if pg != nil {
+		dependencies, _ := unitutil.ParseDependencies(pg.Name, pg.Annotations)
+		for _, dependency := range dependencies {
+			prerequisites = append(prerequisites, pg.Namespace+"/"+dependency.PodGroup)
+		}
+	}
+	for _, prerequisite := range ui.prerequisites {
+		if dependents := uis.dependents[prerequisite]; dependents != nil {
+			if dependents.Delete(unitKey); dependents.Len() == 0 {
+				delete(uis.dependents, prerequisite)
+			}
+		}
+	}
+	for _, prerequisite := range prerequisites {
+		if uis.dependents[prerequisite] == nil {
+			uis.dependents[prerequisite] = sets.NewString()
+		}
+		uis.dependents[prerequisite].Insert(unitKey)
+	}
+	ui.prerequisites = prerequisites
+}
+
+// activateDependents moves the pods of the units depending on the PodGroup to ready queue once they are ready.
+// this is a private function, we assume the lock is acquired
+func (uis *unitInfos) activateDependents(pg *v1alpha1.PodGroup) {
+	for unitKey := range uis.dependents[generateUnitKeyFromPodGroup(pg)] {
+		ui := uis.units[unitKey]
+		if ui == nil || ui.podGroup == nil || len(ui.unSortedPods) == 0 {
+			continue
+		}
+		if _, isReady := uis.readyToBeDispatched(ui); isReady {
+			uis.movePodsToReadyQueue(ui)
+		}
+	}
+}
+
 type unitInfo struct {
 	podGroup     *v1alpha1.PodGroup
 	pods         map[string]struct{}
@@ -336,6 +565,16 @@ type unitInfo struct {
 
 	// begin time wait for unit be ready
 	waitingTimestamp time.Time
+
+	// the reason and message why the unit is blocked by its dependencies, empty if it is not blocked
+	blockedReason  string
+	blockedMessage string
+	// begin time the unit is blocked by its dependencies
+	blockedTimestamp time.Time
+	// the keys of the PodGroups the unit depends on
+	prerequisites []string
+	// the error of parsing the role min member of the PodGroup, empty if it is valid or not set
+	roleMinMemberError string
 }
 
 var _ api.ObservableUnit = &unitInfo{}
@@ -348,11 +587,22 @@ func NewUnitInfo() *unitInfo {
 	}
 }
 
+// maxBlockedConditionRetries is the number of times the blocked condition of a unit will be retried before it is dropped.
+const maxBlockedConditionRetries = 5
+
+const (
+	// ReasonInvalidRoleMinMember is the reason of the Pending condition when the role min member of the PodGroup can not be parsed.
+	ReasonInvalidRoleMinMember = "InvalidRoleMinMember"
+	// MsgInvalidRoleMinMember is the message of the Pending condition when the role min member of the PodGroup can not be parsed.
+	MsgInvalidRoleMinMember = "the role min member is ignored and the min member of the PodGroup is used: %s"
+)
+
 const (
 	MsgNilPodGroup                     string = "DEBUG: pod group is nil"
 	MsgPodGroupBeingDeleted            string = "DEBUG: pod group is being deleted"
 	MsgPodGroupInPendingOrUnknownPhase string = "DEBUG: pod group is in either pending or unknown phase"
 	MsgPodGroupLessThanMinMember       string = "DEBUG: pod group has not yet met the MinMember requirement with numReadyToBeDispatched=%d and minMember=%d"
+	MsgPodGroupBlockedByDependency     string = "DEBUG: pod group is blocked by its dependencies: %s"
 )
 
 func (ui *unitInfo) readyToBeDispatched() (string, bool) {
@@ -17,13 +17,18 @@ limitations under the License.
 package queue
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	godelclientfake "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned/fake"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/events"
+
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
 )
 
 var podGroupKey = "default/pg"
@@ -179,7 +184,7 @@ func TestUnitInfos(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			infos := NewUnitInfos(events.NewFakeRecorder(1000))
+			infos := NewUnitInfos(events.NewFakeRecorder(1000), nil)
 
 			for _, singleOp := range tt.ops {
 				switch singleOp.op {
@@ -232,3 +237,183 @@ func TestUnitInfos(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitInfosDependencies(t *testing.T) {
+	infos := NewUnitInfos(events.NewFakeRecorder(1000), nil)
+	readyPods := func() []string {
+		got := []string{}
+		for len(infos.(*unitInfos).readyUnitPods.List()) > 0 {
+			info, _ := infos.Pop()
+			got = append(got, parsePodKey(info.PodKey))
+		}
+		return got
+	}
+
+	dependent := makePodGroup()
+	dependent.Annotations = map[string]string{unitutil.DependsOnAnnotationKey: "ps:Scheduled"}
+	infos.AddPodGroup(dependent)
+	for _, key := range []string{"p1", "p2", "p3"} {
+		infos.AddUnSortedPodInfo(podGroupKey, makeQueuedPodInfo(key))
+	}
+	if got := readyPods(); len(got) != 0 {
+		t.Fatalf("expected pods held by the missing prerequisite, got %v", got)
+	}
+	if reason := infos.(*unitInfos).units[podGroupKey].blockedReason; reason != unitutil.DependencyNotFound {
+		t.Errorf("expected blocked reason %v, got %v", unitutil.DependencyNotFound, reason)
+	}
+
+	prerequisite := &v1alpha1.PodGroup{
+		ObjectMeta: v1.ObjectMeta{Namespace: "default", Name: "ps"},
+		Spec:       v1alpha1.PodGroupSpec{MinMember: 1},
+		Status:     v1alpha1.PodGroupStatus{Phase: v1alpha1.PodGroupPreScheduling},
+	}
+	infos.AddPodGroup(prerequisite)
+	infos.(*unitInfos).populate()
+	if got := readyPods(); len(got) != 0 {
+		t.Fatalf("expected pods held by the prerequisite phase, got %v", got)
+	}
+	if reason := infos.(*unitInfos).units[podGroupKey].blockedReason; reason != unitutil.DependencyPhaseNotReached {
+		t.Errorf("expected blocked reason %v, got %v", unitutil.DependencyPhaseNotReached, reason)
+	}
+
+	scheduled := prerequisite.DeepCopy()
+	scheduled.Status.Phase = v1alpha1.PodGroupScheduled
+	infos.UpdatePodGroup(prerequisite, scheduled)
+	got, want := sets.NewString(readyPods()...).List(), []string{"p1", "p2", "p3"}
+	if diff := cmp.Diff(want, got); len(diff) > 0 {
+		t.Errorf("Unexpected got diff: %v", diff)
+	}
+	if reason := infos.(*unitInfos).units[podGroupKey].blockedReason; reason != "" {
+		t.Errorf("expected no blocked reason, got %v", reason)
+	}
+}
+
+func TestUnitInfosDependencyCycle(t *testing.T) {
+	infos := NewUnitInfos(events.NewFakeRecorder(1000), nil).(*unitInfos)
+	makeDependentPodGroup := func(name, dependsOn string) *v1alpha1.PodGroup {
+		pg := makePodGroup()
+		pg.Name = name
+		pg.Annotations = map[string]string{unitutil.DependsOnAnnotationKey: dependsOn}
+		return pg
+	}
+
+	// pg -> a -> b -> pg
+	infos.AddPodGroup(makeDependentPodGroup("pg", "a"))
+	infos.AddPodGroup(makeDependentPodGroup("a", "b"))
+	for _, key := range []string{"p1", "p2", "p3"} {
+		infos.AddUnSortedPodInfo(podGroupKey, makeQueuedPodInfo(key))
+	}
+	if reason := infos.units[podGroupKey].blockedReason; reason != unitutil.DependencyPhaseNotReached {
+		t.Errorf("expected blocked reason %v before the cycle is closed, got %v", unitutil.DependencyPhaseNotReached, reason)
+	}
+
+	infos.AddPodGroup(makeDependentPodGroup("b", "pg"))
+	infos.populate()
+	ui := infos.units[podGroupKey]
+	if ui.blockedReason != unitutil.DependencyInvalid {
+		t.Errorf("expected blocked reason %v, got %v", unitutil.DependencyInvalid, ui.blockedReason)
+	}
+	if want := "dependency cycle default/pg -> default/a -> default/b -> default/pg"; ui.blockedMessage != want {
+		t.Errorf("expected blocked message %q, got %q", want, ui.blockedMessage)
+	}
+
+	// the cycle is broken once b no longer depends on pg.
+	infos.UpdatePodGroup(makeDependentPodGroup("b", "pg"), makeDependentPodGroup("b", "c"))
+	infos.populate()
+	if reason := infos.units[podGroupKey].blockedReason; reason != unitutil.DependencyPhaseNotReached {
+		t.Errorf("expected blocked reason %v after the cycle is broken, got %v", unitutil.DependencyPhaseNotReached, reason)
+	}
+}
+
+func TestUnitInfosBlockedCondition(t *testing.T) {
+	dependent := makePodGroup()
+	dependent.Annotations = map[string]string{unitutil.DependsOnAnnotationKey: "ps"}
+	crdClient := godelclientfake.NewSimpleClientset(dependent)
+	infos := NewUnitInfos(events.NewFakeRecorder(1000), crdClient).(*unitInfos)
+
+	infos.AddPodGroup(dependent)
+	for _, key := range []string{"p1", "p2", "p3"} {
+		infos.AddUnSortedPodInfo(podGroupKey, makeQueuedPodInfo(key))
+	}
+	if got := infos.dependents["default/ps"]; !got.Has(podGroupKey) {
+		t.Fatalf("expected the unit to be indexed by its prerequisite, got %v", got)
+	}
+	// The same blocked reason will only be queued once.
+	if infos.blockedUnitsQueue.Len() != 1 {
+		t.Fatalf("expected one blocked unit in queue, got %d", infos.blockedUnitsQueue.Len())
+	}
+	infos.processNextBlockedUnit()
+
+	pg, err := crdClient.SchedulingV1alpha1().PodGroups("default").Get(context.TODO(), "pg", v1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pg.Status.Conditions) != 1 || pg.Status.Conditions[0].Phase != v1alpha1.PodGroupPending ||
+		pg.Status.Conditions[0].Reason != unitutil.DependencyNotFound {
+		t.Errorf("expected Pending condition with reason %v, got %v", unitutil.DependencyNotFound, pg.Status.Conditions)
+	}
+
+	infos.DeletePodGroup(dependent)
+	if len(infos.dependents) != 0 {
+		t.Errorf("expected dependents index to be cleaned up, got %v", infos.dependents)
+	}
+}
+
+func TestUnitInfosRoleMinMemberCondition(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		annotations map[string]string
+		wantReason  string
+	}{
+		{
+			name:        "invalid role min member",
+			annotations: map[string]string{podutil.RoleMinMemberAnnotationKey: `{"ps":4}`},
+			wantReason:  ReasonInvalidRoleMinMember,
+		},
+		{
+			name: "blocked by dependencies",
+			annotations: map[string]string{
+				podutil.RoleMinMemberAnnotationKey: `{"ps":4}`,
+				unitutil.DependsOnAnnotationKey:    "ps",
+			},
+			wantReason: unitutil.DependencyNotFound,
+		},
+		{
+			name:        "valid role min member",
+			annotations: map[string]string{podutil.RoleMinMemberAnnotationKey: `{"ps":1}`},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := makePodGroup()
+			pg.Annotations = tt.annotations
+			crdClient := godelclientfake.NewSimpleClientset(pg)
+			infos := NewUnitInfos(events.NewFakeRecorder(1000), crdClient).(*unitInfos)
+
+			infos.AddPodGroup(pg)
+			for _, key := range []string{"p1", "p2", "p3"} {
+				infos.AddUnSortedPodInfo(podGroupKey, makeQueuedPodInfo(key))
+			}
+			infos.UpdatePodGroup(pg, pg.DeepCopy())
+			wantQueued := 0
+			if len(tt.wantReason) > 0 {
+				wantQueued = 1
+			}
+			if infos.blockedUnitsQueue.Len() != wantQueued {
+				t.Fatalf("expected %d unit in queue, got %d", wantQueued, infos.blockedUnitsQueue.Len())
+			}
+			if wantQueued == 0 {
+				return
+			}
+			infos.processNextBlockedUnit()
+
+			got, err := crdClient.SchedulingV1alpha1().PodGroups("default").Get(context.TODO(), "pg", v1.GetOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got.Status.Conditions) != 1 || got.Status.Conditions[0].Phase != v1alpha1.PodGroupPending ||
+				got.Status.Conditions[0].Reason != tt.wantReason {
+				t.Errorf("expected Pending condition with reason %v, got %v", tt.wantReason, got.Status.Conditions)
+			}
+		})
+	}
+}
@@ -83,6 +83,22 @@ var (
 			Help:           "Number of running goroutines split by the work they do such as updating pod status.",
 			StabilityLevel: metrics.ALPHA,
 		})
+
+	shardMembers = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      DispatcherSubsystem,
+			Name:           "shard_members",
+			Help:           "Number of alive dispatcher replicas observed in sharding mode.",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	shardRebalanceCount = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      DispatcherSubsystem,
+			Name:           "shard_rebalance_count",
+			Help:           "Number of shard rebalancing caused by membership changes and the releases of the previous owners.",
+			StabilityLevel: metrics.ALPHA,
+		})
 )
 
 var (
@@ -204,6 +220,14 @@ func DispatcherGoroutinesDec() {
 	dispatcherGoroutines.Dec()
 }
 
+func ShardMembersSet(value float64) {
+	shardMembers.Set(value)
+}
+
+func ShardRebalanceInc() {
+	shardRebalanceCount.Inc()
+}
+
 func PodShufflingCountInc() {
 	podShufflingCount.Inc()
 }
@@ -49,10 +49,14 @@ var metricsList = []metrics.Registerable{
 	dispatchingAttempts,
 	podUpdatingAttempts,
 	podShufflingCount,
+	shardMembers,
+	shardRebalanceCount,
 	queueSortingLatency,
 
 	pendingUnits,
 	unitPendingDuration,
+	unitDependencyBlocked,
+	unitDependencyWaitDuration,
 }
 
 var registerMetrics sync.Once
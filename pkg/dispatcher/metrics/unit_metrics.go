@@ -40,6 +40,23 @@ var (
 			Buckets:        metrics.ExponentialBuckets(0.001, 2, 20),
 			StabilityLevel: metrics.ALPHA,
 		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.QueueLabel})
+
+	unitDependencyBlocked = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      DispatcherSubsystem,
+			Name:           "unit_dependency_blocked_total",
+			Help:           "Number of times units are blocked by their dependencies, by reason",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.ReasonLabel})
+
+	unitDependencyWaitDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      DispatcherSubsystem,
+			Name:           "unit_dependency_wait_duration_seconds",
+			Help:           "Duration for unit blocked by its dependencies",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 24),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel})
 )
 
 func newPendingUnitsGaugeMetric(labels metrics.Labels) metrics.GaugeMetric {
@@ -61,3 +78,13 @@ func UnitPendingDurationObserve(unitProperty api.UnitProperty, queue string, dur
 	unitLabels[pkgmetrics.QueueLabel] = queue
 	newUnitPendingDurationObserverMetric(unitLabels).Observe(duration)
 }
+
+func UnitDependencyBlockedInc(unitProperty api.UnitProperty, reason string) {
+	unitLabels := api.MustConvertToMetricsLabels(unitProperty)
+	unitLabels[pkgmetrics.ReasonLabel] = reason
+	unitDependencyBlocked.With(unitLabels).Inc()
+}
+
+func UnitDependencyWaitDurationObserve(unitProperty api.UnitProperty, duration float64) {
+	unitDependencyWaitDuration.With(api.MustConvertToMetricsLabels(unitProperty)).Observe(duration)
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/config"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/metrics"
+)
+
+const (
+	// ShardLabelKey is the label of membership leases, whose value is the scheduler name
+	// that the dispatcher replicas serve.
+	ShardLabelKey = "godel.bytedance.com/dispatcher-shard"
+	// ShardMembersAnnotationKey is the annotation of membership leases, whose value is the comma
+	// separated members of the ring the replica has applied. It acknowledges that the replica has
+	// released the keys that ring no longer assigns to it.
+	ShardMembersAnnotationKey = "godel.bytedance.com/dispatcher-shard-members"
+
+	leaseNamePrefix = "dispatcher-"
+)
+
+// MembershipChangeHandler is called after the assignment of this replica changes, with the
+// assignments before and after the change.
+type MembershipChangeHandler func(oldAssignment, newAssignment *Assignment)
+
+// Assignment is an immutable view of the shard keys owned by a replica. A replica owns at most
+// the keys assigned to it by the ring acknowledged in its lease, so a key assigned to the replica
+// by the ring is owned only after the replica has acknowledged it, and the other alive replicas
+// have released it, i.e. the rings acknowledged in their leases don't assign it to them.
+// This keeps a key from being handled by two replicas at the same time. Replicas whose leases
+// expired are not alive and hold nothing.
+type Assignment struct {
+	identity string
+	ring     *Ring
+	// claims are the rings acknowledged by the alive replicas, including this one.
+	claims map[string]*Ring
+}
+
+// NewAssignment creates the assignment of the replica with the given identity.
+func NewAssignment(identity string, ring *Ring, claims map[string]*Ring) *Assignment {
+	return &Assignment{identity: identity, ring: ring, claims: claims}
+}
+
+// Owns returns true if the key is assigned to and acknowledged by the replica, and released by the others.
+func (a *Assignment) Owns(key string) bool {
+	if a == nil || a.ring.Owner(key) != a.identity || a.claims[a.identity].Owner(key) != a.identity {
+		return false
+	}
+	for member, claim := range a.claims {
+		if member != a.identity && claim.Owner(key) == member {
+			return false
+		}
+	}
+	return true
+}
+
+// Members returns the sorted members of the ring.
+func (a *Assignment) Members() []string {
+	if a == nil {
+		return nil
+	}
+	return a.ring.Members()
+}
+
+// Equal returns true if two assignments have the same ring and claims.
+func (a *Assignment) Equal(other *Assignment) bool {
+	if a == nil || other == nil {
+		return a == other
+	}
+	if a.identity != other.identity || !a.ring.Equal(other.ring) || len(a.claims) != len(other.claims) {
+		return false
+	}
+	for member, claim := range a.claims {
+		if otherClaim, ok := other.claims[member]; !ok || !claim.Equal(otherClaim) {
+			return false
+		}
+	}
+	return true
+}
+
+type observedLease struct {
+	renewTime  metav1.MicroTime
+	observedAt time.Time
+}
+
+// Manager maintains the membership of dispatcher replicas through leases, and decides which
+// replica owns a shard key based on the consistent hash ring built from the alive members.
+// Keys moved to this replica are taken over only after their previous owners have released them.
+type Manager struct {
+	client        kubernetes.Interface
+	identity      string
+	schedulerName string
+	namespace     string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	virtualNodes  int
+	now           func() time.Time
+
+	// lastRenew is the last time the lease of this replica was renewed successfully.
+	lastRenew time.Time
+	// acknowledged is the ring acknowledged in the lease of this replica by the last successful renewal.
+	acknowledged *Ring
+	// observed records the leases of other replicas, a lease is alive if its renewTime changed
+	// within the lease duration, which is measured by local clock to tolerate clock skew.
+	observed map[string]observedLease
+
+	mu         sync.RWMutex
+	assignment *Assignment
+	handlers   []MembershipChangeHandler
+}
+
+// NewManager creates a shard manager for the replica with the given identity.
+func NewManager(client kubernetes.Interface, schedulerName, identity string, cfg *config.ShardingConfiguration) *Manager {
+	return &Manager{
+		client:        client,
+		identity:      identity,
+		schedulerName: schedulerName,
+		namespace:     cfg.LeaseNamespace,
+		leaseDuration: time.Duration(cfg.LeaseDurationSeconds) * time.Second,
+		renewInterval: time.Duration(cfg.RenewIntervalSeconds) * time.Second,
+		virtualNodes:  cfg.VirtualNodes,
+		now:           time.Now,
+		observed:      make(map[string]observedLease),
+	}
+}
+
+// Identity returns the identity of this replica.
+func (m *Manager) Identity() string {
+	return m.identity
+}
+
+// AddMembershipChangeHandler registers a handler, it must be called before Run.
+func (m *Manager) AddMembershipChangeHandler(handler MembershipChangeHandler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers = append(m.handlers, handler)
+}
+
+// Owns returns true if this replica owns the shard key. Nothing is owned before the first
+// membership sync or when the lease of this replica can not be renewed.
+func (m *Manager) Owns(key string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.assignment.Owns(key)
+}
+
+// Ring returns the current ring.
+func (m *Manager) Ring() *Ring {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.assignment == nil {
+		return nil
+	}
+	return m.assignment.ring
+}
+
+// Run renews the lease and refreshes the membership periodically until stopCh is closed,
+// the lease will be deleted then so that other replicas can take over the shards at once.
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	wait.Until(m.sync, m.renewInterval, stopCh)
+
+	err := m.client.CoordinationV1().Leases(m.namespace).Delete(context.TODO(), m.leaseName(), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		klog.InfoS("Failed to delete the shard lease", "lease", m.leaseName(), "err", err)
+	}
+}
+
+func (m *Manager) leaseName() string {
+	return leaseNamePrefix + m.identity
+}
+
+// sync renews the lease and refreshes the assignment. A ring is acknowledged in the lease only after
+// the handlers have run for it, and the keys it moves to this replica are taken over only after it is
+// acknowledged, so the sync is repeated once if the ring has changed.
+func (m *Manager) sync() {
+	m.syncOnce()
+	if !m.Ring().Equal(m.acknowledged) {
+		m.syncOnce()
+	}
+}
+
+func (m *Manager) syncOnce() {
+	ring := m.Ring()
+	if err := m.renew(ring); err != nil {
+		klog.InfoS("Failed to renew the shard lease", "lease", m.leaseName(), "err", err)
+	} else {
+		m.lastRenew = m.now()
+		m.acknowledged = ring
+	}
+
+	members, claims, err := m.aliveMembers()
+	if err != nil {
+		klog.InfoS("Failed to list the shard leases", "err", err)
+		return
+	}
+	m.updateAssignment(NewAssignment(m.identity, NewRing(members, m.virtualNodes), claims))
+}
+
+func (m *Manager) renew(ring *Ring) error {
+	leases := m.client.CoordinationV1().Leases(m.namespace)
+	now := metav1.NewMicroTime(m.now())
+	durationSeconds := int32(m.leaseDuration / time.Second)
+	acknowledged := strings.Join(ring.Members(), ",")
+
+	lease, err := leases.Get(context.TODO(), m.leaseName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   m.namespace,
+				Name:        m.leaseName(),
+				Labels:      map[string]string{ShardLabelKey: m.schedulerName},
+				Annotations: map[string]string{ShardMembersAnnotationKey: acknowledged},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &m.identity,
+				LeaseDurationSeconds: &durationSeconds,
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(context.TODO(), lease, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	lease = lease.DeepCopy()
+	if lease.Annotations == nil {
+		lease.Annotations = make(map[string]string)
+	}
+	lease.Annotations[ShardMembersAnnotationKey] = acknowledged
+	lease.Spec.HolderIdentity = &m.identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.RenewTime = &now
+	_, err = leases.Update(context.TODO(), lease, metav1.UpdateOptions{})
+	return err
+}
+
+// aliveMembers returns the alive members and the rings acknowledged by them.
+func (m *Manager) aliveMembers() ([]string, map[string]*Ring, error) {
+	selector := labels.SelectorFromSet(labels.Set{ShardLabelKey: m.schedulerName})
+	leaseList, err := m.client.CoordinationV1().Leases(m.namespace).List(context.TODO(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := m.now()
+	var members []string
+	claims := make(map[string]*Ring)
+	if now.Sub(m.lastRenew) < m.leaseDuration {
+		members = append(members, m.identity)
+		claims[m.identity] = m.acknowledged
+	}
+
+	observed := make(map[string]observedLease, len(leaseList.Items))
+	for i := range leaseList.Items {
+		lease := &leaseList.Items[i]
+		if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == m.identity || lease.Spec.RenewTime == nil {
+			continue
+		}
+		identity := *lease.Spec.HolderIdentity
+		o, ok := m.observed[identity]
+		if !ok || !o.renewTime.Equal(lease.Spec.RenewTime) {
+			o = observedLease{renewTime: *lease.Spec.RenewTime, observedAt: now}
+		}
+		observed[identity] = o
+
+		duration := m.leaseDuration
+		if lease.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+		}
+		if now.Sub(o.observedAt) < duration {
+			members = append(members, identity)
+			claims[identity] = NewRing(parseMembers(lease.Annotations[ShardMembersAnnotationKey]), m.virtualNodes)
+		}
+	}
+	m.observed = observed
+	return members, claims, nil
+}
+
+func parseMembers(value string) []string {
+	if len(value) == 0 {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func (m *Manager) updateAssignment(newAssignment *Assignment) {
+	m.mu.Lock()
+	oldAssignment := m.assignment
+	if oldAssignment.Equal(newAssignment) {
+		m.mu.Unlock()
+		return
+	}
+	m.assignment = newAssignment
+	handlers := m.handlers
+	m.mu.Unlock()
+
+	klog.InfoS("Shard assignment changed", "identity", m.identity, "oldMembers", oldAssignment.Members(), "newMembers", newAssignment.Members())
+	metrics.ShardMembersSet(float64(len(newAssignment.Members())))
+	metrics.ShardRebalanceInc()
+	for _, handler := range handlers {
+		handler(oldAssignment, newAssignment)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/config"
+)
+
+func newTestManager(client *fake.Clientset, identity string, now *time.Time) *Manager {
+	m := NewManager(client, "godel-scheduler", identity, &config.ShardingConfiguration{
+		LeaseNamespace:       "godel-system",
+		LeaseDurationSeconds: 15,
+		RenewIntervalSeconds: 5,
+		VirtualNodes:         32,
+	})
+	m.now = func() time.Time { return *now }
+	return m
+}
+
+func TestManagerMembership(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	m1 := newTestManager(client, "d1", &now)
+	m2 := newTestManager(client, "d2", &now)
+
+	var changes [][]string
+	m1.AddMembershipChangeHandler(func(oldAssignment, newAssignment *Assignment) {
+		if !reflect.DeepEqual(oldAssignment.Members(), newAssignment.Members()) {
+			changes = append(changes, newAssignment.Members())
+		}
+	})
+
+	if m1.Owns("default") {
+		t.Errorf("expected nothing owned before the first sync")
+	}
+
+	m1.sync()
+	m2.sync()
+	m1.sync()
+	m2.sync()
+	if expected := [][]string{{"d1"}, {"d1", "d2"}}; !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected membership changes %v, but got %v", expected, changes)
+	}
+
+	// every key is owned by exactly one replica.
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("ns-%d", i)
+		if m1.Owns(key) == m2.Owns(key) {
+			t.Errorf("expected key %s to be owned by exactly one replica", key)
+		}
+	}
+
+	// the lease of d2 is not renewed within the lease duration.
+	now = now.Add(10 * time.Second)
+	m1.sync()
+	if len(changes) != 2 {
+		t.Errorf("expected no membership change, but got %v", changes)
+	}
+	now = now.Add(10 * time.Second)
+	m1.sync()
+	if expected := []string{"d1"}; !reflect.DeepEqual(changes[len(changes)-1], expected) {
+		t.Errorf("expected members %v, but got %v", expected, changes[len(changes)-1])
+	}
+	for i := 0; i < 100; i++ {
+		if key := fmt.Sprintf("ns-%d", i); !m1.Owns(key) {
+			t.Errorf("expected key %s to be taken over by d1", key)
+		}
+	}
+}
+
+func TestManagerTakeOverAfterRelease(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	m1 := newTestManager(client, "d1", &now)
+	m2 := newTestManager(client, "d2", &now)
+
+	var keys []string
+	for i := 0; i < 100; i++ {
+		keys = append(keys, fmt.Sprintf("ns-%d", i))
+	}
+	countOwned := func(m *Manager) int {
+		var count int
+		for _, key := range keys {
+			if m.Owns(key) {
+				count++
+			}
+		}
+		return count
+	}
+	expectNoOverlap := func(step string) {
+		for _, key := range keys {
+			if m1.Owns(key) && m2.Owns(key) {
+				t.Errorf("%s: expected key %s to be owned by at most one replica", step, key)
+			}
+		}
+	}
+
+	m1.sync()
+	if count := countOwned(m1); count != len(keys) {
+		t.Errorf("expected all keys owned by d1, but got %d", count)
+	}
+
+	// d2 joins, but d1 has not released the keys moved to d2 yet.
+	m2.sync()
+	expectNoOverlap("d2 joined")
+	if count := countOwned(m2); count != 0 {
+		t.Errorf("expected no key taken over before d1 released them, but got %d", count)
+	}
+
+	// d1 releases the keys moved to d2 and acknowledges the new ring.
+	m1.sync()
+	expectNoOverlap("d1 released")
+	if count := countOwned(m1); count == 0 || count == len(keys) {
+		t.Errorf("expected keys split across replicas, but d1 owns %d", count)
+	}
+
+	// d2 takes over the released keys.
+	m2.sync()
+	expectNoOverlap("d2 took over")
+	for _, key := range keys {
+		if !m1.Owns(key) && !m2.Owns(key) {
+			t.Errorf("expected key %s to be owned by one replica", key)
+		}
+	}
+
+	// d1 stops renewing, d2 takes over its keys only after the lease of d1 expires.
+	now = now.Add(10 * time.Second)
+	m2.sync()
+	if count := countOwned(m2); count == len(keys) {
+		t.Errorf("expected keys of d1 not taken over before its lease expired")
+	}
+	now = now.Add(10 * time.Second)
+	m2.sync()
+	if count := countOwned(m2); count != len(keys) {
+		t.Errorf("expected all keys taken over by d2 after the lease of d1 expired, but got %d", count)
+	}
+}
+
+func TestManagerDeleteLeaseOnStop(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	m := newTestManager(client, "d1", &now)
+
+	stopCh := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		m.Run(stopCh)
+		close(done)
+	}()
+
+	if err := wait.PollImmediate(10*time.Millisecond, time.Second, func() (bool, error) { return m.Owns("default"), nil }); err != nil {
+		t.Fatal(err)
+	}
+	lease, err := client.CoordinationV1().Leases("godel-system").Get(context.TODO(), "dispatcher-d1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected lease created, but got error: %v", err)
+	}
+	if lease.Labels[ShardLabelKey] != "godel-scheduler" {
+		t.Errorf("unexpected lease labels: %v", lease.Labels)
+	}
+
+	close(stopCh)
+	<-done
+	leases, _ := client.CoordinationV1().Leases("godel-system").List(context.TODO(), metav1.ListOptions{})
+	if len(leases.Items) != 0 {
+		t.Errorf("expected lease deleted on stop, but got %d leases", len(leases.Items))
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRingOwner(t *testing.T) {
+	if owner := NewRing(nil, 10).Owner("ns"); owner != "" {
+		t.Errorf("expected no owner in empty ring, but got %s", owner)
+	}
+
+	members := []string{"d1", "d2", "d3"}
+	ring := NewRing(members, 128)
+	// the ring is independent of the order of members.
+	reversed := NewRing([]string{"d3", "d2", "d1"}, 128)
+
+	counts := make(map[string]int)
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("ns-%d/pg-%d", i%50, i)
+		owner := ring.Owner(key)
+		if owner != reversed.Owner(key) {
+			t.Fatalf("expected the same owner for key %s", key)
+		}
+		counts[owner]++
+	}
+	for _, member := range members {
+		// each member is expected to own about 1000 keys.
+		if counts[member] < 600 || counts[member] > 1400 {
+			t.Errorf("unbalanced ring, member %s owns %d keys: %v", member, counts[member], counts)
+		}
+	}
+}
+
+func TestRingMinimalMovement(t *testing.T) {
+	oldRing := NewRing([]string{"d1", "d2", "d3"}, 128)
+	newRing := NewRing([]string{"d1", "d2", "d3", "d4"}, 128)
+
+	for i := 0; i < 3000; i++ {
+		key := fmt.Sprintf("ns-%d", i)
+		oldOwner, newOwner := oldRing.Owner(key), newRing.Owner(key)
+		// keys are only moved to the new member.
+		if oldOwner != newOwner && newOwner != "d4" {
+			t.Errorf("key %s moved from %s to %s", key, oldOwner, newOwner)
+		}
+	}
+
+	if !oldRing.Equal(NewRing([]string{"d3", "d1", "d2"}, 16)) {
+		t.Errorf("expected rings with the same members to be equal")
+	}
+	if oldRing.Equal(newRing) {
+		t.Errorf("expected rings with different members to be unequal")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/internal/queue"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/shard"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// shardKeyOfPod returns the key used to decide the owner of the pod in sharding mode.
+// Pods belonging to the same unit share the unit key, so that a unit is never split across dispatchers.
+func shardKeyOfPod(pod *v1.Pod) string {
+	if unitKey := generateUnitKeyFromPod(pod); len(unitKey) > 0 {
+		return unitKey
+	}
+	return pod.Namespace
+}
+
+// ownsPod returns true if the pod should be handled by this dispatcher.
+func (d *Dispatcher) ownsPod(pod *v1.Pod) bool {
+	if d.shardManager == nil {
+		return true
+	}
+	return d.shardManager.Owns(shardKeyOfPod(pod))
+}
+
+// rebalance moves pending pods in or out of the queues when the shard assignment changes.
+// Pods of the shards taken over are added to queues, and pods of the shards handed over are
+// removed from queues together with the cached state of their units.
+func (d *Dispatcher) rebalance(oldAssignment, newAssignment *shard.Assignment) {
+	identity := d.shardManager.Identity()
+	pods, err := d.podLister.List(labels.Everything())
+	if err != nil {
+		klog.InfoS("Failed to list pods for shard rebalancing", "err", err)
+		return
+	}
+
+	var added, removed int
+	releasedUnits := make(map[string]struct{})
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !podutil.PendingPodOfGodel(pod, d.SchedulerName) {
+			continue
+		}
+		key := shardKeyOfPod(pod)
+		owned, wasOwned := newAssignment.Owns(key), oldAssignment.Owns(key)
+		switch {
+		case owned && !wasOwned:
+			podInfo, err := queue.NewQueuedPodInfo(pod)
+			if err != nil || d.SortedPodsQueue.PodInfoExist(podInfo) {
+				continue
+			}
+			d.addPodToPendingOrSortedQueue(pod)
+			added++
+		case !owned && wasOwned:
+			d.deletePodFromPendingOrSortedQueue(pod)
+			if unitKey := generateUnitKeyFromPod(pod); len(unitKey) > 0 {
+				releasedUnits[unitKey] = struct{}{}
+			}
+			removed++
+		}
+	}
+	for unitKey := range releasedUnits {
+		d.UnitInfos.ReleaseUnit(unitKey)
+	}
+	klog.InfoS("Rebalanced the pending pods", "identity", identity, "members", newAssignment.Members(), "added", added, "removed", removed, "releasedUnits", len(releasedUnits))
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatcher
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/config"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/internal/queue"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/metrics"
+	"github.com/kubewharf/godel-scheduler/pkg/dispatcher/shard"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+type fakePendingQueue struct {
+	pods map[string]*queue.QueuedPodInfo
+}
+
+func (q *fakePendingQueue) AddPodInfo(podInfo *queue.QueuedPodInfo) error {
+	q.pods[podInfo.PodKey] = podInfo
+	return nil
+}
+
+func (q *fakePendingQueue) UpdatePodInfo(podInfo *queue.QueuedPodInfo) error {
+	return q.AddPodInfo(podInfo)
+}
+
+func (q *fakePendingQueue) RemovePodInfo(podInfo *queue.QueuedPodInfo) error {
+	delete(q.pods, podInfo.PodKey)
+	return nil
+}
+
+func (q *fakePendingQueue) Pop() ([]*queue.QueuedPodInfo, error) {
+	return nil, nil
+}
+
+func (q *fakePendingQueue) Close() {}
+
+func TestShardKeyOfPod(t *testing.T) {
+	pod := testing_helper.MakePod().Namespace("ns").Name("p").Obj()
+	if key := shardKeyOfPod(pod); key != "ns" {
+		t.Errorf("expected shard key ns, but got %s", key)
+	}
+	unitPod := testing_helper.MakePod().Namespace("ns").Name("p").Annotation(podutil.PodGroupNameAnnotationKey, "pg").Obj()
+	if key := shardKeyOfPod(unitPod); key != "ns/pg" {
+		t.Errorf("expected shard key ns/pg, but got %s", key)
+	}
+}
+
+func TestRebalance(t *testing.T) {
+	client := clientsetfake.NewSimpleClientset()
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	podInformer := informerFactory.Core().V1().Pods()
+
+	var pods []*v1.Pod
+	for i := 0; i < 50; i++ {
+		pod := testing_helper.MakePod().Namespace(fmt.Sprintf("ns-%d", i)).Name("p").UID(fmt.Sprintf("p-%d", i)).Obj()
+		podInformer.Informer().GetStore().Add(pod)
+		pods = append(pods, pod)
+	}
+
+	d := &Dispatcher{
+		podLister:            podInformer.Lister(),
+		UnitInfos:            queue.NewUnitInfos(nil, nil),
+		FIFOPendingPodsQueue: &fakePendingQueue{pods: make(map[string]*queue.QueuedPodInfo)},
+		SortedPodsQueue:      queue.NewSortedFIFO(metrics.NewPendingPodsRecorder("ready")),
+		SchedulerName:        schedulerName,
+		shardManager:         shard.NewManager(client, schedulerName, "d1", &config.ShardingConfiguration{VirtualNodes: 32}),
+	}
+	pendingPods := d.FIFOPendingPodsQueue.(*fakePendingQueue).pods
+
+	singleRing := shard.NewRing([]string{"d1"}, 32)
+	single := shard.NewAssignment("d1", singleRing, map[string]*shard.Ring{"d1": singleRing})
+	d.rebalance(nil, single)
+	if len(pendingPods) != len(pods) {
+		t.Errorf("expected all %d pods taken over, but got %d", len(pods), len(pendingPods))
+	}
+
+	doubleRing := shard.NewRing([]string{"d1", "d2"}, 32)
+	double := shard.NewAssignment("d1", doubleRing, map[string]*shard.Ring{"d1": doubleRing, "d2": doubleRing})
+	d.rebalance(single, double)
+	for _, pod := range pods {
+		_, exist := pendingPods[podutil.GetPodKey(pod)]
+		if owned := double.Owns(shardKeyOfPod(pod)); owned != exist {
+			t.Errorf("expected pod %s in queue: %v, but got %v", podutil.GetPodKey(pod), owned, exist)
+		}
+	}
+	if len(pendingPods) == 0 || len(pendingPods) == len(pods) {
+		t.Errorf("expected pods split across replicas, but got %d pods in queue", len(pendingPods))
+	}
+}
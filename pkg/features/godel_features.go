@@ -84,6 +84,22 @@ const (
 	//
 	// Allows to trigger resource reservation in Godel.
 	ResourceReservation featuregate.Feature = "ResourceReservation"
+
+	// alpha: for now
+	//
+	// Allows to track the capacity of node-local storage pools in scheduler and binder.
+	LocalStoragePool featuregate.Feature = "LocalStoragePool"
+
+	// alpha: for now
+	//
+	// Allows to transform the allocatable of nodes by the configured overcommit policies selected by node labels.
+	NodeOvercommit featuregate.Feature = "NodeOvercommit"
+
+	// alpha: for now
+	//
+	// Allows scheduler to size the feasible node search of pods by the feasibility ratios
+	// and scheduling outcomes of their owners, instead of the static heuristics.
+	AdaptiveNodeSearch featuregate.Feature = "AdaptiveNodeSearch"
 )
 
 func init() {
@@ -104,4 +120,7 @@ var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	EnableColocation:                        {Default: false, PreRelease: featuregate.Alpha},
 	SupportRescheduling:                     {Default: false, PreRelease: featuregate.Alpha},
 	ResourceReservation:                     {Default: false, PreRelease: featuregate.Alpha},
+	LocalStoragePool:                        {Default: false, PreRelease: featuregate.Alpha},
+	NodeOvercommit:                          {Default: false, PreRelease: featuregate.Alpha},
+	AdaptiveNodeSearch:                      {Default: false, PreRelease: featuregate.Alpha},
 }
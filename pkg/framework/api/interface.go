@@ -760,6 +760,8 @@ type ScheduleUnit interface {
 	GetPods() []*QueuedPodInfo
 	// ValidatePodCount checks if the podCount is a valid number in a batch operation for this unit
 	ValidatePodCount(podCount int) bool
+	// ValidatePodRoles checks if the pods meet the min member of each role in a batch operation for this unit
+	ValidatePodRoles(pods []*v1.Pod) bool
 	// NumPods return the number of QueuedPodInfos in the unit
 	NumPods() int
 	// GetPod return a QueuedPodInfo with the same pod.UID
@@ -779,6 +781,8 @@ type ScheduleUnit interface {
 	GetAnnotations() map[string]string
 	// GetMinMember gets the min member value
 	GetMinMember() (int, error)
+	// GetRoleMinMember gets the min member of each role, nil is returned if the unit is not role aware
+	GetRoleMinMember() (map[string]int, error)
 	// GetRequiredAffinity returns required affinity scheduling rules, which
 	// must be met in scheduling.
 	GetRequiredAffinity() ([]UnitAffinityTerm, error)
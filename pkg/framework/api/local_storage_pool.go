@@ -0,0 +1,268 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
+)
+
+// LocalStoragePoolResourcePrefix is the prefix of the resources which describe the capacity of node-local
+// storage pools. The suffix is the name of the storage class, e.g. `local-storage.godel.bytedance.com/ssd`.
+// The capacity could be exposed through node allocatable or CNR allocatable, and CNR takes precedence.
+const LocalStoragePoolResourcePrefix = "local-storage.godel.bytedance.com/"
+
+// GetLocalStoragePoolCapacity parses the capacity of local storage pools from the resource list, keyed by storage class.
+func GetLocalStoragePoolCapacity(resources v1.ResourceList) map[string]int64 {
+	var capacity map[string]int64
+	for name, quantity := range resources {
+		if !strings.HasPrefix(string(name), LocalStoragePoolResourcePrefix) {
+			continue
+		}
+		storageClass := strings.TrimPrefix(string(name), LocalStoragePoolResourcePrefix)
+		if len(storageClass) == 0 {
+			continue
+		}
+		if capacity == nil {
+			capacity = make(map[string]int64)
+		}
+		capacity[storageClass] = quantity.Value()
+	}
+	return capacity
+}
+
+// NodeLocalStoragePools describes the local storage pools of a node.
+type NodeLocalStoragePools struct {
+	// nodeCapacity is parsed from node allocatable.
+	nodeCapacity map[string]int64
+	// cnrCapacity is parsed from CNR allocatable.
+	cnrCapacity map[string]int64
+	// requested is the sum of local volume requests of pods on the node.
+	requested map[string]int64
+	// pods records the local volume requests of each pod, so that they could be removed correctly.
+	pods map[string]map[string]int64
+
+	generation int64
+}
+
+var _ generationstore.StoredObj = &NodeLocalStoragePools{}
+
+func newNodeLocalStoragePools() *NodeLocalStoragePools {
+	return &NodeLocalStoragePools{
+		requested: make(map[string]int64),
+		pods:      make(map[string]map[string]int64),
+	}
+}
+
+func (n *NodeLocalStoragePools) GetGeneration() int64 {
+	return n.generation
+}
+
+func (n *NodeLocalStoragePools) SetGeneration(generation int64) {
+	n.generation = generation
+}
+
+// Capacity returns the capacity of local storage pools, CNR takes precedence over node allocatable.
+func (n *NodeLocalStoragePools) Capacity() map[string]int64 {
+	if n.cnrCapacity != nil {
+		return n.cnrCapacity
+	}
+	return n.nodeCapacity
+}
+
+// Requested returns the sum of local volume requests of pods on the node.
+func (n *NodeLocalStoragePools) Requested() map[string]int64 {
+	return n.requested
+}
+
+func (n *NodeLocalStoragePools) empty() bool {
+	return n.nodeCapacity == nil && n.cnrCapacity == nil && len(n.pods) == 0
+}
+
+func (n *NodeLocalStoragePools) clone() *NodeLocalStoragePools {
+	clone := &NodeLocalStoragePools{
+		nodeCapacity: cloneInt64Map(n.nodeCapacity),
+		cnrCapacity:  cloneInt64Map(n.cnrCapacity),
+		requested:    cloneInt64Map(n.requested),
+		pods:         make(map[string]map[string]int64, len(n.pods)),
+		generation:   n.generation,
+	}
+	// The requests of each pod are never modified once recorded, so they could be shared.
+	for k, v := range n.pods {
+		clone.pods[k] = v
+	}
+	return clone
+}
+
+// LocalStoragePools maintains the capacity and requests of node-local storage pools of all nodes.
+// Operation of this struct is not thread-safe, should ensure thread-safe by callers.
+type LocalStoragePools struct {
+	// nodes is keyed by node name, the real object is ListStore in Cache and RawStore in Snapshot.
+	nodes generationstore.Store
+	// storageClasses records how many nodes expose each storage class as a local storage pool.
+	// It is only changed along with the capacity, which is never modified in Snapshot.
+	storageClasses map[string]int
+	// storageClassesGeneration is used to decide whether storageClasses should be copied to Snapshot.
+	storageClassesGeneration int64
+}
+
+func NewCacheLocalStoragePools() *LocalStoragePools {
+	return &LocalStoragePools{
+		nodes:          generationstore.NewListStore(),
+		storageClasses: make(map[string]int),
+	}
+}
+
+func NewSnapshotLocalStoragePools() *LocalStoragePools {
+	return &LocalStoragePools{
+		nodes:          generationstore.NewRawStore(),
+		storageClasses: make(map[string]int),
+	}
+}
+
+// SetNodeCapacity sets the capacity parsed from node allocatable, nil means the node is removed.
+func (p *LocalStoragePools) SetNodeCapacity(nodeName string, capacity map[string]int64) {
+	p.setCapacity(nodeName, capacity, false)
+}
+
+// SetCNRCapacity sets the capacity parsed from CNR allocatable, nil means the CNR is removed.
+func (p *LocalStoragePools) SetCNRCapacity(nodeName string, capacity map[string]int64) {
+	p.setCapacity(nodeName, capacity, true)
+}
+
+// AddPod adds the local volume requests of the pod to the node. Pods without local volume requests are ignored.
+func (p *LocalStoragePools) AddPod(nodeName, podKey string, requests map[string]int64) {
+	if len(nodeName) == 0 || len(requests) == 0 {
+		return
+	}
+	n := p.getOrCreate(nodeName)
+	if _, ok := n.pods[podKey]; ok {
+		return
+	}
+	n.pods[podKey] = requests
+	for storageClass, quantity := range requests {
+		n.requested[storageClass] += quantity
+	}
+	p.nodes.Set(nodeName, n)
+}
+
+// RemovePod removes the local volume requests of the pod from the node.
+func (p *LocalStoragePools) RemovePod(nodeName, podKey string) {
+	n := p.GetNode(nodeName)
+	if n == nil {
+		return
+	}
+	requests, ok := n.pods[podKey]
+	if !ok {
+		return
+	}
+	delete(n.pods, podKey)
+	for storageClass, quantity := range requests {
+		if n.requested[storageClass] -= quantity; n.requested[storageClass] <= 0 {
+			delete(n.requested, storageClass)
+		}
+	}
+	p.save(nodeName, n)
+}
+
+// GetNode returns the local storage pools of the node, nil will be returned if the node is unknown.
+func (p *LocalStoragePools) GetNode(nodeName string) *NodeLocalStoragePools {
+	if obj := p.nodes.Get(nodeName); obj != nil {
+		return obj.(*NodeLocalStoragePools)
+	}
+	return nil
+}
+
+// IsLocalStorageClass returns true if any node exposes the storage class as a local storage pool.
+func (p *LocalStoragePools) IsLocalStorageClass(storageClass string) bool {
+	return p.storageClasses[storageClass] > 0
+}
+
+// UpdateSnapshot incrementally synchronizes the nodes changed since the last update to the snapshot,
+// including the nodes modified by the snapshot itself.
+func (p *LocalStoragePools) UpdateSnapshot(snapshot *LocalStoragePools) {
+	cache, snapshotNodes := TransferGenerationStore(p.nodes, snapshot.nodes)
+	cache.UpdateRawStore(
+		snapshotNodes,
+		func(key string, obj generationstore.StoredObj) {
+			snapshotNodes.Set(key, obj.(*NodeLocalStoragePools).clone())
+		},
+		generationstore.DefaultCleanFunc(cache, snapshotNodes),
+	)
+	if snapshot.storageClassesGeneration != p.storageClassesGeneration {
+		snapshot.storageClasses = make(map[string]int, len(p.storageClasses))
+		for k, v := range p.storageClasses {
+			snapshot.storageClasses[k] = v
+		}
+		snapshot.storageClassesGeneration = p.storageClassesGeneration
+	}
+}
+
+func (p *LocalStoragePools) setCapacity(nodeName string, capacity map[string]int64, fromCNR bool) {
+	if len(nodeName) == 0 {
+		return
+	}
+	n := p.getOrCreate(nodeName)
+	p.updateStorageClasses(n.Capacity(), -1)
+	if fromCNR {
+		n.cnrCapacity = capacity
+	} else {
+		n.nodeCapacity = capacity
+	}
+	p.updateStorageClasses(n.Capacity(), 1)
+	p.storageClassesGeneration++
+	p.save(nodeName, n)
+}
+
+func (p *LocalStoragePools) updateStorageClasses(capacity map[string]int64, delta int) {
+	for storageClass := range capacity {
+		if p.storageClasses[storageClass] += delta; p.storageClasses[storageClass] <= 0 {
+			delete(p.storageClasses, storageClass)
+		}
+	}
+}
+
+func (p *LocalStoragePools) getOrCreate(nodeName string) *NodeLocalStoragePools {
+	if n := p.GetNode(nodeName); n != nil {
+		return n
+	}
+	return newNodeLocalStoragePools()
+}
+
+// save writes the modified node back to the store so that its generation is refreshed,
+// empty nodes are removed.
+func (p *LocalStoragePools) save(nodeName string, n *NodeLocalStoragePools) {
+	if n.empty() {
+		p.nodes.Delete(nodeName)
+		return
+	}
+	p.nodes.Set(nodeName, n)
+}
+
+func cloneInt64Map(m map[string]int64) map[string]int64 {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]int64, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestGetLocalStoragePoolCapacity(t *testing.T) {
+	resources := v1.ResourceList{
+		v1.ResourceCPU:                                  resource.MustParse("4"),
+		LocalStoragePoolResourcePrefix + "ssd":          resource.MustParse("100Gi"),
+		LocalStoragePoolResourcePrefix + "hdd":          resource.MustParse("1Ti"),
+		v1.ResourceName(LocalStoragePoolResourcePrefix): resource.MustParse("1"),
+	}
+	expected := map[string]int64{
+		"ssd": 100 << 30,
+		"hdd": 1 << 40,
+	}
+	if got := GetLocalStoragePoolCapacity(resources); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected capacity %v, but got %v", expected, got)
+	}
+	if got := GetLocalStoragePoolCapacity(v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")}); got != nil {
+		t.Errorf("expected nil capacity, but got %v", got)
+	}
+}
+
+func TestLocalStoragePools(t *testing.T) {
+	pools := NewCacheLocalStoragePools()
+	pools.SetNodeCapacity("n1", map[string]int64{"ssd": 100})
+	pools.SetNodeCapacity("n2", map[string]int64{"hdd": 100})
+	if !pools.IsLocalStorageClass("ssd") || !pools.IsLocalStorageClass("hdd") || pools.IsLocalStorageClass("nas") {
+		t.Errorf("unexpected local storage classes")
+	}
+
+	// CNR takes precedence over node allocatable.
+	pools.SetCNRCapacity("n1", map[string]int64{"ssd": 80})
+	if got := pools.GetNode("n1").Capacity(); got["ssd"] != 80 {
+		t.Errorf("expected ssd capacity 80 from CNR, but got %v", got)
+	}
+
+	pools.AddPod("n1", "p1", map[string]int64{"ssd": 30})
+	pools.AddPod("n1", "p2", map[string]int64{"ssd": 20})
+	// The same pod will not be counted repeatedly.
+	pools.AddPod("n1", "p2", map[string]int64{"ssd": 20})
+	if got := pools.GetNode("n1").Requested(); got["ssd"] != 50 {
+		t.Errorf("expected ssd requested 50, but got %v", got)
+	}
+
+	snapshot := NewSnapshotLocalStoragePools()
+	pools.UpdateSnapshot(snapshot)
+	if !snapshot.IsLocalStorageClass("ssd") || !snapshot.IsLocalStorageClass("hdd") {
+		t.Errorf("expected storage classes to be synchronized to snapshot")
+	}
+	pools.RemovePod("n1", "p1")
+	if got := pools.GetNode("n1").Requested(); got["ssd"] != 20 {
+		t.Errorf("expected ssd requested 20, but got %v", got)
+	}
+	if got := snapshot.GetNode("n1").Requested(); got["ssd"] != 50 {
+		t.Errorf("expected snapshot not to be affected, but got %v", got)
+	}
+
+	// Storage classes are no longer local once all nodes stop exposing them.
+	pools.SetNodeCapacity("n2", nil)
+	if pools.IsLocalStorageClass("hdd") || pools.GetNode("n2") != nil {
+		t.Errorf("expected node n2 to be removed")
+	}
+	pools.SetCNRCapacity("n1", nil)
+	if got := pools.GetNode("n1").Capacity(); got["ssd"] != 100 {
+		t.Errorf("expected ssd capacity 100 from node, but got %v", got)
+	}
+
+	// Changes of cache and the modifications made in snapshot are both synchronized.
+	snapshot.AddPod("n1", "p3", map[string]int64{"ssd": 10})
+	pools.UpdateSnapshot(snapshot)
+	if got := snapshot.GetNode("n1").Requested(); got["ssd"] != 20 {
+		t.Errorf("expected snapshot ssd requested 20, but got %v", got)
+	}
+	if snapshot.IsLocalStorageClass("hdd") || snapshot.GetNode("n2") != nil {
+		t.Errorf("expected node n2 to be removed from snapshot")
+	}
+}
@@ -23,6 +23,9 @@ import (
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
 const (
@@ -385,6 +388,30 @@ func UnitRequireJobLevelAffinity(unit ScheduleUnit) bool {
 	return false
 }
 
+// GetAffinityRoles returns the roles co-located by the node group of the unit, nil means all the roles are co-located.
+func GetAffinityRoles(unit ScheduleUnit) sets.String {
+	if unit == nil {
+		return nil
+	}
+	value, ok := unit.GetAnnotations()[podutil.AffinityRolesAnnotationKey]
+	if !ok {
+		return nil
+	}
+	roles := sets.NewString()
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); len(role) > 0 {
+			roles.Insert(role)
+		}
+	}
+	return roles
+}
+
+// PodRequireCoLocation checks whether the pod should be placed in the node group divided for the unit,
+// the pods of the roles which are not co-located can be placed anywhere in the original node group.
+func PodRequireCoLocation(affinityRoles sets.String, pod *v1.Pod) bool {
+	return affinityRoles == nil || affinityRoles.Has(podutil.GetPodRole(pod))
+}
+
 func GenerateReadableKey(name string) string {
 	if strings.Contains(name, "[") || strings.Contains(name, "]") {
 		return name
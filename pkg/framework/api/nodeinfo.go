@@ -55,6 +55,7 @@ type NodeInfo interface {
 	GetBestEffortRequested() *Resource
 	GetBestEffortNonZeroRequested() *Resource
 	GetBestEffortAllocatable() *Resource
+	GetOvercommitPolicy() *OvercommitPolicy
 	SetGuaranteedRequested(*Resource)
 	SetGuaranteedNonZeroRequested(*Resource)
 	SetGuaranteedAllocatable(*Resource)
@@ -166,6 +167,9 @@ type NodeInfoImpl struct {
 	// We store best-effort allocatedResources (which is CNR.Status.BestEffortResourceAllocatable.*) explicitly
 	// as int64, to avoid conversions and accessing map.
 	BestEffortAllocatable *Resource
+	// OvercommitPolicy is selected by the labels of Node/NMNode, the allocatable above has been
+	// transformed by it. It's nil if there is no policy or the NodeOvercommit feature is disabled.
+	OvercommitPolicy *OvercommitPolicy
 
 	// ImageStates holds the entry of an image if and only if this image is on the node. The entry can be used for
 	// checking an image's existence and advanced usage (e.g., image locality scheduling policy) based on the image
@@ -403,6 +407,12 @@ func (n *NodeInfoImpl) GetBestEffortAllocatable() *Resource {
 	return n.BestEffortAllocatable
 }
 
+func (n *NodeInfoImpl) GetOvercommitPolicy() *OvercommitPolicy {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.OvercommitPolicy
+}
+
 func (n *NodeInfoImpl) SetGuaranteedRequested(r *Resource) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -533,6 +543,7 @@ func (n *NodeInfoImpl) Clone() NodeInfo {
 		BestEffortRequested:        n.BestEffortRequested.Clone(),
 		BestEffortNonZeroRequested: n.BestEffortNonZeroRequested.Clone(),
 		BestEffortAllocatable:      n.BestEffortAllocatable.Clone(),
+		OvercommitPolicy:           n.OvercommitPolicy,
 		TransientInfo:              n.TransientInfo,
 		UsedPorts:                  make(HostPortInfo),
 		ImageStates:                n.ImageStates,
@@ -723,8 +734,7 @@ func (n *NodeInfoImpl) SetNode(node *v1.Node) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.Node = node
-	n.setGuaranteedAllocatableResource()
-	n.setGuaranteedCapacityResource()
+	n.setNodeAllocatableResource()
 	n.TransientInfo = NewTransientSchedulerInfo()
 	return nil
 }
@@ -743,8 +753,7 @@ func (n *NodeInfoImpl) SetNMNode(nmNode *nodev1alpha1.NMNode) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.NMNode = nmNode
-	n.setGuaranteedAllocatableResource()
-	n.setGuaranteedCapacityResource()
+	n.setNodeAllocatableResource()
 	return nil
 }
 
@@ -778,7 +787,7 @@ func (n *NodeInfoImpl) SetCNR(cnr *katalystv1alpha1.CustomNodeResource) error {
 
 	n.CNR = cnr
 	n.NumaTopologyStatus.parseNumaTopologyStatus(cnr, n.PodInfoMaintainer)
-	n.BestEffortAllocatable = NewResourceFromPtr(cnr.Status.Resources.Allocatable)
+	n.setBestEffortAllocatableResource()
 	return nil
 }
 
@@ -1393,18 +1402,68 @@ func QuantityEqual(r1, r2 *resource.Quantity) bool {
 	return true
 }
 
-// setGuaranteedAllocatableResource sets guaranteed allocatable resource about node, based on Node and NMNode.
+// setNodeAllocatableResource refreshes the overcommit policy and the allocatable after Node or NMNode changed.
+func (n *NodeInfoImpl) setNodeAllocatableResource() {
+	hadPolicy := n.OvercommitPolicy != nil
+	n.setOvercommitPolicy()
+	n.setGuaranteedAllocatableResource()
+	n.setGuaranteedCapacityResource()
+	if hadPolicy || n.OvercommitPolicy != nil {
+		// The best-effort allocatable depends on the policy and the guaranteed allocatable.
+		n.setBestEffortAllocatableResource()
+	}
+}
+
+// setOvercommitPolicy sets the overcommit policy selected by the labels of Node, or NMNode if Node doesn't exist.
+func (n *NodeInfoImpl) setOvercommitPolicy() {
+	n.OvercommitPolicy = nil
+	if !utilfeature.DefaultFeatureGate.Enabled(godelfeatures.NodeOvercommit) {
+		return
+	}
+	var labels map[string]string
+	if n.Node != nil {
+		labels = n.Node.Labels
+	} else if n.NMNode != nil {
+		labels = n.NMNode.Labels
+	}
+	policy, err := GetOvercommitPolicy(labels)
+	if err != nil {
+		klog.InfoS("Failed to get overcommit policy", "node", n.getNodeName(), "err", err)
+		return
+	}
+	n.OvercommitPolicy = policy
+}
+
+// setGuaranteedAllocatableResource sets guaranteed allocatable resource about node, based on Node, NMNode and the overcommit policy.
 func (n *NodeInfoImpl) setGuaranteedAllocatableResource() {
+	n.GuaranteedAllocatable = n.OvercommitPolicy.GuaranteedAllocatable(n.getGuaranteedAllocatableResource())
+}
+
+// getGuaranteedAllocatableResource returns guaranteed allocatable resource about node, based on Node and NMNode.
+func (n *NodeInfoImpl) getGuaranteedAllocatableResource() *Resource {
 	switch {
-	case n.Node == nil && n.NMNode == nil:
-		n.GuaranteedAllocatable = &Resource{}
 	case n.Node != nil && n.NMNode == nil:
-		n.GuaranteedAllocatable = NewResource(n.Node.Status.Allocatable)
+		return NewResource(n.Node.Status.Allocatable)
 	case n.Node == nil && n.NMNode != nil:
-		n.GuaranteedAllocatable = NewResourceFromPtr(n.NMNode.Status.ResourceAllocatable)
+		return NewResourceFromPtr(n.NMNode.Status.ResourceAllocatable)
 	case n.Node != nil && n.NMNode != nil:
-		n.GuaranteedAllocatable = getAllocatableResources(n.Node, n.NMNode)
+		return getAllocatableResources(n.Node, n.NMNode)
 	}
+	return &Resource{}
+}
+
+// setBestEffortAllocatableResource sets best-effort allocatable resource about node, based on CNR and the overcommit policy.
+func (n *NodeInfoImpl) setBestEffortAllocatableResource() {
+	if n.CNR == nil {
+		n.BestEffortAllocatable = &Resource{}
+		return
+	}
+	allocatable := NewResourceFromPtr(n.CNR.Status.Resources.Allocatable)
+	if n.OvercommitPolicy == nil {
+		n.BestEffortAllocatable = allocatable
+		return
+	}
+	n.BestEffortAllocatable = n.OvercommitPolicy.BestEffortAllocatable(allocatable, n.getGuaranteedAllocatableResource(), n.CNR)
 }
 
 // setGuaranteedCapacityResource sets guaranteed capacity resource about node, based on Node and NMNode.
@@ -1462,16 +1521,14 @@ func (n *NodeInfoImpl) RemoveNode() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.Node = nil
-	n.setGuaranteedAllocatableResource()
-	n.setGuaranteedCapacityResource()
+	n.setNodeAllocatableResource()
 }
 
 func (n *NodeInfoImpl) RemoveNMNode() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.NMNode = nil
-	n.setGuaranteedAllocatableResource()
-	n.setGuaranteedCapacityResource()
+	n.setNodeAllocatableResource()
 }
 
 // RemoveCNR removes the CNR object, leaving all other tracking information.
@@ -1479,7 +1536,7 @@ func (n *NodeInfoImpl) RemoveCNR() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.CNR = nil
-	n.BestEffortAllocatable = &Resource{}
+	n.setBestEffortAllocatableResource()
 	n.NumaTopologyStatus.removeCNR()
 }
 
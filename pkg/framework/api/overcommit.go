@@ -0,0 +1,172 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// OvercommitPolicyLabelKey is the label of Node/NMNode whose value is the name of the overcommit policy
+// configured in scheduler and binder, e.g. `godel.bytedance.com/overcommit-policy: cpu-oversell`.
+// Nodes of the same node pool are expected to share the same policy.
+const OvercommitPolicyLabelKey = "godel.bytedance.com/overcommit-policy"
+
+// OvercommitPolicy describes how the allocatable taken from Node/NMNode/CNR is transformed.
+type OvercommitPolicy config.OvercommitPolicy
+
+// overcommitPolicies holds the configured policies keyed by name, it's a map[string]*OvercommitPolicy.
+var overcommitPolicies atomic.Value
+
+// SetOvercommitPolicies validates and sets the overcommit policies selectable by nodes, it should be called
+// before any node is added into the cache, so that the scheduler and the binder transform the allocatable
+// consistently.
+func SetOvercommitPolicies(policies []config.OvercommitPolicy) error {
+	if err := config.ValidateOvercommitPolicies(field.NewPath("overcommitPolicies"), policies).ToAggregate(); err != nil {
+		return err
+	}
+	policyMap := make(map[string]*OvercommitPolicy, len(policies))
+	for i := range policies {
+		policy := OvercommitPolicy(*policies[i].DeepCopy())
+		policyMap[policy.Name] = &policy
+	}
+	overcommitPolicies.Store(policyMap)
+	return nil
+}
+
+// GetOvercommitPolicy returns the overcommit policy selected by the labels, nil will be returned if the labels
+// don't select any policy.
+func GetOvercommitPolicy(labels map[string]string) (*OvercommitPolicy, error) {
+	name, ok := labels[OvercommitPolicyLabelKey]
+	if !ok || len(name) == 0 {
+		return nil, nil
+	}
+	policyMap, _ := overcommitPolicies.Load().(map[string]*OvercommitPolicy)
+	policy, ok := policyMap[name]
+	if !ok {
+		return nil, fmt.Errorf("overcommit policy %s is not configured", name)
+	}
+	return policy, nil
+}
+
+// GuaranteedAllocatable returns the guaranteed allocatable transformed by the policy.
+func (p *OvercommitPolicy) GuaranteedAllocatable(allocatable *Resource) *Resource {
+	if p == nil {
+		return allocatable
+	}
+	return scaleResource(allocatable, p.GuaranteedRatios)
+}
+
+// BestEffortAllocatable returns the best-effort allocatable transformed by the policy. The guaranteed allocatable
+// should be the one before transformation, because the slack is observed from the real node.
+func (p *OvercommitPolicy) BestEffortAllocatable(allocatable, guaranteedAllocatable *Resource, cnr *katalystv1alpha1.CustomNodeResource) *Resource {
+	if p == nil {
+		return allocatable
+	}
+	if p.BestEffortFromSlack {
+		if milliCPU, memory, ok := guaranteedUsage(cnr); ok {
+			allocatable = allocatable.Clone()
+			allocatable.MilliCPU = maxInt64(guaranteedAllocatable.MilliCPU-milliCPU, 0)
+			allocatable.Memory = maxInt64(guaranteedAllocatable.Memory-memory, 0)
+		}
+	}
+	return scaleResource(allocatable, p.BestEffortRatios)
+}
+
+// guaranteedUsage returns the usage of guaranteed pods reported by CNR.
+func guaranteedUsage(cnr *katalystv1alpha1.CustomNodeResource) (int64, int64, bool) {
+	if cnr == nil || cnr.Status.NodeMetricStatus == nil {
+		return 0, 0, false
+	}
+	var milliCPU, memory int64
+	var found bool
+	for _, group := range cnr.Status.NodeMetricStatus.GroupMetric {
+		if podutil.GetResourceTypeFromQoS(group.QoSLevel) != podutil.GuaranteedPod || group.GenericUsage == nil {
+			continue
+		}
+		found = true
+		if group.GenericUsage.CPU != nil {
+			milliCPU += group.GenericUsage.CPU.MilliValue()
+		}
+		if group.GenericUsage.Memory != nil {
+			memory += group.GenericUsage.Memory.Value()
+		}
+	}
+	return milliCPU, memory, found
+}
+
+func scaleResource(r *Resource, ratios map[v1.ResourceName]float64) *Resource {
+	if len(ratios) == 0 {
+		return r
+	}
+	ret := r.Clone()
+	for name, ratio := range ratios {
+		switch name {
+		case v1.ResourceCPU:
+			ret.MilliCPU = int64(float64(ret.MilliCPU) * ratio)
+		case v1.ResourceMemory:
+			ret.Memory = int64(float64(ret.Memory) * ratio)
+		case v1.ResourceEphemeralStorage:
+			ret.EphemeralStorage = int64(float64(ret.EphemeralStorage) * ratio)
+		case v1.ResourcePods:
+			ret.AllowedPodNumber = int(float64(ret.AllowedPodNumber) * ratio)
+		default:
+			if v, ok := ret.ScalarResources[name]; ok {
+				ret.ScalarResources[name] = int64(float64(v) * ratio)
+			}
+		}
+	}
+	return ret
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// OvercommitSummary aggregates the nodes sharing the same overcommit profile, used by metrics.
+type OvercommitSummary struct {
+	Nodes                 int
+	GuaranteedAllocatable *Resource
+	BestEffortAllocatable *Resource
+}
+
+// SummarizeOvercommit adds the node into the summary of its overcommit profile, nodes without policy are skipped.
+// The profiles are keyed by the policy name, which is unique among the configured policies.
+func SummarizeOvercommit(summaries map[string]*OvercommitSummary, n NodeInfo) {
+	policy := n.GetOvercommitPolicy()
+	if policy == nil {
+		return
+	}
+	summary, ok := summaries[policy.Name]
+	if !ok {
+		summary = &OvercommitSummary{GuaranteedAllocatable: &Resource{}, BestEffortAllocatable: &Resource{}}
+		summaries[policy.Name] = summary
+	}
+	summary.Nodes++
+	summary.GuaranteedAllocatable.AddResource(n.GetGuaranteedAllocatable())
+	summary.BestEffortAllocatable.AddResource(n.GetBestEffortAllocatable())
+}
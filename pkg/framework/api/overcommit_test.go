@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	godelfeatures "github.com/kubewharf/godel-scheduler/pkg/features"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+)
+
+func setTestOvercommitPolicies(t *testing.T) {
+	err := SetOvercommitPolicies([]config.OvercommitPolicy{
+		{
+			Name:             "cpu-oversell",
+			GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 1.5, v1.ResourceMemory: 1.0},
+		},
+		{
+			Name:                "slack",
+			GuaranteedRatios:    map[v1.ResourceName]float64{v1.ResourceCPU: 1.5},
+			BestEffortRatios:    map[v1.ResourceName]float64{v1.ResourceMemory: 0.5},
+			BestEffortFromSlack: true,
+		},
+		{
+			Name:             "cpu-double",
+			GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to set overcommit policies: %v", err)
+	}
+	t.Cleanup(func() { SetOvercommitPolicies(nil) })
+}
+
+func TestNodeInfoOvercommit(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, godelfeatures.NodeOvercommit, true)()
+	setTestOvercommitPolicies(t)
+
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("10"),
+		v1.ResourceMemory: resource.MustParse("10Gi"),
+	}
+	makeNode := func(policy string) *v1.Node {
+		node := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: map[string]string{}},
+			Status:     v1.NodeStatus{Allocatable: allocatable, Capacity: allocatable},
+		}
+		if len(policy) > 0 {
+			node.Labels[OvercommitPolicyLabelKey] = policy
+		}
+		return node
+	}
+	beAllocatable := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("4"),
+		v1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+	cnr := &katalystv1alpha1.CustomNodeResource{
+		ObjectMeta: metav1.ObjectMeta{Name: "n"},
+		Status: katalystv1alpha1.CustomNodeResourceStatus{
+			Resources: katalystv1alpha1.Resources{Allocatable: &beAllocatable},
+			NodeMetricStatus: &katalystv1alpha1.NodeMetricStatus{
+				GroupMetric: []katalystv1alpha1.GroupMetricInfo{
+					{
+						QoSLevel: string(util.SharedCores),
+						ResourceUsage: katalystv1alpha1.ResourceUsage{
+							GenericUsage: &katalystv1alpha1.ResourceMetric{
+								CPU:    resource.NewMilliQuantity(3000, resource.DecimalSI),
+								Memory: resource.NewQuantity(2<<30, resource.BinarySI),
+							},
+						},
+					},
+					{
+						QoSLevel: string(util.ReclaimedCores),
+						ResourceUsage: katalystv1alpha1.ResourceUsage{
+							GenericUsage: &katalystv1alpha1.ResourceMetric{
+								CPU:    resource.NewMilliQuantity(1000, resource.DecimalSI),
+								Memory: resource.NewQuantity(1<<30, resource.BinarySI),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		policy         string
+		wantGuaranteed *Resource
+		wantBestEffort *Resource
+	}{
+		{
+			name:           "no policy",
+			wantGuaranteed: &Resource{MilliCPU: 10000, Memory: 10 << 30},
+			wantBestEffort: &Resource{MilliCPU: 4000, Memory: 4 << 30},
+		},
+		{
+			name:           "unknown policy",
+			policy:         "unknown",
+			wantGuaranteed: &Resource{MilliCPU: 10000, Memory: 10 << 30},
+			wantBestEffort: &Resource{MilliCPU: 4000, Memory: 4 << 30},
+		},
+		{
+			name:           "oversell guaranteed cpu",
+			policy:         "cpu-oversell",
+			wantGuaranteed: &Resource{MilliCPU: 15000, Memory: 10 << 30},
+			wantBestEffort: &Resource{MilliCPU: 4000, Memory: 4 << 30},
+		},
+		{
+			name:           "best-effort from slack",
+			policy:         "slack",
+			wantGuaranteed: &Resource{MilliCPU: 15000, Memory: 10 << 30},
+			wantBestEffort: &Resource{MilliCPU: 7000, Memory: 4 << 30},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set CNR before and after Node, the result should be the same.
+			for _, cnrFirst := range []bool{true, false} {
+				n := NewNodeInfo()
+				if cnrFirst {
+					n.SetCNR(cnr)
+					n.SetNode(makeNode(tt.policy))
+				} else {
+					n.SetNode(makeNode(tt.policy))
+					n.SetCNR(cnr)
+				}
+				if got := n.GetGuaranteedAllocatable(); !reflect.DeepEqual(got, tt.wantGuaranteed) {
+					t.Errorf("Expected guaranteed allocatable %+v, got %+v", tt.wantGuaranteed, got)
+				}
+				if got := n.GetBestEffortAllocatable(); !reflect.DeepEqual(got, tt.wantBestEffort) {
+					t.Errorf("Expected best-effort allocatable %+v, got %+v", tt.wantBestEffort, got)
+				}
+
+				// The allocatable should be restored after the policy is removed.
+				n.SetNode(makeNode(""))
+				if got := n.GetGuaranteedAllocatable(); !reflect.DeepEqual(got, &Resource{MilliCPU: 10000, Memory: 10 << 30}) {
+					t.Errorf("Unexpected guaranteed allocatable %+v after policy removed", got)
+				}
+				if got := n.GetBestEffortAllocatable(); !reflect.DeepEqual(got, &Resource{MilliCPU: 4000, Memory: 4 << 30}) {
+					t.Errorf("Unexpected best-effort allocatable %+v after policy removed", got)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeOvercommit(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, godelfeatures.NodeOvercommit, true)()
+	setTestOvercommitPolicies(t)
+
+	summaries := make(map[string]*OvercommitSummary)
+	for _, policy := range []string{"cpu-double", "cpu-double", ""} {
+		n := NewNodeInfo()
+		n.SetNode(&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: map[string]string{OvercommitPolicyLabelKey: policy}},
+			Status:     v1.NodeStatus{Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")}},
+		})
+		SummarizeOvercommit(summaries, n)
+	}
+	if len(summaries) != 1 || summaries["cpu-double"].Nodes != 2 || summaries["cpu-double"].GuaranteedAllocatable.MilliCPU != 4000 {
+		t.Errorf("Unexpected summaries %+v", summaries)
+	}
+}
+
+func TestSetOvercommitPoliciesInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []config.OvercommitPolicy
+	}{
+		{
+			name:     "missing name",
+			policies: []config.OvercommitPolicy{{GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 1.5}}},
+		},
+		{
+			name: "duplicated name",
+			policies: []config.OvercommitPolicy{
+				{Name: "a", GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 1.5}},
+				{Name: "a", GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 2}},
+			},
+		},
+		{
+			name:     "zero guaranteed ratio",
+			policies: []config.OvercommitPolicy{{Name: "a", GuaranteedRatios: map[v1.ResourceName]float64{v1.ResourceCPU: 0}}},
+		},
+		{
+			name:     "negative best-effort ratio",
+			policies: []config.OvercommitPolicy{{Name: "a", BestEffortRatios: map[v1.ResourceName]float64{v1.ResourceMemory: -1}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := SetOvercommitPolicies(tt.policies); err == nil {
+				t.Errorf("Expected error for invalid overcommit policies")
+			}
+		})
+	}
+}
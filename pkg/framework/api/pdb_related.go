@@ -17,6 +17,8 @@ limitations under the License.
 package api
 
 import (
+	"sort"
+
 	policy "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/labels"
 
@@ -131,3 +133,32 @@ func (p *pdbItemImpl) Clone() PDBItem {
 		daemonSetKeys:  NewGenerationStringSet(p.daemonSetKeys.Strings()...),
 	}
 }
+
+// PDBItemDump is the debugging view of a PDBItem.
+type PDBItemDump struct {
+	Key                string   `json:"key"`
+	Selector           string   `json:"selector"`
+	DisruptionsAllowed int32    `json:"disruptionsAllowed"`
+	ReplicaSets        []string `json:"replicaSets,omitempty"`
+	DaemonSets         []string `json:"daemonSets,omitempty"`
+}
+
+// DumpPDBItem converts the PDBItem to a json serializable object, nil will be returned if the pdb is missing.
+func DumpPDBItem(item PDBItem) *PDBItemDump {
+	pdb := item.GetPDB()
+	if pdb == nil {
+		return nil
+	}
+	ret := &PDBItemDump{
+		Key:                pdb.Namespace + "/" + pdb.Name,
+		DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+		ReplicaSets:        item.GetRelatedOwnersByType(util.OwnerTypeReplicaSet),
+		DaemonSets:         item.GetRelatedOwnersByType(util.OwnerTypeDaemonSet),
+	}
+	if selector := item.GetPDBSelector(); selector != nil {
+		ret.Selector = selector.String()
+	}
+	sort.Strings(ret.ReplicaSets)
+	sort.Strings(ret.DaemonSets)
+	return ret
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// PreemptionRecord describes a victim which has been chosen by a preemptor.
+type PreemptionRecord struct {
+	VictimKey    string
+	PreemptorKey string
+	// OwnerKey is the key of victim's owner, it may be empty if the owner is unknown.
+	OwnerKey  string
+	Namespace string
+	Timestamp time.Time
+}
+
+// PreemptionRecords maintains the recent preemption history, indexed by victim's owner and namespace.
+// Operation of this struct is not thread-safe, should ensure thread-safe by callers.
+type PreemptionRecords struct {
+	records    map[string]*PreemptionRecord
+	owners     map[string]sets.String
+	namespaces map[string]sets.String
+	generation int64
+}
+
+func NewPreemptionRecords() *PreemptionRecords {
+	return &PreemptionRecords{
+		records:    make(map[string]*PreemptionRecord),
+		owners:     make(map[string]sets.String),
+		namespaces: make(map[string]sets.String),
+	}
+}
+
+func (r *PreemptionRecords) GetGeneration() int64 {
+	return r.generation
+}
+
+func (r *PreemptionRecords) SetGeneration(generation int64) {
+	r.generation = generation
+}
+
+func (r *PreemptionRecords) Len() int {
+	return len(r.records)
+}
+
+// AddRecord adds the record if the victim has not been recorded yet. The earliest record always
+// takes effect, so that the same preemption observed by multiple events won't be counted repeatedly.
+func (r *PreemptionRecords) AddRecord(record *PreemptionRecord) bool {
+	if record == nil || len(record.VictimKey) == 0 {
+		return false
+	}
+	if _, ok := r.records[record.VictimKey]; ok {
+		return false
+	}
+	r.records[record.VictimKey] = record
+	if len(record.OwnerKey) > 0 {
+		insertIndex(r.owners, record.OwnerKey, record.VictimKey)
+	}
+	insertIndex(r.namespaces, record.Namespace, record.VictimKey)
+	r.generation++
+	return true
+}
+
+// RemoveRecord removes the record of victim only if it was added by the given preemptor.
+func (r *PreemptionRecords) RemoveRecord(victimKey, preemptorKey string) bool {
+	record, ok := r.records[victimKey]
+	if !ok || record.PreemptorKey != preemptorKey {
+		return false
+	}
+	r.removeRecord(record)
+	return true
+}
+
+// CleanUpExpiredRecords removes all the records happened before the given time.
+func (r *PreemptionRecords) CleanUpExpiredRecords(before time.Time) int {
+	var count int
+	for _, record := range r.records {
+		if record.Timestamp.Before(before) {
+			r.removeRecord(record)
+			count++
+		}
+	}
+	return count
+}
+
+// CountByOwner returns the number of victims belonging to the owner which were preempted since the given time.
+func (r *PreemptionRecords) CountByOwner(ownerKey string, since time.Time) int64 {
+	if len(ownerKey) == 0 {
+		return 0
+	}
+	return r.countSince(r.owners[ownerKey], since)
+}
+
+// CountByNamespace returns the number of victims in the namespace which were preempted since the given time.
+func (r *PreemptionRecords) CountByNamespace(namespace string, since time.Time) int64 {
+	return r.countSince(r.namespaces[namespace], since)
+}
+
+// LastPreemptionTimeOfOwner returns the latest time when a victim belonging to the owner was preempted.
+// Zero time will be returned if there is no record of the owner.
+func (r *PreemptionRecords) LastPreemptionTimeOfOwner(ownerKey string) time.Time {
+	var last time.Time
+	if len(ownerKey) == 0 {
+		return last
+	}
+	for victimKey := range r.owners[ownerKey] {
+		if record := r.records[victimKey]; record != nil && record.Timestamp.After(last) {
+			last = record.Timestamp
+		}
+	}
+	return last
+}
+
+func (r *PreemptionRecords) Clone() *PreemptionRecords {
+	clone := &PreemptionRecords{
+		records:    make(map[string]*PreemptionRecord, len(r.records)),
+		owners:     make(map[string]sets.String, len(r.owners)),
+		namespaces: make(map[string]sets.String, len(r.namespaces)),
+		generation: r.generation,
+	}
+	for k, v := range r.records {
+		record := *v
+		clone.records[k] = &record
+	}
+	for k, v := range r.owners {
+		clone.owners[k] = sets.NewString(v.UnsortedList()...)
+	}
+	for k, v := range r.namespaces {
+		clone.namespaces[k] = sets.NewString(v.UnsortedList()...)
+	}
+	return clone
+}
+
+func (r *PreemptionRecords) countSince(victimKeys sets.String, since time.Time) int64 {
+	var count int64
+	for victimKey := range victimKeys {
+		if record := r.records[victimKey]; record != nil && !record.Timestamp.Before(since) {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *PreemptionRecords) removeRecord(record *PreemptionRecord) {
+	delete(r.records, record.VictimKey)
+	if len(record.OwnerKey) > 0 {
+		deleteIndex(r.owners, record.OwnerKey, record.VictimKey)
+	}
+	deleteIndex(r.namespaces, record.Namespace, record.VictimKey)
+	r.generation++
+}
+
+func insertIndex(index map[string]sets.String, key, victimKey string) {
+	s, ok := index[key]
+	if !ok {
+		s = sets.NewString()
+		index[key] = s
+	}
+	s.Insert(victimKey)
+}
+
+func deleteIndex(index map[string]sets.String, key, victimKey string) {
+	s, ok := index[key]
+	if !ok {
+		return
+	}
+	s.Delete(victimKey)
+	if s.Len() == 0 {
+		delete(index, key)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreemptionRecords(t *testing.T) {
+	now := time.Now()
+	records := NewPreemptionRecords()
+	records.AddRecord(&PreemptionRecord{VictimKey: "v1", PreemptorKey: "p1", OwnerKey: "o1", Namespace: "ns1", Timestamp: now.Add(-2 * time.Minute)})
+	records.AddRecord(&PreemptionRecord{VictimKey: "v2", PreemptorKey: "p1", OwnerKey: "o1", Namespace: "ns1", Timestamp: now})
+	records.AddRecord(&PreemptionRecord{VictimKey: "v3", PreemptorKey: "p2", Namespace: "ns2", Timestamp: now})
+
+	// The same victim will not be counted repeatedly.
+	if records.AddRecord(&PreemptionRecord{VictimKey: "v2", PreemptorKey: "p2", OwnerKey: "o1", Namespace: "ns1", Timestamp: now}) {
+		t.Errorf("expected duplicated record to be ignored")
+	}
+	if got := records.CountByOwner("o1", now.Add(-time.Hour)); got != 2 {
+		t.Errorf("expected 2 victims of owner o1, but got %d", got)
+	}
+	if got := records.CountByOwner("o1", now.Add(-time.Minute)); got != 1 {
+		t.Errorf("expected 1 victim of owner o1 in the last minute, but got %d", got)
+	}
+	if got := records.CountByNamespace("ns2", now.Add(-time.Hour)); got != 1 {
+		t.Errorf("expected 1 victim in namespace ns2, but got %d", got)
+	}
+	if got := records.LastPreemptionTimeOfOwner("o1"); !got.Equal(now) {
+		t.Errorf("expected last preemption time %v, but got %v", now, got)
+	}
+
+	clone := records.Clone()
+
+	// Only the preemptor who added the record could remove it.
+	if records.RemoveRecord("v2", "p2") {
+		t.Errorf("expected record not to be removed by other preemptor")
+	}
+	if !records.RemoveRecord("v2", "p1") {
+		t.Errorf("expected record to be removed by its preemptor")
+	}
+	if got := records.CleanUpExpiredRecords(now.Add(-time.Minute)); got != 1 {
+		t.Errorf("expected 1 expired record, but got %d", got)
+	}
+	if got := records.CountByOwner("o1", now.Add(-time.Hour)); got != 0 {
+		t.Errorf("expected no victim of owner o1, but got %d", got)
+	}
+	if got := records.Len(); got != 1 {
+		t.Errorf("expected 1 record, but got %d", got)
+	}
+
+	if got := clone.CountByOwner("o1", now.Add(-time.Hour)); got != 2 {
+		t.Errorf("expected clone not to be affected, but got %d victims of owner o1", got)
+	}
+	if clone.GetGeneration() == records.GetGeneration() {
+		t.Errorf("expected generation to be changed")
+	}
+}
@@ -1180,6 +1180,14 @@ type LoadAwareNodeMetricInfo struct {
 	ProfileMEMUsage      int64
 }
 
+// LoadAwareOwnerUsage describes the historical usage profile of an owner (deployment/podgroup etc.),
+// the ratios are the percentile of usage/request observed from the pods belonging to the owner.
+type LoadAwareOwnerUsage struct {
+	Samples       int
+	MilliCPURatio float64
+	MEMRatio      float64
+}
+
 type (
 	SchedulingStage       int
 	SchedulingStagesState int
@@ -17,6 +17,7 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -41,6 +42,9 @@ type PodGroupUnit struct {
 	priority         int32
 	queuedPodInfoMap map[string]*QueuedPodInfo
 	timestamp        time.Time
+	// roleMinMember is parsed from the annotation of the PodGroup once when the unit is created,
+	// it is nil if the annotation is not set or malformed.
+	roleMinMember map[string]int
 
 	// be used by metrics and tracing
 	// it's generated by calling GetUnitProperty()
@@ -60,12 +64,18 @@ func keyForPodGroupUnit(podGroup *schedulingv1a1.PodGroup) string {
 }
 
 func NewPodGroupUnit(podGroup *schedulingv1a1.PodGroup, priority int32) *PodGroupUnit {
+	var roleMinMember map[string]int
+	if podGroup != nil {
+		// a malformed annotation is reported in the Pending condition of the PodGroup by dispatcher.
+		roleMinMember, _ = ParseRoleMinMember(podGroup)
+	}
 	return &PodGroupUnit{
 		key:              keyForPodGroupUnit(podGroup),
 		podGroup:         podGroup,
 		priority:         priority,
 		queuedPodInfoMap: make(map[string]*QueuedPodInfo),
 		timestamp:        time.Now(),
+		roleMinMember:    roleMinMember,
 	}
 }
 
@@ -96,7 +106,14 @@ func (p *PodGroupUnit) ReadyToBePopulated() bool {
 		return false
 	}
 
-	return len(p.queuedPodInfoMap) >= int(p.podGroup.Spec.MinMember)
+	if len(p.queuedPodInfoMap) < int(p.podGroup.Spec.MinMember) {
+		return false
+	}
+	pods := make([]*v1.Pod, 0, len(p.queuedPodInfoMap))
+	for _, podInfo := range p.queuedPodInfoMap {
+		pods = append(pods, podInfo.Pod)
+	}
+	return p.ValidatePodRoles(pods)
 }
 
 func (p *PodGroupUnit) PodBelongToUnit(pod *v1.Pod) bool {
@@ -136,6 +153,14 @@ func (p *PodGroupUnit) ValidatePodCount(podCount int) bool {
 	return int32(podCount) >= p.podGroup.Spec.MinMember
 }
 
+func (p *PodGroupUnit) ValidatePodRoles(pods []*v1.Pod) bool {
+	roleMinMember, err := p.GetRoleMinMember()
+	if err != nil {
+		return false
+	}
+	return MeetRoleMinMember(roleMinMember, pods)
+}
+
 // If iterating the map is a performance concern here, we can introduce more complex data structure.
 func (p *PodGroupUnit) GetPods() []*QueuedPodInfo {
 	values := make([]*QueuedPodInfo, 0)
@@ -230,6 +255,40 @@ func (p *PodGroupUnit) GetMinMember() (int, error) {
 	return int(p.podGroup.Spec.MinMember), nil
 }
 
+// GetRoleMinMember returns the min member of each role parsed from the annotation of the PodGroup.
+// A malformed annotation is ignored and the unit falls back to the min member of the PodGroup,
+// otherwise the unit would never be ready to be scheduled.
+func (p *PodGroupUnit) GetRoleMinMember() (map[string]int, error) {
+	if p.podGroup == nil {
+		return nil, fmt.Errorf("pod group is nil")
+	}
+	return p.roleMinMember, nil
+}
+
+// ParseRoleMinMember parses the min member of each role from the annotation of the PodGroup,
+// nil is returned if the annotation is not set.
+func ParseRoleMinMember(pg *schedulingv1a1.PodGroup) (map[string]int, error) {
+	value, ok := pg.Annotations[podutil.RoleMinMemberAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var roleMinMember map[string]int
+	if err := json.Unmarshal([]byte(value), &roleMinMember); err != nil {
+		return nil, fmt.Errorf("failed to parse role min member %q: %v", value, err)
+	}
+	sum := 0
+	for role, minMember := range roleMinMember {
+		if len(role) == 0 || minMember < 0 {
+			return nil, fmt.Errorf("invalid min member %d of role %q", minMember, role)
+		}
+		sum += minMember
+	}
+	if sum > int(pg.Spec.MinMember) {
+		return nil, fmt.Errorf("the sum of the min member of all roles %d is greater than the min member %d", sum, pg.Spec.MinMember)
+	}
+	return roleMinMember, nil
+}
+
 // GetRequiredAffinity returns affinity rules specified in PodGroupAffinity.Required
 func (p *PodGroupUnit) GetRequiredAffinity() ([]UnitAffinityTerm, error) {
 	if p.podGroup.Spec.Affinity == nil ||
@@ -338,6 +397,25 @@ func (p *PodGroupUnit) ResetPods() {
 	p.queuedPodInfoMap = make(map[string]*QueuedPodInfo)
 }
 
+// MeetRoleMinMember checks if the number of pods of each role is no less than its min member.
+func MeetRoleMinMember(roleMinMember map[string]int, pods []*v1.Pod) bool {
+	if len(roleMinMember) == 0 {
+		return true
+	}
+	podCountPerRole := make(map[string]int, len(roleMinMember))
+	for _, pod := range pods {
+		if pod != nil {
+			podCountPerRole[podutil.GetPodRole(pod)]++
+		}
+	}
+	for role, minMember := range roleMinMember {
+		if podCountPerRole[role] < minMember {
+			return false
+		}
+	}
+	return true
+}
+
 type SinglePodUnit struct {
 	// key is the identifier of scheduling unit, format is "SinglePodUnit/namespace/podname",
 	// it's intended to store the key instead of generating it every time to reduce the memory cost.
@@ -404,6 +482,10 @@ func (s *SinglePodUnit) ValidatePodCount(podCnt int) bool {
 	return podCnt > 0
 }
 
+func (s *SinglePodUnit) ValidatePodRoles(pods []*v1.Pod) bool {
+	return true
+}
+
 func (s *SinglePodUnit) PodBelongToUnit(pod *v1.Pod) bool {
 	return pod.Namespace == s.Pod.Pod.Namespace && pod.Name == s.Pod.Pod.Name
 }
@@ -529,6 +611,10 @@ func (s *SinglePodUnit) GetMinMember() (int, error) {
 	return 1, nil
 }
 
+func (s *SinglePodUnit) GetRoleMinMember() (map[string]int, error) {
+	return nil, nil
+}
+
 func (s *SinglePodUnit) GetRequiredAffinity() ([]UnitAffinityTerm, error) {
 	return nil, nil
 }
@@ -215,6 +215,106 @@ func TestPodGroupUnit_ReadyToBePopulated(t *testing.T) {
 	}
 }
 
+func TestPodGroupUnit_RoleMinMember(t *testing.T) {
+	createRolePods := func(roles ...string) []*QueuedPodInfo {
+		pods := createQueuedPodInfo(len(roles))
+		for i, role := range roles {
+			pods[i].Pod.Annotations = map[string]string{podutil.PodRoleAnnotationKey: role}
+		}
+		return pods
+	}
+
+	for _, tt := range []struct {
+		desc          string
+		minMember     int32
+		roleMinMember string
+		podsToAdd     []*QueuedPodInfo
+		malformed     bool
+		expectedReady bool
+	}{
+		{
+			desc:          "not role aware",
+			minMember:     2,
+			podsToAdd:     createRolePods("worker", "worker"),
+			expectedReady: true,
+		},
+		{
+			desc:          "all roles meet the min member",
+			minMember:     3,
+			roleMinMember: `{"ps":1,"worker":2}`,
+			podsToAdd:     createRolePods("ps", "worker", "worker", "worker"),
+			expectedReady: true,
+		},
+		{
+			desc:          "enough pods but not enough ps",
+			minMember:     3,
+			roleMinMember: `{"ps":1,"worker":2}`,
+			podsToAdd:     createRolePods("worker", "worker", "worker"),
+			expectedReady: false,
+		},
+		{
+			desc:          "role label is used if the annotation is not set",
+			minMember:     1,
+			roleMinMember: `{"ps":1}`,
+			podsToAdd: []*QueuedPodInfo{{Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				UID:    "0",
+				Labels: map[string]string{podutil.PodRoleAnnotationKey: "ps"},
+			}}}},
+			expectedReady: true,
+		},
+		// Malformed annotations fall back to the min member of the PodGroup.
+		{
+			desc:          "invalid json",
+			minMember:     3,
+			roleMinMember: `{"ps":1,`,
+			podsToAdd:     createRolePods("ps", "worker", "worker"),
+			malformed:     true,
+			expectedReady: true,
+		},
+		{
+			desc:          "negative min member",
+			minMember:     3,
+			roleMinMember: `{"ps":-1}`,
+			podsToAdd:     createRolePods("ps", "worker", "worker"),
+			malformed:     true,
+			expectedReady: true,
+		},
+		{
+			desc:          "sum of role min member greater than min member",
+			minMember:     2,
+			roleMinMember: `{"ps":1,"worker":2}`,
+			podsToAdd:     createRolePods("ps", "worker", "worker"),
+			malformed:     true,
+			expectedReady: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			pg := createPodGroup(pgDefaultNamespace, pgDefaultName, tt.minMember, pgDefaultPriorityClassName)
+			if len(tt.roleMinMember) > 0 {
+				pg.Annotations = map[string]string{podutil.RoleMinMemberAnnotationKey: tt.roleMinMember}
+			}
+			unit := NewPodGroupUnit(pg, pgDefaultPriorityValue)
+			if err := unit.AddPods(tt.podsToAdd); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := ParseRoleMinMember(pg); (err != nil) != tt.malformed {
+				t.Fatalf("expected parsing error %v, got %v", tt.malformed, err)
+			}
+			roleMinMember, err := unit.GetRoleMinMember()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.malformed && roleMinMember != nil {
+				t.Errorf("expected malformed role min member to be ignored, got %v", roleMinMember)
+			}
+			if got := unit.ReadyToBePopulated(); got != tt.expectedReady {
+				t.Errorf("expected ready %v, got %v", tt.expectedReady, got)
+			}
+		})
+	}
+}
+
 func createPodGroup(namespace, name string, minMember int32, priorityClassName string) *schedulingv1a1.PodGroup {
 	pg := &schedulingv1a1.PodGroup{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadaware
+
+import (
+	"time"
+
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "LoadAware"
+
+	// ErrReasonNodeMetricExpired is the reason returned when the node metric is too old to be used.
+	ErrReasonNodeMetricExpired = "NodeMetricInfo has expired and cannot be used"
+	// ErrReasonUsageExceedsThreshold is the reason returned when the node usage exceeds the threshold.
+	ErrReasonUsageExceedsThreshold = "NodeMetricInfo usage exceeds threshold"
+)
+
+// NodeMetricInfoFromCNR builds the profiled usage of the given resource type from the node metric status of the CNR.
+// Nil will be returned if the CNR doesn't exist.
+func NodeMetricInfoFromCNR(cnr *katalystv1alpha1.CustomNodeResource, resourceType podutil.PodResourceType) *framework.LoadAwareNodeMetricInfo {
+	if cnr == nil {
+		return nil
+	}
+	info := &framework.LoadAwareNodeMetricInfo{Name: cnr.Name}
+	if cnr.Status.NodeMetricStatus == nil {
+		return info
+	}
+	info.UpdateTime = cnr.Status.NodeMetricStatus.UpdateTime
+	for _, groupMetric := range cnr.Status.NodeMetricStatus.GroupMetric {
+		if podutil.GetResourceTypeFromQoS(groupMetric.QoSLevel) == resourceType {
+			info.ProfileMilliCPUUsage += groupMetric.GenericUsage.CPU.MilliValue()
+			info.ProfileMEMUsage += groupMetric.GenericUsage.Memory.Value()
+		}
+	}
+	return info
+}
+
+// ValidateNodeMetric checks if the node metric is fresh enough and the usage of the node doesn't
+// exceed the thresholds, which are percentages of the allocatable of the given resource type.
+// Expiration check is skipped if expirationSeconds is not positive.
+func ValidateNodeMetric(nodeMetricInfo *framework.LoadAwareNodeMetricInfo, nodeInfo framework.NodeInfo, resourceType podutil.PodResourceType,
+	expirationSeconds int64, usageThresholds map[v1.ResourceName]int64,
+) *framework.Status {
+	if expirationSeconds > 0 {
+		boundaryTime := metav1.NewTime(time.Now().Add(time.Duration(-expirationSeconds * int64(time.Second))))
+		if nodeMetricInfo.UpdateTime.Before(&boundaryTime) {
+			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonNodeMetricExpired)
+		}
+	}
+
+	if len(usageThresholds) > 0 {
+		allocatable := framework.NewResource(nil)
+		switch resourceType {
+		case podutil.GuaranteedPod:
+			allocatable = nodeInfo.GetGuaranteedAllocatable()
+		case podutil.BestEffortPod:
+			allocatable = nodeInfo.GetBestEffortAllocatable()
+		}
+		for resourceName, threshold := range usageThresholds {
+			// TODO: support more resources.
+			var usage, total int64
+			switch resourceName {
+			case v1.ResourceCPU:
+				usage = nodeMetricInfo.ProfileMilliCPUUsage
+				total = allocatable.MilliCPU
+			case v1.ResourceMemory:
+				usage = nodeMetricInfo.ProfileMEMUsage
+				total = allocatable.Memory
+			}
+			if float64(usage) > float64(threshold)/100.0*float64(total) {
+				// Because we only judge utilization based on metric information, preemption is useless.
+				return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonUsageExceedsThreshold)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepool
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	corelister "k8s.io/client-go/listers/core/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/framework/utils"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// Name is shared by the scheduler and the binder.
+	Name = "LocalStoragePoolChecker"
+
+	// ErrReasonInsufficientLocalStorage is used for insufficient local storage pool predicate error.
+	ErrReasonInsufficientLocalStorage = "node(s) didn't have enough local storage in pool %s"
+)
+
+// LocalStoragePoolHandle provides the local storage pools maintained by LocalStoragePoolStore.
+type LocalStoragePoolHandle interface {
+	// GetNodeLocalStoragePools returns the capacity and requested local storage of the node, keyed by storage class.
+	GetNodeLocalStoragePools(nodeName string) (capacity, requested map[string]int64)
+	// IsLocalStorageClass returns true if the storage class is exposed as a local storage pool by any node.
+	IsLocalStorageClass(storageClass string) bool
+}
+
+// GetPodVolumeRequests returns the storage requested by the volumes of pod, keyed by storage class.
+// Both generic ephemeral volumes and persistent volume claims are taken into account, the latter
+// will be skipped if pvcLister is nil. Claims which have been bound are skipped if skipBoundClaims
+// is set, since their volumes have been provisioned already and won't be requested again by pod.
+func GetPodVolumeRequests(pvcLister corelister.PersistentVolumeClaimLister, pod *v1.Pod, skipBoundClaims bool) map[string]int64 {
+	var requests map[string]int64
+	add := func(storageClassName *string, resources v1.ResourceRequirements) {
+		if storageClassName == nil || len(*storageClassName) == 0 {
+			return
+		}
+		quantity, ok := resources.Requests[v1.ResourceStorage]
+		if !ok {
+			return
+		}
+		if requests == nil {
+			requests = make(map[string]int64)
+		}
+		requests[*storageClassName] += quantity.Value()
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.Ephemeral != nil && volume.Ephemeral.VolumeClaimTemplate != nil:
+			// The claim of generic ephemeral volume is named as `<pod name>-<volume name>`.
+			if skipBoundClaims && isClaimBound(pvcLister, pod.Namespace, pod.Name+"-"+volume.Name) {
+				continue
+			}
+			spec := volume.Ephemeral.VolumeClaimTemplate.Spec
+			add(spec.StorageClassName, spec.Resources)
+		case volume.PersistentVolumeClaim != nil && pvcLister != nil:
+			pvc, err := pvcLister.PersistentVolumeClaims(pod.Namespace).Get(volume.PersistentVolumeClaim.ClaimName)
+			if err != nil || (skipBoundClaims && len(pvc.Spec.VolumeName) > 0) {
+				continue
+			}
+			add(pvc.Spec.StorageClassName, pvc.Spec.Resources)
+		}
+	}
+	return requests
+}
+
+func isClaimBound(pvcLister corelister.PersistentVolumeClaimLister, namespace, name string) bool {
+	if pvcLister == nil {
+		return false
+	}
+	pvc, err := pvcLister.PersistentVolumeClaims(namespace).Get(name)
+	return err == nil && len(pvc.Spec.VolumeName) > 0
+}
+
+// GetPodLocalStorageRequests returns the requests of the incoming pod on local storage pools only.
+// The bound claims are skipped, since they have been provisioned and don't need more storage.
+func GetPodLocalStorageRequests(h LocalStoragePoolHandle, pvcLister corelister.PersistentVolumeClaimLister, pod *v1.Pod) map[string]int64 {
+	requests := GetPodVolumeRequests(pvcLister, pod, true)
+	for storageClass := range requests {
+		if !h.IsLocalStorageClass(storageClass) {
+			delete(requests, storageClass)
+		}
+	}
+	return requests
+}
+
+// PodOp adds or removes the local volume requests of pod in LocalStoragePools. The bound claims
+// are counted as well, since the storage of their volumes is still used by the pod.
+func PodOp(pools *framework.LocalStoragePools, pvcLister corelister.PersistentVolumeClaimLister, pod *v1.Pod, isAdd bool) {
+	nodeName := utils.GetNodeNameFromPod(pod)
+	if len(nodeName) == 0 {
+		return
+	}
+	if isAdd {
+		pools.AddPod(nodeName, podutil.GetPodKey(pod), GetPodVolumeRequests(pvcLister, pod, false))
+	} else {
+		pools.RemovePod(nodeName, podutil.GetPodKey(pod))
+	}
+}
+
+// Fits checks if the local storage pools of the node could satisfy the requests.
+func Fits(h LocalStoragePoolHandle, nodeName string, requests map[string]int64) *framework.Status {
+	if len(requests) == 0 {
+		return nil
+	}
+	capacity, requested := h.GetNodeLocalStoragePools(nodeName)
+	for storageClass, quantity := range requests {
+		if capacity[storageClass]-requested[storageClass] < quantity {
+			return framework.NewStatus(framework.Unschedulable, fmt.Sprintf(ErrReasonInsufficientLocalStorage, storageClass))
+		}
+	}
+	return nil
+}
+
+// Score scores the node according to the available ratio of requested local storage pools after placing the pod.
+// Nodes with more available storage get higher scores if mostAvailable is true, and vice versa.
+func Score(capacity, requested, requests map[string]int64, weights map[string]int64, mostAvailable bool) int64 {
+	var score, weightSum int64
+	for storageClass, quantity := range requests {
+		weight, ok := weights[storageClass]
+		if !ok {
+			weight = 1
+		}
+		total := capacity[storageClass]
+		var availableScore int64
+		if total > 0 {
+			if available := total - requested[storageClass] - quantity; available > 0 {
+				availableScore = available * framework.MaxNodeScore / total
+			}
+		}
+		if !mostAvailable {
+			availableScore = framework.MaxNodeScore - availableScore
+		}
+		score += availableScore * weight
+		weightSum += weight
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return score / weightSum
+}
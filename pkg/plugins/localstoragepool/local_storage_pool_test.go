@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepool
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fakelisters "github.com/kubewharf/godel-scheduler/pkg/framework/api/fake"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func makeClaim(name, storageClass, storage, volumeName string) v1.PersistentVolumeClaim {
+	return v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(storage)}},
+			VolumeName:       volumeName,
+		},
+	}
+}
+
+func TestGetPodVolumeRequests(t *testing.T) {
+	ssd := "ssd"
+	pvcLister := fakelisters.PersistentVolumeClaimLister{
+		makeClaim("pending", "ssd", "10", ""),
+		makeClaim("bound", "ssd", "20", "pv-bound"),
+		makeClaim("p-bound-ephemeral", "ssd", "40", "pv-ephemeral"),
+	}
+	ephemeral := func(name, storage string) v1.Volume {
+		return v1.Volume{
+			Name: name,
+			VolumeSource: v1.VolumeSource{Ephemeral: &v1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &v1.PersistentVolumeClaimTemplate{
+					Spec: v1.PersistentVolumeClaimSpec{
+						StorageClassName: &ssd,
+						Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(storage)}},
+					},
+				},
+			}},
+		}
+	}
+	claim := func(name string) v1.Volume {
+		return v1.Volume{
+			Name:         name,
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: name}},
+		}
+	}
+
+	pod := testinghelper.MakePod().Namespace("default").Name("p").Obj()
+	pod.Spec.Volumes = []v1.Volume{
+		claim("pending"),
+		claim("bound"),
+		claim("unknown"),
+		ephemeral("new-ephemeral", "30"),
+		ephemeral("bound-ephemeral", "40"),
+	}
+
+	// Bound claims have been provisioned, only the pending claim and the new ephemeral volume are requested.
+	expected := map[string]int64{"ssd": 40}
+	if got := GetPodVolumeRequests(pvcLister, pod, true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected requests %v, but got %v", expected, got)
+	}
+	// Bound claims are still used by the pod.
+	expected = map[string]int64{"ssd": 100}
+	if got := GetPodVolumeRequests(pvcLister, pod, false); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected requests %v, but got %v", expected, got)
+	}
+	// Persistent volume claims are skipped without lister.
+	expected = map[string]int64{"ssd": 70}
+	if got := GetPodVolumeRequests(nil, pod, true); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected requests %v, but got %v", expected, got)
+	}
+}
+
+func TestScore(t *testing.T) {
+	capacity := map[string]int64{"ssd": 100, "hdd": 100}
+	requested := map[string]int64{"ssd": 20, "hdd": 60}
+
+	tests := []struct {
+		name          string
+		requests      map[string]int64
+		weights       map[string]int64
+		mostAvailable bool
+		want          int64
+	}{
+		{
+			name:          "most available",
+			requests:      map[string]int64{"ssd": 30},
+			mostAvailable: true,
+			want:          50,
+		},
+		{
+			name:     "least available",
+			requests: map[string]int64{"ssd": 30},
+			want:     50,
+		},
+		{
+			name:          "weighted storage classes",
+			requests:      map[string]int64{"ssd": 30, "hdd": 20},
+			weights:       map[string]int64{"ssd": 3},
+			mostAvailable: true,
+			want:          (50*3 + 20) / 4,
+		},
+		{
+			name:          "unknown storage class",
+			requests:      map[string]int64{"nas": 30},
+			mostAvailable: true,
+			want:          0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Score(capacity, requested, tt.requests, tt.weights, tt.mostAvailable); got != tt.want {
+				t.Errorf("expected score %d, but got %d", tt.want, got)
+			}
+		})
+	}
+}
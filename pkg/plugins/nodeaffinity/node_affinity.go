@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeaffinity
+
+import (
+	"errors"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = "NodeAffinity"
+
+var (
+	ErrNodeSelectorNotMatching = errors.New("node(s) didn't match node selector")
+
+	ErrNodeAffinityNotMatching = errors.New("node(s) didn't match node affinity")
+)
+
+type requiredNodeAffinityTermSelector struct {
+	LabelSelector labels.Selector
+	FieldSelector fields.Selector
+}
+
+// RequiredNodeAffinity holds the parsed nodeSelector and required node affinity terms of a pod.
+type RequiredNodeAffinity struct {
+	nodeLabelSelector                 labels.Selector
+	requiredNodeAffinityTermSelectors []*requiredNodeAffinityTermSelector
+}
+
+// GetRequiredNodeAffinity parses the nodeSelector and required node affinity terms of the pod.
+func GetRequiredNodeAffinity(pod *v1.Pod) *RequiredNodeAffinity {
+	r := &RequiredNodeAffinity{nodeLabelSelector: labels.SelectorFromSet(pod.Spec.NodeSelector)}
+	affinity := pod.Spec.Affinity
+	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		var selectors []*requiredNodeAffinityTermSelector
+		nodeSelectorTerms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		for _, req := range nodeSelectorTerms {
+			// nil or empty term selects no objects
+			if len(req.MatchExpressions) == 0 && len(req.MatchFields) == 0 {
+				continue
+			}
+
+			selector := &requiredNodeAffinityTermSelector{}
+			if len(req.MatchExpressions) != 0 {
+				if labelSelector, err := helper.NodeSelectorRequirementsAsSelector(req.MatchExpressions); err == nil {
+					selector.LabelSelector = labelSelector
+				}
+			}
+			if len(req.MatchFields) != 0 {
+				if fieldSelector, err := helper.NodeSelectorRequirementsAsFieldSelector(req.MatchFields); err == nil {
+					selector.FieldSelector = fieldSelector
+				}
+			}
+
+			if selector.LabelSelector != nil || selector.FieldSelector != nil {
+				selectors = append(selectors, selector)
+			}
+		}
+		r.requiredNodeAffinityTermSelectors = selectors
+	}
+	return r
+}
+
+// Match checks whether the pod is schedulable onto nodes according to
+// the requirements in both NodeAffinity and nodeSelector.
+func (r *RequiredNodeAffinity) Match(pod *v1.Pod, nodeLabels map[string]string, nodeName string) error {
+	// Check if node.Labels match pod.Spec.NodeSelector.
+	if len(pod.Spec.NodeSelector) > 0 && r.nodeLabelSelector != nil {
+		if !r.nodeLabelSelector.Matches(labels.Set(nodeLabels)) {
+			return ErrNodeSelectorNotMatching
+		}
+	}
+
+	// 1. nil NodeSelector matches all nodes (i.e. does not filter out any nodes)
+	// 2. nil []NodeSelectorTerm (equivalent to non-nil empty NodeSelector) matches no nodes
+	// 3. zero-length non-nil []NodeSelectorTerm matches no nodes also, just for simplicity
+	// 4. nil []NodeSelectorRequirement (equivalent to non-nil empty NodeSelectorTerm) matches no nodes
+	// 5. zero-length non-nil []NodeSelectorRequirement matches no nodes also, just for simplicity
+	// 6. non-nil empty NodeSelectorRequirement is not allowed
+	nodeAffinityMatches := true
+	affinity := pod.Spec.Affinity
+	if affinity != nil && affinity.NodeAffinity != nil {
+		nodeAffinity := affinity.NodeAffinity
+		// if no required NodeAffinity requirements, will do no-op, means select all nodes.
+		// TODO: Replace next line with subsequent commented-out line when implement RequiredDuringSchedulingRequiredDuringExecution.
+		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			// if nodeAffinity.RequiredDuringSchedulingRequiredDuringExecution == nil && nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+			return nil
+		}
+
+		// Match node selector for requiredDuringSchedulingIgnoredDuringExecution.
+		if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+			nodeAffinityMatches = nodeAffinityMatches && nodeMatchesNodeSelectorTerms(nodeName, nodeLabels, r.requiredNodeAffinityTermSelectors)
+		}
+
+	}
+
+	if nodeAffinityMatches {
+		return nil
+	}
+	return ErrNodeAffinityNotMatching
+}
+
+// nodeMatchesNodeSelectorTerms checks if a node's labels satisfy a list of node selector terms,
+// terms are ORed, and an empty list of terms will match nothing.
+func nodeMatchesNodeSelectorTerms(nodeName string, nodeLabels labels.Set, selectors []*requiredNodeAffinityTermSelector) bool {
+	nodeFields := fields.Set{util.ObjectNameField: nodeName}
+
+	for _, s := range selectors {
+		if s.LabelSelector != nil {
+			if !s.LabelSelector.Matches(nodeLabels) {
+				continue
+			}
+		}
+		if s.FieldSelector != nil {
+			if !s.FieldSelector.Matches(nodeFields) {
+				continue
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// NodeLabels returns the labels of the node object used by the pod launcher.
+func NodeLabels(launcher podutil.PodLauncher, nodeInfo framework.NodeInfo) map[string]string {
+	switch launcher {
+	case podutil.Kubelet:
+		return nodeInfo.GetNode().Labels
+	case podutil.NodeManager:
+		return nodeInfo.GetNMNode().Labels
+	}
+	return nil
+}
+
+// Fits checks if the node matches the nodeSelector and required node affinity of the pod.
+func Fits(state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	launcher, status := podlauncher.NodeFits(state, pod, nodeInfo)
+	if status != nil {
+		return status
+	}
+	if err := GetRequiredNodeAffinity(pod).Match(pod, NodeLabels(launcher, nodeInfo), nodeInfo.GetNodeName()); err != nil {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, err.Error())
+	}
+	return nil
+}
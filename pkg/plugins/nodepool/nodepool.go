@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+)
+
+const (
+	// Name of the plugin enforcing the node pools, it is shared by the scheduler and the binder.
+	Name = "NodePool"
+	// NodePoolCheckerName is the name of the preemption plugin honoring the node pools, it is shared by
+	// the scheduler and the binder. It takes the args of the NodePool plugin, so that the pools are
+	// configured once for each component.
+	NodePoolCheckerName = "NodePoolChecker"
+
+	// ErrReasonDedicatedNodePool is used when the node belongs to the dedicated node pool of other tenants.
+	ErrReasonDedicatedNodePool = "node(s) belonged to the dedicated node pool of other tenants"
+	// ErrReasonBorrowerPreempt is used when the pod borrowing a node pool tries to preempt the tenants of the pool.
+	ErrReasonBorrowerPreempt = "pods borrowing the node pool could not preempt the tenants of the pool"
+)
+
+// Mode is the mode of a node pool.
+type Mode string
+
+const (
+	// Dedicated pools can only be used by the tenants of the pool.
+	Dedicated Mode = "Dedicated"
+	// Shared pools can be used by all pods, the tenants of the pool have no privilege on it.
+	Shared Mode = "Shared"
+	// Borrowable pools belong to the tenants of the pool, other pods can borrow the idle capacity
+	// of the pool, and the borrowed capacity can be reclaimed by the tenants through preemption.
+	Borrowable Mode = "Borrowable"
+)
+
+var validModes = sets.NewString(string(Dedicated), string(Shared), string(Borrowable))
+
+// Spec is the definition of a node pool, which is converted from the plugin args of scheduler or binder.
+type Spec struct {
+	Name         string
+	Mode         Mode
+	NodeSelector map[string]string
+	Namespaces   []string
+	PodSelector  *metav1.LabelSelector
+}
+
+// ValidateSpecs validates the node pools.
+func ValidateSpecs(path *field.Path, specs []Spec) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, spec := range specs {
+		poolPath := path.Index(i)
+		if len(spec.Name) == 0 {
+			allErrs = append(allErrs, field.Required(poolPath.Child("name"), "node pool name must be set"))
+		} else if names.Has(spec.Name) {
+			allErrs = append(allErrs, field.Duplicate(poolPath.Child("name"), spec.Name))
+		}
+		names.Insert(spec.Name)
+		if !validModes.Has(string(spec.Mode)) {
+			allErrs = append(allErrs, field.NotSupported(poolPath.Child("mode"), spec.Mode, validModes.List()))
+		}
+		if len(spec.NodeSelector) == 0 {
+			allErrs = append(allErrs, field.Required(poolPath.Child("nodeSelector"), "node pool must select nodes"))
+		}
+		if len(spec.Namespaces) == 0 && spec.PodSelector == nil {
+			allErrs = append(allErrs, field.Required(poolPath, "either namespaces or podSelector must be set"))
+		}
+		if spec.PodSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(spec.PodSelector); err != nil {
+				allErrs = append(allErrs, field.Invalid(poolPath.Child("podSelector"), spec.PodSelector, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// Pool is a node pool with compiled selectors.
+type Pool struct {
+	Name string
+	Mode Mode
+
+	nodeSelector labels.Selector
+	namespaces   sets.String
+	podSelector  labels.Selector
+}
+
+// HasTenant returns whether the pod belongs to the tenants of the pool.
+func (p *Pool) HasTenant(pod *v1.Pod) bool {
+	if p.namespaces.Has(pod.Namespace) {
+		return true
+	}
+	return p.podSelector != nil && p.podSelector.Matches(labels.Set(pod.Labels))
+}
+
+// Pools are the node pools in order, a node belongs to the first pool matching it.
+type Pools []*Pool
+
+// NewPools validates and compiles the node pools.
+func NewPools(specs []Spec) (Pools, error) {
+	if err := ValidateSpecs(field.NewPath("pools"), specs).ToAggregate(); err != nil {
+		return nil, err
+	}
+	pools := make(Pools, 0, len(specs))
+	for _, spec := range specs {
+		pool := &Pool{
+			Name:         spec.Name,
+			Mode:         spec.Mode,
+			nodeSelector: labels.SelectorFromSet(spec.NodeSelector),
+			namespaces:   sets.NewString(spec.Namespaces...),
+		}
+		if spec.PodSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.PodSelector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pod selector of node pool %s: %v", spec.Name, err)
+			}
+			pool.podSelector = selector
+		}
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// PoolOfNode returns the pool the node belongs to, nil means the node doesn't belong to any pool.
+func (ps Pools) PoolOfNode(nodeInfo framework.NodeInfo) *Pool {
+	if len(ps) == 0 || nodeInfo == nil {
+		return nil
+	}
+	var nodeLabels labels.Set
+	if node := nodeInfo.GetNode(); node != nil {
+		nodeLabels = node.Labels
+	} else if nmNode := nodeInfo.GetNMNode(); nmNode != nil {
+		nodeLabels = nmNode.Labels
+	}
+	for _, pool := range ps {
+		if pool.nodeSelector.Matches(nodeLabels) {
+			return pool
+		}
+	}
+	return nil
+}
+
+// Fits rejects the nodes belonging to the dedicated node pools of other tenants.
+func (ps Pools) Fits(pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	pool := ps.PoolOfNode(nodeInfo)
+	if pool == nil || pool.Mode != Dedicated || pool.HasTenant(pod) {
+		return nil
+	}
+	return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonDedicatedNodePool)
+}
+
+// CheckVictim lets the tenants of borrowable node pools reclaim the capacity borrowed by other pods,
+// and prevents the borrowers from preempting the tenants. The pool is the one of the victim's node.
+func CheckVictim(pool *Pool, preemptor, victim *v1.Pod) (framework.Code, string) {
+	if pool == nil || pool.Mode != Borrowable {
+		return framework.PreemptionNotSure, ""
+	}
+	preemptorIsTenant, victimIsTenant := pool.HasTenant(preemptor), pool.HasTenant(victim)
+	switch {
+	case preemptorIsTenant && !victimIsTenant:
+		return framework.PreemptionSucceed, ""
+	case !preemptorIsTenant && victimIsTenant:
+		return framework.PreemptionFail, ErrReasonBorrowerPreempt
+	default:
+		return framework.PreemptionNotSure, ""
+	}
+}
@@ -107,10 +107,21 @@ func FitsRequest(podRequest *PodRequest, nodeInfo framework.NodeInfo, ignoredExt
 	}
 }
 
-const ErrReasonTooManyPods = "node(s) had too many pods"
+const (
+	ErrReasonTooManyPods = "node(s) had too many pods"
+
+	errReasonRequestNotFitPrefix = "node(s) could not satisfy "
+	errReasonRequestNotFitSuffix = " request"
+)
 
 func ErrReasonRequestNotFitMessageFunc(request, resource string) string {
-	return "node(s) could not satisfy " + request + " " + resource + " " + "request"
+	return errReasonRequestNotFitPrefix + request + " " + resource + errReasonRequestNotFitSuffix
+}
+
+// IsInsufficientResourceReason returns true if the reason is reported because the node has not enough resources left.
+func IsInsufficientResourceReason(reason string) bool {
+	return reason == ErrReasonTooManyPods ||
+		(strings.HasPrefix(reason, errReasonRequestNotFitPrefix) && strings.HasSuffix(reason, errReasonRequestNotFitSuffix))
 }
 
 func fitsRequestCore(
@@ -757,3 +757,23 @@ func TestFitsRequestCoreForReservation(t *testing.T) {
 		})
 	}
 }
+
+func TestIsInsufficientResourceReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   bool
+	}{
+		{reason: ErrReasonTooManyPods, want: true},
+		{reason: ErrReasonRequestNotFitMessageFunc("2", string(v1.ResourceCPU)), want: true},
+		{reason: ErrReasonRequestNotFitMessageFunc("1Gi", string(v1.ResourceMemory)), want: true},
+		{reason: "node(s) didn't match Pod's node affinity/selector", want: false},
+		{reason: "node(s) had taint {key: value}, that the pod didn't tolerate", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			if got := IsInsufficientResourceReason(tt.reason); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgetchecker
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/framework/utils"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// PreemptionBudgetCheckerName is shared by the scheduler and the binder, so that the same
+// preemption budget could be configured in both components.
+const PreemptionBudgetCheckerName = "PreemptionBudgetChecker"
+
+// PreemptionHistory provides the preemption records maintained by PreemptionBudgetStore.
+type PreemptionHistory interface {
+	GetPreemptionCountOfOwner(ownerKey string, since time.Time) int64
+	GetPreemptionCountOfNamespace(namespace string, since time.Time) int64
+	GetLastPreemptionTimeOfOwner(ownerKey string) time.Time
+}
+
+// Budget limits how often the pods of the same owner or namespace could be preempted.
+// Zero value of each limit means no limitation.
+type Budget struct {
+	Window                 time.Duration
+	MaxVictimsPerOwner     int64
+	MaxVictimsPerNamespace int64
+	MinIntervalPerOwner    time.Duration
+}
+
+// NewBudget builds the budget from plugin args, the default window will be used if windowSeconds is zero.
+func NewBudget(windowSeconds, maxVictimsPerOwner, maxVictimsPerNamespace, minIntervalSecondsPerOwner int64) *Budget {
+	if windowSeconds <= 0 {
+		windowSeconds = config.DefaultPreemptionBudgetWindowSeconds
+	}
+	return &Budget{
+		Window:                 time.Duration(windowSeconds) * time.Second,
+		MaxVictimsPerOwner:     maxVictimsPerOwner,
+		MaxVictimsPerNamespace: maxVictimsPerNamespace,
+		MinIntervalPerOwner:    time.Duration(minIntervalSecondsPerOwner) * time.Second,
+	}
+}
+
+func (b *Budget) IsEmpty() bool {
+	return b.MaxVictimsPerOwner <= 0 && b.MaxVictimsPerNamespace <= 0 && b.MinIntervalPerOwner <= 0
+}
+
+// BudgetUsage records the victims which have been chosen in the current preemption but
+// are not reflected in PreemptionHistory yet.
+type BudgetUsage struct {
+	owners     map[string]int64
+	namespaces map[string]int64
+}
+
+func NewBudgetUsage() *BudgetUsage {
+	return &BudgetUsage{
+		owners:     make(map[string]int64),
+		namespaces: make(map[string]int64),
+	}
+}
+
+func (u *BudgetUsage) Clone() framework.StateData {
+	clone := NewBudgetUsage()
+	for k, v := range u.owners {
+		clone.owners[k] = v
+	}
+	for k, v := range u.namespaces {
+		clone.namespaces[k] = v
+	}
+	return clone
+}
+
+// Add counts the victim into the usage.
+func (u *BudgetUsage) Add(victim *v1.Pod) {
+	if ownerKey := podutil.GetPodOwner(victim); len(ownerKey) > 0 {
+		u.owners[ownerKey]++
+	}
+	u.namespaces[victim.Namespace]++
+}
+
+// CheckBudget checks whether the victim could be preempted without exceeding the budget.
+func CheckBudget(budget *Budget, history PreemptionHistory, usage *BudgetUsage, victim *v1.Pod, now time.Time) (framework.Code, string) {
+	if budget == nil || budget.IsEmpty() || history == nil {
+		return framework.PreemptionNotSure, ""
+	}
+	since := now.Add(-budget.Window)
+	ownerKey := podutil.GetPodOwner(victim)
+
+	if len(ownerKey) > 0 {
+		if budget.MinIntervalPerOwner > 0 && usage.owners[ownerKey] == 0 {
+			// Victims of the same owner chosen by the current preemption are regarded as one preemption.
+			if last := history.GetLastPreemptionTimeOfOwner(ownerKey); !last.IsZero() && now.Sub(last) < budget.MinIntervalPerOwner {
+				return framework.PreemptionFail, fmt.Sprintf("owner %s was preempted within %v", ownerKey, budget.MinIntervalPerOwner)
+			}
+		}
+		if budget.MaxVictimsPerOwner > 0 {
+			if count := history.GetPreemptionCountOfOwner(ownerKey, since) + usage.owners[ownerKey]; count >= budget.MaxVictimsPerOwner {
+				return framework.PreemptionFail, fmt.Sprintf("exceeding preemption budget of owner %s", ownerKey)
+			}
+		}
+	}
+	if budget.MaxVictimsPerNamespace > 0 {
+		if count := history.GetPreemptionCountOfNamespace(victim.Namespace, since) + usage.namespaces[victim.Namespace]; count >= budget.MaxVictimsPerNamespace {
+			return framework.PreemptionFail, fmt.Sprintf("exceeding preemption budget of namespace %s", victim.Namespace)
+		}
+	}
+	return framework.PreemptionNotSure, ""
+}
+
+// RecordVictims adds the victims nominated by the preemptor into records.
+func RecordVictims(records *framework.PreemptionRecords, handler commoncache.CacheHandler, preemptor *v1.Pod, now time.Time) {
+	nominatedNode, err := utils.GetPodNominatedNode(preemptor)
+	if err != nil {
+		// Ignore this error.
+		return
+	}
+	for _, victim := range nominatedNode.VictimPods {
+		var victimPod *v1.Pod
+		if ps, _ := handler.GetPodState(victim.UID); ps != nil {
+			victimPod = ps.Pod
+		}
+		records.AddRecord(NewPreemptionRecord(preemptor, victim, victimPod, now))
+	}
+}
+
+// PreemptionTimeOfBoundPod approximates when the preemption of the bound preemptor was confirmed by
+// the time it was scheduled, the given time is returned if it's unknown.
+func PreemptionTimeOfBoundPod(preemptor *v1.Pod, now time.Time) time.Time {
+	for _, condition := range preemptor.Status.Conditions {
+		if condition.Type == v1.PodScheduled && condition.Status == v1.ConditionTrue && !condition.LastTransitionTime.IsZero() {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return now
+}
+
+// ForgetVictims removes the victims nominated by the preemptor from records.
+func ForgetVictims(records *framework.PreemptionRecords, preemptor *v1.Pod) {
+	nominatedNode, err := utils.GetPodNominatedNode(preemptor)
+	if err != nil {
+		// Ignore this error.
+		return
+	}
+	preemptorKey := podutil.GeneratePodKey(preemptor)
+	for _, victim := range nominatedNode.VictimPods {
+		records.RemoveRecord(podutil.GetPodFullKey(victim.Namespace, victim.Name, victim.UID), preemptorKey)
+	}
+}
+
+// NewPreemptionRecord builds the record of victim for the given preemptor, the victim's owner
+// will be left empty if the victim pod is unknown.
+func NewPreemptionRecord(preemptor *v1.Pod, victim framework.VictimPod, victimPod *v1.Pod, now time.Time) *framework.PreemptionRecord {
+	record := &framework.PreemptionRecord{
+		VictimKey:    podutil.GetPodFullKey(victim.Namespace, victim.Name, victim.UID),
+		PreemptorKey: podutil.GeneratePodKey(preemptor),
+		Namespace:    victim.Namespace,
+		Timestamp:    now,
+	}
+	if victimPod != nil {
+		record.OwnerKey = podutil.GetPodOwner(victimPod)
+	}
+	return record
+}
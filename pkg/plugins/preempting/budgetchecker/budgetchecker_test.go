@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package budgetchecker
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+type fakeHistory struct {
+	*framework.PreemptionRecords
+}
+
+func (h *fakeHistory) GetPreemptionCountOfOwner(ownerKey string, since time.Time) int64 {
+	return h.CountByOwner(ownerKey, since)
+}
+
+func (h *fakeHistory) GetPreemptionCountOfNamespace(namespace string, since time.Time) int64 {
+	return h.CountByNamespace(namespace, since)
+}
+
+func (h *fakeHistory) GetLastPreemptionTimeOfOwner(ownerKey string) time.Time {
+	return h.LastPreemptionTimeOfOwner(ownerKey)
+}
+
+func TestCheckBudget(t *testing.T) {
+	now := time.Now()
+	ownerRef := metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs", UID: "rs", Controller: func() *bool { b := true; return &b }()}
+	victim := testing_helper.MakePod().Namespace("ns").Name("v").UID("v").ControllerRef(ownerRef).Obj()
+	ownerKey := podutil.GetPodOwner(victim)
+
+	tests := []struct {
+		name         string
+		budget       *Budget
+		records      []*framework.PreemptionRecord
+		usage        []*v1.Pod
+		expectedCode framework.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "empty budget",
+			budget:       NewBudget(0, 0, 0, 0),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", OwnerKey: ownerKey, Namespace: "ns", Timestamp: now}},
+			expectedCode: framework.PreemptionNotSure,
+		},
+		{
+			name:         "exceeding budget of owner",
+			budget:       NewBudget(60, 2, 0, 0),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", OwnerKey: ownerKey, Namespace: "ns", Timestamp: now}},
+			usage:        []*v1.Pod{testing_helper.MakePod().Namespace("ns").Name("v2").UID("v2").ControllerRef(ownerRef).Obj()},
+			expectedCode: framework.PreemptionFail,
+			expectedMsg:  "exceeding preemption budget of owner " + ownerKey,
+		},
+		{
+			name:         "records out of window",
+			budget:       NewBudget(60, 1, 1, 0),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", OwnerKey: ownerKey, Namespace: "ns", Timestamp: now.Add(-2 * time.Minute)}},
+			expectedCode: framework.PreemptionNotSure,
+		},
+		{
+			name:         "exceeding budget of namespace",
+			budget:       NewBudget(60, 0, 2, 0),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", Namespace: "ns", Timestamp: now}, {VictimKey: "v2", Namespace: "ns", Timestamp: now}},
+			expectedCode: framework.PreemptionFail,
+			expectedMsg:  "exceeding preemption budget of namespace ns",
+		},
+		{
+			name:         "owner preempted recently",
+			budget:       NewBudget(60, 0, 0, 30),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", OwnerKey: ownerKey, Namespace: "ns", Timestamp: now.Add(-10 * time.Second)}},
+			expectedCode: framework.PreemptionFail,
+			expectedMsg:  "owner " + ownerKey + " was preempted within 30s",
+		},
+		{
+			name:         "owner chosen by the current preemption",
+			budget:       NewBudget(60, 0, 0, 30),
+			records:      []*framework.PreemptionRecord{{VictimKey: "v1", OwnerKey: ownerKey, Namespace: "ns", Timestamp: now.Add(-10 * time.Second)}},
+			usage:        []*v1.Pod{testing_helper.MakePod().Namespace("ns").Name("v2").UID("v2").ControllerRef(ownerRef).Obj()},
+			expectedCode: framework.PreemptionNotSure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := &fakeHistory{framework.NewPreemptionRecords()}
+			for _, record := range tt.records {
+				history.AddRecord(record)
+			}
+			usage := NewBudgetUsage()
+			for _, pod := range tt.usage {
+				usage.Add(pod)
+			}
+			gotCode, gotMsg := CheckBudget(tt.budget, history, usage, victim, now)
+			if gotCode != tt.expectedCode || gotMsg != tt.expectedMsg {
+				t.Errorf("expected (%v, %q), but got (%v, %q)", tt.expectedCode, tt.expectedMsg, gotCode, gotMsg)
+			}
+		})
+	}
+}
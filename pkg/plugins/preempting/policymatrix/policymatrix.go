@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymatrix
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// PreemptionPolicyMatrixName is shared by the scheduler and the binder, so that the same
+// policy matrix could be configured in both components.
+const PreemptionPolicyMatrixName = "PreemptionPolicyMatrix"
+
+type rule struct {
+	victims                map[string]bool
+	maxPriorityGap         *int64
+	allowCrossResourceType bool
+}
+
+// Matrix decides who may preempt whom according to the configured classes and rules.
+type Matrix struct {
+	byWorkloadClass map[string]string
+	byPriorityClass map[string]string
+	rules           map[string]*rule
+}
+
+// NewMatrix validates the classes and rules configured in the scheduler or the binder and builds the matrix.
+func NewMatrix(classes []config.PreemptionClass, rules []config.PreemptionRule) (*Matrix, error) {
+	if err := Validate(classes, rules).ToAggregate(); err != nil {
+		return nil, err
+	}
+	m := &Matrix{
+		byWorkloadClass: make(map[string]string),
+		byPriorityClass: make(map[string]string),
+		rules:           make(map[string]*rule, len(rules)),
+	}
+	for _, c := range classes {
+		for _, name := range c.WorkloadClasses {
+			m.byWorkloadClass[name] = c.Name
+		}
+		for _, name := range c.PriorityClassNames {
+			m.byPriorityClass[name] = c.Name
+		}
+	}
+	for _, r := range rules {
+		victims := make(map[string]bool, len(r.Victims))
+		for _, v := range r.Victims {
+			victims[v] = true
+		}
+		m.rules[r.Preemptor] = &rule{
+			victims:                victims,
+			maxPriorityGap:         r.MaxPriorityGap,
+			allowCrossResourceType: r.AllowCrossResourceType,
+		}
+	}
+	return m, nil
+}
+
+// Validate checks that the class names, PriorityClass names and workload classes are unique,
+// and the rules refer to the declared classes.
+func Validate(classes []config.PreemptionClass, rules []config.PreemptionRule) field.ErrorList {
+	var allErrs field.ErrorList
+	classNames, priorityClasses, workloadClasses := sets.NewString(), sets.NewString(), sets.NewString()
+	for i, class := range classes {
+		classPath := field.NewPath("classes").Index(i)
+		if len(class.Name) == 0 {
+			allErrs = append(allErrs, field.Required(classPath.Child("name"), "class name must be set"))
+		} else if classNames.Has(class.Name) {
+			allErrs = append(allErrs, field.Duplicate(classPath.Child("name"), class.Name))
+		}
+		classNames.Insert(class.Name)
+		if len(class.PriorityClassNames) == 0 && len(class.WorkloadClasses) == 0 {
+			allErrs = append(allErrs, field.Required(classPath, "priorityClassNames or workloadClasses must be set"))
+		}
+		for j, name := range class.PriorityClassNames {
+			if priorityClasses.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(classPath.Child("priorityClassNames").Index(j), name))
+			}
+			priorityClasses.Insert(name)
+		}
+		for j, name := range class.WorkloadClasses {
+			if workloadClasses.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(classPath.Child("workloadClasses").Index(j), name))
+			}
+			workloadClasses.Insert(name)
+		}
+	}
+	preemptors := sets.NewString()
+	for i, rule := range rules {
+		rulePath := field.NewPath("rules").Index(i)
+		if !classNames.Has(rule.Preemptor) {
+			allErrs = append(allErrs, field.NotFound(rulePath.Child("preemptor"), rule.Preemptor))
+		} else if preemptors.Has(rule.Preemptor) {
+			allErrs = append(allErrs, field.Duplicate(rulePath.Child("preemptor"), rule.Preemptor))
+		}
+		preemptors.Insert(rule.Preemptor)
+		for j, victim := range rule.Victims {
+			if !classNames.Has(victim) {
+				allErrs = append(allErrs, field.NotFound(rulePath.Child("victims").Index(j), victim))
+			}
+		}
+		if rule.MaxPriorityGap != nil && *rule.MaxPriorityGap < 0 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("maxPriorityGap"), *rule.MaxPriorityGap, "must be non-negative"))
+		}
+	}
+	return allErrs
+}
+
+func (m *Matrix) IsEmpty() bool {
+	return m == nil || len(m.rules) == 0
+}
+
+// ClassOf returns the class of the pod. The PriorityClass name is authoritative since its use could be
+// restricted by quota and admission, the workload class annotation, which any pod author could set, is
+// only used if the PriorityClass does not belong to any class. Empty string is returned if the pod does
+// not belong to any class.
+func (m *Matrix) ClassOf(pod *v1.Pod) string {
+	if class, ok := m.byPriorityClass[pod.Spec.PriorityClassName]; ok {
+		return class
+	}
+	if workloadClass, ok := pod.Annotations[podutil.WorkloadClassAnnotationKey]; ok {
+		return m.byWorkloadClass[workloadClass]
+	}
+	return ""
+}
+
+// CheckPolicy checks whether the preemptor is allowed to preempt the victim.
+// PreemptionNotSure is returned if the preemptor is not governed by the matrix, so that the
+// decision is left to other plugins. Otherwise, the matrix works as an allowlist: victims
+// which are not in the allowed classes of the preemptor could not be preempted.
+func CheckPolicy(m *Matrix, preemptor, victim *v1.Pod) (framework.Code, string) {
+	if m.IsEmpty() {
+		return framework.PreemptionNotSure, ""
+	}
+	preemptorClass := m.ClassOf(preemptor)
+	if len(preemptorClass) == 0 {
+		return framework.PreemptionNotSure, ""
+	}
+	r, ok := m.rules[preemptorClass]
+	if !ok {
+		return framework.PreemptionFail, fmt.Sprintf("pods of class %s are not allowed to preempt", preemptorClass)
+	}
+	victimClass := m.ClassOf(victim)
+	if !r.victims[victimClass] {
+		if len(victimClass) == 0 {
+			return framework.PreemptionFail, fmt.Sprintf("pods of class %s are not allowed to preempt pods without class", preemptorClass)
+		}
+		return framework.PreemptionFail, fmt.Sprintf("pods of class %s are not allowed to preempt pods of class %s", preemptorClass, victimClass)
+	}
+	if r.maxPriorityGap != nil {
+		gap := int64(podutil.GetPodPriority(preemptor)) - int64(podutil.GetPodPriority(victim))
+		if gap > *r.maxPriorityGap {
+			return framework.PreemptionFail, fmt.Sprintf("priority gap %d exceeds the limit %d of class %s", gap, *r.maxPriorityGap, preemptorClass)
+		}
+	}
+	if !r.allowCrossResourceType {
+		preemptorType, err := podutil.GetPodResourceType(preemptor)
+		if err != nil {
+			return framework.PreemptionFail, err.Error()
+		}
+		victimType, err := podutil.GetPodResourceType(victim)
+		if err != nil {
+			return framework.PreemptionFail, err.Error()
+		}
+		if preemptorType != victimType {
+			return framework.PreemptionFail, fmt.Sprintf("pods of class %s are not allowed to preempt pods of different resource type", preemptorClass)
+		}
+	}
+	return framework.PreemptionSucceed, ""
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymatrix
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	matrix, err := NewMatrix(
+		[]config.PreemptionClass{
+			{Name: "online", PriorityClassNames: []string{"online-high", "online-low"}},
+			{Name: "batch", PriorityClassNames: []string{"batch"}, WorkloadClasses: []string{"offline"}},
+			{Name: "best-effort", PriorityClassNames: []string{"best-effort"}},
+			{Name: "system", PriorityClassNames: []string{"system"}},
+		},
+		[]config.PreemptionRule{
+			{Preemptor: "online", Victims: []string{"batch", "best-effort"}, MaxPriorityGap: pointer.Int64(100), AllowCrossResourceType: true},
+			{Preemptor: "batch", Victims: []string{"best-effort"}},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, err := NewMatrix(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noGap, err := NewMatrix(
+		[]config.PreemptionClass{
+			{Name: "online", PriorityClassNames: []string{"online-high"}},
+			{Name: "batch", PriorityClassNames: []string{"batch"}},
+		},
+		[]config.PreemptionRule{{Preemptor: "online", Victims: []string{"batch"}, MaxPriorityGap: pointer.Int64(0), AllowCrossResourceType: true}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := func(priorityClass string, priority int32) *testing_helper.PodWrapper {
+		return testing_helper.MakePod().PriorityClassName(priorityClass).Priority(priority)
+	}
+
+	tests := []struct {
+		name      string
+		matrix    *Matrix
+		preemptor *v1.Pod
+		victim    *v1.Pod
+		want      framework.Code
+	}{
+		{
+			name:      "empty matrix",
+			matrix:    empty,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("online-low", 10).Obj(),
+			want:      framework.PreemptionNotSure,
+		},
+		{
+			name:      "preemptor without class",
+			matrix:    matrix,
+			preemptor: pod("unknown", 100).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionNotSure,
+		},
+		{
+			name:      "preemptor class without rule",
+			matrix:    matrix,
+			preemptor: pod("system", 1000).Obj(),
+			victim:    pod("best-effort", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "online preempts batch",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "online could not preempt online",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("online-low", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "online could not preempt pods without class",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("", 0).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "priority gap exceeds the limit",
+			matrix:    matrix,
+			preemptor: pod("online-high", 200).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "zero priority gap allows victims of the same priority",
+			matrix:    noGap,
+			preemptor: pod("online-high", 10).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "zero priority gap rejects victims of lower priority",
+			matrix:    noGap,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "priority class takes precedence over workload class of victim",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("online-low", 10).Annotation(podutil.WorkloadClassAnnotationKey, "offline").Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "priority class takes precedence over workload class of preemptor",
+			matrix:    matrix,
+			preemptor: pod("best-effort", 100).Annotation(podutil.WorkloadClassAnnotationKey, "offline").Obj(),
+			victim:    pod("best-effort", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "workload class is used if priority class is not in any class",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("unknown", 10).Annotation(podutil.WorkloadClassAnnotationKey, "offline").Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "batch preempts best-effort of the same resource type",
+			matrix:    matrix,
+			preemptor: pod("batch", 10).Obj(),
+			victim:    pod("best-effort", 1).Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "batch could not preempt best-effort of different resource type",
+			matrix:    matrix,
+			preemptor: pod("batch", 10).Obj(),
+			victim:    pod("best-effort", 1).Annotation(podutil.PodResourceTypeAnnotationKey, string(podutil.BestEffortPod)).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "online preempts batch of different resource type",
+			matrix:    matrix,
+			preemptor: pod("online-high", 100).Obj(),
+			victim:    pod("batch", 10).Annotation(podutil.PodResourceTypeAnnotationKey, string(podutil.BestEffortPod)).Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "batch preemptor with invalid resource type",
+			matrix:    matrix,
+			preemptor: pod("batch", 10).Annotation(podutil.PodResourceTypeAnnotationKey, "invalid").Obj(),
+			victim:    pod("best-effort", 1).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "batch victim with invalid resource type",
+			matrix:    matrix,
+			preemptor: pod("batch", 10).Obj(),
+			victim:    pod("best-effort", 1).Annotation(podutil.PodResourceTypeAnnotationKey, "invalid").Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "batch could not preempt online",
+			matrix:    matrix,
+			preemptor: pod("batch", 1000).Obj(),
+			victim:    pod("online-low", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, msg := CheckPolicy(tt.matrix, tt.preemptor, tt.victim); got != tt.want {
+				t.Errorf("expected %v, got %v: %s", tt.want, got, msg)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		classes []config.PreemptionClass
+		rules   []config.PreemptionRule
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			classes: []config.PreemptionClass{
+				{Name: "online", PriorityClassNames: []string{"online"}},
+				{Name: "batch", WorkloadClasses: []string{"offline"}},
+			},
+			rules:   []config.PreemptionRule{{Preemptor: "online", Victims: []string{"batch"}, MaxPriorityGap: pointer.Int64(10)}},
+			wantErr: false,
+		},
+		{
+			name:    "class without name",
+			classes: []config.PreemptionClass{{PriorityClassNames: []string{"online"}}},
+			wantErr: true,
+		},
+		{
+			name: "duplicated class name",
+			classes: []config.PreemptionClass{
+				{Name: "online", PriorityClassNames: []string{"online-high"}},
+				{Name: "online", PriorityClassNames: []string{"online-low"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "class without priority classes and workload classes",
+			classes: []config.PreemptionClass{{Name: "online"}},
+			wantErr: true,
+		},
+		{
+			name: "priority class in multiple classes",
+			classes: []config.PreemptionClass{
+				{Name: "online", PriorityClassNames: []string{"shared"}},
+				{Name: "batch", PriorityClassNames: []string{"shared"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "workload class in multiple classes",
+			classes: []config.PreemptionClass{
+				{Name: "online", WorkloadClasses: []string{"shared"}},
+				{Name: "batch", WorkloadClasses: []string{"shared"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "rule with unknown preemptor",
+			classes: []config.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+			rules:   []config.PreemptionRule{{Preemptor: "unknown", Victims: []string{"online"}}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated rules of the same preemptor",
+			classes: []config.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+			rules: []config.PreemptionRule{
+				{Preemptor: "online", Victims: []string{"online"}},
+				{Preemptor: "online", Victims: []string{"online"}},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "rule with unknown victim",
+			classes: []config.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+			rules:   []config.PreemptionRule{{Preemptor: "online", Victims: []string{"unknown"}}},
+			wantErr: true,
+		},
+		{
+			name:    "negative priority gap",
+			classes: []config.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+			rules:   []config.PreemptionRule{{Preemptor: "online", Victims: []string{"online"}, MaxPriorityGap: pointer.Int64(-1)}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.classes, tt.rules)
+			if gotErr := len(errs) > 0; gotErr != tt.wantErr {
+				t.Errorf("expected error: %v, got: %v", tt.wantErr, errs)
+			}
+			if _, err := NewMatrix(tt.classes, tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("expected NewMatrix error: %v, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tainttoleration
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
+	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "TaintToleration"
+	// ErrReasonTemplate is the reason returned when the pod doesn't tolerate a taint of the node.
+	ErrReasonTemplate = "node(s) had taint {%s: %s}, that the pod didn't tolerate"
+)
+
+// Fits checks if the pod tolerates all the NoSchedule and NoExecute taints of the node.
+func Fits(state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	launcher, status := podlauncher.NodeFits(state, pod, nodeInfo)
+	if status != nil {
+		return status
+	}
+
+	var taints []v1.Taint
+	switch launcher {
+	case podutil.Kubelet:
+		taints = nodeInfo.GetNode().Spec.Taints
+	case podutil.NodeManager:
+		taints = nodeInfo.GetNMNode().Spec.Taints
+	}
+
+	filterPredicate := func(t *v1.Taint) bool {
+		// PodToleratesNodeTaints is only interested in NoSchedule and NoExecute taints.
+		return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
+	}
+
+	taint, isUntolerated := helper.FindMatchingUntoleratedTaint(taints, pod.Spec.Tolerations, filterPredicate)
+	if !isUntolerated {
+		return nil
+	}
+	return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf(ErrReasonTemplate, taint.Key, taint.Value))
+}
@@ -51,6 +51,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&NodeResourcesBalancedAllocatedArgs{},
 		&LocalStoragePoolCheckerArgs{},
 		&LoadAwareArgs{},
+		&PreemptionBudgetCheckerArgs{},
+		&PreemptionPolicyMatrixArgs{},
+		&NodePoolArgs{},
+		&NetworkTopologyArgs{},
 	)
 	return nil
 }
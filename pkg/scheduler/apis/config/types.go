@@ -23,10 +23,12 @@ import (
 	"net"
 	"strconv"
 
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config/v1alpha1"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 
 	"sigs.k8s.io/yaml"
@@ -88,6 +90,11 @@ const (
 	// for unschedulable units. To change the default unitMaxBackoffDurationSeconds used by the
 	// scheduler, update the ComponentConfig value in defaults.go
 	DefaultUnitMaxBackoffInSeconds = 300
+	// DefaultBackfillMaxReservationSeconds is the default value for the max duration of backfill reservations.
+	DefaultBackfillMaxReservationSeconds = 3600
+	// DefaultScaleUpHintPendingThresholdSeconds is the default value for how long a unit must have been pending
+	// before scale-up hints are computed for it.
+	DefaultScaleUpHintPendingThresholdSeconds = 300
 	// DefaultDisablePreemption is the default value for the option to disable preemption ability
 	// for unschedulable pods.
 	DefaultDisablePreemption        = true
@@ -173,6 +180,22 @@ type GodelSchedulerConfiguration struct {
 	// reserved resources will be released after a period of time.
 	ReservationTimeOutSeconds int64
 
+	// CacheComparePeriodSeconds is the period for comparing the scheduler cache with informers in
+	// the background, the differences will be reported as metrics and events. 0 disables it.
+	CacheComparePeriodSeconds int64
+	// EnableCacheSelfHealing resyncs the objects missed or left behind by the scheduler cache
+	// when they are found by the background cache comparer.
+	EnableCacheSelfHealing bool
+	// EnableCacheInspection exposes the cache inspection endpoints, e.g. /debug/cache/nodes, on the
+	// healthz and metrics servers regardless of profiling.
+	EnableCacheInspection bool
+	// PodMetricsSamplePeriodSeconds is the period for sampling the usage of running pods from
+	// metrics.k8s.io, which is used to build the usage profile of each owner. 0 disables it.
+	PodMetricsSamplePeriodSeconds int64
+	// OvercommitPolicies are the named policies transforming the allocatable of nodes, a node selects its policy
+	// by the label godel.bytedance.com/overcommit-policy. They should be the same as the ones of the binder.
+	OvercommitPolicies []defaultsconfig.OvercommitPolicy
+
 	// TODO: update the comment
 	// Profiles are scheduling profiles that kube-scheduler supports. Pods can
 	// choose to be scheduled under a particular profile by setting its associated
@@ -244,6 +267,61 @@ type GodelSchedulerProfile struct {
 	// If specified, it must be greater than or equal to unitInitialBackoffSeconds. If this value is null,
 	// the default value (10s) will be used.
 	UnitMaxBackoffSeconds *int64
+
+	// UnitPlugins specifies the unit plugins invoked at each extension point of unit scheduling.
+	// The default unit plugins are used for the omitted extension points.
+	UnitPlugins *UnitPlugins
+
+	// UnitPluginConfigs is an optional set of custom plugin arguments for each unit plugin.
+	UnitPluginConfigs []PluginConfig
+
+	// Backfill enables backfill scheduling if it is specified.
+	Backfill *BackfillConfig
+
+	// ScaleUpHint enables scale-up hints for long pending units if it is specified.
+	ScaleUpHint *ScaleUpHintConfig
+}
+
+// BackfillConfig holds the parameters of backfill scheduling. A PodGroup that is blocked gets a time-based
+// reservation on a set of nodes, and other units are only admitted onto those nodes if they are predicted
+// to finish before the reservation time or they are preemptible by the PodGroup.
+type BackfillConfig struct {
+	// MinMember is the min member of the PodGroups that get reservations when blocked, zero means all PodGroups.
+	MinMember int32 `json:"minMember,omitempty"`
+
+	// MaxReservationSeconds bounds how far the reservation time can be in the future, and how long a
+	// reservation lasts before it is given up. If this value is zero, the default value (3600s) will be used.
+	MaxReservationSeconds int64 `json:"maxReservationSeconds,omitempty"`
+}
+
+// ScaleUpHintConfig holds the parameters of scale-up hints. A unit that has been pending for longer than the
+// threshold is simulated against hypothetical nodes built from the templates, and the result is published on
+// its pods so that cluster autoscalers can add the right nodes.
+type ScaleUpHintConfig struct {
+	// PendingThresholdSeconds is how long a unit must have been pending before hints are computed for it.
+	// If this value is zero, the default value (300s) will be used.
+	PendingThresholdSeconds int64 `json:"pendingThresholdSeconds,omitempty"`
+
+	// NodeTemplates are the shapes of nodes that can be added to the cluster.
+	NodeTemplates []NodeTemplate `json:"nodeTemplates,omitempty"`
+}
+
+// NodeTemplate describes a node that a cluster autoscaler is able to add, usually a node group.
+type NodeTemplate struct {
+	// Name identifies the template in recommendations.
+	Name string `json:"name"`
+
+	// Labels are the labels of the nodes created from this template.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are the taints of the nodes created from this template.
+	Taints []v1.Taint `json:"taints,omitempty"`
+
+	// Allocatable is the allocatable resources of the nodes created from this template.
+	Allocatable v1.ResourceList `json:"allocatable"`
+
+	// MaxCount bounds how many nodes can be added from this template, zero means unlimited.
+	MaxCount int32 `json:"maxCount,omitempty"`
 }
 
 // Plugins include multiple extension points. When specified, the list of plugins for
@@ -264,6 +342,22 @@ type Plugins struct {
 	Sorting *PluginSet `json:"sorting,omitempty"`
 }
 
+// UnitPlugins include the extension points of unit scheduling. When specified, the list of plugins
+// for a particular extension point are the only ones enabled and they are called in the order
+// specified here, an empty list disables the extension point. If an extension point is omitted,
+// all the registered plugins implementing it are called in the default order.
+type UnitPlugins struct {
+	// Locating is a list of plugins that should be invoked when narrowing down the node group of a unit.
+	Locating *PluginSet `json:"locating,omitempty"`
+
+	// PreferNode is a list of plugins that should be invoked when preparing the preferred nodes of a pod.
+	PreferNode *PluginSet `json:"preferNode,omitempty"`
+
+	// Grouping is the plugin that should be invoked when splitting the node group of a unit,
+	// JobLevelAffinity is used if it is omitted.
+	Grouping *Plugin `json:"grouping,omitempty"`
+}
+
 // PreemptionPluginSet specifies enabled and disabled plugins for an extension point.
 // If an array is empty, missing, or nil, default plugins at that extension point will be used.
 type VictimSearchingPluginSet struct {
@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
@@ -105,6 +106,20 @@ type RequestedToCapacityRatioArgs struct {
 	// The default resource set includes "cpu" and "memory" with an equal weight.
 	// Allowed weights go from 1 to 100.
 	Resources []ResourceSpec `json:"resources,omitempty"`
+	// ResourceShapes overrides Shape for specific resources, e.g. extended resources like GPU or RDMA,
+	// optionally for the pods of a specific resource type (guaranteed or best-effort) only.
+	ResourceShapes []ResourceShape `json:"resourceShapes,omitempty"`
+}
+
+// ResourceShape defines the priority function shape of a single resource.
+type ResourceShape struct {
+	// Name of the resource.
+	Name string `json:"name"`
+	// PodResourceType is the resource type of pods that the shape applies to, valid values are
+	// "guaranteed" and "best-effort". Empty value means the shape applies to all pods.
+	PodResourceType string `json:"podResourceType,omitempty"`
+	// Points defining priority function shape
+	Shape []UtilizationShapePoint `json:"shape"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -258,4 +273,99 @@ type LoadAwareArgs struct {
 	// EstimatedScalingFactors indicates the factor when estimating resource usage.
 	// Is CPU scaling factor is 80, estimated CPU = 80 / 100 * request.cpu
 	EstimatedScalingFactors map[v1.ResourceName]int64 `json:"estimatedScalingFactors,omitempty"`
+
+	// UsagePercentile indicates which percentile of the owner usage profile is used by the
+	// historical estimator. If this value is zero, the default value will be used.
+	UsagePercentile int64 `json:"usagePercentile,omitempty"`
+	// MinUsageSamples is the min number of samples an owner usage profile needs before the
+	// historical estimator trusts it. If this value is zero, the default value will be used.
+	MinUsageSamples int64 `json:"minUsageSamples,omitempty"`
+	// PeakUsageWindowSeconds indicates the time window in which the node peak usage is estimated
+	// by the historical estimator. Zero means only the latest sample is considered.
+	PeakUsageWindowSeconds int64 `json:"peakUsageWindowSeconds,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PreemptionBudgetCheckerArgs holds arguments used to configure the PreemptionBudgetChecker plugin.
+// Zero value of each limit means no limitation.
+type PreemptionBudgetCheckerArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// WindowSeconds is the sliding time window in which the victims are counted.
+	// If this value is zero, the default value will be used.
+	WindowSeconds int64 `json:"windowSeconds,omitempty"`
+	// MaxVictimsPerOwner is the max number of victims belonging to the same owner in the window.
+	MaxVictimsPerOwner int64 `json:"maxVictimsPerOwner,omitempty"`
+	// MaxVictimsPerNamespace is the max number of victims in the same namespace in the window.
+	MaxVictimsPerNamespace int64 `json:"maxVictimsPerNamespace,omitempty"`
+	// MinIntervalSecondsPerOwner is the min interval between two preemptions of the same owner.
+	MinIntervalSecondsPerOwner int64 `json:"minIntervalSecondsPerOwner,omitempty"`
+}
+
+// NodePoolMode is the mode of a node pool.
+type NodePoolMode string
+
+const (
+	// DedicatedNodePool can only be used by the tenants of the pool.
+	DedicatedNodePool NodePoolMode = "Dedicated"
+	// SharedNodePool can be used by all pods, the tenants of the pool have no privilege on it.
+	SharedNodePool NodePoolMode = "Shared"
+	// BorrowableNodePool belongs to the tenants of the pool, other pods can borrow the idle capacity
+	// of the pool, and the borrowed capacity can be reclaimed by the tenants through preemption.
+	BorrowableNodePool NodePoolMode = "Borrowable"
+)
+
+// NodePool maps the tenants, which are namespaces or applications selected by pod labels, to a set of nodes.
+type NodePool struct {
+	// Name is the unique name of the node pool.
+	Name string `json:"name"`
+	// Mode is the mode of the node pool, one of Dedicated, Shared and Borrowable.
+	Mode NodePoolMode `json:"mode"`
+	// NodeSelector selects the nodes of the pool by node labels.
+	NodeSelector map[string]string `json:"nodeSelector"`
+	// Namespaces are the tenant namespaces of the pool.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// PodSelector selects the tenant applications of the pool by pod labels.
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodePoolArgs holds arguments used to configure the NodePool plugin, the NodePoolChecker preemption
+// plugin takes the same args.
+type NodePoolArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Pools are the node pools, a node belongs to the first pool whose node selector matches its labels.
+	// Nodes not belonging to any pool can be used by all pods.
+	Pools []NodePool `json:"pools,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NetworkTopologyArgs holds arguments used to configure the NetworkTopology unit plugin.
+type NetworkTopologyArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// TopologyKeys are the node label keys of the network topology levels, ordered from the
+	// tightest level to the loosest one, e.g. host, rack, leaf switch and spine switch.
+	TopologyKeys []string `json:"topologyKeys,omitempty"`
+	// MaxDomainsPerLevel limits the number of candidate domains tried at each level, the domains
+	// leaving less fragmentation are tried first. Zero means no limit.
+	MaxDomainsPerLevel int64 `json:"maxDomainsPerLevel,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PreemptionPolicyMatrixArgs holds arguments used to configure the PreemptionPolicyMatrix plugin.
+// Preemptors which do not belong to any class are not governed by the matrix.
+type PreemptionPolicyMatrixArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Classes groups pods by their PriorityClass names or workload classes.
+	Classes []defaultsconfig.PreemptionClass `json:"classes,omitempty"`
+	// Rules declares which classes each class could preempt. Pods of a class without rule
+	// could not preempt any pods.
+	Rules []defaultsconfig.PreemptionRule `json:"rules,omitempty"`
 }
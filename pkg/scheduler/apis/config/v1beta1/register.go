@@ -53,6 +53,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&config.NodeResourcesBalancedAllocatedArgs{},
 		&config.LocalStoragePoolCheckerArgs{},
 		&config.LoadAwareArgs{},
+		&config.PreemptionBudgetCheckerArgs{},
+		&config.PreemptionPolicyMatrixArgs{},
+		&config.NodePoolArgs{},
+		&config.NetworkTopologyArgs{},
 	)
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	componentbaseconfig "k8s.io/component-base/config/v1alpha1"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 )
@@ -67,6 +68,22 @@ type GodelSchedulerConfiguration struct {
 	// reserved resources will be released after a period of time.
 	ReservationTimeOutSeconds int64 `json:"reservationTimeOutSeconds,omitempty"`
 
+	// CacheComparePeriodSeconds is the period for comparing the scheduler cache with informers in
+	// the background, the differences will be reported as metrics and events. 0 disables it.
+	CacheComparePeriodSeconds int64 `json:"cacheComparePeriodSeconds,omitempty"`
+	// EnableCacheSelfHealing resyncs the objects missed or left behind by the scheduler cache
+	// when they are found by the background cache comparer.
+	EnableCacheSelfHealing bool `json:"enableCacheSelfHealing,omitempty"`
+	// EnableCacheInspection exposes the cache inspection endpoints, e.g. /debug/cache/nodes, on the
+	// healthz and metrics servers regardless of profiling.
+	EnableCacheInspection bool `json:"enableCacheInspection,omitempty"`
+	// PodMetricsSamplePeriodSeconds is the period for sampling the usage of running pods from
+	// metrics.k8s.io, which is used to build the usage profile of each owner. 0 disables it.
+	PodMetricsSamplePeriodSeconds int64 `json:"podMetricsSamplePeriodSeconds,omitempty"`
+	// OvercommitPolicies are the named policies transforming the allocatable of nodes, a node selects its policy
+	// by the label godel.bytedance.com/overcommit-policy. They should be the same as the ones of the binder.
+	OvercommitPolicies []defaultsconfig.OvercommitPolicy `json:"overcommitPolicies,omitempty"`
+
 	// TODO: update the comment
 	// Profiles are scheduling profiles that kube-scheduler supports. Pods can
 	// choose to be scheduled under a particular profile by setting its associated
@@ -94,6 +111,12 @@ func (in *GodelSchedulerConfiguration) DecodeNestedObjects(d runtime.Decoder) er
 				return fmt.Errorf("decoding profile.preemptionPluginConfig[%d]: %w", j, err)
 			}
 		}
+		for j := range prof.UnitPluginConfigs {
+			err := prof.UnitPluginConfigs[j].DecodeNestedObjects(d)
+			if err != nil {
+				return fmt.Errorf("decoding profile.unitPluginConfigs[%d]: %w", j, err)
+			}
+		}
 		return nil
 	}
 
@@ -126,6 +149,12 @@ func (in *GodelSchedulerConfiguration) EncodeNestedObjects(e runtime.Encoder) er
 				return fmt.Errorf("encoding profile.preemptionPluginConfig[%d]: %w", j, err)
 			}
 		}
+		for j := range prof.UnitPluginConfigs {
+			err := prof.UnitPluginConfigs[j].EncodeNestedObjects(e)
+			if err != nil {
+				return fmt.Errorf("encoding profile.unitPluginConfigs[%d]: %w", j, err)
+			}
+		}
 		return nil
 	}
 
@@ -201,4 +230,17 @@ type GodelSchedulerProfile struct {
 
 	// BetterSelectPolicies
 	BetterSelectPolicies *config.StringSlice `json:"betterSelectPolicies,omitempty"`
+
+	// UnitPlugins specifies the unit plugins invoked at each extension point of unit scheduling.
+	// The default unit plugins are used for the omitted extension points.
+	UnitPlugins *config.UnitPlugins `json:"unitPlugins,omitempty"`
+
+	// UnitPluginConfigs is an optional set of custom plugin arguments for each unit plugin.
+	UnitPluginConfigs []config.PluginConfig `json:"unitPluginConfigs,omitempty"`
+
+	// Backfill enables backfill scheduling if it is specified.
+	Backfill *config.BackfillConfig `json:"backfill,omitempty"`
+
+	// ScaleUpHint enables scale-up hints for long pending units if it is specified.
+	ScaleUpHint *config.ScaleUpHintConfig `json:"scaleUpHint,omitempty"`
 }
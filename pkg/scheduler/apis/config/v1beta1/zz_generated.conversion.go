@@ -24,6 +24,7 @@ package v1beta1
 import (
 	unsafe "unsafe"
 
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	config "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	tracing "github.com/kubewharf/godel-scheduler/pkg/util/tracing"
 	conversion "k8s.io/apimachinery/pkg/conversion"
@@ -72,6 +73,11 @@ func autoConvert_v1beta1_GodelSchedulerConfiguration_To_config_GodelSchedulerCon
 	out.SubClusterKey = (*string)(unsafe.Pointer(in.SubClusterKey))
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
 	out.ReservationTimeOutSeconds = in.ReservationTimeOutSeconds
+	out.CacheComparePeriodSeconds = in.CacheComparePeriodSeconds
+	out.EnableCacheSelfHealing = in.EnableCacheSelfHealing
+	out.EnableCacheInspection = in.EnableCacheInspection
+	out.PodMetricsSamplePeriodSeconds = in.PodMetricsSamplePeriodSeconds
+	out.OvercommitPolicies = *(*[]apisconfig.OvercommitPolicy)(unsafe.Pointer(&in.OvercommitPolicies))
 	if in.DefaultProfile != nil {
 		in, out := &in.DefaultProfile, &out.DefaultProfile
 		*out = new(config.GodelSchedulerProfile)
@@ -112,6 +118,11 @@ func autoConvert_config_GodelSchedulerConfiguration_To_v1beta1_GodelSchedulerCon
 	out.Tracer = (*tracing.TracerConfiguration)(unsafe.Pointer(in.Tracer))
 	out.SubClusterKey = (*string)(unsafe.Pointer(in.SubClusterKey))
 	out.ReservationTimeOutSeconds = in.ReservationTimeOutSeconds
+	out.CacheComparePeriodSeconds = in.CacheComparePeriodSeconds
+	out.EnableCacheSelfHealing = in.EnableCacheSelfHealing
+	out.EnableCacheInspection = in.EnableCacheInspection
+	out.PodMetricsSamplePeriodSeconds = in.PodMetricsSamplePeriodSeconds
+	out.OvercommitPolicies = *(*[]apisconfig.OvercommitPolicy)(unsafe.Pointer(&in.OvercommitPolicies))
 	if in.DefaultProfile != nil {
 		in, out := &in.DefaultProfile, &out.DefaultProfile
 		*out = new(GodelSchedulerProfile)
@@ -157,6 +168,10 @@ func autoConvert_v1beta1_GodelSchedulerProfile_To_config_GodelSchedulerProfile(i
 	out.MaxWaitingDeletionDuration = in.MaxWaitingDeletionDuration
 	out.CandidatesSelectPolicy = (*string)(unsafe.Pointer(in.CandidatesSelectPolicy))
 	out.BetterSelectPolicies = (*config.StringSlice)(unsafe.Pointer(in.BetterSelectPolicies))
+	out.UnitPlugins = (*config.UnitPlugins)(unsafe.Pointer(in.UnitPlugins))
+	out.UnitPluginConfigs = *(*[]config.PluginConfig)(unsafe.Pointer(&in.UnitPluginConfigs))
+	out.Backfill = (*config.BackfillConfig)(unsafe.Pointer(in.Backfill))
+	out.ScaleUpHint = (*config.ScaleUpHintConfig)(unsafe.Pointer(in.ScaleUpHint))
 	return nil
 }
 
@@ -182,6 +197,10 @@ func autoConvert_config_GodelSchedulerProfile_To_v1beta1_GodelSchedulerProfile(i
 	out.AttemptImpactFactorOnPriority = (*float64)(unsafe.Pointer(in.AttemptImpactFactorOnPriority))
 	out.UnitInitialBackoffSeconds = (*int64)(unsafe.Pointer(in.UnitInitialBackoffSeconds))
 	out.UnitMaxBackoffSeconds = (*int64)(unsafe.Pointer(in.UnitMaxBackoffSeconds))
+	out.UnitPlugins = (*config.UnitPlugins)(unsafe.Pointer(in.UnitPlugins))
+	out.UnitPluginConfigs = *(*[]config.PluginConfig)(unsafe.Pointer(&in.UnitPluginConfigs))
+	out.Backfill = (*config.BackfillConfig)(unsafe.Pointer(in.Backfill))
+	out.ScaleUpHint = (*config.ScaleUpHintConfig)(unsafe.Pointer(in.ScaleUpHint))
 	return nil
 }
 
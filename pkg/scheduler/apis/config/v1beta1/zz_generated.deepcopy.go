@@ -22,6 +22,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	config "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -47,6 +48,13 @@ func (in *GodelSchedulerConfiguration) DeepCopyInto(out *GodelSchedulerConfigura
 		in, out := &in.Tracer, &out.Tracer
 		*out = (*in).DeepCopy()
 	}
+	if in.OvercommitPolicies != nil {
+		in, out := &in.OvercommitPolicies, &out.OvercommitPolicies
+		*out = make([]apisconfig.OvercommitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DefaultProfile != nil {
 		in, out := &in.DefaultProfile, &out.DefaultProfile
 		*out = new(GodelSchedulerProfile)
@@ -161,6 +169,28 @@ func (in *GodelSchedulerProfile) DeepCopyInto(out *GodelSchedulerProfile) {
 			copy(*out, *in)
 		}
 	}
+	if in.UnitPlugins != nil {
+		in, out := &in.UnitPlugins, &out.UnitPlugins
+		*out = new(config.UnitPlugins)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnitPluginConfigs != nil {
+		in, out := &in.UnitPluginConfigs, &out.UnitPluginConfigs
+		*out = make([]config.PluginConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backfill != nil {
+		in, out := &in.Backfill, &out.Backfill
+		*out = new(config.BackfillConfig)
+		**out = **in
+	}
+	if in.ScaleUpHint != nil {
+		in, out := &in.ScaleUpHint, &out.ScaleUpHint
+		*out = new(config.ScaleUpHintConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
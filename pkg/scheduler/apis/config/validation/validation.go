@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	godelvalidation "github.com/kubewharf/godel-scheduler/pkg/util/validation"
 )
@@ -61,6 +62,13 @@ func ValidateGodelSchedulerConfiguration(cc *config.GodelSchedulerConfiguration)
 		if cc.ReservationTimeOutSeconds <= 0 {
 			errs = append(errs, field.Invalid(field.NewPath("ReservationTimeOutSeconds"), cc.ReservationTimeOutSeconds, "ReservationTimeOutSeconds == 0"))
 		}
+		if cc.CacheComparePeriodSeconds < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("cacheComparePeriodSeconds"), cc.CacheComparePeriodSeconds, "must be non-negative"))
+		}
+		if cc.PodMetricsSamplePeriodSeconds < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("podMetricsSamplePeriodSeconds"), cc.PodMetricsSamplePeriodSeconds, "must be non-negative"))
+		}
+		errs = append(errs, defaultsconfig.ValidateOvercommitPolicies(field.NewPath("overcommitPolicies"), cc.OvercommitPolicies)...)
 		// TODO: Restore the following logic.
 		// if cc.SubClusterKey == nil || len(*cc.SubClusterKey) == 0 {
 		// 	errs = append(errs, field.Required(field.NewPath("subClusterKey"), ""))
@@ -75,11 +83,12 @@ func ValidateGodelSchedulerConfiguration(cc *config.GodelSchedulerConfiguration)
 			errs = append(errs, ValidateSubClusterArgs(cc.DefaultProfile, field.NewPath("defaultProfile"))...)
 		}
 		if cc.SubClusterProfiles != nil {
-			for _, profile := range cc.SubClusterProfiles {
+			for i := range cc.SubClusterProfiles {
+				profile := &cc.SubClusterProfiles[i]
 				if len(profile.SubClusterName) == 0 {
 					errs = append(errs, field.Required(field.NewPath("subClusterName"), ""))
 				}
-				errs = append(errs, ValidateSubClusterArgs(cc.DefaultProfile, field.NewPath("subClusterProfile"))...)
+				errs = append(errs, ValidateSubClusterArgs(profile, field.NewPath("subClusterProfile").Index(i))...)
 			}
 		}
 	}
@@ -114,6 +123,84 @@ func ValidateBasePluginsConfiguration(plugins *config.Plugins, fldPath *field.Pa
 	return errs
 }
 
+// ValidateUnitPluginsConfiguration ensures validation of the unit plugins struct
+func ValidateUnitPluginsConfiguration(plugins *config.UnitPlugins, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if plugins == nil {
+		return errs
+	}
+	for _, set := range []struct {
+		name    string
+		plugins *config.PluginSet
+	}{
+		{"locating", plugins.Locating},
+		{"preferNode", plugins.PreferNode},
+	} {
+		if set.plugins == nil {
+			continue
+		}
+		for i, plugin := range set.plugins.Plugins {
+			if len(plugin.Name) == 0 {
+				errs = append(errs, field.Required(fldPath.Child(set.name, "plugins").Index(i).Child("name"), ""))
+			}
+		}
+		errs = append(errs, noDuplicatePlugins(set.plugins, fldPath, set.name)...)
+	}
+	if plugins.Grouping != nil && len(plugins.Grouping.Name) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("grouping", "name"), ""))
+	}
+	return errs
+}
+
+// ValidateBackfillConfiguration ensures validation of the backfill struct
+func ValidateBackfillConfiguration(backfill *config.BackfillConfig, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if backfill == nil {
+		return errs
+	}
+	if backfill.MinMember < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("minMember"), backfill.MinMember, "must be greater than or equal to 0"))
+	}
+	if backfill.MaxReservationSeconds < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxReservationSeconds"), backfill.MaxReservationSeconds, "must be greater than or equal to 0"))
+	}
+	return errs
+}
+
+// ValidateScaleUpHintConfiguration ensures validation of the scale-up hint struct
+func ValidateScaleUpHintConfiguration(hint *config.ScaleUpHintConfig, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	if hint == nil {
+		return errs
+	}
+	if hint.PendingThresholdSeconds < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("pendingThresholdSeconds"), hint.PendingThresholdSeconds, "must be greater than or equal to 0"))
+	}
+	names := sets.NewString()
+	for i, template := range hint.NodeTemplates {
+		templatePath := fldPath.Child("nodeTemplates").Index(i)
+		if len(template.Name) == 0 {
+			errs = append(errs, field.Required(templatePath.Child("name"), ""))
+		} else if names.Has(template.Name) {
+			errs = append(errs, field.Duplicate(templatePath.Child("name"), template.Name))
+		} else {
+			names.Insert(template.Name)
+		}
+		if len(template.Allocatable) == 0 {
+			errs = append(errs, field.Required(templatePath.Child("allocatable"), ""))
+		}
+		for name, quantity := range template.Allocatable {
+			if quantity.Sign() < 0 {
+				errs = append(errs, field.Invalid(templatePath.Child("allocatable").Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+			}
+		}
+		if template.MaxCount < 0 {
+			errs = append(errs, field.Invalid(templatePath.Child("maxCount"), template.MaxCount, "must be greater than or equal to 0"))
+		}
+	}
+	return errs
+}
+
 // ValidatePluginArgsConfiguration ensures validation of the ClientConnectionConfiguration struct
 func ValidatePluginArgsConfiguration(pluginArgs []config.PluginConfig, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
@@ -140,6 +227,10 @@ func ValidateSubClusterArgs(cc *config.GodelSchedulerProfile, fldPath *field.Pat
 	errs = append(errs, ValidateBasePluginsConfiguration(cc.BasePluginsForKubelet, field.NewPath("baseKubeletPlugins"))...)
 	errs = append(errs, ValidateBasePluginsConfiguration(cc.BasePluginsForNM, field.NewPath("baseNMPlugins"))...)
 	errs = append(errs, ValidatePluginArgsConfiguration(cc.PluginConfigs, field.NewPath("pluginConfig"))...)
+	errs = append(errs, ValidateUnitPluginsConfiguration(cc.UnitPlugins, fldPath.Child("unitPlugins"))...)
+	errs = append(errs, ValidatePluginArgsConfiguration(cc.UnitPluginConfigs, fldPath.Child("unitPluginConfigs"))...)
+	errs = append(errs, ValidateBackfillConfiguration(cc.Backfill, fldPath.Child("backfill"))...)
+	errs = append(errs, ValidateScaleUpHintConfiguration(cc.ScaleUpHint, fldPath.Child("scaleUpHint"))...)
 
 	if cc.PercentageOfNodesToScore != nil && (*cc.PercentageOfNodesToScore < 0 || *cc.PercentageOfNodesToScore > 100) {
 		errs = append(errs, field.Invalid(field.NewPath("percentageOfNodesToScore"),
@@ -153,7 +244,7 @@ func ValidateSubClusterArgs(cc *config.GodelSchedulerProfile, fldPath *field.Pat
 		errs = append(errs, field.Invalid(field.NewPath("unitInitialBackoffSeconds"),
 			cc.UnitInitialBackoffSeconds, "must be greater than 0"))
 	}
-	if cc.UnitInitialBackoffSeconds != nil && *cc.UnitMaxBackoffSeconds < *cc.UnitInitialBackoffSeconds {
+	if cc.UnitInitialBackoffSeconds != nil && cc.UnitMaxBackoffSeconds != nil && *cc.UnitMaxBackoffSeconds < *cc.UnitInitialBackoffSeconds {
 		errs = append(errs, field.Invalid(field.NewPath("unitMaxBackoffSeconds"),
 			cc.UnitMaxBackoffSeconds, "must be greater than or equal to UnitInitialBackoffSeconds"))
 	}
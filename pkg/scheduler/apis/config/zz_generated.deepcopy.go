@@ -22,10 +22,28 @@ limitations under the License.
 package config
 
 import (
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackfillConfig) DeepCopyInto(out *BackfillConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackfillConfig.
+func (in *BackfillConfig) DeepCopy() *BackfillConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BackfillConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GodelSchedulerConfiguration) DeepCopyInto(out *GodelSchedulerConfiguration) {
 	*out = *in
@@ -47,6 +65,13 @@ func (in *GodelSchedulerConfiguration) DeepCopyInto(out *GodelSchedulerConfigura
 		*out = new(string)
 		**out = **in
 	}
+	if in.OvercommitPolicies != nil {
+		in, out := &in.OvercommitPolicies, &out.OvercommitPolicies
+		*out = make([]apisconfig.OvercommitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DefaultProfile != nil {
 		in, out := &in.DefaultProfile, &out.DefaultProfile
 		*out = new(GodelSchedulerProfile)
@@ -161,6 +186,28 @@ func (in *GodelSchedulerProfile) DeepCopyInto(out *GodelSchedulerProfile) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.UnitPlugins != nil {
+		in, out := &in.UnitPlugins, &out.UnitPlugins
+		*out = new(UnitPlugins)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnitPluginConfigs != nil {
+		in, out := &in.UnitPluginConfigs, &out.UnitPluginConfigs
+		*out = make([]PluginConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backfill != nil {
+		in, out := &in.Backfill, &out.Backfill
+		*out = new(BackfillConfig)
+		**out = **in
+	}
+	if in.ScaleUpHint != nil {
+		in, out := &in.ScaleUpHint, &out.ScaleUpHint
+		*out = new(ScaleUpHintConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -343,6 +390,101 @@ func (in *NodeLoadArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkTopologyArgs) DeepCopyInto(out *NetworkTopologyArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.TopologyKeys != nil {
+		in, out := &in.TopologyKeys, &out.TopologyKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkTopologyArgs.
+func (in *NetworkTopologyArgs) DeepCopy() *NetworkTopologyArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkTopologyArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NetworkTopologyArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePool) DeepCopyInto(out *NodePool) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePool.
+func (in *NodePool) DeepCopy() *NodePool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolArgs) DeepCopyInto(out *NodePoolArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]NodePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolArgs.
+func (in *NodePoolArgs) DeepCopy() *NodePoolArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodePoolArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeResourcesAffinityArgs) DeepCopyInto(out *NodeResourcesAffinityArgs) {
 	*out = *in
@@ -498,6 +640,43 @@ func (in *NodeResourcesMostAllocatedArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeTemplate) DeepCopyInto(out *NodeTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeTemplate.
+func (in *NodeTemplate) DeepCopy() *NodeTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -620,6 +799,70 @@ func (in *PodTopologySpreadArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionBudgetCheckerArgs) DeepCopyInto(out *PreemptionBudgetCheckerArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionBudgetCheckerArgs.
+func (in *PreemptionBudgetCheckerArgs) DeepCopy() *PreemptionBudgetCheckerArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionBudgetCheckerArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreemptionBudgetCheckerArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreemptionPolicyMatrixArgs) DeepCopyInto(out *PreemptionPolicyMatrixArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Classes != nil {
+		in, out := &in.Classes, &out.Classes
+		*out = make([]apisconfig.PreemptionClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]apisconfig.PreemptionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreemptionPolicyMatrixArgs.
+func (in *PreemptionPolicyMatrixArgs) DeepCopy() *PreemptionPolicyMatrixArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(PreemptionPolicyMatrixArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreemptionPolicyMatrixArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestedToCapacityRatioArgs) DeepCopyInto(out *RequestedToCapacityRatioArgs) {
 	*out = *in
@@ -634,6 +877,13 @@ func (in *RequestedToCapacityRatioArgs) DeepCopyInto(out *RequestedToCapacityRat
 		*out = make([]ResourceSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceShapes != nil {
+		in, out := &in.ResourceShapes, &out.ResourceShapes
+		*out = make([]ResourceShape, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -671,6 +921,27 @@ func (in *ResourceMatchFactor) DeepCopy() *ResourceMatchFactor {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceShape) DeepCopyInto(out *ResourceShape) {
+	*out = *in
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]UtilizationShapePoint, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceShape.
+func (in *ResourceShape) DeepCopy() *ResourceShape {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceShape)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
@@ -687,6 +958,29 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleUpHintConfig) DeepCopyInto(out *ScaleUpHintConfig) {
+	*out = *in
+	if in.NodeTemplates != nil {
+		in, out := &in.NodeTemplates, &out.NodeTemplates
+		*out = make([]NodeTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleUpHintConfig.
+func (in *ScaleUpHintConfig) DeepCopy() *ScaleUpHintConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleUpHintConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAffinityArgs) DeepCopyInto(out *ServiceAffinityArgs) {
 	*out = *in
@@ -772,6 +1066,37 @@ func (in StringSlice) DeepCopy() StringSlice {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnitPlugins) DeepCopyInto(out *UnitPlugins) {
+	*out = *in
+	if in.Locating != nil {
+		in, out := &in.Locating, &out.Locating
+		*out = new(PluginSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreferNode != nil {
+		in, out := &in.PreferNode, &out.PreferNode
+		*out = new(PluginSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Grouping != nil {
+		in, out := &in.Grouping, &out.Grouping
+		*out = new(Plugin)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnitPlugins.
+func (in *UnitPlugins) DeepCopy() *UnitPlugins {
+	if in == nil {
+		return nil
+	}
+	out := new(UnitPlugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UtilizationShapePoint) DeepCopyInto(out *UtilizationShapePoint) {
 	*out = *in
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	defaultsconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
@@ -144,6 +145,31 @@ func ValidateRequestedToCapacityRatioArgs(args config.RequestedToCapacityRatioAr
 	if err := validateResourcesNoMax(args.Resources); err != nil {
 		return err
 	}
+	if err := validateResourceShapes(args.ResourceShapes); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateResourceShapes(shapes []config.ResourceShape) error {
+	supportedPodResourceTypes := sets.NewString("", string(podutil.GuaranteedPod), string(podutil.BestEffortPod))
+	seen := sets.NewString()
+	for i, shape := range shapes {
+		if len(shape.Name) == 0 {
+			return fmt.Errorf("resource name of resourceShapes[%d] must not be empty", i)
+		}
+		if !supportedPodResourceTypes.Has(shape.PodResourceType) {
+			return fmt.Errorf("pod resource type %q of resourceShapes[%d] is not supported, supported values: %v", shape.PodResourceType, i, supportedPodResourceTypes.List())
+		}
+		key := shape.Name + "/" + shape.PodResourceType
+		if seen.Has(key) {
+			return fmt.Errorf("duplicate shape for resource %s and pod resource type %q", shape.Name, shape.PodResourceType)
+		}
+		seen.Insert(key)
+		if err := validateFunctionShape(shape.Shape); err != nil {
+			return fmt.Errorf("invalid shape of resource %s: %v", shape.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -241,5 +267,55 @@ func ValidateLoadAwareArgs(args *config.LoadAwareArgs) error {
 			return fmt.Errorf("resource type %v is invalid", resourceSpec.ResourceType)
 		}
 	}
+	if args.UsagePercentile < 0 || args.UsagePercentile > 100 {
+		return fmt.Errorf("usage percentile %v is not in valid range [0-100]", args.UsagePercentile)
+	}
+	if args.MinUsageSamples < 0 {
+		return fmt.Errorf("invalid negative MinUsageSamples")
+	}
+	if args.PeakUsageWindowSeconds < 0 {
+		return fmt.Errorf("invalid negative PeakUsageWindowSeconds")
+	}
+	return nil
+}
+
+// ValidatePreemptionBudgetCheckerArgs validates that PreemptionBudgetCheckerArgs are correct.
+func ValidatePreemptionBudgetCheckerArgs(args *config.PreemptionBudgetCheckerArgs) error {
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validatePreemptionBudgetSeconds(field.NewPath("windowSeconds"), args.WindowSeconds)...)
+	allErrs = append(allErrs, validatePreemptionBudgetSeconds(field.NewPath("minIntervalSecondsPerOwner"), args.MinIntervalSecondsPerOwner)...)
+	if args.MaxVictimsPerOwner < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxVictimsPerOwner"), args.MaxVictimsPerOwner, "must be non-negative"))
+	}
+	if args.MaxVictimsPerNamespace < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxVictimsPerNamespace"), args.MaxVictimsPerNamespace, "must be non-negative"))
+	}
+	return allErrs.ToAggregate()
+}
+
+func validatePreemptionBudgetSeconds(path *field.Path, seconds int64) field.ErrorList {
+	if seconds < 0 || seconds > defaultsconfig.MaxPreemptionBudgetWindowSeconds {
+		msg := fmt.Sprintf("not in valid range [0-%d]", defaultsconfig.MaxPreemptionBudgetWindowSeconds)
+		return field.ErrorList{field.Invalid(path, seconds, msg)}
+	}
 	return nil
 }
+
+// ValidateNetworkTopologyArgs validates that NetworkTopologyArgs are correct.
+func ValidateNetworkTopologyArgs(args *config.NetworkTopologyArgs) error {
+	var allErrs field.ErrorList
+	path := field.NewPath("topologyKeys")
+	keys := sets.NewString()
+	for i, key := range args.TopologyKeys {
+		if len(key) == 0 {
+			allErrs = append(allErrs, field.Required(path.Index(i), "topology key must be set"))
+		} else if keys.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), key))
+		}
+		keys.Insert(key)
+	}
+	if args.MaxDomainsPerLevel < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxDomainsPerLevel"), args.MaxDomainsPerLevel, "must be greater than or equal to 0"))
+	}
+	return allErrs.ToAggregate()
+}
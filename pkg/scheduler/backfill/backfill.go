@@ -0,0 +1,362 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// ResultScheduled means the unit holding the reservation got scheduled.
+	ResultScheduled = "scheduled"
+	// ResultExpired means the reservation lasted longer than the max reservation duration.
+	ResultExpired = "expired"
+	// ResultReplaced means the reservation was replaced by the one of a unit with higher priority.
+	ResultReplaced = "replaced"
+	// ResultDeleted means the unit holding the reservation was deleted.
+	ResultDeleted = "deleted"
+	// ResultTimeout means the unit holding the reservation timed out.
+	ResultTimeout = "timeout"
+
+	// AdmitFinishBeforeReservation means the unit is predicted to finish before the reservation time.
+	AdmitFinishBeforeReservation = "finishBeforeReservation"
+	// AdmitPreemptible means the unit can be preempted by the unit holding the reservation.
+	AdmitPreemptible = "preemptible"
+	// AdmitHigherPriority means the unit has a higher priority than the unit holding the reservation.
+	AdmitHigherPriority = "higherPriority"
+	// AdmitBlocked means the unit is not allowed to use the reserved nodes.
+	AdmitBlocked = "blocked"
+)
+
+// Reservation reserves a set of nodes for a blocked unit, the unit is predicted to fit in
+// the nodes at the reservation time.
+type Reservation struct {
+	UnitKey  string
+	Priority int32
+	Nodes    sets.String
+	// ReservedTime is the time when enough resources are predicted to be released in the nodes.
+	ReservedTime time.Time
+	// CreatedTime is the time when the unit got blocked the first time.
+	CreatedTime time.Time
+
+	unitProperty framework.UnitProperty
+}
+
+// Manager holds the backfill reservation of a sub cluster. Like EASY backfilling, there is at most one
+// reservation at a time, it belongs to the blocked unit with the highest priority.
+type Manager struct {
+	clock                  clock.Clock
+	minMember              int
+	maxReservationDuration time.Duration
+
+	mu          sync.Mutex
+	reservation *Reservation
+}
+
+// NewManager returns nil if backfill is not configured, all the methods of a nil Manager are no-ops.
+func NewManager(cfg *config.BackfillConfig, clock clock.Clock) *Manager {
+	if cfg == nil {
+		return nil
+	}
+	maxReservationSeconds := cfg.MaxReservationSeconds
+	if maxReservationSeconds == 0 {
+		maxReservationSeconds = config.DefaultBackfillMaxReservationSeconds
+	}
+	return &Manager{
+		clock:                  clock,
+		minMember:              int(cfg.MinMember),
+		maxReservationDuration: time.Duration(maxReservationSeconds) * time.Second,
+	}
+}
+
+// GetReservation returns the current reservation.
+func (m *Manager) GetReservation() *Reservation {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.reservation
+}
+
+// Reserve makes a reservation for the unit which failed to be scheduled in the node group. The reservation
+// of another unit is only replaced if the unit has a higher priority or the reservation has expired.
+func (m *Manager) Reserve(unit *framework.QueuedUnitInfo, minMember int, nodeGroup framework.NodeGroup) {
+	if m == nil || unit.Type() != framework.PodGroupUnitType || minMember < m.minMember || unit.NumPods() == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	existing := m.reservation
+	if existing != nil && existing.UnitKey != unit.UnitKey {
+		if m.expired(existing, now) {
+			m.endReservation(ResultExpired, now)
+			existing = nil
+		} else if unit.GetPriority() <= existing.Priority {
+			return
+		}
+	}
+
+	nodes, reservedTime, ok := m.planReservation(unit, minMember, nodeGroup, now)
+	if !ok {
+		klog.V(4).InfoS("Failed to find nodes to reserve for unit within the max reservation duration", "unitKey", unit.UnitKey, "maxReservationDuration", m.maxReservationDuration)
+		return
+	}
+
+	createdTime := now
+	if existing != nil {
+		if existing.UnitKey == unit.UnitKey {
+			createdTime = existing.CreatedTime
+		} else {
+			m.endReservation(ResultReplaced, now)
+		}
+	}
+	m.reservation = &Reservation{
+		UnitKey:      unit.UnitKey,
+		Priority:     unit.GetPriority(),
+		Nodes:        nodes,
+		ReservedTime: reservedTime,
+		CreatedTime:  createdTime,
+		unitProperty: unit.GetUnitProperty(),
+	}
+	klog.V(4).InfoS("Reserved nodes for blocked unit", "unitKey", unit.UnitKey, "numberOfNodes", nodes.Len(), "reservedTime", reservedTime)
+}
+
+// Release removes the reservation of the unit once it is scheduled, deleted or timed out.
+func (m *Manager) Release(unitKey string, result string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.reservation != nil && m.reservation.UnitKey == unitKey {
+		m.endReservation(result, m.clock.Now())
+	}
+}
+
+// Admit filters out the reserved nodes from the node group if the unit is not allowed to use them.
+func (m *Manager) Admit(unit *framework.QueuedUnitInfo, nodeGroup framework.NodeGroup) framework.NodeGroup {
+	if m == nil {
+		return nodeGroup
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := m.reservation
+	if r == nil || r.UnitKey == unit.UnitKey {
+		return nodeGroup
+	}
+	now := m.clock.Now()
+	if m.expired(r, now) {
+		m.endReservation(ResultExpired, now)
+		return nodeGroup
+	}
+
+	decision := admit(unit, r, now)
+	metrics.UnitBackfillAdmissionInc(unit.GetUnitProperty(), decision)
+	if decision != AdmitBlocked {
+		return nodeGroup
+	}
+	return framework.FilterNodeGroup(nodeGroup, func(nodeInfo framework.NodeInfo) bool {
+		return !r.Nodes.Has(nodeInfo.GetNodeName())
+	})
+}
+
+func (m *Manager) expired(r *Reservation, now time.Time) bool {
+	return now.After(r.CreatedTime.Add(m.maxReservationDuration))
+}
+
+func (m *Manager) endReservation(result string, now time.Time) {
+	r := m.reservation
+	m.reservation = nil
+	klog.V(4).InfoS("Ended backfill reservation", "unitKey", r.UnitKey, "result", result, "starvationDuration", now.Sub(r.CreatedTime))
+	metrics.UnitBackfillStarvationObserve(r.unitProperty, result, now.Sub(r.CreatedTime).Seconds())
+}
+
+// admit decides whether the unit is allowed to use the nodes of the reservation. Units with higher
+// priority are never blocked, since they would have taken over the reservation if they were blocked.
+func admit(unit framework.ScheduleUnit, r *Reservation, now time.Time) string {
+	if unit.GetPriority() > r.Priority {
+		return AdmitHigherPriority
+	}
+	finishBeforeReservation, preemptible := true, unit.GetPriority() < r.Priority
+	for _, podInfo := range unit.GetPods() {
+		duration, ok := podutil.GetExpectedDuration(podInfo.Pod)
+		if !ok || now.Add(duration).After(r.ReservedTime) {
+			finishBeforeReservation = false
+		}
+		if podutil.CanPodBePreempted(podInfo.Pod) < 0 {
+			preemptible = false
+		}
+	}
+	switch {
+	case finishBeforeReservation:
+		return AdmitFinishBeforeReservation
+	case preemptible:
+		return AdmitPreemptible
+	default:
+		return AdmitBlocked
+	}
+}
+
+// slotEvent means `slots` more pods of the unit fit in the node from the time on.
+type slotEvent struct {
+	time     time.Time
+	nodeName string
+	slots    int
+}
+
+// planReservation finds the earliest time when the min member pods of the unit fit in the node group, by
+// predicting when the running pods release their resources. The pods with lower priority that can be
+// preempted release their resources immediately, and other pods release at their expected end time. The
+// pods without expected end time are considered to run forever.
+func (m *Manager) planReservation(unit framework.ScheduleUnit, minMember int, nodeGroup framework.NodeGroup, now time.Time) (sets.String, time.Time, bool) {
+	pod := unit.GetPods()[0].Pod
+	resourceType, err := podutil.GetPodResourceType(pod)
+	if err != nil {
+		return nil, time.Time{}, false
+	}
+	request, _, _ := framework.CalculateResource(pod)
+	horizon := now.Add(m.maxReservationDuration)
+
+	var events []slotEvent
+	visited := sets.NewString()
+	for _, nodeCircle := range nodeGroup.GetNodeCircles() {
+		for _, nodeInfo := range nodeCircle.List() {
+			if visited.Has(nodeInfo.GetNodeName()) {
+				continue
+			}
+			visited.Insert(nodeInfo.GetNodeName())
+			events = append(events, nodeSlotEvents(nodeInfo, resourceType, &request, unit.GetPriority(), minMember, now, horizon)...)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].time.Equal(events[j].time) {
+			return events[i].time.Before(events[j].time)
+		}
+		return events[i].nodeName < events[j].nodeName
+	})
+
+	nodes, total := sets.NewString(), 0
+	for _, e := range events {
+		nodes.Insert(e.nodeName)
+		if total += e.slots; total >= minMember {
+			return nodes, e.time, true
+		}
+	}
+	return nil, time.Time{}, false
+}
+
+func nodeSlotEvents(nodeInfo framework.NodeInfo, resourceType podutil.PodResourceType, request *framework.Resource, priority int32, limit int, now, horizon time.Time) []slotEvent {
+	var allocatable, requested *framework.Resource
+	switch resourceType {
+	case podutil.GuaranteedPod:
+		allocatable, requested = nodeInfo.GetGuaranteedAllocatable(), nodeInfo.GetGuaranteedRequested()
+	case podutil.BestEffortPod:
+		allocatable, requested = nodeInfo.GetBestEffortAllocatable(), nodeInfo.GetBestEffortRequested()
+	}
+	if allocatable == nil {
+		return nil
+	}
+	free := allocatable.Clone()
+	free.SubResource(requested)
+
+	type release struct {
+		time time.Time
+		res  *framework.Resource
+	}
+	var releases []release
+	for _, podInfo := range nodeInfo.GetPods() {
+		p := podInfo.Pod
+		if t, err := podutil.GetPodResourceType(p); err != nil || t != resourceType {
+			continue
+		}
+		releaseTime, ok := predictReleaseTime(p, priority, now)
+		if !ok || releaseTime.After(horizon) {
+			continue
+		}
+		res := podInfo.Res
+		releases = append(releases, release{time: releaseTime, res: &res})
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return releases[i].time.Before(releases[j].time)
+	})
+
+	var events []slotEvent
+	slots := fitSlots(free, request, limit)
+	if slots > 0 {
+		events = append(events, slotEvent{time: now, nodeName: nodeInfo.GetNodeName(), slots: slots})
+	}
+	for _, r := range releases {
+		free.AddResource(r.res)
+		if newSlots := fitSlots(free, request, limit); newSlots > slots {
+			events = append(events, slotEvent{time: r.time, nodeName: nodeInfo.GetNodeName(), slots: newSlots - slots})
+			slots = newSlots
+		}
+	}
+	return events
+}
+
+func predictReleaseTime(pod *v1.Pod, priority int32, now time.Time) (time.Time, bool) {
+	if podutil.GetPodPriority(pod) < priority && podutil.CanPodBePreempted(pod) >= 0 {
+		return now, true
+	}
+	endTime, ok := podutil.GetExpectedEndTime(pod)
+	if !ok {
+		return time.Time{}, false
+	}
+	if endTime.Before(now) {
+		return now, true
+	}
+	return endTime, true
+}
+
+// fitSlots returns how many pods with the request fit in the free resources, at most limit.
+func fitSlots(free, request *framework.Resource, limit int) int {
+	slots := limit
+	fit := func(free, request int64) {
+		if request <= 0 {
+			return
+		}
+		if n := int(free / request); n < slots {
+			slots = n
+		}
+	}
+	fit(free.MilliCPU, request.MilliCPU)
+	fit(free.Memory, request.Memory)
+	for name, quantity := range request.ScalarResources {
+		fit(free.ScalarResources[name], quantity)
+	}
+	if slots < 0 {
+		return 0
+	}
+	return slots
+}
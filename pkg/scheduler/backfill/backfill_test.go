@@ -0,0 +1,321 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+var testStartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type podOption func(*v1.Pod)
+
+func withExpectedDuration(seconds int) podOption {
+	return func(pod *v1.Pod) {
+		pod.Annotations[podutil.ExpectedDurationAnnotationKey] = strconv.Itoa(seconds)
+	}
+}
+
+func withPriority(priority int32, priorityClassName string) podOption {
+	return func(pod *v1.Pod) {
+		pod.Spec.Priority = &priority
+		pod.Spec.PriorityClassName = priorityClassName
+	}
+}
+
+func makePod(name, nodeName string, milliCPU int64, opts ...podOption) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				podutil.PodLauncherAnnotationKey:     string(podutil.Kubelet),
+				podutil.PodResourceTypeAnnotationKey: string(podutil.GuaranteedPod),
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+		Status: v1.PodStatus{StartTime: &metav1.Time{Time: testStartTime}},
+	}
+	for _, opt := range opts {
+		opt(pod)
+	}
+	return pod
+}
+
+func makeNodeInfo(name string, pods ...*v1.Pod) framework.NodeInfo {
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(4000, resource.DecimalSI)},
+		},
+	})
+	for _, pod := range pods {
+		nodeInfo.AddPod(pod)
+	}
+	return nodeInfo
+}
+
+// makeNodeGroup returns three full nodes: the pod on n1 is expected to finish in 10 minutes, the pod on
+// n2 is expected to finish in 30 minutes, and the pod on n3 has no expected duration but can be preempted.
+func makeNodeGroup() framework.NodeGroup {
+	lister := &framework.NodeInfoListerImpl{}
+	for _, nodeInfo := range []framework.NodeInfo{
+		makeNodeInfo("n1", makePod("running-n1", "n1", 4000, withExpectedDuration(600))),
+		makeNodeInfo("n2", makePod("running-n2", "n2", 4000, withExpectedDuration(1800))),
+		makeNodeInfo("n3", makePod("running-n3", "n3", 4000, withPriority(0, "low"))),
+	} {
+		lister.AddNodeInfo(nodeInfo)
+	}
+	return framework.NewNodeGroup(framework.DefaultNodeGroupName, nil, []framework.NodeCircle{
+		framework.NewNodeCircle(framework.DefaultNodeCircleName, lister),
+	})
+}
+
+func makeUnit(name string, priority int32, numPods int, opts ...podOption) *framework.QueuedUnitInfo {
+	pg := &v1alpha1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	pg.Spec.MinMember = int32(numPods)
+	unit := framework.NewPodGroupUnit(pg, priority)
+	for i := 0; i < numPods; i++ {
+		unit.AddPod(&framework.QueuedPodInfo{Pod: makePod(name+"-"+strconv.Itoa(i), "", 4000, opts...)})
+	}
+	return &framework.QueuedUnitInfo{UnitKey: "podgroup/default/" + name, ScheduleUnit: unit}
+}
+
+func nodeNames(nodeGroup framework.NodeGroup) sets.String {
+	names := sets.NewString()
+	for _, nodeCircle := range nodeGroup.GetNodeCircles() {
+		for _, nodeInfo := range nodeCircle.List() {
+			names.Insert(nodeInfo.GetNodeName())
+		}
+	}
+	return names
+}
+
+func TestPlanReservation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		priority             int32
+		minMember            int
+		maxReservation       int64
+		expectedOK           bool
+		expectedNodes        []string
+		expectedReservedTime time.Time
+	}{
+		{
+			name:                 "lower priority pods release resources immediately",
+			priority:             100,
+			minMember:            2,
+			expectedOK:           true,
+			expectedNodes:        []string{"n1", "n3"},
+			expectedReservedTime: testStartTime.Add(10 * time.Minute),
+		},
+		{
+			name:                 "pods with the same priority release resources at expected end time",
+			priority:             0,
+			minMember:            2,
+			expectedOK:           true,
+			expectedNodes:        []string{"n1", "n2"},
+			expectedReservedTime: testStartTime.Add(30 * time.Minute),
+		},
+		{
+			name:           "pods without expected duration run forever",
+			priority:       0,
+			minMember:      3,
+			expectedOK:     false,
+			maxReservation: 3600,
+		},
+		{
+			name:           "release time beyond max reservation duration",
+			priority:       0,
+			minMember:      2,
+			maxReservation: 900,
+			expectedOK:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager(&config.BackfillConfig{MaxReservationSeconds: tt.maxReservation}, clock.NewFakeClock(testStartTime))
+			unit := makeUnit("pg", tt.priority, tt.minMember)
+			nodes, reservedTime, ok := m.planReservation(unit, tt.minMember, makeNodeGroup(), testStartTime)
+			if ok != tt.expectedOK {
+				t.Fatalf("expected ok %v, got %v", tt.expectedOK, ok)
+			}
+			if !ok {
+				return
+			}
+			if !nodes.Equal(sets.NewString(tt.expectedNodes...)) {
+				t.Errorf("expected nodes %v, got %v", tt.expectedNodes, nodes.List())
+			}
+			if !reservedTime.Equal(tt.expectedReservedTime) {
+				t.Errorf("expected reserved time %v, got %v", tt.expectedReservedTime, reservedTime)
+			}
+		})
+	}
+}
+
+func TestManager(t *testing.T) {
+	fakeClock := clock.NewFakeClock(testStartTime)
+	m := NewManager(&config.BackfillConfig{MinMember: 2, MaxReservationSeconds: 1800}, fakeClock)
+	nodeGroup := makeNodeGroup()
+
+	// Units smaller than min member don't reserve nodes.
+	m.Reserve(makeUnit("small", 100, 1), 1, nodeGroup)
+	if r := m.GetReservation(); r != nil {
+		t.Fatalf("expected no reservation, got %v", r.UnitKey)
+	}
+
+	blocked := makeUnit("blocked", 100, 2)
+	m.Reserve(blocked, 2, nodeGroup)
+	r := m.GetReservation()
+	if r == nil || r.UnitKey != blocked.UnitKey || !r.Nodes.Equal(sets.NewString("n1", "n3")) {
+		t.Fatalf("unexpected reservation %+v", r)
+	}
+
+	admitTests := []struct {
+		name          string
+		unit          *framework.QueuedUnitInfo
+		expectedNodes []string
+	}{
+		{
+			name:          "unit holding the reservation",
+			unit:          blocked,
+			expectedNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:          "unit finishing before the reservation time",
+			unit:          makeUnit("short", 100, 1, withExpectedDuration(300)),
+			expectedNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:          "unit that can be preempted",
+			unit:          makeUnit("preemptible", 10, 1, withPriority(10, "low")),
+			expectedNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:          "unit with higher priority",
+			unit:          makeUnit("urgent", 200, 1),
+			expectedNodes: []string{"n1", "n2", "n3"},
+		},
+		{
+			name:          "unit running longer than the reservation time",
+			unit:          makeUnit("long", 10, 1, withExpectedDuration(1200)),
+			expectedNodes: []string{"n2"},
+		},
+		{
+			name:          "unit with the same priority",
+			unit:          makeUnit("same", 100, 1),
+			expectedNodes: []string{"n2"},
+		},
+	}
+	for _, tt := range admitTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeNames(m.Admit(tt.unit, nodeGroup)); !got.Equal(sets.NewString(tt.expectedNodes...)) {
+				t.Errorf("expected nodes %v, got %v", tt.expectedNodes, got.List())
+			}
+		})
+	}
+
+	// A unit with lower priority can't replace the reservation.
+	m.Reserve(makeUnit("lower", 50, 2), 2, nodeGroup)
+	if r := m.GetReservation(); r.UnitKey != blocked.UnitKey {
+		t.Fatalf("expected reservation of %v, got %v", blocked.UnitKey, r.UnitKey)
+	}
+
+	// A unit with higher priority replaces the reservation.
+	higher := makeUnit("higher", 200, 2)
+	m.Reserve(higher, 2, nodeGroup)
+	if r := m.GetReservation(); r.UnitKey != higher.UnitKey {
+		t.Fatalf("expected reservation of %v, got %v", higher.UnitKey, r.UnitKey)
+	}
+
+	// Releasing the reservation of another unit is a no-op.
+	m.Release(blocked.UnitKey, ResultScheduled)
+	if r := m.GetReservation(); r == nil {
+		t.Fatalf("expected reservation of %v, got nil", higher.UnitKey)
+	}
+	m.Release(higher.UnitKey, ResultScheduled)
+	if r := m.GetReservation(); r != nil {
+		t.Fatalf("expected no reservation, got %v", r.UnitKey)
+	}
+
+	// The reservation is released once the unit is deleted or timed out.
+	for _, result := range []string{ResultDeleted, ResultTimeout} {
+		m.Reserve(blocked, 2, nodeGroup)
+		m.Release(blocked.UnitKey, result)
+		if r := m.GetReservation(); r != nil {
+			t.Fatalf("expected no reservation after the unit is %v, got %v", result, r.UnitKey)
+		}
+	}
+
+	// Retrying keeps the created time, and the reservation expires after the max reservation duration.
+	m.Reserve(blocked, 2, nodeGroup)
+	fakeClock.Step(20 * time.Minute)
+	m.Reserve(blocked, 2, nodeGroup)
+	if r := m.GetReservation(); !r.CreatedTime.Equal(testStartTime) {
+		t.Fatalf("expected created time %v, got %v", testStartTime, r.CreatedTime)
+	}
+	fakeClock.Step(20 * time.Minute)
+	if got := nodeNames(m.Admit(makeUnit("long", 10, 1), nodeGroup)); got.Len() != 3 {
+		t.Errorf("expected all nodes after the reservation expired, got %v", got.List())
+	}
+	if r := m.GetReservation(); r != nil {
+		t.Fatalf("expected no reservation, got %v", r.UnitKey)
+	}
+}
+
+func TestNilManager(t *testing.T) {
+	m := NewManager(nil, clock.RealClock{})
+	if m != nil {
+		t.Fatalf("expected nil manager")
+	}
+	nodeGroup := makeNodeGroup()
+	unit := makeUnit("pg", 0, 2)
+	m.Reserve(unit, 2, nodeGroup)
+	m.Release(unit.UnitKey, ResultScheduled)
+	if got := nodeNames(m.Admit(unit, nodeGroup)); got.Len() != 3 {
+		t.Errorf("expected all nodes, got %v", got.List())
+	}
+	if m.GetReservation() != nil {
+		t.Errorf("expected no reservation")
+	}
+}
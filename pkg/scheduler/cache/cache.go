@@ -65,7 +65,7 @@ func newSchedulerCache(handler commoncache.CacheHandler) *schedulerCache {
 	cacheMetrics := newCacheMetrics()
 
 	sc := &schedulerCache{
-		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Cache, commonstores.GlobalRegistries, orderedStoreNames),
+		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Cache, commonstores.GlobalRegistries, storeNames()),
 
 		handler: handler,
 		mu:      handler.Mutex(),
@@ -162,6 +162,16 @@ func (cache *schedulerCache) updateMetrics() {
 	metrics.CacheSize.WithLabelValues("nodes", schedulerName).Set(float64(cacheMetrics.nodeTotalCount))
 	metrics.CacheSize.WithLabelValues("nmnodes", schedulerName).Set(float64(cacheMetrics.nmnodeTotalCount))
 	metrics.CacheSize.WithLabelValues("hybrid_nodes", schedulerName).Set(float64(cacheMetrics.hybridHostTotalCount))
+
+	metrics.OvercommitNodes.Reset()
+	metrics.OvercommitAllocatable.Reset()
+	for profile, summary := range nodeStore.OvercommitSummaries() {
+		metrics.OvercommitNodes.WithLabelValues(profile, schedulerName).Set(float64(summary.Nodes))
+		for qos, allocatable := range map[string]*framework.Resource{Guaranteed: summary.GuaranteedAllocatable, BestEffort: summary.BestEffortAllocatable} {
+			metrics.OvercommitAllocatable.WithLabelValues(profile, qos, "cpu", schedulerName).Set(float64(allocatable.MilliCPU))
+			metrics.OvercommitAllocatable.WithLabelValues(profile, qos, "memory", schedulerName).Set(float64(allocatable.Memory))
+		}
+	}
 }
 
 // -------------------------------------- Other Interface --------------------------------------
@@ -218,6 +228,13 @@ func (cache *schedulerCache) Dump() *commoncache.Dump {
 	}
 }
 
+func (cache *schedulerCache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+
+	return commonstore.DumpStores(cache.CommonStoresSwitch, names...)
+}
+
 func (cache *schedulerCache) IsAssumedPod(pod *v1.Pod) (bool, error) {
 	cache.mu.RLock()
 	defer cache.mu.RUnlock()
@@ -17,6 +17,11 @@ limitations under the License.
 package loadawarestore
 
 import (
+	"math"
+	"sort"
+	"time"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
@@ -27,8 +32,16 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+const (
+	// MaxUsageSamples is the max number of samples kept by a node usage history or an owner usage profile.
+	MaxUsageSamples = 120
+	// UsageHistoryRetention is the max age of samples kept by a node usage history or an owner usage profile.
+	UsageHistoryRetention = time.Hour
+)
+
 type podBasicInfo struct {
 	Key              string
+	OwnerKey         string
 	MilliCPU, Memory int64
 	PodResourceType  podutil.PodResourceType
 }
@@ -40,6 +53,7 @@ func newPodBasicInfo(pod *v1.Pod) (*podBasicInfo, error) {
 	}
 	return &podBasicInfo{
 		Key:             podutil.GetPodKey(pod),
+		OwnerKey:        podutil.GetPodOwner(pod),
 		MilliCPU:        podutil.GetPodRequest(pod, v1.ResourceCPU, resource.DecimalSI).MilliValue(),
 		Memory:          podutil.GetPodRequest(pod, v1.ResourceMemory, resource.DecimalSI).Value(),
 		PodResourceType: resourceType,
@@ -92,6 +106,15 @@ func (i *PodMetricInfos) Clone() *PodMetricInfos {
 	return &clone
 }
 
+func (i *PodMetricInfos) usage() *framework.LoadAwareNodeUsage {
+	return &framework.LoadAwareNodeUsage{
+		ProfileMilliCPU: i.ProfileMilliCPU,
+		ProfileMEM:      i.ProfileMEM,
+		RequestMilliCPU: i.RequestMilliCPU,
+		RequestMEM:      i.RequestMEM,
+	}
+}
+
 // ----------------------------------- NodeMetricInfo -----------------------------------
 
 // ATTENTION: the NodeMetricInfo's lifecycle is accompanied by CNR, not CNR.Status.NodeMetricStatus
@@ -102,7 +125,9 @@ type NodeMetricInfo struct {
 	gtPodMetricInfos *PodMetricInfos
 	bePodMetricInfos *PodMetricInfos
 	allPods          map[string]podBasicInfo
-	generation       int64
+	// history records the profile usage of each CNR update, ordered by timestamp.
+	history    []nodeUsageSample
+	generation int64
 }
 
 var _ generationstore.StoredObj = &NodeMetricInfo{}
@@ -176,10 +201,160 @@ func (i *NodeMetricInfo) Clone() *NodeMetricInfo {
 		gtPodMetricInfos: i.gtPodMetricInfos.Clone(),
 		bePodMetricInfos: i.bePodMetricInfos.Clone(),
 		allPods:          cloneAllPods(i.allPods),
+		history:          append([]nodeUsageSample(nil), i.history...),
 		generation:       i.generation,
 	}
 }
 
+// recordHistory appends the current profile usage to the history if the CNR has been refreshed.
+func (i *NodeMetricInfo) recordHistory() {
+	if !i.cnrExist || i.updateTime.IsZero() {
+		return
+	}
+	if n := len(i.history); n > 0 && !i.history[n-1].timestamp.Before(i.updateTime.Time) {
+		return
+	}
+	i.history = append(i.history, nodeUsageSample{
+		timestamp:  i.updateTime.Time,
+		gtMilliCPU: i.gtPodMetricInfos.ProfileMilliCPU,
+		gtMEM:      i.gtPodMetricInfos.ProfileMEM,
+		beMilliCPU: i.bePodMetricInfos.ProfileMilliCPU,
+		beMEM:      i.bePodMetricInfos.ProfileMEM,
+	})
+	if start := expiredSamples(len(i.history), func(idx int) time.Time { return i.history[idx].timestamp }); start > 0 {
+		i.history = append([]nodeUsageSample(nil), i.history[start:]...)
+	}
+}
+
+// peakUsage returns the node usage whose profile part is the peak value observed in the window
+// ending at the latest CNR update time. The request part is always the latest one.
+func (i *NodeMetricInfo) peakUsage(resourceType podutil.PodResourceType, window time.Duration) *framework.LoadAwareNodeUsage {
+	var usage *framework.LoadAwareNodeUsage
+	if resourceType == podutil.GuaranteedPod {
+		usage = i.gtPodMetricInfos.usage()
+	} else {
+		usage = i.bePodMetricInfos.usage()
+	}
+	if window <= 0 || !i.cnrExist {
+		return usage
+	}
+	since := i.updateTime.Add(-window)
+	for idx := len(i.history) - 1; idx >= 0 && !i.history[idx].timestamp.Before(since); idx-- {
+		milliCPU, mem := i.history[idx].gtMilliCPU, i.history[idx].gtMEM
+		if resourceType != podutil.GuaranteedPod {
+			milliCPU, mem = i.history[idx].beMilliCPU, i.history[idx].beMEM
+		}
+		if milliCPU > usage.ProfileMilliCPU {
+			usage.ProfileMilliCPU = milliCPU
+		}
+		if mem > usage.ProfileMEM {
+			usage.ProfileMEM = mem
+		}
+	}
+	return usage
+}
+
+type nodeUsageSample struct {
+	timestamp         time.Time
+	gtMilliCPU, gtMEM int64
+	beMilliCPU, beMEM int64
+}
+
+// ----------------------------------- OwnerUsageProfile -----------------------------------
+
+// OwnerUsageProfile records the usage/request ratios observed from the pods of the same owner.
+// The ratio of each sample is the total usage of the owner's pods divided by their total requests.
+type OwnerUsageProfile struct {
+	samples    []ownerUsageSample
+	generation int64
+}
+
+type ownerUsageSample struct {
+	timestamp               time.Time
+	milliCPURatio, memRatio float64
+}
+
+var _ generationstore.StoredObj = &OwnerUsageProfile{}
+
+func NewOwnerUsageProfile() *OwnerUsageProfile {
+	return &OwnerUsageProfile{}
+}
+
+func (p *OwnerUsageProfile) GetGeneration() int64 {
+	return p.generation
+}
+
+func (p *OwnerUsageProfile) SetGeneration(generation int64) {
+	p.generation = generation
+}
+
+func (p *OwnerUsageProfile) AddSample(timestamp time.Time, milliCPURatio, memRatio float64) {
+	p.samples = append(p.samples, ownerUsageSample{timestamp: timestamp, milliCPURatio: milliCPURatio, memRatio: memRatio})
+	// Samples come from different nodes, keep them ordered by timestamp.
+	sort.SliceStable(p.samples, func(i, j int) bool { return p.samples[i].timestamp.Before(p.samples[j].timestamp) })
+	if start := expiredSamples(len(p.samples), func(idx int) time.Time { return p.samples[idx].timestamp }); start > 0 {
+		p.samples = append([]ownerUsageSample(nil), p.samples[start:]...)
+	}
+}
+
+// Usage returns the given percentile of the ratios.
+func (p *OwnerUsageProfile) Usage(percentile int64) *framework.LoadAwareOwnerUsage {
+	cpuRatios := make([]float64, len(p.samples))
+	memRatios := make([]float64, len(p.samples))
+	for i := range p.samples {
+		cpuRatios[i] = p.samples[i].milliCPURatio
+		memRatios[i] = p.samples[i].memRatio
+	}
+	return &framework.LoadAwareOwnerUsage{
+		Samples:       len(p.samples),
+		MilliCPURatio: percentileOf(cpuRatios, percentile),
+		MEMRatio:      percentileOf(memRatios, percentile),
+	}
+}
+
+func (p *OwnerUsageProfile) latestTimestamp() time.Time {
+	if len(p.samples) == 0 {
+		return time.Time{}
+	}
+	return p.samples[len(p.samples)-1].timestamp
+}
+
+func (p *OwnerUsageProfile) Clone() *OwnerUsageProfile {
+	return &OwnerUsageProfile{
+		samples:    append([]ownerUsageSample(nil), p.samples...),
+		generation: p.generation,
+	}
+}
+
+// expiredSamples returns the number of the oldest samples which exceed MaxUsageSamples or
+// UsageHistoryRetention. The samples must be ordered by timestamp.
+func expiredSamples(n int, timestampOf func(int) time.Time) int {
+	start := 0
+	if n > MaxUsageSamples {
+		start = n - MaxUsageSamples
+	}
+	if n > 0 {
+		since := timestampOf(n - 1).Add(-UsageHistoryRetention)
+		for start < n-1 && timestampOf(start).Before(since) {
+			start++
+		}
+	}
+	return start
+}
+
+// percentileOf returns the nearest-rank percentile of values.
+func percentileOf(values []float64, percentile int64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	rank := int(math.Ceil(float64(percentile)/100*float64(len(values)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return values[rank]
+}
+
 func parseUpdateTimeFromCNR(cnr *katalystv1alpha1.CustomNodeResource) metav1.Time {
 	if cnr == nil || cnr.Status.NodeMetricStatus == nil {
 		return metav1.Time{}
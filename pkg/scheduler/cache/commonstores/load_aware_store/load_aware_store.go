@@ -17,8 +17,17 @@ limitations under the License.
 package loadawarestore
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
@@ -53,6 +62,8 @@ type LoadAwareStore struct {
 
 	// NodeMetricInfo
 	Store generationstore.Store
+	// OwnerUsageProfile, only updated by Cache.
+	ProfileStore generationstore.Store
 }
 
 func NewCache(handler commoncache.CacheHandler) commonstore.Store {
@@ -61,7 +72,8 @@ func NewCache(handler commoncache.CacheHandler) commonstore.Store {
 		storeType: commonstore.Cache,
 		handler:   handler,
 
-		Store: generationstore.NewListStore(),
+		Store:        generationstore.NewListStore(),
+		ProfileStore: generationstore.NewListStore(),
 	}
 }
 
@@ -71,7 +83,8 @@ func NewSnapshot(handler commoncache.CacheHandler) commonstore.Store {
 		storeType: commonstore.Snapshot,
 		handler:   handler,
 
-		Store: generationstore.NewRawStore(),
+		Store:        generationstore.NewRawStore(),
+		ProfileStore: generationstore.NewRawStore(),
 	}
 }
 
@@ -82,14 +95,18 @@ func (s *LoadAwareStore) AddCNR(cnr *katalystv1alpha1.CustomNodeResource) error
 }
 
 func (s *LoadAwareStore) UpdateCNR(oldCNR, newCNR *katalystv1alpha1.CustomNodeResource) error {
-	if err := s.nodeMetricOp(oldCNR, false); err != nil {
-		return err
+	// The NodeMetricInfo will be reset by newCNR, only remove it explicitly when the node changed,
+	// so that the usage history of the node can be kept.
+	if oldCNR.Name != newCNR.Name {
+		if err := s.nodeMetricOp(oldCNR, false); err != nil {
+			return err
+		}
 	}
 	return s.nodeMetricOp(newCNR, true)
 }
 
 func (s *LoadAwareStore) DeleteCNR(cnr *katalystv1alpha1.CustomNodeResource) error {
-	return s.nodeMetricOp(cnr, true)
+	return s.nodeMetricOp(cnr, false)
 }
 
 func (s *LoadAwareStore) AddPod(pod *v1.Pod) error {
@@ -148,6 +165,14 @@ func (s *LoadAwareStore) UpdateSnapshot(store commonstore.Store) error {
 		},
 		generationstore.DefaultCleanFunc(cache, snapshot),
 	)
+	profileCache, profileSnapshot := framework.TransferGenerationStore(s.ProfileStore, store.(*LoadAwareStore).ProfileStore)
+	profileCache.UpdateRawStore(
+		profileSnapshot,
+		func(key string, so generationstore.StoredObj) {
+			profileSnapshot.Set(key, so.(*OwnerUsageProfile).Clone())
+		},
+		generationstore.DefaultCleanFunc(profileCache, profileSnapshot),
+	)
 	return nil
 }
 
@@ -202,6 +227,7 @@ func (s *LoadAwareStore) nodeMetricOp(cnr *katalystv1alpha1.CustomNodeResource,
 		} else {
 			nodeMetricInfo = NewNodeMetricInfo(nodeName, cnr)
 		}
+		nodeMetricInfo.recordHistory()
 		s.Store.Set(nodeName, nodeMetricInfo)
 	} else {
 		if nodeMetricInfoObj := s.Store.Get(nodeName); nodeMetricInfoObj != nil {
@@ -210,6 +236,7 @@ func (s *LoadAwareStore) nodeMetricOp(cnr *katalystv1alpha1.CustomNodeResource,
 			return nil
 		}
 		nodeMetricInfo.Reset(nil) // Use nil to delete cnr informations.
+		nodeMetricInfo.history = nil
 		if nodeMetricInfo.CanBeRecycle() {
 			s.Store.Delete(nodeName)
 		} else {
@@ -219,11 +246,119 @@ func (s *LoadAwareStore) nodeMetricOp(cnr *katalystv1alpha1.CustomNodeResource,
 	return nil
 }
 
+// PeriodWorker samples the usage of running pods to build the usage profile of each owner if
+// the PodMetricsLister is provided, and recycles the profiles of owners which are gone.
+func (s *LoadAwareStore) PeriodWorker(mu *sync.RWMutex) {
+	if s.storeType != commonstore.Cache {
+		return
+	}
+	lister, period := s.handler.PodMetricsLister(), s.handler.PodMetricsSamplePeriod()
+	if lister == nil || period <= 0 {
+		return
+	}
+	go wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), period)
+		defer cancel()
+		podMetrics, err := lister.List(ctx)
+		if err != nil {
+			klog.ErrorS(err, "Failed to list pod metrics")
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		s.AddPodMetrics(podMetrics)
+		s.recycleOwnerUsage(time.Now())
+	}, period, s.handler.StopCh())
+}
+
+// AddPodMetrics adds a usage sample to the profile of each owner, the usage/request ratio of the
+// sample is calculated from the metrics of the owner's own pods.
+func (s *LoadAwareStore) AddPodMetrics(podMetrics []metricsv1beta1.PodMetrics) {
+	if len(podMetrics) == 0 {
+		return
+	}
+	pods := make(map[string]podBasicInfo)
+	s.Store.Range(func(_ string, obj generationstore.StoredObj) {
+		for key, pInfo := range obj.(*NodeMetricInfo).allPods {
+			if len(pInfo.OwnerKey) > 0 {
+				pods[key] = pInfo
+			}
+		}
+	})
+
+	type ownerUsage struct {
+		timestamp                   time.Time
+		usageMilliCPU, usageMEM     int64
+		requestMilliCPU, requestMEM int64
+	}
+	owners := make(map[string]*ownerUsage)
+	for i := range podMetrics {
+		pm := &podMetrics[i]
+		pInfo, ok := pods[pm.Namespace+"/"+pm.Name]
+		if !ok {
+			continue
+		}
+		usage := owners[pInfo.OwnerKey]
+		if usage == nil {
+			usage = &ownerUsage{}
+			owners[pInfo.OwnerKey] = usage
+		}
+		for _, c := range pm.Containers {
+			usage.usageMilliCPU += c.Usage.Cpu().MilliValue()
+			usage.usageMEM += c.Usage.Memory().Value()
+		}
+		usage.requestMilliCPU += pInfo.MilliCPU
+		usage.requestMEM += pInfo.Memory
+		if pm.Timestamp.After(usage.timestamp) {
+			usage.timestamp = pm.Timestamp.Time
+		}
+	}
+
+	for ownerKey, usage := range owners {
+		if usage.requestMilliCPU == 0 || usage.requestMEM == 0 {
+			continue
+		}
+		var profile *OwnerUsageProfile
+		if obj := s.ProfileStore.Get(ownerKey); obj != nil {
+			profile = obj.(*OwnerUsageProfile)
+		} else {
+			profile = NewOwnerUsageProfile()
+		}
+		profile.AddSample(usage.timestamp,
+			float64(usage.usageMilliCPU)/float64(usage.requestMilliCPU),
+			float64(usage.usageMEM)/float64(usage.requestMEM))
+		s.ProfileStore.Set(ownerKey, profile)
+	}
+}
+
+// recycleOwnerUsage deletes the profiles whose owner has no pod left, or whose samples are all
+// out of UsageHistoryRetention.
+func (s *LoadAwareStore) recycleOwnerUsage(now time.Time) {
+	owners := sets.NewString()
+	s.Store.Range(func(_ string, obj generationstore.StoredObj) {
+		for _, pInfo := range obj.(*NodeMetricInfo).allPods {
+			owners.Insert(pInfo.OwnerKey)
+		}
+	})
+	var expired []string
+	s.ProfileStore.Range(func(ownerKey string, obj generationstore.StoredObj) {
+		if !owners.Has(ownerKey) || obj.(*OwnerUsageProfile).latestTimestamp().Add(UsageHistoryRetention).Before(now) {
+			expired = append(expired, ownerKey)
+		}
+	})
+	for _, ownerKey := range expired {
+		s.ProfileStore.Delete(ownerKey)
+	}
+}
+
 // -------------------------------------- Other Interface --------------------------------------
 
 type StoreHandle interface {
 	GetLoadAwareNodeMetricInfo(nodeName string, resourceType podutil.PodResourceType) *framework.LoadAwareNodeMetricInfo
 	GetLoadAwareNodeUsage(nodeName string, resourceType podutil.PodResourceType) *framework.LoadAwareNodeUsage
+	GetLoadAwareNodePeakUsage(nodeName string, resourceType podutil.PodResourceType, window time.Duration) *framework.LoadAwareNodeUsage
+	GetLoadAwareOwnerUsage(ownerKey string, percentile int64) *framework.LoadAwareOwnerUsage
 }
 
 var _ StoreHandle = &LoadAwareStore{}
@@ -281,10 +416,54 @@ func (s *LoadAwareStore) GetLoadAwareNodeUsage(nodeName string, resourceType pod
 	} else {
 		podMetricsInfos = nodeMetricInfo.bePodMetricInfos
 	}
-	return &framework.LoadAwareNodeUsage{
-		ProfileMilliCPU: podMetricsInfos.ProfileMilliCPU,
-		ProfileMEM:      podMetricsInfos.ProfileMEM,
-		RequestMilliCPU: podMetricsInfos.RequestMilliCPU,
-		RequestMEM:      podMetricsInfos.RequestMEM,
+	return podMetricsInfos.usage()
+}
+
+// GetLoadAwareNodePeakUsage is similar to GetLoadAwareNodeUsage, but the profile usage is the peak value
+// observed in the window ending at the latest CNR update time.
+func (s *LoadAwareStore) GetLoadAwareNodePeakUsage(nodeName string, resourceType podutil.PodResourceType, window time.Duration) *framework.LoadAwareNodeUsage {
+	nodeMetricInfoObj := s.Store.Get(nodeName)
+	if nodeMetricInfoObj == nil {
+		return s.GetLoadAwareNodeUsage(nodeName, resourceType)
+	}
+	return nodeMetricInfoObj.(*NodeMetricInfo).peakUsage(resourceType, window)
+}
+
+// GetLoadAwareOwnerUsage returns the usage profile of the owner, nil will be returned if there is no sample.
+func (s *LoadAwareStore) GetLoadAwareOwnerUsage(ownerKey string, percentile int64) *framework.LoadAwareOwnerUsage {
+	if len(ownerKey) == 0 {
+		return nil
 	}
+	obj := s.ProfileStore.Get(ownerKey)
+	if obj == nil {
+		return nil
+	}
+	return obj.(*OwnerUsageProfile).Usage(percentile)
+}
+
+// NodeMetricInfoDump is the debugging view of a NodeMetricInfo.
+type NodeMetricInfoDump struct {
+	CNRExist        bool                          `json:"cnrExist"`
+	UpdateTime      metav1.Time                   `json:"updateTime"`
+	PodCount        int                           `json:"podCount"`
+	GuaranteedUsage *framework.LoadAwareNodeUsage `json:"guaranteedUsage"`
+	BestEffortUsage *framework.LoadAwareNodeUsage `json:"bestEffortUsage"`
+}
+
+var _ commonstore.Dumpable = &LoadAwareStore{}
+
+// Dump returns the metric infos grouped by node name.
+func (s *LoadAwareStore) Dump() interface{} {
+	ret := make(map[string]*NodeMetricInfoDump, s.Store.Len())
+	s.Store.Range(func(nodeName string, obj generationstore.StoredObj) {
+		info := obj.(*NodeMetricInfo)
+		ret[nodeName] = &NodeMetricInfoDump{
+			CNRExist:        info.cnrExist,
+			UpdateTime:      info.updateTime,
+			PodCount:        len(info.allPods),
+			GuaranteedUsage: info.gtPodMetricInfos.usage(),
+			BestEffortUsage: info.bePodMetricInfos.usage(),
+		}
+	})
+	return ret
 }
@@ -24,6 +24,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
@@ -265,3 +267,127 @@ func TestLoadAwareStore_UpdateSnapshot(t *testing.T) {
 		})
 	}
 }
+
+func TestOwnerUsageProfile(t *testing.T) {
+	base := time.Now()
+	profile := NewOwnerUsageProfile()
+	// Samples from different nodes may arrive out of order.
+	profile.AddSample(base.Add(2*time.Second), 0.8, 0.4)
+	profile.AddSample(base, 0.5, 0.2)
+	profile.AddSample(base.Add(time.Second), 0.2, 0.6)
+
+	if diff := cmp.Diff(&framework.LoadAwareOwnerUsage{Samples: 3, MilliCPURatio: 0.5, MEMRatio: 0.4}, profile.Usage(50)); len(diff) > 0 {
+		t.Errorf("Got diff: %v", diff)
+	}
+	if diff := cmp.Diff(&framework.LoadAwareOwnerUsage{Samples: 3, MilliCPURatio: 0.8, MEMRatio: 0.6}, profile.Usage(95)); len(diff) > 0 {
+		t.Errorf("Got diff: %v", diff)
+	}
+
+	// Samples out of retention should be dropped.
+	profile.AddSample(base.Add(UsageHistoryRetention+time.Second/2), 0.1, 0.1)
+	if diff := cmp.Diff(&framework.LoadAwareOwnerUsage{Samples: 3, MilliCPURatio: 0.8, MEMRatio: 0.6}, profile.Usage(100)); len(diff) > 0 {
+		t.Errorf("Got diff: %v", diff)
+	}
+
+	for i := 0; i < 2*MaxUsageSamples; i++ {
+		profile.AddSample(base.Add(UsageHistoryRetention+time.Second), 0.3, 0.3)
+	}
+	if got := profile.Usage(100).Samples; got != MaxUsageSamples {
+		t.Errorf("Expected %v samples, got %v", MaxUsageSamples, got)
+	}
+}
+
+func TestLoadAwareStore_AddPodMetrics(t *testing.T) {
+	ownedPod := func(name, owner string) *v1.Pod {
+		return testinghelper.MakePod().Name(name).UID(name).
+			Annotation(podutil.PodStateAnnotationKey, string(podutil.PodAssumed)).Annotation(podutil.SchedulerAnnotationKey, "godel-scheduler").
+			Annotation(podutil.AssumedNodeAnnotationKey, nodeName).Req(map[v1.ResourceName]string{v1.ResourceCPU: "100m", v1.ResourceMemory: "100"}).
+			ControllerRef(metav1.OwnerReference{Kind: podutil.ReplicaSetKind, Name: owner, UID: types.UID(owner)}).
+			Obj()
+	}
+	podMetrics := func(name string, timestamp time.Time, milliCPU, mem int64) metricsv1beta1.PodMetrics {
+		return metricsv1beta1.PodMetrics{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Timestamp:  metav1.NewTime(timestamp),
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{
+					Usage: v1.ResourceList{
+						v1.ResourceCPU:    *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+						v1.ResourceMemory: *resource.NewQuantity(mem, resource.BinarySI),
+					},
+				},
+			},
+		}
+	}
+
+	// Both owners run on the same node and share the same QoS group.
+	pods := []*v1.Pod{ownedPod("a0", "rs-a"), ownedPod("a1", "rs-a"), ownedPod("b0", "rs-b")}
+	nodeInfo := framework.NewNodeInfo()
+	handler := commoncache.MakeCacheHandlerWrapper().
+		NodeHandler(func(s string) framework.NodeInfo { return nodeInfo }).Obj()
+	cache := NewCache(handler).(*LoadAwareStore)
+	for _, p := range pods {
+		cache.AddPod(p)
+		nodeInfo.AddPod(p)
+	}
+	cache.AddCNR(cnr)
+
+	cache.AddPodMetrics([]metricsv1beta1.PodMetrics{
+		podMetrics("a0", now.Add(-time.Second), 20, 40),
+		podMetrics("a1", now, 60, 40),
+		podMetrics("b0", now, 90, 30),
+		podMetrics("unknown", now, 100, 100),
+	})
+	cache.AddPodMetrics([]metricsv1beta1.PodMetrics{
+		podMetrics("a0", now.Add(time.Second), 40, 80),
+		podMetrics("a1", now.Add(time.Second), 40, 80),
+	})
+
+	ownerA, ownerB := podutil.GetPodOwner(pods[0]), podutil.GetPodOwner(pods[2])
+	if diff := cmp.Diff(&framework.LoadAwareOwnerUsage{Samples: 2, MilliCPURatio: 0.4, MEMRatio: 0.8}, cache.GetLoadAwareOwnerUsage(ownerA, 100)); len(diff) > 0 {
+		t.Errorf("Got owner %v diff: %v", ownerA, diff)
+	}
+	if diff := cmp.Diff(&framework.LoadAwareOwnerUsage{Samples: 1, MilliCPURatio: 0.9, MEMRatio: 0.3}, cache.GetLoadAwareOwnerUsage(ownerB, 100)); len(diff) > 0 {
+		t.Errorf("Got owner %v diff: %v", ownerB, diff)
+	}
+
+	// The profile of the owner whose pods are all gone should be recycled.
+	cache.DeletePod(pods[2])
+	cache.recycleOwnerUsage(now.Add(time.Second))
+	if cache.ProfileStore.Get(ownerA) == nil {
+		t.Errorf("Expected profile of owner %v to be kept", ownerA)
+	}
+	if cache.ProfileStore.Get(ownerB) != nil {
+		t.Errorf("Expected profile of owner %v to be recycled", ownerB)
+	}
+
+	// The profile whose samples are all out of retention should be recycled.
+	cache.recycleOwnerUsage(now.Add(UsageHistoryRetention + 2*time.Second))
+	if cache.ProfileStore.Get(ownerA) != nil {
+		t.Errorf("Expected profile of owner %v to be recycled", ownerA)
+	}
+}
+
+func TestLoadAwareStore_DeleteCNR(t *testing.T) {
+	handler := commoncache.MakeCacheHandlerWrapper().
+		NodeHandler(func(s string) framework.NodeInfo { return framework.NewNodeInfo() }).Obj()
+	cache := NewCache(handler).(*LoadAwareStore)
+	cache.AddPod(p0)
+	cache.AddCNR(cnr)
+	if got := cache.GetLoadAwareNodePeakUsage(nodeName, podutil.GuaranteedPod, time.Hour); got == nil || got.ProfileMilliCPU != 250 {
+		t.Fatalf("Expected peak usage from history, got %+v", got)
+	}
+
+	if err := cache.DeleteCNR(cnr); err != nil {
+		t.Fatalf("DeleteCNR got error: %v", err)
+	}
+	info := cache.Store.Get(nodeName).(*NodeMetricInfo)
+	if info.cnrExist || len(info.history) != 0 {
+		t.Errorf("Expected cnr and history to be removed, got cnrExist=%v history=%v", info.cnrExist, len(info.history))
+	}
+
+	cache.DeletePod(p0)
+	if cache.Store.Get(nodeName) != nil {
+		t.Errorf("Expected node metric info to be recycled")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepoolstore
+
+import (
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	corelister "k8s.io/client-go/listers/core/v1"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	"github.com/kubewharf/godel-scheduler/pkg/features"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/localstoragepool"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const Name commonstore.StoreName = "LocalStoragePoolStore"
+
+func (s *LocalStoragePoolStore) Name() commonstore.StoreName {
+	return Name
+}
+
+func init() {
+	commonstores.GlobalRegistries.Register(
+		Name,
+		func(h commoncache.CacheHandler) bool {
+			return utilfeature.DefaultFeatureGate.Enabled(features.LocalStoragePool)
+		},
+		NewCache,
+		NewSnapshot)
+}
+
+// -------------------------------------- LocalStoragePoolStore --------------------------------------
+
+// LocalStoragePoolStore tracks the capacity of node-local storage pools and the local volume requests of
+// pods placed on each node.
+type LocalStoragePoolStore struct {
+	commonstore.BaseStore
+	storeType commonstore.StoreType
+	handler   commoncache.CacheHandler
+
+	pools *framework.LocalStoragePools
+}
+
+var _ commonstore.Store = &LocalStoragePoolStore{}
+
+func NewCache(handler commoncache.CacheHandler) commonstore.Store {
+	return &LocalStoragePoolStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Cache,
+		handler:   handler,
+
+		pools: framework.NewCacheLocalStoragePools(),
+	}
+}
+
+func NewSnapshot(handler commoncache.CacheHandler) commonstore.Store {
+	return &LocalStoragePoolStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Snapshot,
+		handler:   handler,
+
+		pools: framework.NewSnapshotLocalStoragePools(),
+	}
+}
+
+// -------------------------------------- ClusterCache --------------------------------------
+
+func (s *LocalStoragePoolStore) AddNode(node *v1.Node) error {
+	s.pools.SetNodeCapacity(node.Name, framework.GetLocalStoragePoolCapacity(node.Status.Allocatable))
+	return nil
+}
+
+func (s *LocalStoragePoolStore) UpdateNode(_, newNode *v1.Node) error {
+	return s.AddNode(newNode)
+}
+
+func (s *LocalStoragePoolStore) DeleteNode(node *v1.Node) error {
+	s.pools.SetNodeCapacity(node.Name, nil)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) AddCNR(cnr *katalystv1alpha1.CustomNodeResource) error {
+	var capacity map[string]int64
+	if allocatable := cnr.Status.Resources.Allocatable; allocatable != nil {
+		capacity = framework.GetLocalStoragePoolCapacity(*allocatable)
+	}
+	s.pools.SetCNRCapacity(cnr.Name, capacity)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) UpdateCNR(_, newCNR *katalystv1alpha1.CustomNodeResource) error {
+	return s.AddCNR(newCNR)
+}
+
+func (s *LocalStoragePoolStore) DeleteCNR(cnr *katalystv1alpha1.CustomNodeResource) error {
+	s.pools.SetCNRCapacity(cnr.Name, nil)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) AddPod(pod *v1.Pod) error {
+	if !podutil.BoundPod(pod) && !podutil.AssumedPodOfGodel(pod, s.handler.SchedulerType()) {
+		return nil
+	}
+	localstoragepool.PodOp(s.pools, s.handler.PvcLister(), pod, true)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) UpdatePod(oldPod, newPod *v1.Pod) error {
+	localstoragepool.PodOp(s.pools, s.handler.PvcLister(), oldPod, false)
+	return s.AddPod(newPod)
+}
+
+func (s *LocalStoragePoolStore) DeletePod(pod *v1.Pod) error {
+	localstoragepool.PodOp(s.pools, s.handler.PvcLister(), pod, false)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) AssumePod(podInfo *framework.CachePodInfo) error {
+	localstoragepool.PodOp(s.pools, s.handler.PvcLister(), podInfo.Pod, true)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) ForgetPod(podInfo *framework.CachePodInfo) error {
+	localstoragepool.PodOp(s.pools, s.handler.PvcLister(), podInfo.Pod, false)
+	return nil
+}
+
+func (s *LocalStoragePoolStore) UpdateSnapshot(store commonstore.Store) error {
+	s.pools.UpdateSnapshot(store.(*LocalStoragePoolStore).pools)
+	return nil
+}
+
+// -------------------------------------- Other Interface --------------------------------------
+
+type StoreHandle interface {
+	localstoragepool.LocalStoragePoolHandle
+	PvcLister() corelister.PersistentVolumeClaimLister
+}
+
+var _ StoreHandle = &LocalStoragePoolStore{}
+
+func (s *LocalStoragePoolStore) GetNodeLocalStoragePools(nodeName string) (map[string]int64, map[string]int64) {
+	n := s.pools.GetNode(nodeName)
+	if n == nil {
+		return nil, nil
+	}
+	return n.Capacity(), n.Requested()
+}
+
+func (s *LocalStoragePoolStore) IsLocalStorageClass(storageClass string) bool {
+	return s.pools.IsLocalStorageClass(storageClass)
+}
+
+func (s *LocalStoragePoolStore) PvcLister() corelister.PersistentVolumeClaimLister {
+	return s.handler.PvcLister()
+}
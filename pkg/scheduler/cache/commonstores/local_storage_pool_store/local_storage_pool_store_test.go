@@ -0,0 +1,127 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepoolstore
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	fakelisters "github.com/kubewharf/godel-scheduler/pkg/framework/api/fake"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func makePod(name, nodeName string, claims ...string) *v1.Pod {
+	pod := testinghelper.MakePod().Namespace("default").Name(name).UID(name).Node(nodeName).Obj()
+	for _, claim := range claims {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+			Name:         claim,
+			VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: claim}},
+		})
+	}
+	return pod
+}
+
+func makeClaim(name, storage, volumeName string) v1.PersistentVolumeClaim {
+	storageClass := "ssd"
+	return v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse(storage)}},
+			VolumeName:       volumeName,
+		},
+	}
+}
+
+func TestLocalStoragePoolStore(t *testing.T) {
+	pvcLister := fakelisters.PersistentVolumeClaimLister{
+		makeClaim("c1", "10", ""),
+		makeClaim("c2", "20", ""),
+		makeClaim("c3", "30", ""),
+		makeClaim("bound", "40", "pv"),
+	}
+	handler := commoncache.MakeCacheHandlerWrapper().
+		SchedulerType("godel-scheduler").PVCLister(pvcLister).
+		Obj()
+	cache, snapshot := NewCache(handler).(*LocalStoragePoolStore), NewSnapshot(handler).(*LocalStoragePoolStore)
+
+	node := testinghelper.MakeNode().Name("n").Obj()
+	node.Status.Allocatable = v1.ResourceList{framework.LocalStoragePoolResourcePrefix + "ssd": resource.MustParse("100")}
+	otherNode := testinghelper.MakeNode().Name("n-other").Obj()
+	otherNode.Status.Allocatable = node.Status.Allocatable
+	cache.AddNode(node)
+	cache.AddNode(otherNode)
+
+	checkRequested := func(s *LocalStoragePoolStore, expected int64) {
+		t.Helper()
+		capacity, requested := s.GetNodeLocalStoragePools("n")
+		if capacity["ssd"] != 100 || requested["ssd"] != expected {
+			t.Errorf("expected capacity 100 and requested %d, but got %v and %v", expected, capacity, requested)
+		}
+	}
+
+	// The bound claim is still used by the pod and should be counted.
+	cache.AddPod(makePod("p1", "n", "c1", "bound"))
+	cache.UpdateSnapshot(snapshot)
+	if !snapshot.IsLocalStorageClass("ssd") {
+		t.Errorf("expected ssd to be local storage class in snapshot")
+	}
+	checkRequested(cache, 50)
+	checkRequested(snapshot, 50)
+
+	// Assume in snapshot will not affect cache, and will be refreshed by the next update.
+	snapshot.AssumePod(&framework.CachePodInfo{Pod: makePod("p2", "n", "c2")})
+	checkRequested(snapshot, 70)
+	checkRequested(cache, 50)
+	cache.UpdateSnapshot(snapshot)
+	checkRequested(snapshot, 50)
+
+	// Assume and forget in cache.
+	p3 := makePod("p3", "n", "c3")
+	cache.AssumePod(&framework.CachePodInfo{Pod: p3})
+	cache.UpdateSnapshot(snapshot)
+	checkRequested(snapshot, 80)
+	cache.ForgetPod(&framework.CachePodInfo{Pod: p3})
+	cache.UpdateSnapshot(snapshot)
+	checkRequested(snapshot, 50)
+
+	// Only the changed nodes are synchronized to snapshot.
+	unchanged := snapshot.pools.GetNode("n")
+	cache.DeleteNode(otherNode)
+	cache.UpdateSnapshot(snapshot)
+	if snapshot.pools.GetNode("n") != unchanged {
+		t.Errorf("expected unchanged node not to be cloned again")
+	}
+	if snapshot.pools.GetNode("n-other") != nil {
+		t.Errorf("expected deleted node to be removed from snapshot")
+	}
+
+	cache.DeletePod(makePod("p1", "n", "c1", "bound"))
+	cache.DeleteNode(node)
+	cache.UpdateSnapshot(snapshot)
+	if capacity, requested := snapshot.GetNodeLocalStoragePools("n"); capacity != nil || requested != nil {
+		t.Errorf("expected node to be removed, but got %v and %v", capacity, requested)
+	}
+	if snapshot.IsLocalStorageClass("ssd") {
+		t.Errorf("expected ssd not to be local storage class in snapshot")
+	}
+}
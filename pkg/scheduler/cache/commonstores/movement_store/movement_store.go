@@ -17,6 +17,8 @@ limitations under the License.
 package movementstore
 
 import (
+	"sort"
+
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -28,6 +30,7 @@ import (
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/framework/utils"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores"
+	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
@@ -168,3 +171,28 @@ func (s *MovementStore) GetSuggestedMovementAndNodes(ownerKey string) map[string
 func (s *MovementStore) GetDeletedPodsFromMovement(movementName string) sets.String {
 	return s.store.GetDeletedPodsFromMovement(movementName)
 }
+
+// MovementDump is the debugging view of a movement.
+type MovementDump struct {
+	Algorithm   string   `json:"algorithm"`
+	Owners      []string `json:"owners"`
+	DeletedPods []string `json:"deletedPods,omitempty"`
+}
+
+var _ commonstore.Dumpable = &MovementStore{}
+
+// Dump returns the movements stored, keyed by movement name.
+func (s *MovementStore) Dump() interface{} {
+	ret := make(map[string]*MovementDump, s.store.MovementStates.Len())
+	s.store.MovementStates.Range(func(movementName string, obj generationstore.StoredObj) {
+		movementState := obj.(framework.MovementState)
+		owners := movementState.GetOwnerList()
+		sort.Strings(owners)
+		ret[movementName] = &MovementDump{
+			Algorithm:   movementState.GetAlgorithmName(),
+			Owners:      owners,
+			DeletedPods: movementState.GetDeletedPods().List(),
+		}
+	})
+	return ret
+}
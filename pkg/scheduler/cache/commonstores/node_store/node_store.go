@@ -531,6 +531,18 @@ func (s *NodeStore) Delete(nodeName string, nodeInfo framework.NodeInfo) {
 	}
 }
 
+// OvercommitSummaries aggregates the nodes by their overcommit profiles.
+func (s *NodeStore) OvercommitSummaries() map[string]*framework.OvercommitSummary {
+	summaries := make(map[string]*framework.OvercommitSummary)
+	s.Store.Range(func(nodeName string, v generationstore.StoredObj) {
+		if s.Deleted.Has(nodeName) {
+			return
+		}
+		framework.SummarizeOvercommit(summaries, v.(framework.NodeInfo))
+	})
+	return summaries
+}
+
 // AllNodesClone return all nodes's deepcopy and organize them in map.
 func (s *NodeStore) AllNodesClone() map[string]framework.NodeInfo {
 	nodes := make(map[string]framework.NodeInfo, s.Store.Len())
@@ -17,6 +17,8 @@ limitations under the License.
 package pdbstore
 
 import (
+	"sort"
+
 	policy "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -391,3 +393,17 @@ func (s *PdbStore) GetOwnersForPDB(key, ownerType string) []string {
 	pdbItem := obj.(framework.PDBItem)
 	return pdbItem.GetRelatedOwnersByType(ownerType)
 }
+
+var _ commonstore.Dumpable = &PdbStore{}
+
+// Dump returns the pdbs stored, sorted by key.
+func (s *PdbStore) Dump() interface{} {
+	ret := make([]*framework.PDBItemDump, 0, s.Pdbs.Len())
+	s.Pdbs.Range(func(_ string, obj generationstore.StoredObj) {
+		if item := framework.DumpPDBItem(obj.(framework.PDBItem)); item != nil {
+			ret = append(ret, item)
+		}
+	})
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionbudgetstore
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/preempting/budgetchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores"
+	preemptionstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_store"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const Name commonstore.StoreName = "PreemptionBudgetStore"
+
+// PreemptionRecordsRetention is how long a preemption record will be kept in the store.
+var PreemptionRecordsRetention = time.Duration(config.MaxPreemptionBudgetWindowSeconds) * time.Second
+
+func (s *PreemptionBudgetStore) Name() commonstore.StoreName {
+	return Name
+}
+
+func init() {
+	commonstores.GlobalRegistries.Register(
+		Name,
+		func(h commoncache.CacheHandler) bool { return h.IsStoreEnabled(string(preemptionstore.Name)) },
+		NewCache,
+		NewSnapshot)
+}
+
+// -------------------------------------- PreemptionBudgetStore --------------------------------------
+
+// PreemptionBudgetStore records the victims chosen by preemptors recently, which is used to limit
+// how often the pods of the same owner or namespace could be preempted.
+// Victims are recorded when the preemptor is assumed by the current scheduler, or observed from
+// the informer as an assumed pod of other schedulers.
+type PreemptionBudgetStore struct {
+	commonstore.BaseStore
+	storeType commonstore.StoreType
+	handler   commoncache.CacheHandler
+
+	records *framework.PreemptionRecords
+}
+
+var _ commonstore.Store = &PreemptionBudgetStore{}
+
+func NewCache(handler commoncache.CacheHandler) commonstore.Store {
+	return &PreemptionBudgetStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Cache,
+		handler:   handler,
+
+		records: framework.NewPreemptionRecords(),
+	}
+}
+
+func NewSnapshot(handler commoncache.CacheHandler) commonstore.Store {
+	return &PreemptionBudgetStore{
+		BaseStore: commonstore.NewBaseStore(),
+		storeType: commonstore.Snapshot,
+		handler:   handler,
+
+		records: framework.NewPreemptionRecords(),
+	}
+}
+
+// AddPod records the victims of assumed preemptors. Bound pods are skipped because their preemption
+// time is unknown, and they may be re-added when the informer resyncs.
+func (s *PreemptionBudgetStore) AddPod(pod *v1.Pod) error {
+	if podutil.BoundPod(pod) || !podutil.AssumedPodOfGodel(pod, s.handler.SchedulerType()) {
+		return nil
+	}
+	budgetchecker.RecordVictims(s.records, s.handler, pod, time.Now())
+	return nil
+}
+
+func (s *PreemptionBudgetStore) UpdatePod(_, newPod *v1.Pod) error {
+	// Preemption records are never removed when preemptor is updated or deleted, since the victims
+	// have already been (or are going to be) evicted.
+	return s.AddPod(newPod)
+}
+
+// AssumePod/ForgetPod are skipped in Snapshot, the victims chosen in the current scheduling cycle
+// are counted by the plugin itself.
+func (s *PreemptionBudgetStore) AssumePod(podInfo *framework.CachePodInfo) error {
+	if s.storeType == commonstore.Snapshot {
+		return nil
+	}
+	budgetchecker.RecordVictims(s.records, s.handler, podInfo.Pod, time.Now())
+	return nil
+}
+
+func (s *PreemptionBudgetStore) ForgetPod(podInfo *framework.CachePodInfo) error {
+	if s.storeType == commonstore.Snapshot {
+		return nil
+	}
+	budgetchecker.ForgetVictims(s.records, podInfo.Pod)
+	return nil
+}
+
+func (s *PreemptionBudgetStore) UpdateSnapshot(store commonstore.Store) error {
+	snapshot := store.(*PreemptionBudgetStore)
+	if snapshot.records.GetGeneration() != s.records.GetGeneration() {
+		snapshot.records = s.records.Clone()
+	}
+	return nil
+}
+
+func (s *PreemptionBudgetStore) PeriodWorker(mu *sync.RWMutex) {
+	go wait.Until(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if count := s.records.CleanUpExpiredRecords(time.Now().Add(-PreemptionRecordsRetention)); count > 0 {
+			klog.V(4).InfoS("Cleaned up expired preemption records", "count", count, "remaining", s.records.Len())
+		}
+	}, time.Minute, s.handler.StopCh())
+}
+
+// -------------------------------------- Other Interface --------------------------------------
+
+type StoreHandle interface {
+	budgetchecker.PreemptionHistory
+}
+
+var _ StoreHandle = &PreemptionBudgetStore{}
+
+func (s *PreemptionBudgetStore) GetPreemptionCountOfOwner(ownerKey string, since time.Time) int64 {
+	return s.records.CountByOwner(ownerKey, since)
+}
+
+func (s *PreemptionBudgetStore) GetPreemptionCountOfNamespace(namespace string, since time.Time) int64 {
+	return s.records.CountByNamespace(namespace, since)
+}
+
+func (s *PreemptionBudgetStore) GetLastPreemptionTimeOfOwner(ownerKey string) time.Time {
+	return s.records.LastPreemptionTimeOfOwner(ownerKey)
+}
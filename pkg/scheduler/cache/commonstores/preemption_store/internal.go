@@ -18,6 +18,7 @@ package preemptionstore
 
 import (
 	"fmt"
+	"sort"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	corelister "k8s.io/client-go/listers/core/v1"
@@ -208,3 +209,22 @@ func (pDetail *PreemptionDetails) String() string {
 	}
 	return fmt.Sprintf("%v", *pDetail)
 }
+
+// Dump returns the preemptors of each victim grouped by node name.
+func (pDetail *PreemptionDetails) Dump() map[string]map[string][]string {
+	if pDetail == nil {
+		return nil
+	}
+	ret := make(map[string]map[string][]string, pDetail.NodeToVictims.Len())
+	pDetail.NodeToVictims.Range(func(nodeName string, obj generationstore.StoredObj) {
+		detailForNode := obj.(*PreemptionDetailForNode)
+		victims := make(map[string][]string, detailForNode.VictimToPreemptors.Len())
+		detailForNode.VictimToPreemptors.Range(func(victimKey string, obj generationstore.StoredObj) {
+			preemptors := obj.(framework.GenerationStringSet).Strings()
+			sort.Strings(preemptors)
+			victims[victimKey] = preemptors
+		})
+		ret[nodeName] = victims
+	})
+	return ret
+}
@@ -203,3 +203,10 @@ func (s *PreemptionStore) podOp(pod *v1.Pod, isAdd bool) error {
 	}
 	return nil
 }
+
+var _ commonstore.Dumpable = &PreemptionStore{}
+
+// Dump returns the preemptors of each victim grouped by node name.
+func (s *PreemptionStore) Dump() interface{} {
+	return s.store.Dump()
+}
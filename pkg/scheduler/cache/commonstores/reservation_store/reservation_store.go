@@ -790,3 +790,10 @@ func (s *ReservationStore) getAvailableNodesWithoutIndex(placeholder string) ([]
 	}
 	return nodes, nil
 }
+
+var _ commonstore.Dumpable = &ReservationStore{}
+
+// Dump returns the reservation infos grouped by node name.
+func (s *ReservationStore) Dump() interface{} {
+	return s.reservations.Dump()
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/events"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestPeriodicComparer(t *testing.T) {
+	n1 := testinghelper.MakeNode().Name("n1").Obj()
+	n2 := testinghelper.MakeNode().Name("n2").Obj()
+	n3 := testinghelper.MakeNode().Name("n3").Obj()
+	p1 := testinghelper.MakePod().Namespace("default").Name("p1").UID("p1").Node("n1").Obj()
+	p2 := testinghelper.MakePod().Namespace("default").Name("p2").UID("p2").Node("n2").Obj()
+	p3 := testinghelper.MakePod().Namespace("default").Name("p3").UID("p3").Node("n1").Obj()
+
+	tests := []struct {
+		name        string
+		selfHealing bool
+		// drift expected in the third round.
+		expectedDrift *CacheDrift
+	}{
+		{
+			name:        "drift is reported continuously without self healing",
+			selfHealing: false,
+			expectedDrift: &CacheDrift{
+				MissedNodes:    []*v1.Node{n2},
+				RedundantNodes: []*v1.Node{n3},
+				MissedPods:     []*v1.Pod{p2},
+				RedundantPods:  []*v1.Pod{p3},
+			},
+		},
+		{
+			name:          "drift is resynced with self healing",
+			selfHealing:   true,
+			expectedDrift: &CacheDrift{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, node := range []*v1.Node{n1, n2} {
+				nodeIndexer.Add(node)
+			}
+			for _, pod := range []*v1.Pod{p1, p2} {
+				podIndexer.Add(pod)
+			}
+
+			handler := commoncache.MakeCacheHandlerWrapper().
+				SubCluster(framework.DefaultSubCluster).
+				PodAssumedTTL(time.Minute).Period(10 * time.Second).StopCh(stopCh).
+				Obj()
+			schedulerCache := godelcache.New(handler)
+			for _, node := range []*v1.Node{n1, n3} {
+				schedulerCache.AddNode(node)
+			}
+			for _, pod := range []*v1.Pod{p1, p3} {
+				schedulerCache.AddPod(pod)
+			}
+
+			comparer := NewPeriodicComparer(
+				corelisters.NewNodeLister(nodeIndexer),
+				corelisters.NewPodLister(podIndexer),
+				schedulerCache,
+				podutil.BoundPod,
+				events.NewFakeRecorder(100),
+				"godel-scheduler",
+				tt.selfHealing,
+			)
+
+			// The first round only collects the suspects.
+			drift, err := comparer.Compare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(drift, &CacheDrift{}) {
+				t.Errorf("expected no drift in the first round, but got %#v", drift)
+			}
+
+			// The second round confirms the drift.
+			comparer.CompareAndHeal()
+
+			drift, err = comparer.Compare()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sortDrift(drift)
+			if !reflect.DeepEqual(drift, tt.expectedDrift) {
+				t.Errorf("expected drift %#v, but got %#v", tt.expectedDrift, drift)
+			}
+		})
+	}
+}
+
+func sortDrift(drift *CacheDrift) {
+	sort.Slice(drift.MissedNodes, func(i, j int) bool { return drift.MissedNodes[i].Name < drift.MissedNodes[j].Name })
+	sort.Slice(drift.RedundantNodes, func(i, j int) bool { return drift.RedundantNodes[i].Name < drift.RedundantNodes[j].Name })
+	sort.Slice(drift.MissedPods, func(i, j int) bool { return drift.MissedPods[i].UID < drift.MissedPods[j].UID })
+	sort.Slice(drift.RedundantPods, func(i, j int) bool { return drift.RedundantPods[i].UID < drift.RedundantPods[j].UID })
+}
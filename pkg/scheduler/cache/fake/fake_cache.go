@@ -24,6 +24,7 @@ import (
 	policy "k8s.io/api/policy/v1"
 
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
 	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
@@ -161,6 +162,10 @@ func (c *Cache) Dump() *commoncache.Dump {
 	return &commoncache.Dump{}
 }
 
+func (c *Cache) DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{} {
+	return nil
+}
+
 func (c *Cache) AddPodGroup(podGroup *schedulingv1a1.PodGroup) error {
 	return nil
 }
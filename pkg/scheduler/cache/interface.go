@@ -21,6 +21,7 @@ import (
 
 	schedulingv1a1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/util/generationstore"
 	unitstatus "github.com/kubewharf/godel-scheduler/pkg/util/unitstatus"
@@ -68,6 +69,10 @@ type SchedulerCache interface {
 	// This method is expensive, and should be only used in non-critical path.
 	Dump() *commoncache.Dump
 
+	// DumpStores returns the content of the common stores which implement commonstore.Dumpable,
+	// keyed by store name. All the dumpable stores will be returned if names is empty.
+	DumpStores(names ...commonstore.StoreName) map[commonstore.StoreName]interface{}
+
 	// PodCount returns the number of pods in the cache (including those from deleted nodes).
 	PodCount() (int, error)
 
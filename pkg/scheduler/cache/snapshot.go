@@ -46,7 +46,7 @@ func NewEmptySnapshot(handler commoncache.CacheHandler) *Snapshot {
 	nodeSlices := newNodeSlices()
 
 	s := &Snapshot{
-		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Snapshot, commonstores.GlobalRegistries, orderedStoreNames),
+		CommonStoresSwitch: commonstore.MakeStoreSwitch(handler, commonstore.Snapshot, commonstores.GlobalRegistries, storeNames()),
 
 		handler: handler,
 
@@ -18,12 +18,15 @@ package cache
 
 import (
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores"
 	loadawarestore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/load_aware_store"
+	localstoragepoolstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/local_storage_pool_store"
 	movementstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/movement_store"
 	nodestore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/node_store"
 	pdbstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/pdb_store"
 	podstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/pod_store"
 	podgroupstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/podgroup_store"
+	preemptionbudgetstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_budget_store"
 	preemptionstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_store"
 	reservationstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/reservation_store"
 	unitstatusstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/unit_status_store"
@@ -39,9 +42,25 @@ var orderedStoreNames = []commonstore.StoreName{
 	reservationstore.Name,
 	movementstore.Name,
 	preemptionstore.Name,
+	preemptionbudgetstore.Name,
 	unitstatusstore.Name,
 	loadawarestore.Name,
+	localstoragepoolstore.Name,
 
 	nodestore.Name, // NodeStore be placed second to last.
 	podstore.Name,  // PodStore must be placed at the end.
 }
+
+// storeNames returns the ordered in-tree stores with the out-of-tree stores inserted
+// before NodeStore and PodStore.
+func storeNames() []commonstore.StoreName {
+	outOfTree := commonstores.GlobalRegistries.OutOfTreeStoreNames()
+	if len(outOfTree) == 0 {
+		return orderedStoreNames
+	}
+	tail := len(orderedStoreNames) - 2
+	names := make([]commonstore.StoreName, 0, len(orderedStoreNames)+len(outOfTree))
+	names = append(names, orderedStoreNames[:tail]...)
+	names = append(names, outOfTree...)
+	return append(names, orderedStoreNames[tail:]...)
+}
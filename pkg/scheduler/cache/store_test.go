@@ -30,6 +30,7 @@ import (
 	pdbstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/pdb_store"
 	podstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/pod_store"
 	podgroupstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/podgroup_store"
+	preemptionbudgetstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_budget_store"
 	preemptionstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_store"
 	unitstatusstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/unit_status_store"
 
@@ -83,6 +84,7 @@ func Test_makeStoreSwitch(t *testing.T) {
 				pdbstore.Name,
 				podgroupstore.Name,
 				preemptionstore.Name,
+				preemptionbudgetstore.Name,
 				unitstatusstore.Name,
 				loadawarestore.Name,
 				nodestore.Name,
@@ -136,3 +138,28 @@ func Test_makeStoreSwitch(t *testing.T) {
 		})
 	}
 }
+
+func Test_storeNamesWithOutOfTreeStores(t *testing.T) {
+	const name commonstore.StoreName = "FakeOutOfTreeStore"
+	newStore := func(commoncache.CacheHandler) commonstore.Store { return nil }
+	// The store is never built so that the other tests are not affected.
+	neverBuilt := func(commoncache.CacheHandler) bool { return false }
+
+	if err := commonstores.GlobalRegistries.RegisterOutOfTree(name, neverBuilt, newStore, newStore); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := commonstores.GlobalRegistries.RegisterOutOfTree(name, neverBuilt, newStore, newStore); err == nil {
+		t.Errorf("expected error when registering a duplicated store")
+	}
+	if err := commonstores.GlobalRegistries.RegisterOutOfTree(podstore.Name, neverBuilt, newStore, newStore); err == nil {
+		t.Errorf("expected error when registering an in-tree store")
+	}
+
+	names := storeNames()
+	if len(names) != len(orderedStoreNames)+1 {
+		t.Fatalf("expected %d stores, but got %v", len(orderedStoreNames)+1, names)
+	}
+	if names[len(names)-3] != name || names[len(names)-2] != nodestore.Name || names[len(names)-1] != podstore.Name {
+		t.Errorf("expected out-of-tree store before NodeStore and PodStore, but got %v", names)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podscheduler
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/clock"
+)
+
+const (
+	// StaticNodeSearch sizes the feasible node search by the configured percentage or the static heuristics.
+	StaticNodeSearch = "static"
+	// AdaptiveNodeSearch sizes the feasible node search by the history of pods with the same owner.
+	AdaptiveNodeSearch = "adaptive"
+
+	// nodeSearchDecay is the weight of history in the exponentially weighted ratios.
+	nodeSearchDecay = 0.8
+	// minNodeSearchSamples is the number of searches required before the budget is adjusted.
+	minNodeSearchSamples = 3
+	// maxNodeSearchFactor is the max factor of the budget for the owners failing to be scheduled.
+	maxNodeSearchFactor = 4.0
+	// minNodeSearchFactor is the min factor of the budget for the owners which are easy to fit.
+	minNodeSearchFactor = 0.5
+	// minFailureRatio is the failure ratio below which the failures of an owner are ignored.
+	minFailureRatio = 0.1
+	// easyFeasibleRatio is the feasibility ratio above which an owner is considered easy to fit.
+	easyFeasibleRatio = 0.5
+	// nodeSearchStatsTTL is the duration after which the stats of an inactive owner are dropped.
+	nodeSearchStatsTTL = 30 * time.Minute
+	// nodeSearchStatsCleanupInterval is the min interval between two cleanups of inactive owners.
+	nodeSearchStatsCleanupInterval = time.Minute
+)
+
+// nodeSearchStats is the history of feasible node searches of an owner.
+type nodeSearchStats struct {
+	samples int
+	// feasibleRatio is the exponentially weighted ratio of feasible nodes among the evaluated nodes.
+	feasibleRatio float64
+	// failureRatio is the exponentially weighted ratio of searches finding no feasible node.
+	failureRatio float64
+	lastUpdate   time.Time
+}
+
+// nodeSearchBudget tracks the feasibility ratios and scheduling outcomes of pod owners (or
+// templates), and sizes the feasible node search of the following pods of the same owner.
+// Owners which failed to find feasible nodes get a larger budget so that large gangs and
+// hard-to-fit pods find enough candidates, while easy ones get a smaller budget to save latency.
+type nodeSearchBudget struct {
+	mu          sync.Mutex
+	clock       clock.Clock
+	stats       map[string]*nodeSearchStats
+	lastCleanup time.Time
+}
+
+func newNodeSearchBudget(clock clock.Clock) *nodeSearchBudget {
+	return &nodeSearchBudget{
+		clock:       clock,
+		stats:       make(map[string]*nodeSearchStats),
+		lastCleanup: clock.Now(),
+	}
+}
+
+// factor returns the factor to scale the static budget of the owner.
+func (b *nodeSearchBudget) factor(key string) float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.stats[key]
+	if !ok || s.samples < minNodeSearchSamples {
+		return 1
+	}
+	if s.failureRatio >= minFailureRatio {
+		return 1 + s.failureRatio*(maxNodeSearchFactor-1)
+	}
+	if s.feasibleRatio >= easyFeasibleRatio {
+		return minNodeSearchFactor
+	}
+	return 1
+}
+
+// numNodesToFind scales the static budget by the history of the owner, the result is at least
+// minNodes (the number of members to place) and at most numAllNodes.
+func (b *nodeSearchBudget) numNodesToFind(key string, staticNum, minNodes, numAllNodes int32) int32 {
+	num := int32(math.Ceil(float64(staticNum) * b.factor(key)))
+	if num < minNodes {
+		num = minNodes
+	}
+	if num < 1 {
+		num = 1
+	}
+	if num > numAllNodes {
+		num = numAllNodes
+	}
+	return num
+}
+
+// observe records the result of a feasible node search of the owner.
+func (b *nodeSearchBudget) observe(key string, evaluated, feasible int) {
+	if evaluated <= 0 {
+		return
+	}
+	now := b.clock.Now()
+	feasibleRatio := float64(feasible) / float64(evaluated)
+	var failure float64
+	if feasible == 0 {
+		failure = 1
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s, ok := b.stats[key]
+	if !ok {
+		b.stats[key] = &nodeSearchStats{samples: 1, feasibleRatio: feasibleRatio, failureRatio: failure, lastUpdate: now}
+	} else {
+		s.samples++
+		s.feasibleRatio = nodeSearchDecay*s.feasibleRatio + (1-nodeSearchDecay)*feasibleRatio
+		s.failureRatio = nodeSearchDecay*s.failureRatio + (1-nodeSearchDecay)*failure
+		s.lastUpdate = now
+	}
+
+	if now.Sub(b.lastCleanup) < nodeSearchStatsCleanupInterval {
+		return
+	}
+	b.lastCleanup = now
+	for k, s := range b.stats {
+		if now.Sub(s.lastUpdate) > nodeSearchStatsTTL {
+			delete(b.stats, k)
+		}
+	}
+}
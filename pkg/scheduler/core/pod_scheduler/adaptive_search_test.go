@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podscheduler
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestNodeSearchBudget(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+
+	tests := []struct {
+		name        string
+		observe     func(b *nodeSearchBudget)
+		staticNum   int32
+		minNodes    int32
+		numAllNodes int32
+		want        int32
+	}{
+		{
+			name:        "no history",
+			observe:     func(b *nodeSearchBudget) {},
+			staticNum:   20,
+			minNodes:    10,
+			numAllNodes: 1000,
+			want:        20,
+		},
+		{
+			name: "not enough samples",
+			observe: func(b *nodeSearchBudget) {
+				b.observe("owner", 100, 0)
+				b.observe("owner", 100, 0)
+			},
+			staticNum:   20,
+			minNodes:    10,
+			numAllNodes: 1000,
+			want:        20,
+		},
+		{
+			name: "always failed",
+			observe: func(b *nodeSearchBudget) {
+				for i := 0; i < 3; i++ {
+					b.observe("owner", 100, 0)
+				}
+			},
+			staticNum:   20,
+			minNodes:    10,
+			numAllNodes: 1000,
+			want:        80,
+		},
+		{
+			name: "always failed but limited by all nodes",
+			observe: func(b *nodeSearchBudget) {
+				for i := 0; i < 3; i++ {
+					b.observe("owner", 100, 0)
+				}
+			},
+			staticNum:   20,
+			minNodes:    10,
+			numAllNodes: 50,
+			want:        50,
+		},
+		{
+			name: "easy to fit",
+			observe: func(b *nodeSearchBudget) {
+				for i := 0; i < 3; i++ {
+					b.observe("owner", 10, 8)
+				}
+			},
+			staticNum:   20,
+			minNodes:    4,
+			numAllNodes: 1000,
+			want:        10,
+		},
+		{
+			name: "easy to fit but limited by min nodes",
+			observe: func(b *nodeSearchBudget) {
+				for i := 0; i < 3; i++ {
+					b.observe("owner", 10, 8)
+				}
+			},
+			staticNum:   20,
+			minNodes:    15,
+			numAllNodes: 1000,
+			want:        15,
+		},
+		{
+			name: "hard to fit without failures",
+			observe: func(b *nodeSearchBudget) {
+				for i := 0; i < 3; i++ {
+					b.observe("owner", 100, 10)
+				}
+			},
+			staticNum:   20,
+			minNodes:    10,
+			numAllNodes: 1000,
+			want:        20,
+		},
+		{
+			name: "failures are forgotten",
+			observe: func(b *nodeSearchBudget) {
+				b.observe("owner", 100, 0)
+				for i := 0; i < 20; i++ {
+					b.observe("owner", 10, 8)
+				}
+			},
+			staticNum:   20,
+			minNodes:    4,
+			numAllNodes: 1000,
+			want:        10,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newNodeSearchBudget(fakeClock)
+			tt.observe(b)
+			if got := b.numNodesToFind("owner", tt.staticNum, tt.minNodes, tt.numAllNodes); got != tt.want {
+				t.Errorf("numNodesToFind() = %v, want %v", got, tt.want)
+			}
+			if got := b.numNodesToFind("other", tt.staticNum, tt.minNodes, tt.numAllNodes); got != tt.staticNum && got != tt.numAllNodes {
+				t.Errorf("expected static budget for owner without history, got %v", got)
+			}
+		})
+	}
+}
+
+func TestNodeSearchBudgetCleanup(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	b := newNodeSearchBudget(fakeClock)
+	b.observe("inactive", 100, 0)
+
+	fakeClock.Step(nodeSearchStatsTTL + time.Second)
+	b.observe("active", 100, 0)
+
+	if _, ok := b.stats["inactive"]; ok {
+		t.Errorf("expected stats of inactive owner to be dropped")
+	}
+	if _, ok := b.stats["active"]; !ok {
+		t.Errorf("expected stats of active owner to be kept")
+	}
+}
+
+func TestNodeSearchMode(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "default"}}
+	podWithAnnotation := pod.DeepCopy()
+	podWithAnnotation.Annotations = map[string]string{
+		podutil.IncreasePercentageOfNodesToScoreAnnotationKey: podutil.IncreasePercentageOfNodesToScore,
+	}
+
+	tests := []struct {
+		name                              string
+		pod                               *v1.Pod
+		adaptive                          bool
+		percentageOfNodesToScore          int32
+		increasedPercentageOfNodesToScore int32
+		want                              string
+	}{
+		{
+			name: "adaptive search disabled",
+			pod:  pod,
+			want: StaticNodeSearch,
+		},
+		{
+			name:     "adaptive search enabled",
+			pod:      pod,
+			adaptive: true,
+			want:     AdaptiveNodeSearch,
+		},
+		{
+			name:                     "percentageOfNodesToScore set",
+			pod:                      pod,
+			adaptive:                 true,
+			percentageOfNodesToScore: 10,
+			want:                     StaticNodeSearch,
+		},
+		{
+			name:                              "increasedPercentageOfNodesToScore set and pod has annotation key",
+			pod:                               podWithAnnotation,
+			adaptive:                          true,
+			increasedPercentageOfNodesToScore: 20,
+			want:                              StaticNodeSearch,
+		},
+		{
+			name:                              "increasedPercentageOfNodesToScore set but pod has no annotation key",
+			pod:                               pod,
+			adaptive:                          true,
+			increasedPercentageOfNodesToScore: 20,
+			want:                              AdaptiveNodeSearch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &podScheduler{
+				percentageOfNodesToScore:          tt.percentageOfNodesToScore,
+				increasedPercentageOfNodesToScore: tt.increasedPercentageOfNodesToScore,
+			}
+			if tt.adaptive {
+				g.nodeSearchBudget = newNodeSearchBudget(clock.NewFakeClock(time.Now()))
+			}
+			if got := g.nodeSearchMode(tt.pod); got != tt.want {
+				t.Errorf("nodeSearchMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -37,6 +38,7 @@ import (
 	godelclient "github.com/kubewharf/godel-scheduler-api/pkg/client/clientset/versioned"
 	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	"github.com/kubewharf/godel-scheduler/pkg/features"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/framework/api/config"
 	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
@@ -45,6 +47,7 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/core"
 	schedulerframework "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/runtime"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/util"
 	schedulerutil "github.com/kubewharf/godel-scheduler/pkg/scheduler/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/constraints"
@@ -95,6 +98,9 @@ type podScheduler struct {
 	schedulerPreemptionFramework framework.SchedulerPreemptionFramework
 
 	betterSelectPoliciesRegistry map[string]betterSelectPolicy
+
+	// nodeSearchBudget sizes the feasible node search adaptively, it is nil if AdaptiveNodeSearch is disabled.
+	nodeSearchBudget *nodeSearchBudget
 }
 
 // node groups
@@ -286,6 +292,20 @@ func (gs *podScheduler) ScheduleInNodeCircles(
 	scheduleInSpecificNodeCircle := func(nodeCircle framework.NodeCircle) (result core.PodScheduleResult, err error) {
 		startPredicateEvalTime := time.Now()
 		feasibleNodes, filteredNodesStatuses, err := gs.findNodesThatFitPod(ctx, f, state, pod, nodeCircle, usr, cachedStatusMap)
+		searchMode := gs.nodeSearchMode(pod)
+		podProperty, _ := framework.GetPodProperty(state)
+		defer func() {
+			searchResult := string(metrics.ScheduledResult)
+			if _, ok := err.(*framework.FitError); ok {
+				searchResult = string(metrics.UnschedulableResult)
+			} else if err != nil {
+				searchResult = string(metrics.ErrorResult)
+			}
+			metrics.ObserveNodeSearchDuration(podProperty, searchMode, searchResult, helper.SinceInSeconds(startPredicateEvalTime))
+		}()
+		if err == nil && searchMode == AdaptiveNodeSearch {
+			gs.nodeSearchBudget.observe(nodeSearchKey(pod), len(feasibleNodes)+len(filteredNodesStatuses), len(feasibleNodes))
+		}
 
 		message := "evaluate " + strconv.Itoa(len(feasibleNodes)+len(filteredNodesStatuses)) + " nodes, find " + strconv.Itoa(len(feasibleNodes)) + " feasible nodes"
 		klog.V(4).InfoS(message, "pod", podutil.GetPodKey(pod), "nodeCircle", nodeCircle.GetKey())
@@ -341,6 +361,12 @@ func (gs *podScheduler) ScheduleInNodeCircles(
 		if err != nil {
 			return result, err
 		}
+		for _, nodeScore := range nodeScoreList {
+			if nodeScore.Name == selectedNode {
+				metrics.ObserveNodeSearchSelectedScore(podProperty, searchMode, float64(nodeScore.Score))
+				break
+			}
+		}
 
 		// TODO: we use disablePreemption field to control whether to use prepare node plugins, but they are too scattered and need to be concentrated
 		return core.PodScheduleResult{
@@ -467,11 +493,14 @@ func (gs *podScheduler) findFeasibleNodes(
 	if size == 0 {
 		return nil, nil
 	}
-	var increasePercentageOfNodesToScore bool
-	if v, ok := pod.Annotations[podutil.IncreasePercentageOfNodesToScoreAnnotationKey]; ok && v == podutil.IncreasePercentageOfNodesToScore {
-		increasePercentageOfNodesToScore = true
-	}
+	increasePercentageOfNodesToScore := needIncreasePercentageOfNodesToScore(pod)
 	numNodesToFind := gs.numFeasibleNodesToFind(int32(size), isLongRunningTask, usr, increasePercentageOfNodesToScore)
+	searchMode := gs.nodeSearchMode(pod)
+	if searchMode == AdaptiveNodeSearch {
+		numNodesToFind = gs.nodeSearchBudget.numNodesToFind(nodeSearchKey(pod), numNodesToFind, int32(usr.AllMember), int32(size))
+	}
+	podProperty, _ := framework.GetPodProperty(state)
+	metrics.ObserveNodeSearchBudget(podProperty, searchMode, float64(numNodesToFind))
 	if numNodesToFind == 0 {
 		return nil, nil
 	}
@@ -550,6 +579,32 @@ func getNumberOfFeasibleNodesToFind(percentage int32, numAllNodes int32) int32 {
 	return expectedNodeCount
 }
 
+func needIncreasePercentageOfNodesToScore(pod *v1.Pod) bool {
+	v, ok := pod.Annotations[podutil.IncreasePercentageOfNodesToScoreAnnotationKey]
+	return ok && v == podutil.IncreasePercentageOfNodesToScore
+}
+
+// nodeSearchMode returns whether the feasible node search of the pod is sized adaptively. The
+// configured percentage of nodes to score always takes precedence over the adaptive search.
+func (gs *podScheduler) nodeSearchMode(pod *v1.Pod) string {
+	if gs.nodeSearchBudget == nil || gs.percentageOfNodesToScore != schedulerconfig.DefaultPercentageOfNodesToScore {
+		return StaticNodeSearch
+	}
+	if needIncreasePercentageOfNodesToScore(pod) && gs.increasedPercentageOfNodesToScore != schedulerconfig.DefaultIncreasedPercentageOfNodesToScore {
+		return StaticNodeSearch
+	}
+	return AdaptiveNodeSearch
+}
+
+// nodeSearchKey returns the key to aggregate the feasible node search history of the pod,
+// pods with the same owner (or template) share the same history.
+func nodeSearchKey(pod *v1.Pod) string {
+	if owner := podutil.GetPodOwner(pod); len(owner) > 0 {
+		return owner
+	}
+	return podutil.GetPodTemplateKey(pod)
+}
+
 // numFeasibleNodesToFind returns the number of feasible nodes that once found, the scheduler stops
 // its search for more feasible nodes.
 func (gs *podScheduler) numFeasibleNodesToFind(numAllNodes int32, longRunningTask bool, usr *framework.UnitSchedulingRequest, increasePercentageOfNodesToScore bool) (numNodes int32) {
@@ -877,6 +932,8 @@ func NewPodScheduler(
 	percentageOfNodesToScore int32,
 	increasedPercentageOfNodesToScore int32,
 	basePlugins framework.PluginCollectionSet,
+	registry schedulerframework.Registry,
+	preemptionRegistry schedulerframework.Registry,
 	pluginArgs map[string]*schedulerconfig.PluginConfig,
 	preemptionPluginArgs map[string]*schedulerconfig.PluginConfig,
 ) core.PodScheduler {
@@ -898,7 +955,10 @@ func NewPodScheduler(
 		candidateSelectPolicy:             candidateSelectPolicy,
 		betterSelectPolicies:              betterSelectPolicies,
 	}
-	pluginRegistry, err := schedulerframework.NewPluginsRegistry(schedulerframework.NewInTreeRegistry(), pluginArgs, gs)
+	if utilfeature.DefaultFeatureGate.Enabled(features.AdaptiveNodeSearch) {
+		gs.nodeSearchBudget = newNodeSearchBudget(clock)
+	}
+	pluginRegistry, err := schedulerframework.NewPluginsRegistry(registry, pluginArgs, gs)
 	if err != nil {
 		klog.ErrorS(err, "Failed to initialize PodScheduler", "schedulerName", schedulerName, "subCluster", subCluster, "switchType", switchType, "pluginArgs", pluginArgs)
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
@@ -909,7 +969,7 @@ func NewPodScheduler(
 	}
 	gs.pluginRegistry = pluginRegistry
 
-	preemptionPluginRegistry, err := schedulerframework.NewPluginsRegistry(schedulerframework.NewInTreePreemptionRegistry(), preemptionPluginArgs, gs)
+	preemptionPluginRegistry, err := schedulerframework.NewPluginsRegistry(preemptionRegistry, preemptionPluginArgs, gs)
 	if err != nil {
 		klog.ErrorS(err, "Failed to initialize preemption registry", "schedulerName", schedulerName, "subCluster", subCluster, "switchType", switchType, "basePlugins", basePlugins)
 		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
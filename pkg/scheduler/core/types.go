@@ -55,6 +55,8 @@ type PodScheduler interface {
 
 type UnitScheduler interface {
 	Schedule(context.Context)
+	// ReleaseReservation releases the backfill reservation held by the unit, if any.
+	ReleaseReservation(unitKey string, result string)
 
 	CanBeRecycle() bool
 	Close()
@@ -191,6 +193,13 @@ type SchedulingUnitInfo struct {
 
 	UnitCycleState *framework.CycleState
 
+	// StartTimestamp is the time when the current scheduling attempt of this unit starts.
+	StartTimestamp time.Time
+
+	// LocatedNodeGroup is the node group located for the whole unit before grouping, the pods of the roles which
+	// are not co-located are placed in it.
+	LocatedNodeGroup framework.NodeGroup
+
 	// ATTENTION: The following fields will be RESET during scheduling.
 	// So we don't need to care about them during initialization.
 	NotScheduledPodKeysByTemplate map[string]sets.String
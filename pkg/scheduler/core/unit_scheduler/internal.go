@@ -28,6 +28,8 @@ import (
 	"k8s.io/klog/v2"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/noderesources"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/core"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
@@ -64,7 +66,7 @@ func getAndInitClonedPod(spanContext tracing.SpanContext, podInfo *framework.Que
 }
 
 func updateFailedSchedulingPod(cs clientset.Interface, schedulerName string,
-	failedPod *v1.Pod, reEnqueue bool, err error, reason string,
+	failedPod *v1.Pod, reEnqueue bool, err error, reason string, scaleUpHint *v1.PodCondition,
 ) error {
 	podCopy := failedPod.DeepCopy()
 	if !reEnqueue {
@@ -87,6 +89,13 @@ func updateFailedSchedulingPod(cs clientset.Interface, schedulerName string,
 	// mark failed pods to be e2e exclusive when calculating slo
 	podCopy.Annotations[podutil.E2EExcludedPodAnnotationKey] = "true"
 
+	// publish the scale-up hint along with the scheduling failure, drop the stale one if there is no hint anymore
+	if scaleUpHint != nil {
+		podutil.UpdatePodCondition(&podCopy.Status, scaleUpHint)
+	} else {
+		podutil.RemovePodCondition(&podCopy.Status, podutil.ScaleUpRecommendationCondition)
+	}
+
 	// update pod via API server
 	return updateFailedPodCondition(cs, failedPod, podCopy, reason, err)
 }
@@ -116,6 +125,42 @@ func updateFailedPodCondition(cs clientset.Interface, pod, failed *v1.Pod, reaso
 	return nil
 }
 
+// isResourceDrivenFailure returns true if the pending pods of the unit failed because of insufficient resources,
+// that is, for each template of the pending pods, some node only rejected them for lacking resources.
+// Adding nodes won't help the pods rejected everywhere for other reasons, such as affinity, taints or quota.
+func isResourceDrivenFailure(unitInfo *core.SchedulingUnitInfo) bool {
+	pending := false
+	for tmplKey, podKeys := range unitInfo.NotScheduledPodKeysByTemplate {
+		if len(podKeys) == 0 {
+			continue
+		}
+		pending = true
+		if !hasResourceOnlyRejection(unitInfo.NodeToStatusMapByTemplate[tmplKey]) {
+			return false
+		}
+	}
+	return pending
+}
+
+func hasResourceOnlyRejection(nodeToStatus framework.NodeToStatusMap) bool {
+	for _, status := range nodeToStatus {
+		if status.Code() != framework.Unschedulable || len(status.Reasons()) == 0 {
+			continue
+		}
+		resourceOnly := true
+		for _, reason := range status.Reasons() {
+			if !noderesources.IsInsufficientResourceReason(reason) {
+				resourceOnly = false
+				break
+			}
+		}
+		if resourceOnly {
+			return true
+		}
+	}
+	return false
+}
+
 func getAttemptsLabel(p *framework.QueuedPodInfo) string {
 	// We break down the pod scheduling duration by attempts capped to a limit
 	// to avoid ending up with a high cardinality metric.
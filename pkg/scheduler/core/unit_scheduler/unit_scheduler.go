@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
 	corelister "k8s.io/client-go/listers/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -39,6 +40,8 @@ import (
 	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/framework/utils"
+	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/backfill"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/core"
 	schedulerframework "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework"
@@ -48,6 +51,7 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
 	schedulingqueue "github.com/kubewharf/godel-scheduler/pkg/scheduler/queue"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/reconciler"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/scaleup"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
 	"github.com/kubewharf/godel-scheduler/pkg/util/interpretabity"
@@ -85,6 +89,12 @@ type unitScheduler struct {
 
 	PluginRegistry framework.PluginMap
 	PluginOrder    framework.PluginOrder
+	Plugins        *unitruntime.UnitPlugins
+
+	// Backfill holds the reservation of the blocked unit, it is nil if backfill is disabled.
+	Backfill *backfill.Manager
+	// ScaleUpHint computes the scale-up hints of long pending units, it is nil if scale-up hints are disabled.
+	ScaleUpHint *scaleup.Recommender
 
 	Recorder events.EventRecorder
 	// TODO: following fields useless for now
@@ -121,6 +131,11 @@ func NewUnitScheduler(
 	queue schedulingqueue.SchedulingQueue,
 	reconciler *reconciler.FailedTaskReconciler,
 	podScheduler core.PodScheduler,
+	registry schedulerframework.UnitRegistry,
+	unitPlugins *schedulerconfig.UnitPlugins,
+	unitPluginArgs map[string]*schedulerconfig.PluginConfig,
+	backfillConfig *schedulerconfig.BackfillConfig,
+	scaleUpHintConfig *schedulerconfig.ScaleUpHintConfig,
 	clock clock.Clock,
 	recorder events.EventRecorder,
 	// misc...
@@ -145,7 +160,9 @@ func NewUnitScheduler(
 		Scheduler:  podScheduler,
 		Reconciler: reconciler,
 
-		nextUnit: schedulingqueue.MakeNextUnitFunc(queue),
+		nextUnit:    schedulingqueue.MakeNextUnitFunc(queue),
+		Backfill:    backfill.NewManager(backfillConfig, clock),
+		ScaleUpHint: scaleup.NewRecommender(scaleUpHintConfig, clock),
 
 		Recorder:                recorder,
 		MetricsRecorder:         runtime.NewMetricsRecorder(1000, time.Second, switchType, subCluster, schedulerName),
@@ -155,8 +172,14 @@ func NewUnitScheduler(
 		MaxWaitingDeletionDuration: maxWaitingDeletionDuration,
 	}
 
-	gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(schedulerframework.NewUnitInTreeRegistry(), nil, gs)
+	gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(registry, unitPlugins, unitPluginArgs, gs)
 	gs.PluginOrder = schedulerframework.NewOrderedUnitPluginRegistry()
+	plugins, err := unitruntime.NewUnitPlugins(gs.PluginRegistry, gs.PluginOrder, unitPlugins)
+	if err != nil {
+		klog.ErrorS(err, "Failed to build unit plugins", "unitPlugins", unitPlugins)
+		klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+	}
+	gs.Plugins = plugins
 
 	return gs
 }
@@ -295,6 +318,10 @@ func (gs *unitScheduler) Close() {
 	gs.PodScheduler().Close()
 }
 
+func (gs *unitScheduler) ReleaseReservation(unitKey string, result string) {
+	gs.Backfill.Release(unitKey, result)
+}
+
 func (gs *unitScheduler) Schedule(ctx context.Context) {
 	gs.LatestScheduleTimestamp = gs.Clock.Now()
 	snapshot, switchType, subCluster := gs.Snapshot, gs.switchType, gs.subCluster
@@ -310,32 +337,35 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 	if err != nil {
 		klog.InfoS("Failed to construct scheduling unit info", "switchType", switchType, "subCluster", subCluster, "unitKey", queuedUnitInfo.UnitKey, "err", err)
 		gs.recordUnitSchedulingResults(queuedUnitInfo, false, "FailToConstructUnitInfo", core.ReturnAction, helper.TruncateMessage(err.Error()))
-		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToConstructUnitInfo")
+		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToConstructUnitInfo", nil)
 		return
 	}
 
 	if err = gs.Cache.UpdateSnapshot(snapshot); err != nil {
 		klog.InfoS("Failed to update snapshot", "switchType", switchType, "subCluster", subCluster, "unitKey", unitInfo.UnitKey, "err", err)
 		gs.recordUnitSchedulingResults(queuedUnitInfo, false, "FailToUpdateSnapshot", core.ReturnAction, helper.TruncateMessage(err.Error()))
-		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToUpdateSnapshot")
+		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToUpdateSnapshot", nil)
 		return
 	}
 
-	unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.PluginRegistry, gs.PluginOrder, unitInfo.QueuedUnitInfo)
+	unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.Plugins, unitInfo.QueuedUnitInfo)
 
 	nodeGroup, status := unitFramework.RunLocatingPlugins(ctx, unitInfo.QueuedUnitInfo, unitInfo.UnitCycleState, snapshot.MakeBasicNodeGroup())
 	if !status.IsSuccess() {
 		klog.InfoS("Failed to run locating plugins", "switchType", switchType, "subCluster", subCluster, "unitKey", unitInfo.UnitKey, "status", status)
 		gs.recordUnitSchedulingResults(queuedUnitInfo, false, "FailToLocating", core.ReturnAction, helper.TruncateMessage(status.AsError().Error()))
-		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToLocating")
+		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToLocating", nil)
 		return
 	}
+	// Keep the nodes reserved for the blocked unit away from the units that would delay it.
+	nodeGroup = gs.Backfill.Admit(unitInfo.QueuedUnitInfo, nodeGroup)
+	unitInfo.LocatedNodeGroup = nodeGroup
 
 	nodeGroups, status := unitFramework.RunGroupingPlugin(ctx, unitInfo.QueuedUnitInfo, unitInfo.UnitCycleState, nodeGroup)
 	if !status.IsSuccess() {
 		klog.InfoS("Failed to run grouping plugin", "switchType", switchType, "subCluster", subCluster, "unitKey", unitInfo.UnitKey, "status", status)
 		gs.recordUnitSchedulingResults(queuedUnitInfo, false, "FailToGrouping", core.ReturnAction, helper.TruncateMessage(status.AsError().Error()))
-		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToGrouping")
+		gs.handleSchedulingUnitFailure(ctx, core.NewUnitResult(false, 0), unitInfo, err, "FailToGrouping", nil)
 		return
 	}
 
@@ -345,6 +375,8 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 
 		// record final scheduling result,
 		finalUnitResult = core.NewUnitResult(false, unitInfo.AllMember)
+		// whether the pending pods of the final scheduling result failed for insufficient resources.
+		resourceDriven bool
 	)
 
 	// TODO: we will cache some feasible nodes based on pod owners, make sure this (per node group scheduling) will not affect that
@@ -357,7 +389,7 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 		unitInfo.SetUnitTraceContextFields(tracing.SchedulerScheduleSpan, tracing.WithNodeGroupField(nodeGroupName))
 
 		unitResult := gs.scheduleUnitInNodeGroup(ctx, unitInfo, unitFramework, nodeGroup)
-		scheduleSucceed := (unitInfo.EverScheduled && len(unitResult.SuccessfulPods) > 0) || len(unitResult.SuccessfulPods) >= unitInfo.MinMember
+		scheduleSucceed := (unitInfo.EverScheduled && len(unitResult.SuccessfulPods) > 0) || meetMinMember(unitInfo, unitResult.SuccessfulPods)
 		if scheduleSucceed && gs.applyToCache(ctx, unitInfo, unitResult) {
 			msg := "Schedule unit succeeded both for snapshot and cache"
 			klog.V(4).InfoS(msg, "switchType", switchType, "subCluster", subCluster, "unitKey", unitInfo.UnitKey, "nodeGroup", nodeGroupName)
@@ -409,6 +441,7 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 		// keep the scheduling result with most successful Pods.
 		if len(unitResult.SuccessfulPods) >= len(finalUnitResult.SuccessfulPods) {
 			finalUnitResult = unitResult
+			resourceDriven = !unitResult.Successfully && isResourceDrivenFailure(unitInfo)
 		}
 
 		if unitResult.Successfully {
@@ -420,6 +453,10 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 
 	// if scheduling failed, stop the workflow and return
 	if !finalUnitResult.Successfully {
+		// Only the units blocked by insufficient resources are expected to fit once resources are released.
+		if !unitInfo.EverScheduled && resourceDriven {
+			gs.Backfill.Reserve(unitInfo.QueuedUnitInfo, unitInfo.MinMember, nodeGroup)
+		}
 		gs.recordUnitSchedulingResults(queuedUnitInfo, false, FailToScheduleUnit, core.ReturnAction, helper.TruncateMessage(errMessage))
 		klog.V(4).InfoS(errMessage)
 
@@ -434,11 +471,16 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 
 		// re-enqueue pods based on the `schedulingSuccessfully` value of scheduling result
 		// TODO: add more specific error messages -> attach scheduling errors to scheduling result
-		gs.handleSchedulingUnitFailure(ctx, finalUnitResult, unitInfo, errors.New(errMessage), "SchedulingFailed")
+		gs.handleSchedulingUnitFailure(ctx, finalUnitResult, unitInfo, errors.New(errMessage), "SchedulingFailed", gs.scaleUpHint(ctx, unitInfo, finalUnitResult, resourceDriven))
 
 		return
 	}
 
+	gs.Backfill.Release(unitInfo.UnitKey, backfill.ResultScheduled)
+	if len(finalUnitResult.FailedPods) == 0 {
+		gs.ScaleUpHint.Forget(unitInfo.UnitKey)
+	}
+
 	message := fmt.Sprintf("Schedule unit successfully. uint message: %v; successful pods:%d, failed pods:%d",
 		unitMessage, len(finalUnitResult.SuccessfulPods), len(finalUnitResult.FailedPods))
 	klog.V(4).InfoS("Scheduled unit successfully", "unitKey", unitInfo.UnitKey, "numSuccessfulPods", len(finalUnitResult.SuccessfulPods), "numFailedPods", len(finalUnitResult.FailedPods))
@@ -446,7 +488,7 @@ func (gs *unitScheduler) Schedule(ctx context.Context) {
 		"ScheduleUnitSuccessfully", core.ContinueAction, helper.TruncateMessage(message))
 
 	// in case of scheduling partially success
-	gs.handleSchedulingUnitFailure(ctx, finalUnitResult, unitInfo, errors.New(errMessage), "SchedulingFailed")
+	gs.handleSchedulingUnitFailure(ctx, finalUnitResult, unitInfo, errors.New(errMessage), "SchedulingFailed", gs.scaleUpHint(ctx, unitInfo, finalUnitResult, resourceDriven))
 
 	// TODO: actually we'd better delete cached nodes from scheduler cache for PodGroup(instances)
 	// in order not to cause conflicts with next round of scheduling (rejected by binder),
@@ -467,6 +509,7 @@ func (gs *unitScheduler) constructSchedulingUnitInfo(ctx context.Context, queued
 		UnitKey:        queuedUnitInfo.UnitKey,
 		QueuedUnitInfo: queuedUnitInfo,
 		UnitCycleState: framework.NewCycleState(),
+		StartTimestamp: gs.LatestScheduleTimestamp,
 	}
 
 	unit := queuedUnitInfo.ScheduleUnit
@@ -485,6 +528,9 @@ func (gs *unitScheduler) constructSchedulingUnitInfo(ctx context.Context, queued
 	if !unitInfo.EverScheduled && unitInfo.MinMember > unitInfo.AllMember {
 		return unitInfo, fmt.Errorf("min member is greater than all member which is unexpected")
 	}
+	if !unitInfo.EverScheduled && !meetMinMember(unitInfo, allPodKeys(unitInfo)) {
+		return unitInfo, fmt.Errorf("pods can not meet the min member of each role which is unexpected")
+	}
 
 	// only when the node partition is Physical and the preemption feature is disabled,
 	// we will reset the selected scheduler annotation and let dispatcher re-dispatch these pods when scheduling failed
@@ -496,6 +542,28 @@ func (gs *unitScheduler) constructSchedulingUnitInfo(ctx context.Context, queued
 	return unitInfo, nil
 }
 
+// meetMinMember checks whether the pods meet the min member of the unit, including the min member of each role.
+func meetMinMember(unitInfo *core.SchedulingUnitInfo, podKeys []string) bool {
+	if len(podKeys) < unitInfo.MinMember {
+		return false
+	}
+	pods := make([]*v1.Pod, 0, len(podKeys))
+	for _, key := range podKeys {
+		if runningUnitInfo := unitInfo.DispatchedPods[key]; runningUnitInfo != nil {
+			pods = append(pods, runningUnitInfo.QueuedPodInfo.Pod)
+		}
+	}
+	return unitInfo.QueuedUnitInfo.ValidatePodRoles(pods)
+}
+
+func allPodKeys(unitInfo *core.SchedulingUnitInfo) []string {
+	keys := make([]string, 0, len(unitInfo.DispatchedPods))
+	for key := range unitInfo.DispatchedPods {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 func (gs *unitScheduler) constructRunningUnitInfo(ctx context.Context, unit framework.ScheduleUnit, unitInfo *core.SchedulingUnitInfo) {
 	runningUnitMap := unitInfo.DispatchedPods
 	if runningUnitMap == nil {
@@ -579,7 +647,7 @@ func (gs *unitScheduler) unReservePods(ctx context.Context, unitInfo *core.Sched
 }
 
 func (gs *unitScheduler) handleSchedulingUnitFailure(ctx context.Context, result *core.UnitResult, unitInfo *core.SchedulingUnitInfo,
-	err error, reason string,
+	err error, reason string, scaleUpHint *v1.PodCondition,
 ) {
 	queue, switchType, subCluster := gs.Queue, gs.switchType, gs.subCluster
 	// 1. remove successful pods before re-enqueue
@@ -628,12 +696,58 @@ func (gs *unitScheduler) handleSchedulingUnitFailure(ctx context.Context, result
 				podError = got
 			}
 		}
-		if updateErr := updateFailedSchedulingPod(gs.client, gs.schedulerName, podInfos[i].Pod, !unitInfo.DispatchToAnotherScheduler, podError, reason); updateErr != nil {
+		if updateErr := updateFailedSchedulingPod(gs.client, gs.schedulerName, podInfos[i].Pod, !unitInfo.DispatchToAnotherScheduler, podError, reason, scaleUpHint); updateErr != nil {
 			klog.InfoS("Failed to update the failed scheduling pod", "switchType", switchType, "subCluster", subCluster, "pod", klog.KObj(podInfos[i].Pod), "err", updateErr)
 		}
 	}
 }
 
+// scaleUpHint computes the scale-up hint of the pods which failed to be scheduled for insufficient resources,
+// the filter plugins of each pod are run against the hypothetical nodes built from the node templates.
+// The pods placed in the existing nodes by the scheduling result are not simulated, so the free capacity of
+// the existing nodes is taken into account.
+func (gs *unitScheduler) scaleUpHint(ctx context.Context, unitInfo *core.SchedulingUnitInfo, result *core.UnitResult, resourceDriven bool) *v1.PodCondition {
+	if gs.ScaleUpHint == nil || !resourceDriven {
+		return nil
+	}
+	scheduled := sets.NewString(result.SuccessfulPods...)
+	var pods []*v1.Pod
+	for _, podInfo := range unitInfo.QueuedUnitInfo.GetPods() {
+		if !scheduled.Has(podutil.GetPodKey(podInfo.Pod)) {
+			pods = append(pods, podInfo.Pod)
+		}
+	}
+	nodeGroup := ""
+	if unitInfo.LocatedNodeGroup != nil {
+		nodeGroup = unitInfo.LocatedNodeGroup.GetKey()
+	}
+	type simulation struct {
+		fwk   framework.SchedulerFramework
+		state *framework.CycleState
+	}
+	simulations := make(map[string]*simulation)
+	fits := func(pod *v1.Pod, nodeInfo framework.NodeInfo) bool {
+		podKey := podutil.GetPodKey(pod)
+		sim, ok := simulations[podKey]
+		if !ok {
+			// pods failing the prefilter plugins are recorded as nil, they fit in none of the nodes.
+			_, fwk, _, state, err := gs.BootstrapSchedulePod(ctx, pod.DeepCopy(), nil, nodeGroup)
+			if err == nil {
+				state.SetRecordPluginMetrics(false)
+				if status := fwk.RunPreFilterPlugins(ctx, state, pod); status.IsSuccess() {
+					sim = &simulation{fwk: fwk, state: state}
+				}
+			}
+			simulations[podKey] = sim
+		}
+		if sim == nil {
+			return false
+		}
+		return sim.fwk.RunFilterPlugins(ctx, sim.state, pod, nodeInfo).Merge().IsSuccess()
+	}
+	return gs.ScaleUpHint.Recommend(unitInfo.QueuedUnitInfo, pods, fits).PodCondition()
+}
+
 func (gs *unitScheduler) recordUnitSchedulingResults(unitInfo *framework.QueuedUnitInfo, successful bool, reason string, action string, message string) {
 	if unitInfo == nil || unitInfo.ScheduleUnit == nil {
 		return
@@ -723,7 +837,7 @@ func (gs *unitScheduler) applyToCache(ctx context.Context, unitInfo *core.Schedu
 			//
 			// At the same time, we will decide to apply or roll back the previous operations based on whether the unit
 			// scheduling conditions are met.
-			if !unitInfo.EverScheduled && i < unitInfo.MinMember {
+			if !unitInfo.EverScheduled && !meetMinMember(unitInfo, result.SuccessfulPods[:i]) {
 				// For min-fail, we revert the operations and return false.
 				msg := "Failed to assume pod in scheduler cache and result in a min-fail, ready to revert"
 				klog.InfoS(msg, "numSuccessfulPods", len(result.SuccessfulPods), "podIndex", i)
@@ -791,6 +905,11 @@ func (gs *unitScheduler) PersistSuccessfulPods(ctx context.Context,
 			runningUnitInfo.ClonedPod.Annotations[podutil.E2EExcludedPodAnnotationKey] = "true"
 		}
 
+		runningUnitInfo.ClonedPod.Annotations[podutil.ScheduleStartedTimestampAnnotationKey] = unitInfo.StartTimestamp.Format(helper.TimestampLayout)
+		runningUnitInfo.ClonedPod.Annotations[podutil.ScheduledTimestampAnnotationKey] = gs.Clock.Now().Format(helper.TimestampLayout)
+		// the scale-up hint published by the previous failed attempts is stale now
+		podutil.RemovePodCondition(&runningUnitInfo.ClonedPod.Status, podutil.ScaleUpRecommendationCondition)
+
 		err := util.PatchPod(gs.client, runningUnitInfo.QueuedPodInfo.Pod, runningUnitInfo.ClonedPod)
 		if err == nil {
 			updatingTraceContext.WithTags(tracing.WithResultTag(tracing.ResultSuccess))
@@ -18,6 +18,7 @@ package unitscheduler
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -61,6 +62,7 @@ import (
 	unitruntime "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_runtime"
 	schedulingqueue "github.com/kubewharf/godel-scheduler/pkg/scheduler/queue"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/reconciler"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/scaleup"
 	schedulerutil "github.com/kubewharf/godel-scheduler/pkg/scheduler/util"
 	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
@@ -1073,6 +1075,8 @@ func TestScheduleUnitInNodeGroup_SinglePod(t *testing.T) {
 				100,
 				100,
 				basePlugins,
+				schedulerframework.NewInTreeRegistry(),
+				schedulerframework.NewInTreePreemptionRegistry(),
 				nil,
 				nil,
 			)
@@ -1107,7 +1111,8 @@ func TestScheduleUnitInNodeGroup_SinglePod(t *testing.T) {
 				QueuePriorityScore: float64(unit.GetPriority()),
 			}
 			unitInfo, _ := gs.constructSchedulingUnitInfo(context.Background(), queuedUnitInfo)
-			unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.PluginRegistry, nil, unitInfo.QueuedUnitInfo)
+			unitPlugins, _ := unitruntime.NewUnitPlugins(gs.PluginRegistry, nil, nil)
+			unitFramework := unitruntime.NewUnitFramework(gs, gs, unitPlugins, unitInfo.QueuedUnitInfo)
 
 			lister := framework.NewClusterNodeInfoLister().(*framework.NodeInfoListerImpl)
 			for _, n := range tt.nodes {
@@ -2151,6 +2156,8 @@ func TestScheduleUnitInNodeGroup_PodGroup(t *testing.T) {
 				100,
 				100,
 				basePlugins,
+				schedulerframework.NewInTreeRegistry(),
+				schedulerframework.NewInTreePreemptionRegistry(),
 				nil,
 				preemptionPluginArgs,
 			)
@@ -2188,7 +2195,8 @@ func TestScheduleUnitInNodeGroup_PodGroup(t *testing.T) {
 				QueuePriorityScore: float64(unit.GetPriority()),
 			}
 			unitInfo, _ := gs.constructSchedulingUnitInfo(context.Background(), queuedUnitInfo)
-			unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.PluginRegistry, nil, unitInfo.QueuedUnitInfo)
+			unitPlugins, _ := unitruntime.NewUnitPlugins(gs.PluginRegistry, nil, nil)
+			unitFramework := unitruntime.NewUnitFramework(gs, gs, unitPlugins, unitInfo.QueuedUnitInfo)
 
 			lister := framework.NewClusterNodeInfoLister().(*framework.NodeInfoListerImpl)
 			for _, n := range tt.nodes {
@@ -4377,6 +4385,8 @@ func TestScheduleUnit_Rescheduling(t *testing.T) {
 					100,
 					100,
 					basePlugins,
+					schedulerframework.NewInTreeRegistry(),
+					schedulerframework.NewInTreePreemptionRegistry(),
 					nil,
 					nil,
 				)
@@ -4404,7 +4414,7 @@ func TestScheduleUnit_Rescheduling(t *testing.T) {
 					Recorder:                   broadcaster.NewRecorder(testSchedulerName),
 					MaxWaitingDeletionDuration: config.DefaultMaxWaitingDeletionDuration * time.Second,
 				}
-				gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(schedulerframework.NewUnitInTreeRegistry(), nil, gs)
+				gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(schedulerframework.NewUnitInTreeRegistry(), nil, nil, gs)
 
 				unit := framework.NewPodGroupUnit(tt.podGroup, 100)
 				for _, p := range tt.pendingPods {
@@ -4416,7 +4426,8 @@ func TestScheduleUnit_Rescheduling(t *testing.T) {
 					QueuePriorityScore: float64(unit.GetPriority()),
 				}
 				unitInfo, _ := gs.constructSchedulingUnitInfo(context.Background(), queuedUnitInfo)
-				unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.PluginRegistry, nil, unitInfo.QueuedUnitInfo)
+				unitPlugins, _ := unitruntime.NewUnitPlugins(gs.PluginRegistry, nil, nil)
+				unitFramework := unitruntime.NewUnitFramework(gs, gs, unitPlugins, unitInfo.QueuedUnitInfo)
 				nodeGroup := snapshot.MakeBasicNodeGroup()
 				nodeGroup, _ = unitFramework.RunLocatingPlugins(context.Background(), unit, unitInfo.UnitCycleState, nodeGroup)
 
@@ -5157,6 +5168,8 @@ func TestScheduleUnit_ReschedulingWithPreemption(t *testing.T) {
 				100,
 				100,
 				basePlugins,
+				schedulerframework.NewInTreeRegistry(),
+				schedulerframework.NewInTreePreemptionRegistry(),
 				nil,
 				nil,
 			)
@@ -5184,7 +5197,7 @@ func TestScheduleUnit_ReschedulingWithPreemption(t *testing.T) {
 				Recorder:                   broadcaster.NewRecorder(testSchedulerName),
 				MaxWaitingDeletionDuration: config.DefaultMaxWaitingDeletionDuration * time.Second,
 			}
-			gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(schedulerframework.NewUnitInTreeRegistry(), nil, gs)
+			gs.PluginRegistry = schedulerframework.NewUnitPluginsRegistry(schedulerframework.NewUnitInTreeRegistry(), nil, nil, gs)
 
 			unit := framework.NewPodGroupUnit(tt.podGroup, 100)
 			for _, p := range tt.pendingPods {
@@ -5196,7 +5209,8 @@ func TestScheduleUnit_ReschedulingWithPreemption(t *testing.T) {
 				QueuePriorityScore: float64(unit.GetPriority()),
 			}
 			unitInfo, _ := gs.constructSchedulingUnitInfo(context.Background(), queuedUnitInfo)
-			unitFramework := unitruntime.NewUnitFramework(gs, gs, gs.PluginRegistry, nil, unitInfo.QueuedUnitInfo)
+			unitPlugins, _ := unitruntime.NewUnitPlugins(gs.PluginRegistry, nil, nil)
+			unitFramework := unitruntime.NewUnitFramework(gs, gs, unitPlugins, unitInfo.QueuedUnitInfo)
 			nodeGroup := snapshot.MakeBasicNodeGroup()
 			nodeGroup, _ = unitFramework.RunLocatingPlugins(context.Background(), unit, unitInfo.UnitCycleState, nodeGroup)
 
@@ -5257,3 +5271,167 @@ func TestScheduleUnit_ReschedulingWithPreemption(t *testing.T) {
 		})
 	}
 }
+
+func TestScaleUpHint(t *testing.T) {
+	templates := []config.NodeTemplate{
+		{
+			Name:        "cpu",
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("8"), v1.ResourcePods: resource.MustParse("110")},
+		},
+	}
+	makePods := func(nodeSelector map[string]string) []*v1.Pod {
+		var pods []*v1.Pod
+		for i := 0; i < 3; i++ {
+			name := fmt.Sprintf("foo%d", i)
+			pods = append(pods, testing_helper.MakePod().Namespace("default").Name(name).UID(name).
+				Priority(100).PriorityClassName("pc").
+				Req(map[v1.ResourceName]string{v1.ResourceCPU: "4"}).
+				NodeSelector(nodeSelector).
+				Annotation(podutil.PodGroupNameAnnotationKey, "pg").Obj())
+		}
+		return pods
+	}
+
+	tests := []struct {
+		name                   string
+		pods                   []*v1.Pod
+		expectedResourceDriven bool
+		expectedHint           *scaleup.Recommendation
+	}{
+		{
+			name:                   "insufficient resources, the pods placed in the existing node are not simulated",
+			pods:                   makePods(nil),
+			expectedResourceDriven: true,
+			expectedHint:           &scaleup.Recommendation{NodeTemplate: "cpu", NodeCount: 1, Pods: 2},
+		},
+		{
+			name:                   "node affinity mismatch, adding nodes doesn't help",
+			pods:                   makePods(map[string]string{"pool": "gpu"}),
+			expectedResourceDriven: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedulerName := "scheduler"
+			stop := make(chan struct{})
+			defer close(stop)
+
+			client := clientsetfake.NewSimpleClientset()
+			informerFactory := informers.NewSharedInformerFactory(client, 0)
+			broadcaster := cmdutil.NewEventBroadcasterAdapter(client)
+			crdClient := godelclientfake.NewSimpleClientset()
+			crdInformerFactory := crdinformers.NewSharedInformerFactory(crdClient, 0)
+
+			pcInformer := informerFactory.Scheduling().V1().PriorityClasses().Informer()
+			informerFactory.Start(stop)
+			informerFactory.WaitForCacheSync(stop)
+			pcInformer.GetIndexer().Add(testing_helper.MakePriorityClass().Name("pc").Obj())
+
+			sCache := godelcache.New(commoncache.MakeCacheHandlerWrapper().
+				ComponentName("scheduler").SchedulerType(schedulerName).SubCluster(framework.DefaultSubCluster).
+				PodAssumedTTL(30 * time.Second).Period(10 * time.Second).StopCh(make(<-chan struct{})).
+				EnableStore("PreemptionStore").
+				Obj())
+			snapshot := godelcache.NewEmptySnapshot(commoncache.MakeCacheHandlerWrapper().
+				SubCluster(framework.DefaultSubCluster).SwitchType(framework.DefaultSubClusterSwitchType).
+				PodLister(informerFactory.Core().V1().Pods().Lister()).
+				EnableStore("PreemptionStore").
+				Obj())
+			queue := schedulingqueue.NewSchedulingQueue(sCache, nil, nil, nil, false)
+
+			node := testing_helper.MakeNode().Name("n").Capacity(map[v1.ResourceName]string{v1.ResourceCPU: "4", v1.ResourcePods: "110"}).Obj()
+			sCache.AddNode(node)
+			sCache.UpdateSnapshot(snapshot)
+
+			basePlugins := framework.PluginCollectionSet{
+				string(podutil.Kubelet): &framework.PluginCollection{
+					Filters: []*framework.PluginSpec{
+						framework.NewPluginSpec(noderesources.FitName),
+						framework.NewPluginSpec(nodeaffinity.Name),
+					},
+				},
+			}
+			fakeClock := clock.NewFakeClock(time.Now())
+			podScheduler := podscheduler.NewPodScheduler(
+				schedulerName,
+				framework.DisableScheduleSwitch,
+				"",
+				client,
+				crdClient,
+				informerFactory,
+				crdInformerFactory,
+				snapshot,
+				fakeClock,
+				true,
+				config.CandidateSelectPolicyBest,
+				[]string{config.BetterPreemptionPolicyAscending},
+				100,
+				100,
+				basePlugins,
+				schedulerframework.NewInTreeRegistry(),
+				schedulerframework.NewInTreePreemptionRegistry(),
+				nil,
+				nil,
+			)
+
+			gs := &unitScheduler{
+				schedulerName:     testSchedulerName,
+				switchType:        framework.SwitchType(1),
+				disablePreemption: true,
+
+				podLister: testing_helper.NewFakePodLister(nil),
+				pgLister:  testing_helper.NewFakePodGroupLister(nil),
+
+				Cache:      sCache,
+				Snapshot:   snapshot,
+				Queue:      queue,
+				Reconciler: reconciler.NewFailedTaskReconciler(nil, nil, sCache, ""),
+				Scheduler:  podScheduler,
+
+				ScaleUpHint: scaleup.NewRecommender(&config.ScaleUpHintConfig{NodeTemplates: templates}, fakeClock),
+
+				Recorder: broadcaster.NewRecorder(testSchedulerName),
+			}
+
+			unit := framework.NewPodGroupUnit(testing_helper.MakePodGroup().Namespace("default").Name("pg").MinMember(3).Obj(), 100)
+			for _, p := range tt.pods {
+				unit.AddPod(&framework.QueuedPodInfo{Pod: p})
+			}
+			queuedUnitInfo := &framework.QueuedUnitInfo{
+				UnitKey:                 unit.GetKey(),
+				ScheduleUnit:            unit,
+				QueuePriorityScore:      float64(unit.GetPriority()),
+				InitialAttemptTimestamp: fakeClock.Now().Add(-time.Hour),
+			}
+			unitInfo, _ := gs.constructSchedulingUnitInfo(context.Background(), queuedUnitInfo)
+			unitPlugins, _ := unitruntime.NewUnitPlugins(gs.PluginRegistry, nil, nil)
+			unitFramework := unitruntime.NewUnitFramework(gs, gs, unitPlugins, unitInfo.QueuedUnitInfo)
+
+			lister := framework.NewClusterNodeInfoLister().(*framework.NodeInfoListerImpl)
+			lister.AddNodeInfo(snapshot.GetNodeInfo(node.Name))
+			nodeGroup := snapshot.MakeBasicNodeGroup()
+			nodeGroup.SetNodeCircles([]framework.NodeCircle{framework.NewNodeCircle("", lister)})
+			unitResult := gs.scheduleUnitInNodeGroup(context.Background(), unitInfo, unitFramework, nodeGroup)
+
+			resourceDriven := isResourceDrivenFailure(unitInfo)
+			if resourceDriven != tt.expectedResourceDriven {
+				t.Fatalf("expected resource driven %v, got %v", tt.expectedResourceDriven, resourceDriven)
+			}
+			hint := gs.scaleUpHint(context.Background(), unitInfo, unitResult, resourceDriven)
+			if tt.expectedHint == nil {
+				if hint != nil {
+					t.Fatalf("expected no hint, got %+v", hint)
+				}
+				return
+			}
+			if hint == nil || hint.Status != v1.ConditionTrue {
+				t.Fatalf("expected hint %+v, got %+v", tt.expectedHint, hint)
+			}
+			var got scaleup.Recommendation
+			if err := json.Unmarshal([]byte(hint.Message), &got); err != nil || got != *tt.expectedHint {
+				t.Errorf("expected hint %+v, got %s", tt.expectedHint, hint.Message)
+			}
+		})
+	}
+}
@@ -35,6 +35,7 @@ import (
 	crdinformers "github.com/kubewharf/godel-scheduler-api/pkg/client/informers/externalversions"
 	godelfeatures "github.com/kubewharf/godel-scheduler/pkg/features"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/backfill"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/features"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
@@ -157,6 +158,27 @@ func (sched *Scheduler) onCSINodeAdd(obj interface{}) {
 	)
 }
 
+func (sched *Scheduler) onCSIStorageCapacityAdd(obj interface{}) {
+	// Pods which failed to be scheduled due to insufficient storage capacity may become schedulable.
+	sched.ScheduleSwitch.Process(
+		// TODO: Parse SwitchType for CSI
+		framework.SwitchTypeAll,
+		func(dataSet ScheduleDataSet) {
+			dataSet.SchedulingQueue().MoveAllToActiveOrBackoffQueue(util.CSIStorageCapacityAdd)
+		},
+	)
+}
+
+func (sched *Scheduler) onCSIStorageCapacityUpdate(oldObj, newObj interface{}) {
+	sched.ScheduleSwitch.Process(
+		// TODO: Parse SwitchType for CSI
+		framework.SwitchTypeAll,
+		func(dataSet ScheduleDataSet) {
+			dataSet.SchedulingQueue().MoveAllToActiveOrBackoffQueue(util.CSIStorageCapacityUpdate)
+		},
+	)
+}
+
 func (sched *Scheduler) onCSINodeUpdate(oldObj, newObj interface{}) {
 	sched.ScheduleSwitch.Process(
 		// TODO: Parse SwitchType for CSI
@@ -510,6 +532,9 @@ func (sched *Scheduler) updatePodGroupToCache(oldObj interface{}, newObj interfa
 			// So we need to move pods to active queue on PodGroupUpdate for this scenario.
 			dataSet.SchedulingQueue().MoveAllToActiveOrBackoffQueue(util.PodGroupUpdate)
 			dataSet.SchedulingQueue().ActivePodGroupUnit(unitutil.GetPodGroupKey(newPodGroup))
+			if newPodGroup.Status.Phase == schedulingv1a1.PodGroupTimeout {
+				dataSet.UnitScheduler().ReleaseReservation(unitutil.GetUnitKeyFromPodGroup(unitutil.GetPodGroupKey(newPodGroup)), backfill.ResultTimeout)
+			}
 		},
 	)
 }
@@ -536,6 +561,13 @@ func (sched *Scheduler) deletePodGroupFromCache(obj interface{}) {
 	if err := sched.commonCache.DeletePodGroup(podGroup); err != nil {
 		klog.InfoS("Failed to remove pod group from scheduler cache", "err", err)
 	}
+
+	sched.ScheduleSwitch.Process(
+		framework.SwitchTypeAll,
+		func(dataSet ScheduleDataSet) {
+			dataSet.UnitScheduler().ReleaseReservation(unitutil.GetUnitKeyFromPodGroup(unitutil.GetPodGroupKey(podGroup)), backfill.ResultDeleted)
+		},
+	)
 }
 
 func nodeAllocatableChanged(newNode *v1.Node, oldNode *v1.Node) bool {
@@ -972,6 +1004,15 @@ func addAllEventHandlers(
 		)
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(features.CSIStorageCapacity) {
+		informerFactory.Storage().V1().CSIStorageCapacities().Informer().AddEventHandler(
+			cache.ResourceEventHandlerFuncs{
+				AddFunc:    sched.onCSIStorageCapacityAdd,
+				UpdateFunc: sched.onCSIStorageCapacityUpdate,
+			},
+		)
+	}
+
 	// On add and delete of PVs, it will affect equivalence cache items
 	// related to persistent volume
 	informerFactory.Core().V1().PersistentVolumes().Informer().AddEventHandler(
@@ -31,6 +31,7 @@ import (
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/coscheduling"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeaffinity"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeports"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/noderesources"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeunschedulable"
@@ -38,6 +39,7 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/tainttoleration"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/volumebinding"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/newlystartedprotectionchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/nodepoolchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/pdbchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/podlauncherchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/preemptibilitychecker"
@@ -59,6 +61,7 @@ func basePluginsForKubelet() *framework.PluginCollection {
 			framework.NewPluginSpec(volumebinding.Name),
 			framework.NewPluginSpec(nodeaffinity.Name),
 			framework.NewPluginSpec(tainttoleration.Name),
+			framework.NewPluginSpec(nodepool.Name),
 		},
 		Searchings: []*framework.VictimSearchingPluginCollectionSpec{
 			framework.NewVictimSearchingPluginCollectionSpec(
@@ -85,21 +88,32 @@ func basePluginsForKubelet() *framework.PluginCollection {
 				false,
 				false,
 			),
+			// pdbs are respected even when the tenants reclaim the borrowed capacity.
 			framework.NewVictimSearchingPluginCollectionSpec(
 				[]config.Plugin{
-					{Name: priorityvaluechecker.PriorityValueCheckerName},
+					{Name: pdbchecker.PDBCheckerName},
 				},
 				false,
 				false,
-				true,
+				false,
 			),
+			// the tenants of borrowable node pools reclaim the borrowed capacity regardless of priority,
+			// so the checkers that must not be bypassed have to be placed before it.
 			framework.NewVictimSearchingPluginCollectionSpec(
 				[]config.Plugin{
-					{Name: pdbchecker.PDBCheckerName},
+					{Name: nodepoolchecker.NodePoolCheckerName},
 				},
 				false,
+				true,
+				false,
+			),
+			framework.NewVictimSearchingPluginCollectionSpec(
+				[]config.Plugin{
+					{Name: priorityvaluechecker.PriorityValueCheckerName},
+				},
 				false,
 				false,
+				true,
 			),
 		},
 		Sortings: []*framework.PluginSpec{
@@ -123,6 +137,7 @@ func basePluginsForNodeManager() *framework.PluginCollection {
 			framework.NewPluginSpec(volumebinding.Name),
 			framework.NewPluginSpec(nodeaffinity.Name),
 			framework.NewPluginSpec(tainttoleration.Name),
+			framework.NewPluginSpec(nodepool.Name),
 		},
 	}
 }
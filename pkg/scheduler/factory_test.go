@@ -32,7 +32,12 @@ import (
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/nodepoolchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/pdbchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/priorityvaluechecker"
+	frameworkruntime "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/runtime"
 	godelqueue "github.com/kubewharf/godel-scheduler/pkg/scheduler/queue"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
 )
 
 const (
@@ -195,3 +200,66 @@ func (t *TestPlugin) ScoreExtensions() framework.ScoreExtensions {
 func (t *TestPlugin) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
 	return nil
 }
+
+type fakeVictimSearchingPlugin struct {
+	name string
+	code framework.Code
+}
+
+var _ framework.VictimSearchingPlugin = &fakeVictimSearchingPlugin{}
+
+func (pl *fakeVictimSearchingPlugin) Name() string {
+	return pl.name
+}
+
+func (pl *fakeVictimSearchingPlugin) VictimSearching(_ *v1.Pod, _ *framework.PodInfo, _, _ *framework.CycleState, _ *framework.VictimState) (framework.Code, string) {
+	return pl.code, ""
+}
+
+func TestBasePluginsRespectPDBWhenReclaiming(t *testing.T) {
+	tests := []struct {
+		name         string
+		codes        map[string]framework.Code
+		expectedCode framework.Code
+	}{
+		{
+			name: "tenant reclaims borrowed capacity regardless of priority",
+			codes: map[string]framework.Code{
+				nodepoolchecker.NodePoolCheckerName:           framework.PreemptionSucceed,
+				priorityvaluechecker.PriorityValueCheckerName: framework.PreemptionFail,
+			},
+			expectedCode: framework.PreemptionSucceed,
+		},
+		{
+			name: "tenant could not reclaim borrowed capacity protected by pdb",
+			codes: map[string]framework.Code{
+				nodepoolchecker.NodePoolCheckerName: framework.PreemptionSucceed,
+				pdbchecker.PDBCheckerName:           framework.PreemptionFail,
+			},
+			expectedCode: framework.PreemptionFail,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			basePlugins := basePluginsForKubelet()
+			registry := framework.PluginMap{}
+			for _, collection := range basePlugins.Searchings {
+				for _, plugin := range collection.GetSearchingPlugins() {
+					code, ok := tt.codes[plugin.GetName()]
+					if !ok {
+						code = framework.PreemptionNotSure
+					}
+					registry[plugin.GetName()] = &fakeVictimSearchingPlugin{name: plugin.GetName(), code: code}
+				}
+			}
+			pfwk := frameworkruntime.NewPreemptionFramework(registry, basePlugins)
+
+			preemptor := testinghelper.MakePod().Namespace("tenant").Name("tenant").Obj()
+			victim := testinghelper.MakePod().Namespace("default").Name("borrower").Obj()
+			code, _ := pfwk.RunVictimSearchingPlugins(preemptor, framework.NewPodInfo(victim), framework.NewCycleState(), framework.NewCycleState(), nil)
+			if code != tt.expectedCode {
+				t.Errorf("expected code %v, but got %v", tt.expectedCode, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	HistoricalEstimatorName = "historicalEstimator"
+
+	DefaultUsagePercentile = 95
+	DefaultMinUsageSamples = 3
+)
+
+// HistoricalEstimator estimates the pod by the usage profile of its owner (deployment/podgroup etc.),
+// and estimates the node by the peak usage in a time window. It falls back to NodeMetricEstimator
+// if the owner has not enough samples. Owner profiles are only sampled when the scheduler enables
+// podMetricsSamplePeriodSeconds.
+type HistoricalEstimator struct {
+	*NodeMetricEstimator
+
+	usagePercentile int64
+	minUsageSamples int
+	peakUsageWindow time.Duration
+}
+
+func NewHistoricalEstimator(args *config.LoadAwareArgs, handle handle.PodFrameworkHandle) (Estimator, error) {
+	nodeMetricEstimator, err := NewNodeMetricEstimator(args, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	usagePercentile := args.UsagePercentile
+	if usagePercentile == 0 {
+		usagePercentile = DefaultUsagePercentile
+	}
+	minUsageSamples := args.MinUsageSamples
+	if minUsageSamples == 0 {
+		minUsageSamples = DefaultMinUsageSamples
+	}
+
+	return &HistoricalEstimator{
+		NodeMetricEstimator: nodeMetricEstimator.(*NodeMetricEstimator),
+
+		usagePercentile: usagePercentile,
+		minUsageSamples: int(minUsageSamples),
+		peakUsageWindow: time.Duration(args.PeakUsageWindowSeconds) * time.Second,
+	}, nil
+}
+
+func (e *HistoricalEstimator) Name() string {
+	return HistoricalEstimatorName
+}
+
+func (e *HistoricalEstimator) EstimatePod(pod *v1.Pod) (*framework.Resource, error) {
+	resourceType, err := podutil.GetPodResourceType(pod)
+	if err != nil {
+		return nil, err
+	}
+	requests, _ := PodRequestsAndLimits(pod)
+	estimated := e.scalingResource(requests, resourceType)
+
+	ownerUsage := e.pluginHandle.GetLoadAwareOwnerUsage(podutil.GetPodOwner(pod), e.usagePercentile)
+	if ownerUsage == nil || ownerUsage.Samples < e.minUsageSamples {
+		return framework.NewResource(estimated), nil
+	}
+	// Replace the scaled requests with the historical usage.
+	if v, ok := estimated[v1.ResourceCPU]; ok {
+		v.SetMilli(int64(float64(util.GetNonZeroQuantityForResource(v1.ResourceCPU, requests).MilliValue()) * ownerUsage.MilliCPURatio))
+		estimated[v1.ResourceCPU] = v
+	}
+	if v, ok := estimated[v1.ResourceMemory]; ok {
+		v.Set(int64(float64(util.GetNonZeroQuantityForResource(v1.ResourceMemory, requests).Value()) * ownerUsage.MEMRatio))
+		estimated[v1.ResourceMemory] = v
+	}
+	return framework.NewResource(estimated), nil
+}
+
+func (e *HistoricalEstimator) EstimateNode(nodeInfo framework.NodeInfo, resourceType podutil.PodResourceType) (*framework.Resource, error) {
+	nodeUsage := e.pluginHandle.GetLoadAwareNodePeakUsage(nodeInfo.GetNodeName(), resourceType, e.peakUsageWindow)
+	return e.estimateNodeUsage(nodeUsage, resourceType), nil
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	katalystv1alpha1 "github.com/kubewharf/katalyst-api/pkg/apis/node/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commonstore "github.com/kubewharf/godel-scheduler/pkg/common/store"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
+	loadawarestore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/load_aware_store"
+	st "github.com/kubewharf/godel-scheduler/pkg/scheduler/testing"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func makeOwnedPod(key, owner string) *v1.Pod {
+	p := makeBasicPod(key)
+	if len(owner) > 0 {
+		p.OwnerReferences = append(p.OwnerReferences, metav1.OwnerReference{Kind: podutil.ReplicaSetKind, Name: owner, UID: types.UID("uid-" + owner)})
+	}
+	return p
+}
+
+func TestHistoricalEstimator(t *testing.T) {
+	/*
+		pods on node: [p0, p1] owned by rs, [p2] without owner.
+		pods in metrics: [p0, p1], the usage/request ratios of rs are [0.5, 0.8, 0.2] in order.
+	*/
+	podsOnNode := []*v1.Pod{makeOwnedPod("p0", "rs"), makeOwnedPod("p1", "rs"), makeOwnedPod("p2", "")}
+
+	nodeAllocatable := makeResource(map[v1.ResourceName]string{v1.ResourceCPU: "100", v1.ResourceMemory: "100Gi"})
+	cnrWithUsage := func(updateTime time.Time, usage int64) *katalystv1alpha1.CustomNodeResource {
+		return &katalystv1alpha1.CustomNodeResource{
+			ObjectMeta: metav1.ObjectMeta{Name: defaultNodeName},
+			Status: katalystv1alpha1.CustomNodeResourceStatus{
+				Resources: katalystv1alpha1.Resources{
+					Allocatable: &nodeAllocatable,
+					Capacity:    &nodeAllocatable,
+				},
+				NodeMetricStatus: &katalystv1alpha1.NodeMetricStatus{
+					UpdateTime: newMetaV1Time(updateTime),
+					GroupMetric: []katalystv1alpha1.GroupMetricInfo{
+						{
+							QoSLevel: string(util.ReclaimedCores),
+							ResourceUsage: katalystv1alpha1.ResourceUsage{
+								GenericUsage: &katalystv1alpha1.ResourceMetric{
+									CPU:    resource.NewMilliQuantity(usage, resource.DecimalSI),
+									Memory: resource.NewQuantity(usage, resource.BinarySI),
+								},
+							},
+							PodList: []string{"/p0", "/p1"},
+						},
+					},
+				},
+			},
+		}
+	}
+	cnrs := []*katalystv1alpha1.CustomNodeResource{
+		cnrWithUsage(now.Add(-20*time.Second), 100),
+		cnrWithUsage(now.Add(-10*time.Second), 160),
+		cnrWithUsage(now, 40),
+	}
+	// The usage of rs is shared equally by p0 and p1.
+	podMetricsWithUsage := func(timestamp time.Time, usage int64) []metricsv1beta1.PodMetrics {
+		var podMetrics []metricsv1beta1.PodMetrics
+		for _, name := range []string{"p0", "p1"} {
+			podMetrics = append(podMetrics, metricsv1beta1.PodMetrics{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Timestamp:  metav1.NewTime(timestamp),
+				Containers: []metricsv1beta1.ContainerMetrics{
+					{
+						Usage: v1.ResourceList{
+							v1.ResourceCPU:    *resource.NewMilliQuantity(usage/2, resource.DecimalSI),
+							v1.ResourceMemory: *resource.NewQuantity(usage/2, resource.BinarySI),
+						},
+					},
+				},
+			})
+		}
+		return podMetrics
+	}
+	podMetrics := [][]metricsv1beta1.PodMetrics{
+		podMetricsWithUsage(now.Add(-20*time.Second), 100),
+		podMetricsWithUsage(now.Add(-10*time.Second), 160),
+		podMetricsWithUsage(now, 40),
+	}
+
+	tests := []struct {
+		name                   string
+		minUsageSamples        int64
+		peakUsageWindowSeconds int64
+		pod                    *v1.Pod
+		wantPod                *framework.Resource
+		wantNode               *framework.Resource
+	}{
+		{
+			name:     "owner with enough samples, latest node usage",
+			pod:      makeOwnedPod("p3", "rs"),
+			wantPod:  &framework.Resource{MilliCPU: 80, Memory: 80},
+			wantNode: &framework.Resource{MilliCPU: 40 + 60, Memory: 40 + 60},
+		},
+		{
+			name:                   "owner with enough samples, peak node usage",
+			peakUsageWindowSeconds: 15,
+			pod:                    makeOwnedPod("p3", "rs"),
+			wantPod:                &framework.Resource{MilliCPU: 80, Memory: 80},
+			wantNode:               &framework.Resource{MilliCPU: 160 + 60, Memory: 160 + 60},
+		},
+		{
+			name:            "owner without enough samples",
+			minUsageSamples: 4,
+			pod:             makeOwnedPod("p3", "rs"),
+			wantPod:         &framework.Resource{MilliCPU: 60, Memory: 60},
+			wantNode:        &framework.Resource{MilliCPU: 40 + 60, Memory: 40 + 60},
+		},
+		{
+			name:     "owner without profile",
+			pod:      makeOwnedPod("p3", "other"),
+			wantPod:  &framework.Resource{MilliCPU: 60, Memory: 60},
+			wantNode: &framework.Resource{MilliCPU: 40 + 60, Memory: 40 + 60},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loadAwareSchedulingArgs := config.LoadAwareArgs{
+				Resources: []config.ResourceSpec{
+					{
+						Name:         string(v1.ResourceCPU),
+						Weight:       1,
+						ResourceType: podutil.BestEffortPod,
+					},
+					{
+						Name:         string(v1.ResourceMemory),
+						Weight:       1,
+						ResourceType: podutil.BestEffortPod,
+					},
+				},
+				EstimatedScalingFactors: defaultEstimatedScalingFactors,
+				MinUsageSamples:         tt.minUsageSamples,
+				PeakUsageWindowSeconds:  tt.peakUsageWindowSeconds,
+			}
+
+			schedulerCache := godelcache.New(commoncache.MakeCacheHandlerWrapper().
+				ComponentName("").SchedulerType("").SubCluster(framework.DefaultSubCluster).
+				PodAssumedTTL(time.Second).Period(10 * time.Second).StopCh(make(<-chan struct{})).
+				Obj())
+			snapshot := godelcache.NewEmptySnapshot(commoncache.MakeCacheHandlerWrapper().
+				SubCluster(framework.DefaultSubCluster).SwitchType(framework.DefaultSubClusterSwitchType).
+				Obj())
+			{
+				// Prepare cache and snapshot.
+				schedulerCache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: defaultNodeName}})
+				for _, p := range podsOnNode {
+					schedulerCache.AddPod(p)
+				}
+				schedulerCache.AddCNR(cnrs[0])
+				for i := 1; i < len(cnrs); i++ {
+					schedulerCache.UpdateCNR(cnrs[i-1], cnrs[i])
+				}
+				store := schedulerCache.(commonstore.CommonStoresSwitch).Find(loadawarestore.Name).(*loadawarestore.LoadAwareStore)
+				for i := range podMetrics {
+					store.AddPodMetrics(podMetrics[i])
+				}
+				schedulerCache.UpdateSnapshot(snapshot)
+			}
+			fh, _ := st.NewPodFrameworkHandle(nil, nil, nil, nil, nil, snapshot, nil, nil, nil, nil)
+
+			estimator, err := NewHistoricalEstimator(&loadAwareSchedulingArgs, fh)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(estimator.Name(), HistoricalEstimatorName); len(diff) > 0 {
+				t.Errorf("Got diff: %v", diff)
+			}
+
+			gotPod, err := estimator.EstimatePod(tt.pod)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(gotPod, tt.wantPod); len(diff) > 0 {
+				t.Errorf("Got pod diff: %v", diff)
+			}
+
+			gotNode, err := estimator.EstimateNode(snapshot.GetNodeInfo(defaultNodeName), podutil.BestEffortPod)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(gotNode, tt.wantNode); len(diff) > 0 {
+				t.Errorf("Got node diff: %v", diff)
+			}
+		})
+	}
+}
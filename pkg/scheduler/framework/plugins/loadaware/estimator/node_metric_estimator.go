@@ -18,9 +18,9 @@ package estimator
 
 import (
 	"fmt"
-	"time"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/loadaware"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	loadawarestore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/load_aware_store"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
@@ -29,7 +29,6 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -102,40 +101,11 @@ func (e *NodeMetricEstimator) ValidateNode(nodeInfo framework.NodeInfo, resource
 		return framework.NewStatus(framework.Error, fmt.Sprintf("no metric info on %v", nodeInfo.GetNodeName()))
 	}
 
+	var expirationSeconds int64
 	if e.filterExpiredNodeMetrics {
-		boundaryTime := metav1.NewTime(time.Now().Add(time.Duration(-e.nodeMetricExpirationSeconds * int64(time.Second))))
-		if nodeMetricInfo.UpdateTime.Before(&boundaryTime) {
-			return framework.NewStatus(framework.UnschedulableAndUnresolvable, "NodeMetricInfo has expired and cannot be used")
-		}
-	}
-
-	if len(e.usageThresholds) > 0 {
-		allocatable := framework.NewResource(nil)
-		switch resourceType {
-		case podutil.GuaranteedPod:
-			allocatable = nodeInfo.GetGuaranteedAllocatable()
-		case podutil.BestEffortPod:
-			allocatable = nodeInfo.GetBestEffortAllocatable()
-		}
-		for resourceName, threshold := range e.usageThresholds {
-			// TODO: support more resources.
-			var usage, total int64
-			switch resourceName {
-			case v1.ResourceCPU:
-				usage = nodeMetricInfo.ProfileMilliCPUUsage
-				total = allocatable.MilliCPU
-			case v1.ResourceMemory:
-				usage = nodeMetricInfo.ProfileMEMUsage
-				total = allocatable.Memory
-			}
-			if float64(usage) > float64(threshold)/100.0*float64(total) {
-				// Because we only judge utilization based on metric information, preemption is useless.
-				return framework.NewStatus(framework.UnschedulableAndUnresolvable, "NodeMetricInfo usage exceeds threshold")
-			}
-		}
+		expirationSeconds = e.nodeMetricExpirationSeconds
 	}
-
-	return nil
+	return utils.ValidateNodeMetric(nodeMetricInfo, nodeInfo, resourceType, expirationSeconds, e.usageThresholds)
 }
 
 func (e *NodeMetricEstimator) EstimatePod(pod *v1.Pod) (*framework.Resource, error) {
@@ -149,6 +119,12 @@ func (e *NodeMetricEstimator) EstimatePod(pod *v1.Pod) (*framework.Resource, err
 
 func (e *NodeMetricEstimator) EstimateNode(nodeInfo framework.NodeInfo, resourceType podutil.PodResourceType) (*framework.Resource, error) {
 	nodeUsage := e.pluginHandle.GetLoadAwareNodeUsage(nodeInfo.GetNodeName(), resourceType)
+	return e.estimateNodeUsage(nodeUsage, resourceType), nil
+}
+
+// ------------------------------ internal function ------------------------------
+
+func (e *NodeMetricEstimator) estimateNodeUsage(nodeUsage *framework.LoadAwareNodeUsage, resourceType podutil.PodResourceType) *framework.Resource {
 	var estimatedResource *framework.Resource
 	{
 		estimatedResource = framework.NewResource(e.scalingResource(v1.ResourceList{
@@ -160,11 +136,9 @@ func (e *NodeMetricEstimator) EstimateNode(nodeInfo framework.NodeInfo, resource
 		estimatedResource.MilliCPU += nodeUsage.ProfileMilliCPU
 		estimatedResource.Memory += nodeUsage.ProfileMEM
 	}
-	return estimatedResource, nil
+	return estimatedResource
 }
 
-// ------------------------------ internal function ------------------------------
-
 func (e *NodeMetricEstimator) scalingResource(resource v1.ResourceList, resourceType podutil.PodResourceType) v1.ResourceList {
 	ret := make(v1.ResourceList)
 	// only consider interested resources
@@ -30,6 +30,7 @@ type FactoryFn func(args *config.LoadAwareArgs, handle handle.PodFrameworkHandle
 var Estimators = map[string]FactoryFn{
 	DefaultEstimatorName:    NewDefaultEstimator,
 	NodeMetricEstimatorName: NewNodeMetricEstimator,
+	HistoricalEstimatorName: NewHistoricalEstimator,
 }
 
 type Estimator interface {
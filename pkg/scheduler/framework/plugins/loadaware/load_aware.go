@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/loadaware"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/validation"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
@@ -33,7 +34,7 @@ import (
 )
 
 const (
-	Name = "LoadAware"
+	Name = utils.Name
 )
 
 // resourceToWeightMap contains resource name and weight.
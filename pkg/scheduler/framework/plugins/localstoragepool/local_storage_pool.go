@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localstoragepool
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/localstoragepool"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/validation"
+	localstoragepoolstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/local_storage_pool_store"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = utils.Name
+
+	// preFilterStateKey is the key in CycleState to LocalStoragePoolChecker pre-computed data.
+	preFilterStateKey = "PreFilter" + Name
+)
+
+// preFilterState holds the local storage requested by the pod, keyed by storage class.
+type preFilterState map[string]int64
+
+// Clone the prefilter state.
+func (s preFilterState) Clone() framework.StateData {
+	// The state is not impacted by adding/removing existing pods, hence we don't need to make a deep copy.
+	return s
+}
+
+// LocalStoragePoolChecker is a plugin that checks if the local storage pools of the node could satisfy
+// the local volumes of the pod, and scores nodes according to the available local storage.
+type LocalStoragePoolChecker struct {
+	pluginHandle  localstoragepoolstore.StoreHandle
+	mostAvailable bool
+	weights       map[string]int64
+}
+
+var (
+	_ framework.PreFilterPlugin = &LocalStoragePoolChecker{}
+	_ framework.FilterPlugin    = &LocalStoragePoolChecker{}
+	_ framework.ScorePlugin     = &LocalStoragePoolChecker{}
+)
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *LocalStoragePoolChecker) Name() string {
+	return Name
+}
+
+// PreFilter invoked at the prefilter extension point.
+func (pl *LocalStoragePoolChecker) PreFilter(_ context.Context, cycleState *framework.CycleState, pod *v1.Pod) *framework.Status {
+	var s preFilterState
+	if pl.pluginHandle != nil {
+		s = utils.GetPodLocalStorageRequests(pl.pluginHandle, pl.pluginHandle.PvcLister(), pod)
+	}
+	cycleState.Write(preFilterStateKey, s)
+	return nil
+}
+
+// PreFilterExtensions do not exist for this plugin.
+func (pl *LocalStoragePoolChecker) PreFilterExtensions() framework.PreFilterExtensions {
+	return nil
+}
+
+func getPreFilterState(cycleState *framework.CycleState) (preFilterState, error) {
+	c, err := cycleState.Read(preFilterStateKey)
+	if err != nil {
+		// preFilterState doesn't exist, likely PreFilter wasn't invoked.
+		return nil, fmt.Errorf("reading %q from cycleState: %w", preFilterStateKey, err)
+	}
+
+	s, ok := c.(preFilterState)
+	if !ok {
+		return nil, fmt.Errorf("%+v  convert to localstoragepool.preFilterState error", c)
+	}
+	return s, nil
+}
+
+// Filter invoked at the filter extension point.
+func (pl *LocalStoragePoolChecker) Filter(_ context.Context, cycleState *framework.CycleState, _ *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	requests, err := getPreFilterState(cycleState)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	return utils.Fits(pl.pluginHandle, nodeInfo.GetNodeName(), requests)
+}
+
+// Score invoked at the Score extension point.
+func (pl *LocalStoragePoolChecker) Score(_ context.Context, cycleState *framework.CycleState, _ *v1.Pod, nodeName string) (int64, *framework.Status) {
+	requests, err := getPreFilterState(cycleState)
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+	if len(requests) == 0 {
+		return 0, nil
+	}
+	capacity, requested := pl.pluginHandle.GetNodeLocalStoragePools(nodeName)
+	return utils.Score(capacity, requested, requests, pl.weights, pl.mostAvailable), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *LocalStoragePoolChecker) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, handle handle.PodFrameworkHandle) (framework.Plugin, error) {
+	args, err := getLocalStoragePoolCheckerArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new LocalStoragePoolChecker plugin: %v", err)
+	}
+	if err := validation.ValidateLocalStoragePoolCheckerArgs(args); err != nil {
+		return nil, err
+	}
+
+	var pluginHandle localstoragepoolstore.StoreHandle
+	if ins := handle.FindStore(localstoragepoolstore.Name); ins != nil {
+		pluginHandle = ins.(localstoragepoolstore.StoreHandle)
+	}
+
+	weights := make(map[string]int64, len(args.StorageClassWeights))
+	for _, spec := range args.StorageClassWeights {
+		weights[spec.Name] = spec.Weight
+	}
+	return &LocalStoragePoolChecker{
+		pluginHandle:  pluginHandle,
+		mostAvailable: args.ScorePolicy == config.ScorePolicyMostAvailable,
+		weights:       weights,
+	}, nil
+}
+
+func getLocalStoragePoolCheckerArgs(obj runtime.Object) (*config.LocalStoragePoolCheckerArgs, error) {
+	if obj == nil {
+		return &config.LocalStoragePoolCheckerArgs{ScorePolicy: config.ScorePolicyMostAvailable}, nil
+	}
+	args, ok := obj.(*config.LocalStoragePoolCheckerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type LocalStoragePoolCheckerArgs, got %T", obj)
+	}
+	return args, nil
+}
@@ -21,12 +21,12 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	pluginhelper "github.com/kubewharf/godel-scheduler/pkg/plugins/helper"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/nodeaffinity"
 	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
@@ -35,18 +35,12 @@ import (
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
-	Name              = "NodeAffinity"
+	Name              = utils.Name
 	preFilterStateKey = "PreFilter" + Name
 )
 
-type requiredNodeAffinityTermSelector struct {
-	LabelSelector labels.Selector
-	FieldSelector fields.Selector
-}
-
 type preFilterState struct {
-	nodeLabelSelector                 labels.Selector
-	requiredNodeAffinityTermSelectors []*requiredNodeAffinityTermSelector
+	*utils.RequiredNodeAffinity
 }
 
 func getPreFilterState(cycleState *framework.CycleState) (*preFilterState, error) {
@@ -85,36 +79,7 @@ func (pl *NodeAffinity) Name() string {
 }
 
 func (a *NodeAffinity) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) *framework.Status {
-	data := &preFilterState{nodeLabelSelector: labels.SelectorFromSet(pod.Spec.NodeSelector)}
-	affinity := pod.Spec.Affinity
-	if affinity != nil && affinity.NodeAffinity != nil && affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
-		var selectors []*requiredNodeAffinityTermSelector
-		nodeSelectorTerms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
-		for _, req := range nodeSelectorTerms {
-			// nil or empty term selects no objects
-			if len(req.MatchExpressions) == 0 && len(req.MatchFields) == 0 {
-				continue
-			}
-
-			selector := &requiredNodeAffinityTermSelector{}
-			if len(req.MatchExpressions) != 0 {
-				if labelSelector, err := helper.NodeSelectorRequirementsAsSelector(req.MatchExpressions); err == nil {
-					selector.LabelSelector = labelSelector
-				}
-			}
-			if len(req.MatchFields) != 0 {
-				if fieldSelector, err := helper.NodeSelectorRequirementsAsFieldSelector(req.MatchFields); err == nil {
-					selector.FieldSelector = fieldSelector
-				}
-			}
-
-			if selector.LabelSelector != nil || selector.FieldSelector != nil {
-				selectors = append(selectors, selector)
-			}
-		}
-		data.requiredNodeAffinityTermSelectors = selectors
-	}
-	state.Write(preFilterStateKey, data)
+	state.Write(preFilterStateKey, &preFilterState{utils.GetRequiredNodeAffinity(pod)})
 	return nil
 }
 
@@ -129,14 +94,7 @@ func (pl *NodeAffinity) Filter(ctx context.Context, state *framework.CycleState,
 		return status
 	}
 
-	var nodeLabels map[string]string
-	switch podLauncher {
-	case podutil.Kubelet:
-		nodeLabels = nodeInfo.GetNode().Labels
-	case podutil.NodeManager:
-		nodeLabels = nodeInfo.GetNMNode().Labels
-	}
-
+	nodeLabels := utils.NodeLabels(podLauncher, nodeInfo)
 	nodeName := nodeInfo.GetNodeName()
 	nodeAffinityRelated, err := getPreFilterState(state)
 	if err != nil {
@@ -0,0 +1,123 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+)
+
+// Name of this plugin.
+const Name = nodepool.Name
+
+// NewPools converts the node pools of the plugin args and compiles them, the args are shared by
+// the NodePool plugin and the NodePoolChecker preemption plugin.
+func NewPools(args *config.NodePoolArgs) (nodepool.Pools, error) {
+	specs := make([]nodepool.Spec, len(args.Pools))
+	for i, pool := range args.Pools {
+		specs[i] = nodepool.Spec{
+			Name:         pool.Name,
+			Mode:         nodepool.Mode(pool.Mode),
+			NodeSelector: pool.NodeSelector,
+			Namespaces:   pool.Namespaces,
+			PodSelector:  pool.PodSelector,
+		}
+	}
+	return nodepool.NewPools(specs)
+}
+
+// NodePool isolates the nodes of tenants by node pools. Dedicated pools are only used by their tenants,
+// and pods prefer the nodes where they would not be preempted for borrowing the capacity of others.
+type NodePool struct {
+	handle handle.PodFrameworkHandle
+	pools  nodepool.Pools
+}
+
+var (
+	_ framework.FilterPlugin = &NodePool{}
+	_ framework.ScorePlugin  = &NodePool{}
+)
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, handle handle.PodFrameworkHandle) (framework.Plugin, error) {
+	args, err := GetArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := NewPools(args)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePool{handle: handle, pools: pools}, nil
+}
+
+// GetArgs returns the NodePoolArgs of the plugin args.
+func GetArgs(obj runtime.Object) (*config.NodePoolArgs, error) {
+	if obj == nil {
+		return &config.NodePoolArgs{}, nil
+	}
+	ptr, ok := obj.(*config.NodePoolArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NodePoolArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodePool) Name() string {
+	return Name
+}
+
+// Filter invoked at the filter extension point.
+// It rejects the nodes belonging to the dedicated node pools of other tenants.
+func (pl *NodePool) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
+	return pl.pools.Fits(pod, nodeInfo)
+}
+
+// Score invoked at the score extension point.
+// Tenants prefer the nodes of their own pools, and other pods avoid borrowing the capacity of
+// borrowable pools since the borrowed capacity may be reclaimed by preemption.
+func (pl *NodePool) Score(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v, node is nil", nodeName, err))
+	}
+	pool := pl.pools.PoolOfNode(nodeInfo)
+	switch {
+	case pool == nil:
+		return framework.MaxNodeScore / 2, nil
+	case pool.HasTenant(pod):
+		return framework.MaxNodeScore, nil
+	case pool.Mode == nodepool.Borrowable:
+		return framework.MinNodeScore, nil
+	default:
+		return framework.MaxNodeScore / 2, nil
+	}
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *NodePool) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
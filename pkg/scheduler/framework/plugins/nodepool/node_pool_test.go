@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func testNodePools() []config.NodePool {
+	return []config.NodePool{
+		{
+			Name:         "dedicated",
+			Mode:         config.DedicatedNodePool,
+			NodeSelector: map[string]string{"pool": "dedicated"},
+			Namespaces:   []string{"tenant-a"},
+		},
+		{
+			Name:         "borrowable",
+			Mode:         config.BorrowableNodePool,
+			NodeSelector: map[string]string{"pool": "borrowable"},
+			PodSelector:  &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}},
+		},
+		{
+			Name:         "shared",
+			Mode:         config.SharedNodePool,
+			NodeSelector: map[string]string{"pool": "shared"},
+			Namespaces:   []string{"tenant-a"},
+		},
+	}
+}
+
+func TestNodePoolFilter(t *testing.T) {
+	tenantA := testinghelper.MakePod().Namespace("tenant-a").Name("p").Obj()
+	appB := testinghelper.MakePod().Namespace("default").Name("p").Label("app", "b").Obj()
+	other := testinghelper.MakePod().Namespace("default").Name("p").Obj()
+
+	tests := []struct {
+		name      string
+		pod       *v1.Pod
+		nodeLabel string
+		wantPool  string
+		wantCode  framework.Code
+	}{
+		{
+			name:      "tenant on dedicated pool",
+			pod:       tenantA,
+			nodeLabel: "dedicated",
+			wantPool:  "dedicated",
+			wantCode:  framework.Success,
+		},
+		{
+			name:      "other tenant on dedicated pool",
+			pod:       appB,
+			nodeLabel: "dedicated",
+			wantPool:  "dedicated",
+			wantCode:  framework.UnschedulableAndUnresolvable,
+		},
+		{
+			name:      "borrower on borrowable pool",
+			pod:       other,
+			nodeLabel: "borrowable",
+			wantPool:  "borrowable",
+			wantCode:  framework.Success,
+		},
+		{
+			name:      "other pod on shared pool",
+			pod:       other,
+			nodeLabel: "shared",
+			wantPool:  "shared",
+			wantCode:  framework.Success,
+		},
+		{
+			name:      "node not in any pool",
+			pod:       other,
+			nodeLabel: "none",
+			wantCode:  framework.Success,
+		},
+	}
+
+	pl, err := New(&config.NodePoolArgs{Pools: testNodePools()}, nil)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(testinghelper.MakeNode().Name("n").Label("pool", tt.nodeLabel).Obj())
+
+			pool := pl.(*NodePool).pools.PoolOfNode(nodeInfo)
+			if (pool == nil && len(tt.wantPool) > 0) || (pool != nil && pool.Name != tt.wantPool) {
+				t.Errorf("expected pool %q, got %+v", tt.wantPool, pool)
+			}
+			status := pl.(framework.FilterPlugin).Filter(context.Background(), framework.NewCycleState(), tt.pod, nodeInfo)
+			if status.Code() != tt.wantCode {
+				t.Errorf("expected code %v, got %v", tt.wantCode, status.Code())
+			}
+		})
+	}
+}
+
+func TestNewNodePoolInvalidArgs(t *testing.T) {
+	pools := testNodePools()
+	pools[1].Name = pools[0].Name
+	if _, err := New(&config.NodePoolArgs{Pools: pools}, nil); err == nil {
+		t.Errorf("expected error for duplicated node pool names")
+	}
+
+	pools = testNodePools()
+	pools[0].Namespaces = nil
+	if _, err := New(&config.NodePoolArgs{Pools: pools}, nil); err == nil {
+		t.Errorf("expected error for node pool without tenants")
+	}
+}
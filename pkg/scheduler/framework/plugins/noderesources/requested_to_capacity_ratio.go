@@ -29,6 +29,7 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/validation"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
 const (
@@ -37,6 +38,12 @@ const (
 	maxUtilization               = 100
 )
 
+// defaultRequestedToCapacityRatioShape favors nodes with high utilization, which is used if no args provided.
+var defaultRequestedToCapacityRatioShape = []config.UtilizationShapePoint{
+	{Utilization: 0, Score: 0},
+	{Utilization: 100, Score: int32(config.MaxCustomPriorityScore)},
+}
+
 // NewRequestedToCapacityRatio initializes a new plugin and returns it.
 func NewRequestedToCapacityRatio(plArgs runtime.Object, handle handle.PodFrameworkHandle) (framework.Plugin, error) {
 	args, err := getRequestedToCapacityRatioArgs(plArgs)
@@ -48,17 +55,6 @@ func NewRequestedToCapacityRatio(plArgs runtime.Object, handle handle.PodFramewo
 		return nil, err
 	}
 
-	shape := make([]helper.FunctionShapePoint, 0, len(args.Shape))
-	for _, point := range args.Shape {
-		shape = append(shape, helper.FunctionShapePoint{
-			Utilization: int64(point.Utilization),
-			// MaxCustomPriorityScore may diverge from the max score used in the scheduler and defined by MaxNodeScore,
-			// therefore we need to scale the score returned by requested to capacity ratio to the score range
-			// used by the scheduler.
-			Score: int64(point.Score) * (framework.MaxNodeScore / config.MaxCustomPriorityScore),
-		})
-	}
-
 	resourceToWeightMap := make(resourceToWeightMap)
 	for _, resource := range args.Resources {
 		resourceToWeightMap[v1.ResourceName(resource.Name)] = resource.Weight
@@ -67,18 +63,53 @@ func NewRequestedToCapacityRatio(plArgs runtime.Object, handle handle.PodFramewo
 			resourceToWeightMap[v1.ResourceName(resource.Name)] = 1
 		}
 	}
+	if len(resourceToWeightMap) == 0 {
+		for resource, weight := range defaultRequestedRatioResources {
+			resourceToWeightMap[resource] = weight
+		}
+	}
+
+	shapes := resourceShapes{
+		defaultShape: buildFunctionShape(args.Shape),
+		shapes:       make(map[resourceShapeKey]helper.FunctionShape, len(args.ResourceShapes)),
+	}
+	for _, resourceShape := range args.ResourceShapes {
+		key := resourceShapeKey{v1.ResourceName(resourceShape.Name), podutil.PodResourceType(resourceShape.PodResourceType)}
+		shapes.shapes[key] = buildFunctionShape(resourceShape.Shape)
+		if _, ok := resourceToWeightMap[key.resource]; !ok {
+			// resources with a specific shape are always considered when scoring.
+			resourceToWeightMap[key.resource] = 1
+		}
+	}
 
 	return &RequestedToCapacityRatio{
 		handle: handle,
 		resourceAllocationScorer: resourceAllocationScorer{
 			RequestedToCapacityRatioName,
-			buildRequestedToCapacityRatioScorerFunction(shape, resourceToWeightMap),
+			buildRequestedToCapacityRatioScorerFunction(shapes, resourceToWeightMap),
 			resourceToWeightMap,
 		},
 	}, nil
 }
 
+func buildFunctionShape(points []config.UtilizationShapePoint) helper.FunctionShape {
+	shape := make([]helper.FunctionShapePoint, 0, len(points))
+	for _, point := range points {
+		shape = append(shape, helper.FunctionShapePoint{
+			Utilization: int64(point.Utilization),
+			// MaxCustomPriorityScore may diverge from the max score used in the scheduler and defined by MaxNodeScore,
+			// therefore we need to scale the score returned by requested to capacity ratio to the score range
+			// used by the scheduler.
+			Score: int64(point.Score) * (framework.MaxNodeScore / config.MaxCustomPriorityScore),
+		})
+	}
+	return shape
+}
+
 func getRequestedToCapacityRatioArgs(obj runtime.Object) (config.RequestedToCapacityRatioArgs, error) {
+	if obj == nil {
+		return config.RequestedToCapacityRatioArgs{Shape: defaultRequestedToCapacityRatioShape}, nil
+	}
 	ptr, ok := obj.(*config.RequestedToCapacityRatioArgs)
 	if !ok {
 		return config.RequestedToCapacityRatioArgs{}, fmt.Errorf("want args to be of type RequestedToCapacityRatioArgs, got %T", obj)
@@ -86,6 +117,28 @@ func getRequestedToCapacityRatioArgs(obj runtime.Object) (config.RequestedToCapa
 	return *ptr, nil
 }
 
+type resourceShapeKey struct {
+	resource        v1.ResourceName
+	podResourceType podutil.PodResourceType
+}
+
+// resourceShapes holds the shapes of resources, the shape for a specific pod resource type takes
+// precedence over the shape for all pods, and the default shape is used if neither exists.
+type resourceShapes struct {
+	defaultShape helper.FunctionShape
+	shapes       map[resourceShapeKey]helper.FunctionShape
+}
+
+func (rs resourceShapes) get(resource v1.ResourceName, podResourceType podutil.PodResourceType) helper.FunctionShape {
+	if shape, ok := rs.shapes[resourceShapeKey{resource, podResourceType}]; ok {
+		return shape
+	}
+	if shape, ok := rs.shapes[resourceShapeKey{resource: resource}]; ok {
+		return shape
+	}
+	return rs.defaultShape
+}
+
 // RequestedToCapacityRatio is a score plugin that allow users to apply bin packing
 // on core resources like CPU, Memory as well as extended resources like accelerators.
 type RequestedToCapacityRatio struct {
@@ -114,19 +167,32 @@ func (pl *RequestedToCapacityRatio) ScoreExtensions() framework.ScoreExtensions
 	return nil
 }
 
-func buildRequestedToCapacityRatioScorerFunction(scoringFunctionShape helper.FunctionShape, resourceToWeightMap resourceToWeightMap) scoreFunc {
-	rawScoringFunction := helper.BuildBrokenLinearFunction(scoringFunctionShape)
-	resourceScoringFunction := func(requested, capacity int64) int64 {
-		if capacity == 0 || requested > capacity {
-			return rawScoringFunction(maxUtilization)
-		}
+func buildRequestedToCapacityRatioScorerFunction(shapes resourceShapes, resourceToWeightMap resourceToWeightMap) scoreFunc {
+	buildResourceScoringFunction := func(shape helper.FunctionShape) func(requested, capacity int64) int64 {
+		rawScoringFunction := helper.BuildBrokenLinearFunction(shape)
+		return func(requested, capacity int64) int64 {
+			if capacity == 0 || requested > capacity {
+				return rawScoringFunction(maxUtilization)
+			}
 
-		return rawScoringFunction(maxUtilization - (capacity-requested)*maxUtilization/capacity)
+			return rawScoringFunction(maxUtilization - (capacity-requested)*maxUtilization/capacity)
+		}
+	}
+	resourceScoringFunctions := make(map[resourceShapeKey]func(requested, capacity int64) int64)
+	for resource := range resourceToWeightMap {
+		for _, podResourceType := range []podutil.PodResourceType{podutil.GuaranteedPod, podutil.BestEffortPod} {
+			resourceScoringFunctions[resourceShapeKey{resource, podResourceType}] = buildResourceScoringFunction(shapes.get(resource, podResourceType))
+		}
 	}
+
 	return func(state *framework.CycleState, requested, allocatable resourceToValueMap, includeVolumes bool, requestedVolumes int, allocatableVolumes int) int64 {
+		podResourceType, _ := framework.GetPodResourceType(state)
+		if podResourceType != podutil.BestEffortPod {
+			podResourceType = podutil.GuaranteedPod
+		}
 		var nodeScore, weightSum int64
 		for resource, weight := range resourceToWeightMap {
-			resourceScore := resourceScoringFunction(requested[resource], allocatable[resource])
+			resourceScore := resourceScoringFunctions[resourceShapeKey{resource, podResourceType}](requested[resource], allocatable[resource])
 			if resourceScore > 0 {
 				nodeScore += resourceScore * weight
 				weightSum += weight
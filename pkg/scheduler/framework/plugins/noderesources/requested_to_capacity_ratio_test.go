@@ -673,3 +673,85 @@ func TestResourceBinPackingMultipleExtended(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestedToCapacityRatioResourceShapes(t *testing.T) {
+	gpu := v1.ResourceName("nvidia.com/gpu")
+	args := &config.RequestedToCapacityRatioArgs{
+		// least requested for cpu.
+		Shape: []config.UtilizationShapePoint{
+			{Utilization: 0, Score: 10},
+			{Utilization: 100, Score: 0},
+		},
+		Resources: []config.ResourceSpec{
+			{Name: "cpu", Weight: 1},
+		},
+		ResourceShapes: []config.ResourceShape{
+			// bin packing for gpu of all pods.
+			{Name: string(gpu), Shape: []config.UtilizationShapePoint{{Utilization: 0, Score: 0}, {Utilization: 100, Score: 10}}},
+			// spreading for gpu of best-effort pods.
+			{Name: string(gpu), PodResourceType: string(podutil.BestEffortPod), Shape: []config.UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}}},
+		},
+	}
+	p, err := NewRequestedToCapacityRatio(args, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scorer := p.(*RequestedToCapacityRatio).resourceAllocationScorer
+	if weight := scorer.resourceToWeightMap[gpu]; weight != 1 {
+		t.Errorf("expected resource with specific shape considered with weight 1, but got %d", weight)
+	}
+
+	tests := []struct {
+		name            string
+		podResourceType podutil.PodResourceType
+		requested       resourceToValueMap
+		allocatable     resourceToValueMap
+		expectedScore   int64
+	}{
+		{
+			name:            "guaranteed pod uses bin packing shape for gpu",
+			podResourceType: podutil.GuaranteedPod,
+			requested:       resourceToValueMap{v1.ResourceCPU: 2000, gpu: 6},
+			allocatable:     resourceToValueMap{v1.ResourceCPU: 8000, gpu: 8},
+			// cpu: 75, gpu: 75
+			expectedScore: 75,
+		},
+		{
+			name:            "best-effort pod uses its own shape for gpu",
+			podResourceType: podutil.BestEffortPod,
+			requested:       resourceToValueMap{v1.ResourceCPU: 2000, gpu: 6},
+			allocatable:     resourceToValueMap{v1.ResourceCPU: 8000, gpu: 8},
+			// cpu: 75, gpu: 25
+			expectedScore: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := framework.NewCycleState()
+			if err := framework.SetPodResourceTypeState(tt.podResourceType, state); err != nil {
+				t.Errorf("cycle state error: %v", err)
+			}
+			if score := scorer.scorer(state, tt.requested, tt.allocatable, false, 0, 0); score != tt.expectedScore {
+				t.Errorf("expected score %d, but got %d", tt.expectedScore, score)
+			}
+		})
+	}
+}
+
+func TestRequestedToCapacityRatioDefaultArgs(t *testing.T) {
+	p, err := NewRequestedToCapacityRatio(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scorer := p.(*RequestedToCapacityRatio).resourceAllocationScorer
+	if !reflect.DeepEqual(scorer.resourceToWeightMap, defaultRequestedRatioResources) {
+		t.Errorf("expected default resources %v, but got %v", defaultRequestedRatioResources, scorer.resourceToWeightMap)
+	}
+	state := framework.NewCycleState()
+	// the default shape favors nodes with high utilization.
+	requested := resourceToValueMap{v1.ResourceCPU: 3000, v1.ResourceMemory: 3000}
+	allocatable := resourceToValueMap{v1.ResourceCPU: 4000, v1.ResourceMemory: 4000}
+	if score := scorer.scorer(state, requested, allocatable, false, 0, 0); score != 75 {
+		t.Errorf("expected score 75, but got %d", score)
+	}
+}
@@ -23,10 +23,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
-	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/nodeunschedulable"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
-	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
-	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 )
 
 // NodeUnschedulable plugin filters nodes that set node.Spec.Unschedulable=true unless
@@ -35,14 +33,13 @@ type NodeUnschedulable struct{}
 
 var _ framework.FilterPlugin = &NodeUnschedulable{}
 
-// Name is the name of the plugin used in the plugin registry and configurations.
-const Name = "NodeUnschedulable"
-
 const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = utils.Name
 	// ErrReasonUnknownCondition is used for NodeUnknownCondition predicate error.
-	ErrReasonUnknownCondition = "node(s) had unknown conditions"
+	ErrReasonUnknownCondition = utils.ErrReasonUnknownCondition
 	// ErrReasonUnschedulable is used for NodeUnschedulable predicate error.
-	ErrReasonUnschedulable = "node(s) were unschedulable"
+	ErrReasonUnschedulable = utils.ErrReasonUnschedulable
 )
 
 // Name returns name of the plugin. It is used in logs, etc.
@@ -52,30 +49,7 @@ func (pl *NodeUnschedulable) Name() string {
 
 // Filter invoked at the filter extension point.
 func (pl *NodeUnschedulable) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
-	launcher, status := podlauncher.NodeFits(state, pod, nodeInfo)
-	if status != nil {
-		return status
-	}
-
-	switch launcher {
-	case podutil.Kubelet:
-		// If pod tolerate unschedulable taint, it's also tolerate `node.Spec.Unschedulable`.
-		podToleratesUnschedulable := helper.TolerationsTolerateTaint(pod.Spec.Tolerations, &v1.Taint{
-			Key:    v1.TaintNodeUnschedulable,
-			Effect: v1.TaintEffectNoSchedule,
-		})
-
-		// TODO (k82cn): deprecates `node.Spec.Unschedulable` in 1.13.
-		if nodeInfo.GetNode().Spec.Unschedulable && !podToleratesUnschedulable {
-			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonUnschedulable)
-		}
-	case podutil.NodeManager:
-		nmNode := nodeInfo.GetNMNode()
-		if nmNode == nil {
-			return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonUnknownCondition)
-		}
-	}
-	return nil
+	return utils.Fits(state, pod, nodeInfo)
 }
 
 // New initializes a new plugin and returns it.
@@ -26,6 +26,7 @@ import (
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	pluginhelper "github.com/kubewharf/godel-scheduler/pkg/plugins/helper"
 	"github.com/kubewharf/godel-scheduler/pkg/plugins/podlauncher"
+	utils "github.com/kubewharf/godel-scheduler/pkg/plugins/tainttoleration"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
@@ -44,7 +45,7 @@ var (
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
-	Name = "TaintToleration"
+	Name = utils.Name
 	// preScoreStateKey is the key in CycleState to TaintToleration pre-computed data for Scoring.
 	preScoreStateKey = "PreScore" + Name
 	// ErrReasonNotMatch is the Filter reason status when not matching.
@@ -59,32 +60,7 @@ func (pl *TaintToleration) Name() string {
 // Filter invoked at the filter extension point.
 // Only Node is supported currently, we can add support for CNR when it is in need.
 func (pl *TaintToleration) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) *framework.Status {
-	launcher, status := podlauncher.NodeFits(state, pod, nodeInfo)
-	if status != nil {
-		return status
-	}
-
-	var taints []v1.Taint
-	switch launcher {
-	case podutil.Kubelet:
-		taints = nodeInfo.GetNode().Spec.Taints
-	case podutil.NodeManager:
-		taints = nodeInfo.GetNMNode().Spec.Taints
-	}
-
-	filterPredicate := func(t *v1.Taint) bool {
-		// PodToleratesNodeTaints is only interested in NoSchedule and NoExecute taints.
-		return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
-	}
-
-	taint, isUntolerated := helper.FindMatchingUntoleratedTaint(taints, pod.Spec.Tolerations, filterPredicate)
-	if !isUntolerated {
-		return nil
-	}
-
-	errReason := fmt.Sprintf("node(s) had taint {%s: %s}, that the pod didn't tolerate",
-		taint.Key, taint.Value)
-	return framework.NewStatus(framework.UnschedulableAndUnresolvable, errReason)
+	return utils.Fits(state, pod, nodeInfo)
 }
 
 // preScoreState computed at PreScore and used at Score.
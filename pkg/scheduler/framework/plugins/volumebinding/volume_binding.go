@@ -18,6 +18,8 @@ package volumebinding
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -30,13 +32,47 @@ import (
 
 // VolumeBinding is a plugin that binds pod volumes in scheduling.
 type VolumeBinding struct {
+	handle handle.PodFrameworkHandle
 	binder scheduling.BaseVolumeBinder
 }
 
-var _ framework.FilterPlugin = &VolumeBinding{}
+var (
+	_ framework.PreFilterPlugin = &VolumeBinding{}
+	_ framework.FilterPlugin    = &VolumeBinding{}
+	_ framework.ScorePlugin     = &VolumeBinding{}
+)
+
+const (
+	// Name is the name of the plugin used in Registry and configurations.
+	Name = "VolumeBinding"
+
+	// stateKey is the key in CycleState to the volumes of the pod found on each node.
+	stateKey = Name
+)
+
+// stateData holds the volumes of the pod found on each node by Filter, which are reused by Score.
+type stateData struct {
+	sync.Mutex
+	podVolumesByNode map[string][]*scheduling.VolumeResource
+}
 
-// Name is the name of the plugin used in Registry and configurations.
-const Name = "VolumeBinding"
+// Clone the state data.
+func (d *stateData) Clone() framework.StateData {
+	// The volumes found on each node are not impacted by adding/removing existing pods, hence we don't need to make a deep copy.
+	return d
+}
+
+func getStateData(cs *framework.CycleState) (*stateData, error) {
+	c, err := cs.Read(stateKey)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := c.(*stateData)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to volumebinding.stateData error", c)
+	}
+	return s, nil
+}
 
 // Name returns name of the plugin. It is used in logs, etc.
 func (pl *VolumeBinding) Name() string {
@@ -52,6 +88,21 @@ func podHasPVCs(pod *v1.Pod) bool {
 	return false
 }
 
+// PreFilter invoked at the prefilter extension point, it initializes the state data shared by Filter and Score.
+func (pl *VolumeBinding) PreFilter(ctx context.Context, cs *framework.CycleState, pod *v1.Pod) *framework.Status {
+	// If pod does not request any PVC, we don't need to do anything.
+	if !podHasPVCs(pod) {
+		return nil
+	}
+	cs.Write(stateKey, &stateData{podVolumesByNode: make(map[string][]*scheduling.VolumeResource)})
+	return nil
+}
+
+// PreFilterExtensions do not exist for this plugin.
+func (pl *VolumeBinding) PreFilterExtensions() framework.PreFilterExtensions {
+	return nil
+}
+
 // Filter invoked at the filter extension point.
 // It evaluates if a pod can fit due to the volumes it requests,
 // for both bound and unbound PVCs.
@@ -74,7 +125,7 @@ func (pl *VolumeBinding) Filter(ctx context.Context, cs *framework.CycleState, p
 	}
 
 	podLauncher, _ := podutil.GetPodLauncher(pod)
-	reasons, err := pl.binder.FindPodVolumes(pod, nodeInfo.GetNodeName(), nodeInfo.GetNodeLabels(podLauncher))
+	resources, reasons, err := pl.binder.FindPodVolumesWithResources(pod, nodeInfo.GetNodeName(), nodeInfo.GetNodeLabels(podLauncher))
 	if err != nil {
 		return framework.NewStatus(framework.Error, err.Error())
 	}
@@ -86,17 +137,83 @@ func (pl *VolumeBinding) Filter(ctx context.Context, cs *framework.CycleState, p
 		}
 		return status
 	}
+	if state, err := getStateData(cs); err == nil {
+		state.Lock()
+		state.podVolumesByNode[nodeInfo.GetNodeName()] = resources
+		state.Unlock()
+	}
+	return nil
+}
+
+// Score invoked at the Score extension point.
+// It favors nodes on which the unbound PVCs of the pod fit best, that is, the matched static PVs or the
+// storage capacity used for dynamic provisioning are utilized the most. Nodes that no volume needs to be
+// bound or provisioned on get zero.
+func (pl *VolumeBinding) Score(ctx context.Context, cs *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	if !podHasPVCs(pod) {
+		return 0, nil
+	}
+	if state, err := getStateData(cs); err == nil {
+		state.Lock()
+		resources, ok := state.podVolumesByNode[nodeName]
+		state.Unlock()
+		if ok {
+			return volumeResourcesScore(resources), nil
+		}
+	}
+
+	// The volumes are not found by Filter, e.g. the plugin is only enabled for scoring.
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+
+	podLauncher, _ := podutil.GetPodLauncher(pod)
+	resources, reasons, err := pl.binder.FindPodVolumesWithResources(pod, nodeName, nodeInfo.GetNodeLabels(podLauncher))
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, err.Error())
+	}
+	if len(reasons) > 0 {
+		return 0, nil
+	}
+	return volumeResourcesScore(resources), nil
+}
+
+// volumeResourcesScore returns the average utilization of the volumes with known capacity, scaled to MaxNodeScore.
+func volumeResourcesScore(resources []*scheduling.VolumeResource) int64 {
+	var score, count int64
+	for _, r := range resources {
+		if r.Capacity <= 0 {
+			continue
+		}
+		requested := r.Requested
+		if requested > r.Capacity {
+			requested = r.Capacity
+		}
+		score += requested * framework.MaxNodeScore / r.Capacity
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return score / count
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *VolumeBinding) ScoreExtensions() framework.ScoreExtensions {
 	return nil
 }
 
 // New initializes a new plugin with volume binder and returns it.
 func New(_ runtime.Object, fh handle.PodFrameworkHandle) (framework.Plugin, error) {
 	return &VolumeBinding{
+		handle: fh,
 		binder: scheduling.NewBaseVolumeBinder(
 			fh.SharedInformerFactory().Storage().V1().CSINodes(),
 			fh.SharedInformerFactory().Core().V1().PersistentVolumeClaims(),
 			fh.SharedInformerFactory().Core().V1().PersistentVolumes(),
 			fh.SharedInformerFactory().Storage().V1().StorageClasses(),
+			scheduling.NewCapacityCheck(fh.SharedInformerFactory()),
 		),
 	}, nil
 }
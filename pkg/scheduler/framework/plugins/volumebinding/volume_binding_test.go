@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/volume/scheduling"
@@ -105,10 +106,89 @@ func TestVolumeBinding(t *testing.T) {
 			p := &VolumeBinding{
 				binder: fakeVolumeBinder,
 			}
-			gotStatus := p.Filter(context.Background(), nil, item.pod, nodeInfo)
+			state := framework.NewCycleState()
+			if status := p.PreFilter(context.Background(), state, item.pod); !status.IsSuccess() {
+				t.Fatalf("unexpected PreFilter status: %v", status)
+			}
+			gotStatus := p.Filter(context.Background(), state, item.pod, nodeInfo)
 			if !reflect.DeepEqual(gotStatus, item.wantStatus) {
 				t.Errorf("status does not match: %v, want: %v", gotStatus, item.wantStatus)
 			}
 		})
 	}
 }
+
+func TestVolumeBindingScoreReusesFilterResult(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{},
+					},
+				},
+			},
+		},
+	}
+	config := &scheduling.FakeVolumeBinderConfig{
+		FindResources: []*scheduling.VolumeResource{{Requested: 10, Capacity: 40}},
+	}
+	p := &VolumeBinding{
+		binder: scheduling.NewFakeVolumeBinder(config),
+	}
+
+	state := framework.NewCycleState()
+	if status := p.PreFilter(context.Background(), state, pod); !status.IsSuccess() {
+		t.Fatalf("unexpected PreFilter status: %v", status)
+	}
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}})
+	if status := p.Filter(context.Background(), state, pod, nodeInfo); !status.IsSuccess() {
+		t.Fatalf("unexpected Filter status: %v", status)
+	}
+
+	// Score should use the volumes found by Filter instead of finding them again.
+	config.FindResources = []*scheduling.VolumeResource{{Requested: 10, Capacity: 10}}
+	score, status := p.Score(context.Background(), state, pod, "n1")
+	if !status.IsSuccess() {
+		t.Fatalf("unexpected Score status: %v", status)
+	}
+	if score != 25 {
+		t.Errorf("expected score 25, but got %d", score)
+	}
+}
+
+func TestVolumeResourcesScore(t *testing.T) {
+	table := []struct {
+		name      string
+		resources []*scheduling.VolumeResource
+		want      int64
+	}{
+		{
+			name: "no volumes to bind or provision",
+			want: 0,
+		},
+		{
+			name:      "unknown capacity",
+			resources: []*scheduling.VolumeResource{{Requested: 10}},
+			want:      0,
+		},
+		{
+			name: "best fit volumes get higher score",
+			resources: []*scheduling.VolumeResource{
+				{Requested: 10, Capacity: 10},
+				{Requested: 10, Capacity: 40},
+				{Requested: 10},
+			},
+			want: (100 + 25) / 2,
+		},
+	}
+
+	for _, item := range table {
+		t.Run(item.name, func(t *testing.T) {
+			if got := volumeResourcesScore(item.resources); got != item.want {
+				t.Errorf("expected score %d, but got %d", item.want, got)
+			}
+		})
+	}
+}
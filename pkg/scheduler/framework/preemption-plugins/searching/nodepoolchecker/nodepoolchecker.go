@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepoolchecker
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+	nodepoolplugin "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodepool"
+)
+
+const (
+	NodePoolCheckerName   = nodepool.NodePoolCheckerName
+	preemptingNodePoolKey = "Preempting-" + NodePoolCheckerName
+)
+
+// NodePoolChecker lets the tenants of borrowable node pools reclaim the capacity borrowed by other pods,
+// and prevents the borrowers from preempting the tenants. It takes the args of the NodePool plugin, and
+// is placed in a ForceQuickPass plugin collection before the PriorityValueChecker by default, so that the
+// tenants reclaim regardless of priority.
+type NodePoolChecker struct {
+	pools nodepool.Pools
+}
+
+var (
+	_ framework.NodePrePreemptingPlugin = &NodePoolChecker{}
+	_ framework.VictimSearchingPlugin   = &NodePoolChecker{}
+)
+
+// NewNodePoolChecker initializes a new plugin and returns it.
+func NewNodePoolChecker(plArgs runtime.Object, _ handle.PodFrameworkHandle) (framework.Plugin, error) {
+	args, err := nodepoolplugin.GetArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new NodePoolChecker plugin: %v", err)
+	}
+	pools, err := nodepoolplugin.NewPools(args)
+	if err != nil {
+		return nil, err
+	}
+	return &NodePoolChecker{pools: pools}, nil
+}
+
+func (npc *NodePoolChecker) Name() string {
+	return NodePoolCheckerName
+}
+
+func (npc *NodePoolChecker) NodePrePreempting(_ *v1.Pod, nodeInfo framework.NodeInfo, _, preemptionState *framework.CycleState) *framework.Status {
+	preemptionState.Write(preemptingNodePoolKey, &nodePoolState{pool: npc.pools.PoolOfNode(nodeInfo)})
+	return nil
+}
+
+func (npc *NodePoolChecker) VictimSearching(preemptor *v1.Pod, podInfo *framework.PodInfo, _, preemptionState *framework.CycleState, _ *framework.VictimState) (framework.Code, string) {
+	s, err := getNodePoolState(preemptionState)
+	if err != nil {
+		return framework.Error, err.Error()
+	}
+	return nodepool.CheckVictim(s.pool, preemptor, podInfo.Pod)
+}
+
+type nodePoolState struct {
+	pool *nodepool.Pool
+}
+
+func (s *nodePoolState) Clone() framework.StateData {
+	return s
+}
+
+func getNodePoolState(state *framework.CycleState) (*nodePoolState, error) {
+	c, err := state.Read(preemptingNodePoolKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", preemptingNodePoolKey, err)
+	}
+	s, ok := c.(*nodePoolState)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to NodePoolChecker.nodePoolState error", c)
+	}
+	return s, nil
+}
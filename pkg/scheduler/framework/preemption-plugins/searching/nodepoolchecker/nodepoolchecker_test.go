@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepoolchecker
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+)
+
+func TestNodePoolChecker(t *testing.T) {
+	pools := []config.NodePool{
+		{
+			Name:         "borrowable",
+			Mode:         config.BorrowableNodePool,
+			NodeSelector: map[string]string{"pool": "borrowable"},
+			Namespaces:   []string{"tenant"},
+		},
+		{
+			Name:         "dedicated",
+			Mode:         config.DedicatedNodePool,
+			NodeSelector: map[string]string{"pool": "dedicated"},
+			Namespaces:   []string{"tenant"},
+		},
+	}
+	tenant := testinghelper.MakePod().Namespace("tenant").Name("tenant").Priority(10).Obj()
+	borrower := testinghelper.MakePod().Namespace("default").Name("borrower").Priority(100).Obj()
+
+	tests := []struct {
+		name           string
+		nodeLabel      string
+		preemptor      *v1.Pod
+		victim         *v1.Pod
+		expectedStatus *framework.Status
+	}{
+		{
+			name:           "tenant reclaims borrowed capacity",
+			nodeLabel:      "borrowable",
+			preemptor:      tenant,
+			victim:         borrower,
+			expectedStatus: framework.NewStatus(framework.PreemptionSucceed, ""),
+		},
+		{
+			name:           "borrower could not preempt tenant",
+			nodeLabel:      "borrowable",
+			preemptor:      borrower,
+			victim:         tenant,
+			expectedStatus: framework.NewStatus(framework.PreemptionFail, nodepool.ErrReasonBorrowerPreempt),
+		},
+		{
+			name:           "tenants preempt each other",
+			nodeLabel:      "borrowable",
+			preemptor:      tenant,
+			victim:         tenant,
+			expectedStatus: framework.NewStatus(framework.PreemptionNotSure, ""),
+		},
+		{
+			name:           "not borrowable pool",
+			nodeLabel:      "dedicated",
+			preemptor:      tenant,
+			victim:         borrower,
+			expectedStatus: framework.NewStatus(framework.PreemptionNotSure, ""),
+		},
+		{
+			name:           "node not in any pool",
+			nodeLabel:      "none",
+			preemptor:      tenant,
+			victim:         borrower,
+			expectedStatus: framework.NewStatus(framework.PreemptionNotSure, ""),
+		},
+	}
+
+	pl, err := NewNodePoolChecker(&config.NodePoolArgs{Pools: pools}, nil)
+	if err != nil {
+		t.Fatalf("failed to create plugin: %v", err)
+	}
+	checker := pl.(*NodePoolChecker)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(testinghelper.MakeNode().Name("n").Label("pool", tt.nodeLabel).Obj())
+			preemptionState := framework.NewCycleState()
+			if status := checker.NodePrePreempting(tt.preemptor, nodeInfo, nil, preemptionState); status != nil {
+				t.Fatalf("failed to run NodePrePreempting: %v", status)
+			}
+			gotCode, gotMsg := checker.VictimSearching(tt.preemptor, framework.NewPodInfo(tt.victim), nil, preemptionState, nil)
+			if gotStatus := framework.NewStatus(gotCode, gotMsg); !reflect.DeepEqual(tt.expectedStatus, gotStatus) {
+				t.Errorf("expected to get status: %v, but got: %v", tt.expectedStatus, gotStatus)
+			}
+		})
+	}
+}
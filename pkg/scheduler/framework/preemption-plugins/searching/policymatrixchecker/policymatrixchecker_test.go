@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policymatrixchecker
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+
+	apisconfig "github.com/kubewharf/godel-scheduler/pkg/apis/config"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+func TestPreemptionPolicyMatrix(t *testing.T) {
+	args := &config.PreemptionPolicyMatrixArgs{
+		Classes: []apisconfig.PreemptionClass{
+			{Name: "online", PriorityClassNames: []string{"online"}},
+			{Name: "batch", PriorityClassNames: []string{"batch"}},
+		},
+		Rules: []apisconfig.PreemptionRule{
+			{Preemptor: "online", Victims: []string{"batch"}, MaxPriorityGap: pointer.Int64(100)},
+		},
+	}
+	pl, err := NewPreemptionPolicyMatrix(args, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := pl.(*PreemptionPolicyMatrix)
+
+	pod := func(priorityClass string, priority int32) *testing_helper.PodWrapper {
+		return testing_helper.MakePod().PriorityClassName(priorityClass).Priority(priority)
+	}
+	tests := []struct {
+		name      string
+		preemptor *v1.Pod
+		victim    *v1.Pod
+		want      framework.Code
+	}{
+		{
+			name:      "online preempts batch",
+			preemptor: pod("online", 100).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionSucceed,
+		},
+		{
+			name:      "batch could not preempt online",
+			preemptor: pod("batch", 100).Obj(),
+			victim:    pod("online", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "priority gap exceeds the limit",
+			preemptor: pod("online", 1000).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "preemptor of different resource type",
+			preemptor: pod("online", 100).Annotation(podutil.PodResourceTypeAnnotationKey, string(podutil.BestEffortPod)).Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "preemptor with invalid resource type",
+			preemptor: pod("online", 100).Annotation(podutil.PodResourceTypeAnnotationKey, "invalid").Obj(),
+			victim:    pod("batch", 10).Obj(),
+			want:      framework.PreemptionFail,
+		},
+		{
+			name:      "preemptor without class",
+			preemptor: pod("unknown", 100).Obj(),
+			victim:    pod("online", 10).Obj(),
+			want:      framework.PreemptionNotSure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podInfo := framework.NewPodInfo(tt.victim)
+			if got, msg := checker.VictimSearching(tt.preemptor, podInfo, nil, nil, nil); got != tt.want {
+				t.Errorf("expected %v, got %v: %s", tt.want, got, msg)
+			}
+		})
+	}
+}
+
+func TestNewPreemptionPolicyMatrix(t *testing.T) {
+	if _, err := NewPreemptionPolicyMatrix(nil, nil); err != nil {
+		t.Errorf("expected no error for nil args, got %v", err)
+	}
+	invalid := &config.PreemptionPolicyMatrixArgs{
+		Classes: []apisconfig.PreemptionClass{{Name: "online", PriorityClassNames: []string{"online"}}},
+		Rules:   []apisconfig.PreemptionRule{{Preemptor: "online", Victims: []string{"unknown"}}},
+	}
+	if _, err := NewPreemptionPolicyMatrix(invalid, nil); err == nil {
+		t.Errorf("expected error for invalid args")
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionbudgetchecker
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/preempting/budgetchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/validation"
+	preemptionbudgetstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_budget_store"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+)
+
+const (
+	PreemptionBudgetCheckerName       = budgetchecker.PreemptionBudgetCheckerName
+	SearchingPreemptionBudgetCheckKey = "Searching-" + PreemptionBudgetCheckerName
+)
+
+// PreemptionBudgetChecker rejects the victims whose owner or namespace has been preempted too often recently.
+type PreemptionBudgetChecker struct {
+	budget       *budgetchecker.Budget
+	pluginHandle preemptionbudgetstore.StoreHandle
+	// usage is the victims chosen by the previous preemptors in the same unit.
+	usage *budgetchecker.BudgetUsage
+}
+
+var (
+	_ framework.ClusterPrePreemptingPlugin = &PreemptionBudgetChecker{}
+	_ framework.NodePrePreemptingPlugin    = &PreemptionBudgetChecker{}
+	_ framework.VictimSearchingPlugin      = &PreemptionBudgetChecker{}
+	_ framework.PostVictimSearchingPlugin  = &PreemptionBudgetChecker{}
+	_ framework.NodePostPreemptingPlugin   = &PreemptionBudgetChecker{}
+)
+
+// NewPreemptionBudgetChecker initializes a new plugin and returns it.
+func NewPreemptionBudgetChecker(plArgs runtime.Object, handle handle.PodFrameworkHandle) (framework.Plugin, error) {
+	args, err := getPreemptionBudgetCheckerArgs(plArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to new PreemptionBudgetChecker plugin: %v", err)
+	}
+	if err := validation.ValidatePreemptionBudgetCheckerArgs(args); err != nil {
+		return nil, err
+	}
+
+	checker := &PreemptionBudgetChecker{
+		budget: budgetchecker.NewBudget(args.WindowSeconds, args.MaxVictimsPerOwner, args.MaxVictimsPerNamespace, args.MinIntervalSecondsPerOwner),
+	}
+	if ins := handle.FindStore(preemptionbudgetstore.Name); ins != nil {
+		checker.pluginHandle = ins.(preemptionbudgetstore.StoreHandle)
+	}
+	return checker, nil
+}
+
+func (pbc *PreemptionBudgetChecker) Name() string {
+	return PreemptionBudgetCheckerName
+}
+
+func (pbc *PreemptionBudgetChecker) ClusterPrePreempting(_ *v1.Pod, _, commonState *framework.CycleState) *framework.Status {
+	// get from common state first
+	if usage, err := getBudgetUsage(commonState); err == nil {
+		pbc.usage = usage
+		return nil
+	}
+	pbc.usage = budgetchecker.NewBudgetUsage()
+	commonState.Write(SearchingPreemptionBudgetCheckKey, pbc.usage)
+	return nil
+}
+
+func (pbc *PreemptionBudgetChecker) NodePrePreempting(_ *v1.Pod, _ framework.NodeInfo, _, preemptionState *framework.CycleState) *framework.Status {
+	usage := pbc.usage
+	if usage == nil {
+		usage = budgetchecker.NewBudgetUsage()
+	}
+	preemptionState.Write(SearchingPreemptionBudgetCheckKey, usage.Clone())
+	return nil
+}
+
+func (pbc *PreemptionBudgetChecker) VictimSearching(_ *v1.Pod, podInfo *framework.PodInfo, _, preemptionState *framework.CycleState, _ *framework.VictimState) (framework.Code, string) {
+	if pbc.pluginHandle == nil {
+		return framework.PreemptionNotSure, ""
+	}
+	usage, err := getBudgetUsage(preemptionState)
+	if err != nil {
+		return framework.Error, err.Error()
+	}
+	return budgetchecker.CheckBudget(pbc.budget, pbc.pluginHandle, usage, podInfo.Pod, time.Now())
+}
+
+func (pbc *PreemptionBudgetChecker) PostVictimSearching(_ *v1.Pod, podInfo *framework.PodInfo, _, preemptionState *framework.CycleState, _ *framework.VictimState) *framework.Status {
+	usage, err := getBudgetUsage(preemptionState)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	usage.Add(podInfo.Pod)
+	return nil
+}
+
+func (pbc *PreemptionBudgetChecker) NodePostPreempting(_ *v1.Pod, victims []*v1.Pod, _, _ *framework.CycleState) *framework.Status {
+	if pbc.usage == nil {
+		return nil
+	}
+	for _, victim := range victims {
+		pbc.usage.Add(victim)
+	}
+	return nil
+}
+
+func getBudgetUsage(state *framework.CycleState) (*budgetchecker.BudgetUsage, error) {
+	data, err := state.Read(SearchingPreemptionBudgetCheckKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", SearchingPreemptionBudgetCheckKey, err)
+	}
+	usage, ok := data.(*budgetchecker.BudgetUsage)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to PreemptionBudgetChecker.BudgetUsage error", data)
+	}
+	return usage, nil
+}
+
+func getPreemptionBudgetCheckerArgs(obj runtime.Object) (*config.PreemptionBudgetCheckerArgs, error) {
+	if obj == nil {
+		return &config.PreemptionBudgetCheckerArgs{}, nil
+	}
+	ptr, ok := obj.(*config.PreemptionBudgetCheckerArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type PreemptionBudgetCheckerArgs, got %T", obj)
+	}
+	return ptr, nil
+}
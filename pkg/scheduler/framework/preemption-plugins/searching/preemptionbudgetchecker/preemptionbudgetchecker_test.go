@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemptionbudgetchecker
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/preempting/budgetchecker"
+	testing_helper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+type fakeHistory struct {
+	*framework.PreemptionRecords
+}
+
+func (h *fakeHistory) GetPreemptionCountOfOwner(ownerKey string, since time.Time) int64 {
+	return h.CountByOwner(ownerKey, since)
+}
+
+func (h *fakeHistory) GetPreemptionCountOfNamespace(namespace string, since time.Time) int64 {
+	return h.CountByNamespace(namespace, since)
+}
+
+func (h *fakeHistory) GetLastPreemptionTimeOfOwner(ownerKey string) time.Time {
+	return h.LastPreemptionTimeOfOwner(ownerKey)
+}
+
+func TestPreemptionBudgetChecker(t *testing.T) {
+	controller := true
+	ownerRef := metav1.OwnerReference{Kind: "ReplicaSet", Name: "rs", UID: "rs", Controller: &controller}
+	victims := []*v1.Pod{
+		testing_helper.MakePod().Namespace("ns").Name("v1").UID("v1").ControllerRef(ownerRef).Obj(),
+		testing_helper.MakePod().Namespace("ns").Name("v2").UID("v2").ControllerRef(ownerRef).Obj(),
+	}
+	history := &fakeHistory{framework.NewPreemptionRecords()}
+	history.AddRecord(&framework.PreemptionRecord{
+		VictimKey: "v0", OwnerKey: podutil.GetPodOwner(victims[0]), Namespace: "ns", Timestamp: time.Now(),
+	})
+	checker := &PreemptionBudgetChecker{
+		budget:       budgetchecker.NewBudget(60, 2, 0, 0),
+		pluginHandle: history,
+	}
+
+	checkVictim := func(victim *v1.Pod, preemptionState *framework.CycleState, expectedCode framework.Code) {
+		t.Helper()
+		if code, msg := checker.VictimSearching(nil, framework.NewPodInfo(victim), nil, preemptionState, nil); code != expectedCode {
+			t.Errorf("expected %v for victim %s, but got %v: %s", expectedCode, victim.Name, code, msg)
+		}
+	}
+
+	commonState := framework.NewCycleState()
+	if status := checker.ClusterPrePreempting(nil, nil, commonState); status != nil {
+		t.Fatalf("unexpected status: %v", status)
+	}
+
+	// Victims chosen on the same node are counted into the budget.
+	preemptionState := framework.NewCycleState()
+	if status := checker.NodePrePreempting(nil, nil, nil, preemptionState); status != nil {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	checkVictim(victims[0], preemptionState, framework.PreemptionNotSure)
+	if status := checker.PostVictimSearching(nil, framework.NewPodInfo(victims[0]), nil, preemptionState, nil); status != nil {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	checkVictim(victims[1], preemptionState, framework.PreemptionFail)
+
+	// Victims chosen on other nodes are not counted.
+	preemptionState = framework.NewCycleState()
+	checker.NodePrePreempting(nil, nil, nil, preemptionState)
+	checkVictim(victims[1], preemptionState, framework.PreemptionNotSure)
+
+	// Victims of the previous preemptors in the same unit are counted.
+	if status := checker.NodePostPreempting(nil, victims[:1], nil, nil); status != nil {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	checker.ClusterPrePreempting(nil, nil, commonState)
+	preemptionState = framework.NewCycleState()
+	checker.NodePrePreempting(nil, nil, nil, preemptionState)
+	checkVictim(victims[1], preemptionState, framework.PreemptionFail)
+}
@@ -27,8 +27,10 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/coscheduling"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/imagelocality"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/loadaware"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/localstoragepool"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeaffinity"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodelabel"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeports"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodepreferavoidpods"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/noderesources"
@@ -39,9 +41,12 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/tainttoleration"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/volumebinding"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/newlystartedprotectionchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/nodepoolchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/pdbchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/podlauncherchecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/policymatrixchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/preemptibilitychecker"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/preemptionbudgetchecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/searching/priorityvaluechecker"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/sorting/priority"
 	starttime "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/preemption-plugins/sorting/start_time"
@@ -56,6 +61,18 @@ type PluginFactory = func(configuration runtime.Object, handle handle.PodFramewo
 // All plugins must be in the registry before initializing the framework.
 type Registry map[string]PluginFactory
 
+// Merge merges the provided registry to the current one, it returns an error
+// if a plugin in the provided registry is already registered.
+func (r Registry) Merge(in Registry) error {
+	for name, factory := range in {
+		if _, ok := r[name]; ok {
+			return fmt.Errorf("a plugin named %v already exists", name)
+		}
+		r[name] = factory
+	}
+	return nil
+}
+
 // NewOrderedPluginRegistry builds the registry with all the filter plugins.
 // If a filter plugin is not in the registry, it will be ignored.
 // So a new plugin having Filter method need be added to the registry.
@@ -69,6 +86,7 @@ func NewOrderedPluginRegistry() framework.PluginList {
 			podlauncher.Name,
 			volumebinding.Name,
 			nodelabel.Name,
+			nodepool.Name,
 
 			// UnschedulableAndUnresolvable or Unschedulable
 			// ...
@@ -114,14 +132,17 @@ func NewInTreeRegistry() Registry {
 		nodevolumelimits.GCEPDName:     nodevolumelimits.NewGCEPD,
 		nodevolumelimits.EBSName:       nodevolumelimits.NewEBS,
 
-		noderesources.FitName:                   noderesources.NewFit,
-		noderesources.MostAllocatedName:         noderesources.NewMostAllocated,
-		noderesources.LeastAllocatedName:        noderesources.NewLeastAllocated,
-		noderesources.BalancedAllocationName:    noderesources.NewBalancedAllocation,
-		noderesources.AdaptiveCpuToMemRatioName: noderesources.NewAdaptiveCpuToMemRatio,
-		noderesources.NodeResourcesAffinityName: noderesources.NewNodeResourcesAffinity,
-
-		loadaware.Name: loadaware.NewLoadAware,
+		noderesources.FitName:                      noderesources.NewFit,
+		noderesources.MostAllocatedName:            noderesources.NewMostAllocated,
+		noderesources.LeastAllocatedName:           noderesources.NewLeastAllocated,
+		noderesources.BalancedAllocationName:       noderesources.NewBalancedAllocation,
+		noderesources.AdaptiveCpuToMemRatioName:    noderesources.NewAdaptiveCpuToMemRatio,
+		noderesources.NodeResourcesAffinityName:    noderesources.NewNodeResourcesAffinity,
+		noderesources.RequestedToCapacityRatioName: noderesources.NewRequestedToCapacityRatio,
+
+		loadaware.Name:        loadaware.NewLoadAware,
+		localstoragepool.Name: localstoragepool.New,
+		nodepool.Name:         nodepool.New,
 	}
 }
 
@@ -133,6 +154,9 @@ func NewInTreePreemptionRegistry() Registry {
 		pdbchecker.PDBCheckerName:                                       pdbchecker.NewPDBChecker,
 		priorityvaluechecker.PriorityValueCheckerName:                   priorityvaluechecker.NewPriorityValueChecker,
 		newlystartedprotectionchecker.NewlyStartedProtectionCheckerName: newlystartedprotectionchecker.NewNewlyStartedProtectionChecker,
+		preemptionbudgetchecker.PreemptionBudgetCheckerName:             preemptionbudgetchecker.NewPreemptionBudgetChecker,
+		policymatrixchecker.PreemptionPolicyMatrixName:                  policymatrixchecker.NewPreemptionPolicyMatrix,
+		nodepoolchecker.NodePoolCheckerName:                             nodepoolchecker.NewNodePoolChecker,
 		// sorting plugins
 		priority.MinHighestPriorityName:       priority.NewMinHighestPriority,
 		priority.MinPrioritySumName:           priority.NewMinPrioritySum,
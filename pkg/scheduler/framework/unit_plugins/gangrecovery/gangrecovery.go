@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangrecovery
+
+import (
+	"context"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+const (
+	Name = "GangRecovery"
+)
+
+// GangRecovery prefers the nodes where the running members of a PodGroup are located, so that
+// replacement members of a recovering gang will be placed onto the gang's existing topology.
+type GangRecovery struct {
+	handler handle.UnitFrameworkHandle
+}
+
+var (
+	_ framework.LocatingPlugin      = &GangRecovery{}
+	_ framework.PreferNodeExtension = &GangRecovery{}
+)
+
+func New(_ runtime.Object, handler handle.UnitFrameworkHandle) (framework.Plugin, error) {
+	return &GangRecovery{handler: handler}, nil
+}
+
+func (i *GangRecovery) Name() string {
+	return Name
+}
+
+func (i *GangRecovery) Locating(ctx context.Context, unit framework.ScheduleUnit, unitCycleState *framework.CycleState, nodeGroup framework.NodeGroup) (framework.NodeGroup, *framework.Status) {
+	if unit.Type() != framework.PodGroupUnitType {
+		return nodeGroup, nil
+	}
+	if unitutil.ParsePodGroupRecoveryPolicy(unit.GetAnnotations()) == unitutil.PodGroupRecoveryNone {
+		return nodeGroup, nil
+	}
+
+	runningPods := i.handler.GetUnitStatus(unit.GetKey()).GetRunningPods()
+	if len(runningPods) == 0 {
+		return nodeGroup, nil
+	}
+
+	preferredNodes := nodeGroup.GetPreferredNodes()
+	nodeNames := sets.NewString()
+	for _, pod := range runningPods {
+		nodeName := pod.Spec.NodeName
+		if len(nodeName) == 0 || nodeNames.Has(nodeName) {
+			continue
+		}
+		nodeNames.Insert(nodeName)
+
+		nodeInfo, err := nodeGroup.Get(nodeName)
+		if err != nil || nodeInfo == nil {
+			continue
+		}
+		preferredNodes.Add(nodeInfo, i)
+	}
+	klog.V(4).InfoS("GangRecovery Locating for ScheduleUnit", "unitKey", unit.GetKey(), "preferredNodes", nodeNames.List())
+
+	return nodeGroup, nil
+}
+
+func (i *GangRecovery) PreparePreferNode(ctx context.Context, unitCycleState, state *framework.CycleState, pod *v1.Pod) *framework.Status {
+	return nil
+}
+
+func (i *GangRecovery) PrePreferNode(ctx context.Context, unitCycleState, podCycleState *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo) (framework.NodeInfo, *framework.CycleState, *framework.Status) {
+	return nodeInfo, podCycleState, nil
+}
+
+func (i *GangRecovery) PostPreferNode(ctx context.Context, unitCycleState, podCycleState *framework.CycleState, pod *v1.Pod, nodeInfo framework.NodeInfo, status *framework.Status) *framework.Status {
+	return nil
+}
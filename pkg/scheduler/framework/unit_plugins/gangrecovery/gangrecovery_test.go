@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gangrecovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/framework/api/fake"
+	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/testing/fakehandle"
+	testinghelper "github.com/kubewharf/godel-scheduler/pkg/testing-helper"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
+)
+
+func TestLocating(t *testing.T) {
+	var nodes []*v1.Node
+	for _, name := range []string{"node-1", "node-2", "node-3"} {
+		nodes = append(nodes, testinghelper.MakeNode().Name(name).Obj())
+	}
+	nodeLister := fake.NewNodeInfoLister(nodes)
+
+	makePodGroup := func(policy unitutil.PodGroupRecoveryPolicy) *v1alpha1.PodGroup {
+		pg := &v1alpha1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pg"},
+			Spec:       v1alpha1.PodGroupSpec{MinMember: 3},
+		}
+		if policy != unitutil.PodGroupRecoveryNone {
+			pg.Annotations = map[string]string{unitutil.PodGroupRecoveryPolicyAnnotationKey: string(policy)}
+		}
+		return pg
+	}
+	makeMember := func(name, nodeName string) *v1.Pod {
+		return testinghelper.MakePod().Namespace("default").Name(name).UID(name).
+			Annotation(podutil.PodGroupNameAnnotationKey, "pg").
+			Node(nodeName).Obj()
+	}
+
+	tests := []struct {
+		name        string
+		podGroup    *v1alpha1.PodGroup
+		runningPods []*v1.Pod
+		expected    sets.String
+	}{
+		{
+			name:        "no recovery policy",
+			podGroup:    makePodGroup(unitutil.PodGroupRecoveryNone),
+			runningPods: []*v1.Pod{makeMember("p1", "node-1")},
+			expected:    sets.NewString(),
+		},
+		{
+			name:     "no running members",
+			podGroup: makePodGroup(unitutil.PodGroupRecoveryWaitForReplacement),
+			expected: sets.NewString(),
+		},
+		{
+			name:        "prefer nodes of running members",
+			podGroup:    makePodGroup(unitutil.PodGroupRecoveryWaitForReplacement),
+			runningPods: []*v1.Pod{makeMember("p1", "node-1"), makeMember("p2", "node-3"), makeMember("p3", "node-3")},
+			expected:    sets.NewString("node-1", "node-3"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := godelcache.New(commoncache.MakeCacheHandlerWrapper().
+				ComponentName("").SchedulerType("").SubCluster(framework.DefaultSubCluster).
+				PodAssumedTTL(time.Second).Period(10 * time.Second).StopCh(make(<-chan struct{})).
+				Obj())
+			for _, pod := range tt.runningPods {
+				if err := cache.AddPod(pod); err != nil {
+					t.Fatalf("failed to add pod: %v", err)
+				}
+			}
+
+			unit := framework.NewPodGroupUnit(tt.podGroup, 0)
+			unit.AddPod(&framework.QueuedPodInfo{Pod: makeMember("p4", "")})
+			nodeGroup := framework.NewNodeGroup(framework.DefaultNodeGroupName, nil, []framework.NodeCircle{framework.NewNodeCircle(framework.DefaultNodeCircleName, nodeLister)})
+
+			pl, err := New(nil, &fakehandle.MockUnitFrameworkHandle{Cache: cache})
+			if err != nil {
+				t.Fatalf("err: %v", err)
+			}
+			outputNodeGroup, status := pl.(framework.LocatingPlugin).Locating(context.Background(), unit, framework.NewCycleState(), nodeGroup)
+			if status != nil {
+				t.Fatalf("failed to locating node group: %v", status)
+			}
+
+			gotNodeNames := sets.NewString()
+			for _, node := range outputNodeGroup.GetPreferredNodes().List() {
+				gotNodeNames.Insert(node.GetNodeName())
+			}
+			if !tt.expected.Equal(gotNodeNames) {
+				t.Errorf("expected %v, got %v", tt.expected.List(), gotNodeNames.List())
+			}
+		})
+	}
+}
@@ -138,7 +138,7 @@ func (i *JobLevelAffinity) findNodeGroups(
 	var topologyTree []*topologyElem
 	topologyTree = append(topologyTree, newTopologyElem(nodeCircles[0]))
 
-	minRequest, err := computeUnitMinResourceRequest(unit, everScheduled)
+	minRequest, err := ComputeUnitMinResourceRequest(unit, everScheduled)
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func divideNodesByRequireAffinity(
 	unitAffinityTerms []framework.UnitAffinityTerm,
 	assignedNodes sets.String,
 	nodeGroup framework.NodeGroup,
-	request *preCheckResource,
+	request *PreCheckResource,
 ) ([]*topologyElem, error) {
 	requiredAffinityTerms := newNodeGroupAffinityTerms(unitAffinityTerms)
 	nodeCircle := nodeGroup.GetNodeCircles()[0] // the caller must ensure that the node group has at least one node circle.
@@ -238,7 +238,7 @@ func sortAndMarkTopologyElems(
 	unit framework.ScheduleUnit,
 	topologyElems []*topologyElem,
 	sortRules []framework.SortRule,
-	unitRequest *preCheckResource,
+	unitRequest *PreCheckResource,
 	isParentCutOff bool,
 ) {
 	if len(topologyElems) == 0 {
@@ -372,7 +372,7 @@ func divideNodesByPreferAffinity(
 	startIndexOfNewElem int,
 	assignedOrPreferredNodes sets.String,
 	nodeGroup framework.NodeGroup,
-	request *preCheckResource,
+	request *PreCheckResource,
 ) ([]*topologyElem, error) {
 	preferAffinityTerms := newNodeGroupAffinityTerms([]framework.UnitAffinityTerm{unitAffinityTerm})
 	preferAffinitySpecs, err := getPreferAffinitySpecs(ctx, podLauncher, preferAffinityTerms, assignedOrPreferredNodes, nodeGroup)
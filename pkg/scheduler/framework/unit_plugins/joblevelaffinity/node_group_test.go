@@ -396,7 +396,7 @@ func TestSortAndMarkTopologyElems(t *testing.T) {
 	for i := range testCases {
 		tt := &testCases[i]
 		t.Run(tt.name, func(t *testing.T) {
-			minRequest, err := computeUnitMinResourceRequest(tt.unit, false)
+			minRequest, err := ComputeUnitMinResourceRequest(tt.unit, false)
 			if err != nil {
 				t.Errorf("failed to get min request: %v", err)
 			}
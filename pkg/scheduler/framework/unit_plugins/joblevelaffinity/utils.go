@@ -7,11 +7,12 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
-// We precheck the allocatable resource in a topology domain to avoid uncessary scheduling process.
+// PreCheckResource is prechecked against the allocatable resource in a topology domain to avoid uncessary scheduling process.
 // We only precheck the most common resources instead of all.
-type preCheckResource struct {
+type PreCheckResource struct {
 	MilliCPU int64
 	Memory   int64
 	GPU      int64
@@ -19,7 +20,7 @@ type preCheckResource struct {
 
 const maxInt64 = int64(((1 << 63) - 1))
 
-func (r *preCheckResource) add(resource map[string]*resource.Quantity) {
+func (r *PreCheckResource) add(resource map[string]*resource.Quantity) {
 	if q := resource[v1.ResourceCPU.String()]; q != nil {
 		r.MilliCPU += q.MilliValue()
 	}
@@ -31,7 +32,7 @@ func (r *preCheckResource) add(resource map[string]*resource.Quantity) {
 	}
 }
 
-func (r *preCheckResource) getMinWith(resource map[string]*resource.Quantity) {
+func (r *PreCheckResource) getMinWith(resource map[string]*resource.Quantity) {
 	if q := resource[v1.ResourceCPU.String()]; q != nil {
 
 		r.MilliCPU = min(r.MilliCPU, q.MilliValue())
@@ -44,24 +45,25 @@ func (r *preCheckResource) getMinWith(resource map[string]*resource.Quantity) {
 	}
 }
 
-func (r *preCheckResource) multipliedBy(b int64) {
+func (r *PreCheckResource) multipliedBy(b int64) {
 	r.MilliCPU = r.MilliCPU * b
 	r.Memory = r.Memory * b
 	r.GPU = r.GPU * b
 }
 
-func (r *preCheckResource) greater(s *framework.Resource) bool {
+func (r *PreCheckResource) greater(s *framework.Resource) bool {
 	if r == nil {
 		return false
 	}
 	return r.MilliCPU > s.MilliCPU || r.Memory > s.Memory || r.GPU > s.ScalarResources[util.ResourceGPU]
 }
 
+// ComputeUnitMinResourceRequest computes the `minRequest` of the unit, it is also used by the NetworkTopology plugin.
 // If the unit is `everScheduled`, the minimum request quantity for all pods is considered as the `minRequest` of the unit.
 // Otherwise, we compute the `minRequest` accordingly based on whether min < all is met.
-func computeUnitMinResourceRequest(unit framework.ScheduleUnit, everScheduled bool) (*preCheckResource, error) {
+func ComputeUnitMinResourceRequest(unit framework.ScheduleUnit, everScheduled bool) (*PreCheckResource, error) {
 	if everScheduled {
-		minRequest := preCheckResource{
+		minRequest := PreCheckResource{
 			MilliCPU: maxInt64,
 			Memory:   maxInt64,
 			GPU:      maxInt64,
@@ -85,13 +87,21 @@ func computeUnitMinResourceRequest(unit framework.ScheduleUnit, everScheduled bo
 		return &minRequest, nil
 	}
 
-	minRequest := preCheckResource{}
+	minRequest := PreCheckResource{}
 	minMember, err := unit.GetMinMember()
 	if err != nil {
 		return nil, err
 	}
 	allMember := unit.NumPods()
 
+	roleMinMember, err := unit.GetRoleMinMember()
+	if err != nil {
+		return nil, err
+	}
+	if affinityRoles := framework.GetAffinityRoles(unit); affinityRoles != nil || len(roleMinMember) > 0 {
+		return computeRoleMinResourceRequest(unit, minMember == allMember, roleMinMember, affinityRoles), nil
+	}
+
 	if minMember == allMember {
 		for _, podInfo := range unit.GetPods() {
 			if podInfo == nil || podInfo.Pod == nil {
@@ -112,6 +122,35 @@ func computeUnitMinResourceRequest(unit framework.ScheduleUnit, everScheduled bo
 	return &minRequest, nil
 }
 
+// computeRoleMinResourceRequest computes the `minRequest` of the co-located roles. The pods of the same role are
+// considered to be the same, and the min member of each role is taken as its pod count. For the roles without
+// min member, all of their pods are taken if min == all, otherwise none of them are.
+func computeRoleMinResourceRequest(unit framework.ScheduleUnit, minEqualsAll bool, roleMinMember map[string]int, affinityRoles sets.String) *PreCheckResource {
+	podsPerRole := make(map[string][]*v1.Pod)
+	for _, podInfo := range unit.GetPods() {
+		if podInfo == nil || podInfo.Pod == nil || !framework.PodRequireCoLocation(affinityRoles, podInfo.Pod) {
+			continue
+		}
+		role := podutil.GetPodRole(podInfo.Pod)
+		podsPerRole[role] = append(podsPerRole[role], podInfo.Pod)
+	}
+
+	minRequest := PreCheckResource{}
+	for role, pods := range podsPerRole {
+		count, ok := roleMinMember[role]
+		if !ok && minEqualsAll {
+			count = len(pods)
+		}
+		roleRequest := PreCheckResource{}
+		roleRequest.add(podutil.GetPodRequests(pods[0]))
+		roleRequest.multipliedBy(int64(count))
+		minRequest.MilliCPU += roleRequest.MilliCPU
+		minRequest.Memory += roleRequest.Memory
+		minRequest.GPU += roleRequest.GPU
+	}
+	return &minRequest
+}
+
 func min(a, b int64) int64 {
 	if a < b {
 		return a
@@ -6,6 +6,7 @@ import (
 	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -139,13 +140,13 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 	testCases := []struct {
 		name          string
 		unit          framework.ScheduleUnit
-		expected      preCheckResource
+		expected      PreCheckResource
 		everScheduled bool
 	}{
 		{
 			name: "unit has one template, min = all, not scheduled",
 			unit: makeUnit(3, podA1, podA2, podA3),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 30 * 1000,
 				Memory:   96 * bytesPerGi,
 				GPU:      0,
@@ -155,7 +156,7 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 		{
 			name: "unit has one template, min = all, scheduled",
 			unit: makeUnit(3, podA1, podA2, podA3),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 10 * 1000,
 				Memory:   32 * bytesPerGi,
 				GPU:      0,
@@ -165,7 +166,7 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 		{
 			name: "unit has one template, min < all, not scheduled",
 			unit: makeUnit(2, podA1, podA2, podA3),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 20 * 1000,
 				Memory:   64 * bytesPerGi,
 				GPU:      0,
@@ -175,7 +176,7 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 		{
 			name: "unit has one template, min < all, scheduled",
 			unit: makeUnit(2, podA1, podA2, podA3),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 10 * 1000,
 				Memory:   32 * bytesPerGi,
 				GPU:      0,
@@ -185,7 +186,7 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 		{
 			name: "unit has multiple templates, min = all, not scheduled",
 			unit: makeUnit(3, podB1, podB2, podC1),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 55 * 1000,
 				Memory:   192 * bytesPerGi,
 				GPU:      70,
@@ -195,7 +196,7 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 		{
 			name: "unit has multiple templates, min = all, scheduled",
 			unit: makeUnit(2, podB1, podB2, podC1),
-			expected: preCheckResource{
+			expected: PreCheckResource{
 				MilliCPU: 15 * 1000,
 				Memory:   64 * bytesPerGi,
 				GPU:      20,
@@ -206,12 +207,106 @@ func TestComputeUnitMinResourceRequest(t *testing.T) {
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := computeUnitMinResourceRequest(tt.unit, tt.everScheduled)
+			got, err := ComputeUnitMinResourceRequest(tt.unit, tt.everScheduled)
 			if err != nil {
 				t.Errorf("unexpected error %v", err)
 			}
 			if got.MilliCPU != tt.expected.MilliCPU || got.Memory != tt.expected.Memory || got.GPU != tt.expected.GPU {
-				t.Errorf("expected preCheckResource: %#v, got %#v", tt.expected, got)
+				t.Errorf("expected PreCheckResource: %#v, got %#v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestComputeRoleMinResourceRequest(t *testing.T) {
+	makeRolePod := func(name, role, cpu string) *framework.QueuedPodInfo {
+		return &framework.QueuedPodInfo{
+			Pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					UID:         types.UID(name),
+					Annotations: map[string]string{podutil.PodRoleAnnotationKey: role},
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Resources: v1.ResourceRequirements{
+								Requests: v1.ResourceList{
+									v1.ResourceCPU: resource.MustParse(cpu),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	ps := makeRolePod("ps", "ps", "4")
+	worker1 := makeRolePod("worker1", "worker", "10")
+	worker2 := makeRolePod("worker2", "worker", "10")
+	worker3 := makeRolePod("worker3", "worker", "10")
+	evaluator := makeRolePod("evaluator", "evaluator", "2")
+
+	testCases := []struct {
+		name          string
+		minMember     int32
+		pods          []*framework.QueuedPodInfo
+		roleMinMember string
+		affinityRoles string
+		expected      int64
+	}{
+		{
+			name:          "min member of each role",
+			minMember:     3,
+			pods:          []*framework.QueuedPodInfo{ps, worker1, worker2, worker3},
+			roleMinMember: `{"ps":1,"worker":2}`,
+			expected:      24 * 1000,
+		},
+		{
+			name:          "roles not co-located are excluded",
+			minMember:     4,
+			pods:          []*framework.QueuedPodInfo{ps, worker1, worker2, worker3, evaluator},
+			roleMinMember: `{"ps":1,"worker":2,"evaluator":1}`,
+			affinityRoles: "ps, worker",
+			expected:      24 * 1000,
+		},
+		{
+			name:          "co-located roles without min member, min = all",
+			minMember:     3,
+			pods:          []*framework.QueuedPodInfo{ps, worker1, worker2},
+			affinityRoles: "worker",
+			expected:      20 * 1000,
+		},
+		{
+			name:          "co-located roles without min member, min < all",
+			minMember:     2,
+			pods:          []*framework.QueuedPodInfo{ps, worker1, worker2},
+			roleMinMember: `{"ps":1}`,
+			affinityRoles: "ps,worker",
+			expected:      4 * 1000,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if len(tt.roleMinMember) > 0 {
+				annotations[podutil.RoleMinMemberAnnotationKey] = tt.roleMinMember
+			}
+			if len(tt.affinityRoles) > 0 {
+				annotations[podutil.AffinityRolesAnnotationKey] = tt.affinityRoles
+			}
+			unit := framework.NewPodGroupUnit(&v1alpha1.PodGroup{
+				ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+				Spec:       v1alpha1.PodGroupSpec{MinMember: tt.minMember},
+			}, 0)
+			unit.AddPods(tt.pods)
+
+			got, err := ComputeUnitMinResourceRequest(unit, false)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got.MilliCPU != tt.expected {
+				t.Errorf("expected milli cpu %v, got %v", tt.expected, got.MilliCPU)
 			}
 		})
 	}
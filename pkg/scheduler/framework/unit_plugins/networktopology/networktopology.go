@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/validation"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	Name = "NetworkTopology"
+)
+
+// NetworkTopology places a ScheduleUnit in the smallest enclosing domain of a hierarchical network
+// topology, e.g. host < rack < leaf switch < spine switch. It emits the node groups level by level
+// from the tightest one, and the domains of the same level are ordered by the fragmentation left behind.
+// The required PodGroupAffinity of the unit is respected by intersecting each domain with the required
+// affinity domains, and in that case the unit is never placed beyond a single required affinity domain.
+// The preferred PodGroupAffinity and the sort rules of the unit are ignored.
+type NetworkTopology struct {
+	handler            handle.UnitFrameworkHandle
+	topologyKeys       []string
+	maxDomainsPerLevel int
+}
+
+var _ framework.GroupingPlugin = &NetworkTopology{}
+
+func New(plArgs runtime.Object, handler handle.UnitFrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateNetworkTopologyArgs(args); err != nil {
+		return nil, err
+	}
+	return &NetworkTopology{
+		handler:            handler,
+		topologyKeys:       args.TopologyKeys,
+		maxDomainsPerLevel: int(args.MaxDomainsPerLevel),
+	}, nil
+}
+
+func getArgs(obj runtime.Object) (*config.NetworkTopologyArgs, error) {
+	if obj == nil {
+		return &config.NetworkTopologyArgs{}, nil
+	}
+	ptr, ok := obj.(*config.NetworkTopologyArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NetworkTopologyArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+func (pl *NetworkTopology) Name() string {
+	return Name
+}
+
+func (pl *NetworkTopology) Grouping(ctx context.Context, unit framework.ScheduleUnit, unitCycleState *framework.CycleState, nodeGroup framework.NodeGroup) ([]framework.NodeGroup, *framework.Status) {
+	if unit.Type() == framework.SinglePodUnitType || len(pl.topologyKeys) == 0 || unit.NumPods() == 0 {
+		return []framework.NodeGroup{nodeGroup}, nil
+	}
+
+	pod := unit.GetPods()[0].Pod
+	podLauncher, err := podutil.GetPodLauncher(pod)
+	if err != nil {
+		return nil, framework.AsStatus(fmt.Errorf("pod launcher in unit %v is invalid: %v", unit.GetKey(), err))
+	}
+	resourceType, err := podutil.GetPodResourceType(pod)
+	if err != nil {
+		return nil, framework.AsStatus(fmt.Errorf("resource type in unit %v is invalid: %v", unit.GetKey(), err))
+	}
+	everScheduled, err := framework.GetEverScheduledState(unitCycleState)
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	request, err := joblevelaffinity.ComputeUnitMinResourceRequest(unit, everScheduled)
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	required, err := unit.GetRequiredAffinity()
+	if err != nil {
+		return nil, framework.AsStatus(err)
+	}
+	requiredKeys := make([]string, 0, len(required))
+	for _, term := range required {
+		requiredKeys = append(requiredKeys, term.TopologyKey)
+	}
+	sort.Strings(requiredKeys)
+
+	tree := newTopologyTree(pl.topologyKeys, requiredKeys, podLauncher, resourceType, nodeGroup)
+	nodeGroups := tree.getNodeGroups(request, pl.getAssignedNodesOfUnit(unit, nodeGroup), pl.maxDomainsPerLevel)
+	if originPreferredNodes := nodeGroup.GetPreferredNodes(); originPreferredNodes != nil {
+		for _, ng := range nodeGroups {
+			ng.SetPreferredNodes(framework.FilterPreferredNodes(originPreferredNodes, func(ni framework.NodeInfo) bool {
+				n, err := ng.Get(ni.GetNodeName())
+				return err == nil && n != nil
+			}))
+		}
+	}
+	// The original node group is the loosest fallback, unless the loosest domain already covers all the nodes.
+	// The unconstrained fallback is not allowed if the unit has required affinity.
+	if len(requiredKeys) == 0 && !tree.coversAllNodes(nodeGroups) {
+		nodeGroups = append(nodeGroups, nodeGroup)
+	}
+
+	klog.V(4).InfoS("NetworkTopology Grouping for ScheduleUnit got nodeGroups", "unitKey", unit.GetKey(), "numberOfNodeGroups", len(nodeGroups))
+	return nodeGroups, nil
+}
+
+// getAssignedNodesOfUnit returns the nodes in the node group where running pods in the unit are assigned.
+func (pl *NetworkTopology) getAssignedNodesOfUnit(unit framework.ScheduleUnit, nodeGroup framework.NodeGroup) sets.String {
+	assigned := sets.NewString()
+	if pl.handler == nil {
+		return assigned
+	}
+	for _, pod := range pl.handler.GetUnitStatus(unit.GetKey()).GetRunningPods() {
+		nodeName := pod.Spec.NodeName
+		if len(nodeName) == 0 || assigned.Has(nodeName) {
+			continue
+		}
+		if n, err := nodeGroup.Get(nodeName); err == nil && n != nil {
+			assigned.Insert(nodeName)
+		}
+	}
+	return assigned
+}
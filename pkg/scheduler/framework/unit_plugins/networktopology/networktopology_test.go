@@ -0,0 +1,238 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+var testTopologyKeys = []string{"hostname", "rack", "leaf", "spine"}
+
+func makeNodeInfo(name, rack, leaf string, requestedMilliCPU int64) framework.NodeInfo {
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"hostname": name, "rack": rack, "leaf": leaf, "spine": "s1"},
+		},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(4000, resource.DecimalSI)},
+		},
+	})
+	if requestedMilliCPU > 0 {
+		nodeInfo.AddPod(makePod("running-"+name, name, requestedMilliCPU))
+	}
+	return nodeInfo
+}
+
+func makePod(name, nodeName string, milliCPU int64) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				podutil.PodLauncherAnnotationKey:     string(podutil.Kubelet),
+				podutil.PodResourceTypeAnnotationKey: string(podutil.GuaranteedPod),
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeUnit(numPods int, milliCPU int64) framework.ScheduleUnit {
+	return makeUnitWithRequiredAffinity(numPods, milliCPU)
+}
+
+func makeUnitWithRequiredAffinity(numPods int, milliCPU int64, topologyKeys ...string) framework.ScheduleUnit {
+	pg := &v1alpha1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: "pg", Namespace: "default"}}
+	pg.Spec.MinMember = int32(numPods)
+	if len(topologyKeys) > 0 {
+		affinity := &v1alpha1.PodGroupAffinity{}
+		for _, key := range topologyKeys {
+			affinity.Required = append(affinity.Required, v1alpha1.PodGroupAffinityTerm{TopologyKey: key})
+		}
+		pg.Spec.Affinity = &v1alpha1.Affinity{PodGroupAffinity: affinity}
+	}
+	unit := framework.NewPodGroupUnit(pg, 0)
+	for i := 0; i < numPods; i++ {
+		unit.AddPod(&framework.QueuedPodInfo{Pod: makePod("pod"+strconv.Itoa(i), "", milliCPU)})
+	}
+	return unit
+}
+
+func TestGrouping(t *testing.T) {
+	// spine s1 -> leaf l1 -> rack r1 (h1, h2), rack r2 (h3, h4)
+	//          -> leaf l2 -> rack r3 (h5, h6, h7, h8)
+	// zone z1 (h1, h2, h3, h5), zone z2 (h4, h6, h7, h8)
+	zones := map[string]string{"h1": "z1", "h2": "z1", "h3": "z1", "h4": "z2", "h5": "z1", "h6": "z2", "h7": "z2", "h8": "z2"}
+	lister := &framework.NodeInfoListerImpl{}
+	for _, nodeInfo := range []framework.NodeInfo{
+		makeNodeInfo("h1", "r1", "l1", 0),
+		makeNodeInfo("h2", "r1", "l1", 0),
+		makeNodeInfo("h3", "r2", "l1", 2000),
+		makeNodeInfo("h4", "r2", "l1", 0),
+		makeNodeInfo("h5", "r3", "l2", 0),
+		makeNodeInfo("h6", "r3", "l2", 0),
+		makeNodeInfo("h7", "r3", "l2", 0),
+		makeNodeInfo("h8", "r3", "l2", 0),
+	} {
+		nodeInfo.GetNode().Labels["zone"] = zones[nodeInfo.GetNodeName()]
+		lister.AddNodeInfo(nodeInfo)
+	}
+	nodeGroup := framework.NewNodeGroup(framework.DefaultNodeGroupName, nil, []framework.NodeCircle{
+		framework.NewNodeCircle(framework.DefaultNodeCircleName, lister),
+	})
+
+	tests := []struct {
+		name     string
+		args     *config.NetworkTopologyArgs
+		unit     framework.ScheduleUnit
+		expected []string
+	}{
+		{
+			name: "no topology keys",
+			args: &config.NetworkTopologyArgs{},
+			unit: makeUnit(2, 3000),
+			expected: []string{
+				framework.DefaultNodeGroupName,
+			},
+		},
+		{
+			name: "unit fits in a host",
+			args: &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys, MaxDomainsPerLevel: 2},
+			unit: makeUnit(1, 3000),
+			expected: []string{
+				"spine:s1;leaf:l1;rack:r1;hostname:h1;",
+				"spine:s1;leaf:l1;rack:r1;hostname:h2;",
+				"spine:s1;leaf:l1;rack:r2;",
+				"spine:s1;leaf:l1;rack:r1;",
+				"spine:s1;leaf:l1;",
+				"spine:s1;leaf:l2;",
+				"spine:s1;",
+			},
+		},
+		{
+			name: "unit falls back to racks ordered by fragmentation",
+			args: &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit: makeUnit(2, 3000),
+			expected: []string{
+				"spine:s1;leaf:l1;rack:r2;",
+				"spine:s1;leaf:l1;rack:r1;",
+				"spine:s1;leaf:l2;rack:r3;",
+				"spine:s1;leaf:l1;",
+				"spine:s1;",
+			},
+		},
+		{
+			name: "unit only fits in the spine",
+			args: &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit: makeUnit(6, 3000),
+			expected: []string{
+				"spine:s1;",
+			},
+		},
+		{
+			name: "domains are intersected with required affinity",
+			args: &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit: makeUnitWithRequiredAffinity(2, 3000, "zone"),
+			expected: []string{
+				"zone:z1;spine:s1;leaf:l1;rack:r1;",
+				"zone:z2;spine:s1;leaf:l2;rack:r3;",
+				"zone:z1;spine:s1;leaf:l1;",
+				"zone:z1;spine:s1;",
+				"zone:z2;spine:s1;",
+			},
+		},
+		{
+			name:     "unit with required affinity does not fall back to the whole node group",
+			args:     &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit:     makeUnitWithRequiredAffinity(6, 3000, "zone"),
+			expected: []string{},
+		},
+		{
+			name:     "nodes without required topology label are ignored",
+			args:     &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit:     makeUnitWithRequiredAffinity(1, 3000, "zone", "unknown"),
+			expected: []string{},
+		},
+		{
+			name: "unit does not fit in any domain",
+			args: &config.NetworkTopologyArgs{TopologyKeys: testTopologyKeys},
+			unit: makeUnit(12, 3000),
+			expected: []string{
+				framework.DefaultNodeGroupName,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pl, err := New(tt.args, nil)
+			if err != nil {
+				t.Fatalf("failed to create plugin: %v", err)
+			}
+			unitCycleState := framework.NewCycleState()
+			framework.SetEverScheduledState(false, unitCycleState)
+			nodeGroups, status := pl.(framework.GroupingPlugin).Grouping(context.Background(), tt.unit, unitCycleState, nodeGroup)
+			if !status.IsSuccess() {
+				t.Fatalf("unexpected status: %v", status)
+			}
+			got := make([]string, 0, len(nodeGroups))
+			for _, ng := range nodeGroups {
+				got = append(got, ng.GetKey())
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected node groups %v, got %v", tt.expected, got)
+			}
+			for i := range tt.expected {
+				if expected := framework.GenerateReadableKey(tt.expected[i]); got[i] != expected {
+					t.Errorf("index %v: expected node group %v, got %v", i, expected, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewInvalidArgs(t *testing.T) {
+	if _, err := New(&config.NetworkTopologyArgs{TopologyKeys: []string{"rack", "rack"}}, nil); err == nil {
+		t.Errorf("expected error for duplicated topology keys")
+	}
+	if _, err := New(&config.NetworkTopologyArgs{MaxDomainsPerLevel: -1}, nil); err == nil {
+		t.Errorf("expected error for negative maxDomainsPerLevel")
+	}
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopology
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
+	"github.com/kubewharf/godel-scheduler/pkg/util"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+// domain is a node in the topology tree, it contains all the nodes under a topology value of a level.
+type domain struct {
+	// key is the joint string of the topology values from the loosest level down to the level of the domain,
+	// in the format of "spine:s1;leaf:l1;rack:r1;".
+	key       string
+	nodes     *framework.NodeInfoListerImpl
+	nodeNames sets.String
+	children  sets.String
+
+	allocatable *framework.Resource
+	requested   *framework.Resource
+}
+
+// topologyTree groups the nodes by the topology levels, levels[0] holds the domains of the tightest level.
+// If the unit has required affinity, each domain is intersected with the required affinity domain, and the
+// required affinity domains make up the loosest level. Nodes without the required topology labels are ignored.
+type topologyTree struct {
+	levels       []map[string]*domain
+	requiredKeys []string
+	numNodes     int
+}
+
+func newTopologyTree(topologyKeys, requiredKeys []string, podLauncher podutil.PodLauncher, resourceType podutil.PodResourceType, nodeGroup framework.NodeGroup) *topologyTree {
+	numLevels := len(topologyKeys)
+	if len(requiredKeys) > 0 {
+		numLevels++
+	}
+	tree := &topologyTree{levels: make([]map[string]*domain, numLevels), requiredKeys: requiredKeys}
+	for i := range tree.levels {
+		tree.levels[i] = make(map[string]*domain)
+	}
+
+	visited := sets.NewString()
+	for _, nodeCircle := range nodeGroup.GetNodeCircles() {
+		for _, nodeInfo := range nodeCircle.List() {
+			nodeName := nodeInfo.GetNodeName()
+			if visited.Has(nodeName) {
+				continue
+			}
+			visited.Insert(nodeName)
+			tree.addNode(topologyKeys, nodeInfo, nodeInfo.GetNodeLabels(podLauncher), resourceType)
+		}
+	}
+	tree.numNodes = visited.Len()
+	return tree
+}
+
+func (t *topologyTree) addNode(topologyKeys []string, nodeInfo framework.NodeInfo, labels map[string]string, resourceType podutil.PodResourceType) {
+	requiredKey, ok := requiredDomainKey(t.requiredKeys, labels)
+	if !ok {
+		return
+	}
+	keys := domainKeys(topologyKeys, labels, requiredKey)
+	if len(t.requiredKeys) > 0 {
+		keys = append(keys, requiredKey)
+	}
+	allocatable, requested := getNodeResource(nodeInfo, resourceType)
+	for level, key := range keys {
+		if len(key) == 0 {
+			continue
+		}
+		d, ok := t.levels[level][key]
+		if !ok {
+			d = &domain{
+				key:         key,
+				nodes:       &framework.NodeInfoListerImpl{},
+				nodeNames:   sets.NewString(),
+				children:    sets.NewString(),
+				allocatable: &framework.Resource{},
+				requested:   &framework.Resource{},
+			}
+			t.levels[level][key] = d
+		}
+		d.nodes.AddNodeInfo(nodeInfo)
+		d.nodeNames.Insert(nodeInfo.GetNodeName())
+		d.allocatable.AddResource(allocatable)
+		d.requested.AddResource(requested)
+		if level > 0 && len(keys[level-1]) > 0 {
+			d.children.Insert(keys[level-1])
+		}
+	}
+}
+
+// requiredDomainKey returns the key of the required affinity domain of the node, in the format of
+// "key1:value1;key2:value2;". It returns false if the node does not have all the required topology labels.
+func requiredDomainKey(requiredKeys []string, labels map[string]string) (string, bool) {
+	var builder strings.Builder
+	for _, key := range requiredKeys {
+		value, ok := labels[key]
+		if !ok {
+			return "", false
+		}
+		builder.WriteString(key)
+		builder.WriteByte(':')
+		builder.WriteString(value)
+		builder.WriteByte(';')
+	}
+	return builder.String(), true
+}
+
+// domainKeys returns the domain keys of the node at all levels prefixed by the required affinity domain key,
+// the key is empty if the node does not have the topology label of that level.
+func domainKeys(topologyKeys []string, labels map[string]string, prefix string) []string {
+	keys := make([]string, len(topologyKeys))
+	var builder strings.Builder
+	builder.WriteString(prefix)
+	for level := len(topologyKeys) - 1; level >= 0; level-- {
+		value, ok := labels[topologyKeys[level]]
+		builder.WriteString(topologyKeys[level])
+		builder.WriteByte(':')
+		builder.WriteString(value)
+		builder.WriteByte(';')
+		if ok {
+			keys[level] = builder.String()
+		}
+	}
+	return keys
+}
+
+// getNodeGroups returns the node groups of the feasible domains level by level from the tightest one,
+// and the domains of the same level are sorted by the fragmentation left behind. A domain is skipped
+// if it has the same nodes as its only child, which has been emitted at the lower level.
+func (t *topologyTree) getNodeGroups(request *joblevelaffinity.PreCheckResource, assigned sets.String, maxDomainsPerLevel int) []framework.NodeGroup {
+	nodeGroups := make([]framework.NodeGroup, 0)
+	emitted := sets.NewString()
+	for level, domains := range t.levels {
+		nextEmitted := sets.NewString()
+		candidates := make([]*domain, 0, len(domains))
+		for _, d := range domains {
+			if level > 0 && d.children.Len() == 1 {
+				childKey := d.children.List()[0]
+				if child := t.levels[level-1][childKey]; emitted.Has(childKey) && child.nodeNames.Len() == d.nodeNames.Len() {
+					nextEmitted.Insert(d.key)
+					continue
+				}
+			}
+			if !d.nodeNames.IsSuperset(assigned) || !fits(request, d.allocatable, d.requested) {
+				continue
+			}
+			candidates = append(candidates, d)
+		}
+
+		scores := make(map[string]float64, len(candidates))
+		for _, d := range candidates {
+			scores[d.key] = fragmentation(request, d.allocatable, d.requested)
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if scores[candidates[i].key] != scores[candidates[j].key] {
+				return scores[candidates[i].key] < scores[candidates[j].key]
+			}
+			return candidates[i].key < candidates[j].key
+		})
+		if maxDomainsPerLevel > 0 && len(candidates) > maxDomainsPerLevel {
+			candidates = candidates[:maxDomainsPerLevel]
+		}
+
+		for _, d := range candidates {
+			nextEmitted.Insert(d.key)
+			nodeGroups = append(nodeGroups, framework.NewNodeGroup(d.key, nil, []framework.NodeCircle{framework.NewNodeCircle(d.key, d.nodes)}))
+		}
+		emitted = nextEmitted
+	}
+	return nodeGroups
+}
+
+// coversAllNodes returns true if the last node group contains all the nodes of the tree.
+func (t *topologyTree) coversAllNodes(nodeGroups []framework.NodeGroup) bool {
+	if len(nodeGroups) == 0 {
+		return false
+	}
+	circles := nodeGroups[len(nodeGroups)-1].GetNodeCircles()
+	return len(circles) == 1 && circles[0].Len() == t.numNodes
+}
+
+func getNodeResource(nodeInfo framework.NodeInfo, resourceType podutil.PodResourceType) (*framework.Resource, *framework.Resource) {
+	switch resourceType {
+	case podutil.GuaranteedPod:
+		return nodeInfo.GetGuaranteedAllocatable(), nodeInfo.GetGuaranteedRequested()
+	case podutil.BestEffortPod:
+		return nodeInfo.GetBestEffortAllocatable(), nodeInfo.GetBestEffortRequested()
+	}
+	return nil, nil
+}
+
+// fits only checks the most common resources in a domain to avoid unnecessary scheduling process.
+func fits(request *joblevelaffinity.PreCheckResource, allocatable, requested *framework.Resource) bool {
+	return request.MilliCPU <= allocatable.MilliCPU-requested.MilliCPU &&
+		request.Memory <= allocatable.Memory-requested.Memory &&
+		request.GPU <= allocatable.ScalarResources[util.ResourceGPU]-requested.ScalarResources[util.ResourceGPU]
+}
+
+// fragmentation returns the average ratio of the resources left behind in the domain after placing the unit,
+// only the resources requested by the unit are taken into account. The lower the better.
+func fragmentation(request *joblevelaffinity.PreCheckResource, allocatable, requested *framework.Resource) float64 {
+	var sum float64
+	var count int
+	ratio := func(request, allocatable, requested int64) {
+		if request <= 0 || allocatable <= 0 {
+			return
+		}
+		sum += float64(allocatable-requested-request) / float64(allocatable)
+		count++
+	}
+	ratio(request.MilliCPU, allocatable.MilliCPU, requested.MilliCPU)
+	ratio(request.Memory, allocatable.Memory, requested.Memory)
+	ratio(request.GPU, allocatable.ScalarResources[util.ResourceGPU], requested.ScalarResources[util.ResourceGPU])
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
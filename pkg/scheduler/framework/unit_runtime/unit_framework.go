@@ -19,19 +19,17 @@ package unitruntime
 import (
 	"context"
 	"fmt"
-	"math"
-	"sort"
 	"strconv"
 	"time"
 
 	v1 "k8s.io/api/core/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/core"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
-	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
 	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
 	"github.com/kubewharf/godel-scheduler/pkg/util/interpretabity"
@@ -54,61 +52,31 @@ type UnitFramework struct {
 	handle         handle.UnitFrameworkHandle
 	schedulerHooks core.SchedulerHooks
 
-	locatingPlugins []framework.LocatingPlugin
-	groupingPlugins map[string]framework.GroupingPlugin
+	plugins *UnitPlugins
 }
 
-// ATTENTION: Considering that UnitPlugin belongs to scheduling optimization behavior, all implemented plugins should be registered.
-// The plugin should adaptively execute the corresponding logic based on the FeatureGate and the ScheduleUnit to be scheduled,
-// without the need to adjust the plugin's registration or not through a configuration file.
+// ATTENTION: Considering that UnitPlugin belongs to scheduling optimization behavior, all implemented plugins are enabled
+// unless the profile narrows them down. The plugin should adaptively execute the corresponding logic based on the
+// FeatureGate and the ScheduleUnit to be scheduled.
 func NewUnitFramework(
 	handle handle.UnitFrameworkHandle,
 	schedulerHooks core.SchedulerHooks,
-	pluginRegistry framework.PluginMap,
-	pluginOrder framework.PluginOrder,
+	plugins *UnitPlugins,
 	unit framework.ScheduleUnit, // TODO: Support customized plugins based on specific ScheduleUnit.
 ) SchedulerUnitFramework {
-	f := &UnitFramework{
-		handle:          handle,
-		schedulerHooks:  schedulerHooks,
-		locatingPlugins: make([]framework.LocatingPlugin, 0),
-		groupingPlugins: make(map[string]framework.GroupingPlugin),
+	if plugins == nil {
+		plugins = &UnitPlugins{}
 	}
-
-	for _, pl := range pluginRegistry {
-		if locatingPlugin, ok := pl.(framework.LocatingPlugin); ok && locatingPlugin != nil {
-			f.locatingPlugins = append(f.locatingPlugins, locatingPlugin)
-		}
-		if groupingPlugin, ok := pl.(framework.GroupingPlugin); ok && groupingPlugin != nil {
-			f.groupingPlugins[groupingPlugin.Name()] = groupingPlugin
-		}
+	return &UnitFramework{
+		handle:         handle,
+		schedulerHooks: schedulerHooks,
+		plugins:        plugins,
 	}
-
-	// Sorting locating plugins
-	if pluginOrder != nil {
-		sort.SliceStable(f.locatingPlugins, func(i, j int) bool {
-			// if the index of plugin is not in pluginOrder, use infinity as the index
-			// so that the plugin will be put at the end of the slice
-			iIndex, exist := pluginOrder[f.locatingPlugins[i].Name()]
-			if !exist {
-				klog.InfoS("WARN: Plugin was not found in the PluginOrder map", "pluginName", f.locatingPlugins[i].Name())
-				iIndex = math.MaxInt32
-			}
-			jIndex, exist := pluginOrder[f.locatingPlugins[j].Name()]
-			if !exist {
-				klog.InfoS("WARN: Plugin was not found in the PluginOrder map", "pluginName", f.locatingPlugins[j].Name())
-				jIndex = math.MaxInt32
-			}
-			return iIndex < jIndex
-		})
-	}
-
-	return f
 }
 
 func (f *UnitFramework) RunLocatingPlugins(ctx context.Context, unit framework.ScheduleUnit, unitCycleState *framework.CycleState, nodeGroup framework.NodeGroup) (framework.NodeGroup, *framework.Status) {
 	// Run all Locating Plugins.
-	for _, pl := range f.locatingPlugins {
+	for _, pl := range f.plugins.Locating {
 		var status *framework.Status
 		nodeGroup, status = pl.Locating(ctx, unit, unitCycleState, nodeGroup)
 		if !status.IsSuccess() {
@@ -119,8 +87,8 @@ func (f *UnitFramework) RunLocatingPlugins(ctx context.Context, unit framework.S
 }
 
 func (f *UnitFramework) RunPreparePreferNodesPlugins(ctx context.Context, unitCycleState, state *framework.CycleState, pod *v1.Pod) *framework.Status {
-	// Run all Locating Plugins.
-	for _, pl := range f.locatingPlugins {
+	// Run all PreferNode Plugins.
+	for _, pl := range f.plugins.PreferNode {
 		status := pl.PreparePreferNode(ctx, unitCycleState, state, pod)
 		if !status.IsSuccess() {
 			return status
@@ -132,17 +100,16 @@ func (f *UnitFramework) RunPreparePreferNodesPlugins(ctx context.Context, unitCy
 func (f *UnitFramework) RunGroupingPlugin(ctx context.Context, unit framework.ScheduleUnit, unitCycleState *framework.CycleState, nodeGroup framework.NodeGroup) ([]framework.NodeGroup, *framework.Status) {
 	var nodeGroups []framework.NodeGroup
 	switch {
-	case framework.UnitRequireJobLevelAffinity(unit):
-		groupingPlugin, ok := f.groupingPlugins[joblevelaffinity.Name]
-		if ok && groupingPlugin != nil {
-			gotNodeGroups, status := groupingPlugin.Grouping(ctx, unit, unitCycleState, nodeGroup)
-			if !status.IsSuccess() {
-				return nil, status
-			}
-			nodeGroups = gotNodeGroups
-		} else {
-			return nil, framework.AsStatus(fmt.Errorf("No JobLevelAffinity Grouping registered, which is unexpected"))
+	case f.plugins.Grouping != nil:
+		// The grouping plugin decides whether the unit needs to be divided, e.g. JobLevelAffinity only divides
+		// the units requiring job level affinity.
+		gotNodeGroups, status := f.plugins.Grouping.Grouping(ctx, unit, unitCycleState, nodeGroup)
+		if !status.IsSuccess() {
+			return nil, status
 		}
+		nodeGroups = gotNodeGroups
+	case framework.UnitRequireJobLevelAffinity(unit):
+		return nil, framework.AsStatus(fmt.Errorf("No Grouping plugin registered, which is unexpected"))
 	default:
 		// By default
 		nodeGroups = []framework.NodeGroup{nodeGroup}
@@ -186,6 +153,7 @@ func (f *UnitFramework) Scheduling(ctx context.Context, unitInfo *core.Schedulin
 
 	templateCount := len(unitInfo.NotScheduledPodKeysByTemplate)
 	commonPreemptionState := framework.NewCycleState()
+	affinityRoles := framework.GetAffinityRoles(unitInfo.QueuedUnitInfo)
 
 	for tmplKey, podKeys := range unitInfo.NotScheduledPodKeysByTemplate {
 		klog.InfoS("Will schedule in specific node group for the pod template", "template", tmplKey, "nodeGroup", nodeGroup.GetKey())
@@ -200,7 +168,7 @@ func (f *UnitFramework) Scheduling(ctx context.Context, unitInfo *core.Schedulin
 
 			scheduled, err := f.scheduleOneUnitInstance(ctx, unitInfo.ScheduledIndex, markIndex, unitInfo.NodeToStatusMapByTemplate,
 				runningUnitInfo, unitInfo.UnitKey, unitInfo.QueuedUnitInfo.QueuePriorityScore, unitInfo.UnitCycleState, commonPreemptionState,
-				nodeGroup, usr)
+				podNodeGroup(unitInfo, affinityRoles, runningUnitInfo.QueuedPodInfo.Pod, nodeGroup), usr)
 			defer tracing.AsyncFinishTraceContext(scheduleTraceContext, time.Now())
 
 			if scheduled {
@@ -257,6 +225,15 @@ func (f *UnitFramework) Scheduling(ctx context.Context, unitInfo *core.Schedulin
 	return result
 }
 
+// podNodeGroup returns the node group where the pod is placed. The pods of the roles which are not co-located
+// are placed in the node group located for the whole unit instead of the one divided by the grouping plugin.
+func podNodeGroup(unitInfo *core.SchedulingUnitInfo, affinityRoles sets.String, pod *v1.Pod, nodeGroup framework.NodeGroup) framework.NodeGroup {
+	if unitInfo.LocatedNodeGroup == nil || framework.PodRequireCoLocation(affinityRoles, pod) {
+		return nodeGroup
+	}
+	return unitInfo.LocatedNodeGroup
+}
+
 func (f *UnitFramework) Preempting(ctx context.Context, unitInfo *core.SchedulingUnitInfo, nodeGroup framework.NodeGroup) *core.UnitPreemptionResult {
 	// TODO: carry more unit indicators for making better scheduling decisions
 	// TODO: get all member from pod owner if unit is not pod group
@@ -285,6 +262,7 @@ func (f *UnitFramework) Preempting(ctx context.Context, unitInfo *core.Schedulin
 	}
 
 	commonPreemptionState := framework.NewCycleState()
+	affinityRoles := framework.GetAffinityRoles(unitInfo.QueuedUnitInfo)
 	for tmplKey, podKeys := range unitInfo.NotScheduledPodKeysByTemplate {
 		klog.InfoS("Will preempt in specific node group for the pod template", "template", tmplKey, "nodeGroup", nodeGroup.GetKey())
 
@@ -298,7 +276,7 @@ func (f *UnitFramework) Preempting(ctx context.Context, unitInfo *core.Schedulin
 
 			scheduled, err := f.preemptOneUnitInstance(ctx, unitInfo.ScheduledIndex, markIndex, runningUnitInfo, unitInfo.UnitKey,
 				unitInfo.QueuedUnitInfo.QueuePriorityScore, unitInfo.UnitCycleState, commonPreemptionState,
-				nodeGroup, unitInfo.NodeToStatusMapByTemplate[tmplKey], templateToNominatedNodes[runningUnitInfo.QueuedPodInfo.OwnerReferenceKey])
+				podNodeGroup(unitInfo, affinityRoles, runningUnitInfo.QueuedPodInfo.Pod, nodeGroup), unitInfo.NodeToStatusMapByTemplate[tmplKey], templateToNominatedNodes[runningUnitInfo.QueuedPodInfo.OwnerReferenceKey])
 			defer tracing.AsyncFinishTraceContext(preemptTraceContext, time.Now())
 
 			if scheduled {
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unitruntime
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
+)
+
+// UnitPlugins contains the unit plugins enabled at each extension point, in the order they run.
+type UnitPlugins struct {
+	Locating   []framework.LocatingPlugin
+	PreferNode []framework.LocatingPlugin
+	Grouping   framework.GroupingPlugin
+}
+
+// NewUnitPlugins picks the unit plugins out of the plugin map according to the profile configuration.
+// An omitted extension point enables every plugin implementing it, sorted by the plugin order, and an
+// omitted grouping plugin falls back to JobLevelAffinity.
+func NewUnitPlugins(pluginMap framework.PluginMap, pluginOrder framework.PluginOrder, cfg *schedulerconfig.UnitPlugins) (*UnitPlugins, error) {
+	if cfg == nil {
+		cfg = &schedulerconfig.UnitPlugins{}
+	}
+	plugins := &UnitPlugins{}
+
+	var err error
+	if plugins.Locating, err = locatingPlugins(pluginMap, pluginOrder, cfg.Locating); err != nil {
+		return nil, fmt.Errorf("locating: %v", err)
+	}
+	if plugins.PreferNode, err = locatingPlugins(pluginMap, pluginOrder, cfg.PreferNode); err != nil {
+		return nil, fmt.Errorf("preferNode: %v", err)
+	}
+
+	if cfg.Grouping == nil {
+		// The default grouping plugin is optional, RunGroupingPlugin reports its absence on demand.
+		if groupingPlugin, ok := pluginMap[joblevelaffinity.Name].(framework.GroupingPlugin); ok {
+			plugins.Grouping = groupingPlugin
+		}
+		return plugins, nil
+	}
+	pl, ok := pluginMap[cfg.Grouping.Name]
+	if !ok {
+		return nil, fmt.Errorf("grouping: unit plugin %v is not initialized", cfg.Grouping.Name)
+	}
+	groupingPlugin, ok := pl.(framework.GroupingPlugin)
+	if !ok {
+		return nil, fmt.Errorf("grouping: unit plugin %v does not implement GroupingPlugin", cfg.Grouping.Name)
+	}
+	plugins.Grouping = groupingPlugin
+	return plugins, nil
+}
+
+func locatingPlugins(pluginMap framework.PluginMap, pluginOrder framework.PluginOrder, set *schedulerconfig.PluginSet) ([]framework.LocatingPlugin, error) {
+	plugins := make([]framework.LocatingPlugin, 0)
+	if set != nil {
+		for _, p := range set.Plugins {
+			pl, ok := pluginMap[p.Name]
+			if !ok {
+				return nil, fmt.Errorf("unit plugin %v is not initialized", p.Name)
+			}
+			locatingPlugin, ok := pl.(framework.LocatingPlugin)
+			if !ok {
+				return nil, fmt.Errorf("unit plugin %v does not implement LocatingPlugin", p.Name)
+			}
+			plugins = append(plugins, locatingPlugin)
+		}
+		return plugins, nil
+	}
+
+	for _, pl := range pluginMap {
+		if locatingPlugin, ok := pl.(framework.LocatingPlugin); ok && locatingPlugin != nil {
+			plugins = append(plugins, locatingPlugin)
+		}
+	}
+	sort.SliceStable(plugins, func(i, j int) bool {
+		// if the index of plugin is not in pluginOrder, use infinity as the index
+		// so that the plugin will be put at the end of the slice
+		iIndex, exist := pluginOrder[plugins[i].Name()]
+		if !exist {
+			klog.InfoS("WARN: Plugin was not found in the PluginOrder map", "pluginName", plugins[i].Name())
+			iIndex = math.MaxInt32
+		}
+		jIndex, exist := pluginOrder[plugins[j].Name()]
+		if !exist {
+			klog.InfoS("WARN: Plugin was not found in the PluginOrder map", "pluginName", plugins[j].Name())
+			jIndex = math.MaxInt32
+		}
+		if iIndex != jIndex {
+			return iIndex < jIndex
+		}
+		return plugins[i].Name() < plugins[j].Name()
+	})
+	return plugins, nil
+}
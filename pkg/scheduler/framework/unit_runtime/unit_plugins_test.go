@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unitruntime
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
+)
+
+type fakeLocatingPlugin struct {
+	name string
+}
+
+func (pl *fakeLocatingPlugin) Name() string { return pl.name }
+
+func (pl *fakeLocatingPlugin) Locating(_ context.Context, _ framework.ScheduleUnit, _ *framework.CycleState, nodeGroup framework.NodeGroup) (framework.NodeGroup, *framework.Status) {
+	return nodeGroup, nil
+}
+
+func (pl *fakeLocatingPlugin) PreparePreferNode(_ context.Context, _, _ *framework.CycleState, _ *v1.Pod) *framework.Status {
+	return nil
+}
+
+type fakeGroupingPlugin struct {
+	name string
+}
+
+func (pl *fakeGroupingPlugin) Name() string { return pl.name }
+
+func (pl *fakeGroupingPlugin) Grouping(_ context.Context, _ framework.ScheduleUnit, _ *framework.CycleState, nodeGroup framework.NodeGroup) ([]framework.NodeGroup, *framework.Status) {
+	return []framework.NodeGroup{nodeGroup}, nil
+}
+
+func pluginSet(names ...string) *schedulerconfig.PluginSet {
+	set := &schedulerconfig.PluginSet{Plugins: []schedulerconfig.Plugin{}}
+	for _, name := range names {
+		set.Plugins = append(set.Plugins, schedulerconfig.Plugin{Name: name})
+	}
+	return set
+}
+
+func locatingNames(plugins []framework.LocatingPlugin) []string {
+	names := []string{}
+	for _, pl := range plugins {
+		names = append(names, pl.Name())
+	}
+	return names
+}
+
+func TestNewUnitPlugins(t *testing.T) {
+	pluginMap := framework.PluginMap{
+		"a":                   &fakeLocatingPlugin{name: "a"},
+		"b":                   &fakeLocatingPlugin{name: "b"},
+		"c":                   &fakeLocatingPlugin{name: "c"},
+		"grouping":            &fakeGroupingPlugin{name: "grouping"},
+		joblevelaffinity.Name: &fakeGroupingPlugin{name: joblevelaffinity.Name},
+	}
+	pluginOrder := framework.PluginOrder{"b": 0, "a": 1}
+
+	tests := []struct {
+		name           string
+		cfg            *schedulerconfig.UnitPlugins
+		wantLocating   []string
+		wantPreferNode []string
+		wantGrouping   string
+		wantErr        bool
+	}{
+		{
+			name:           "nil configuration enables all plugins in default order",
+			cfg:            nil,
+			wantLocating:   []string{"b", "a", "c"},
+			wantPreferNode: []string{"b", "a", "c"},
+			wantGrouping:   joblevelaffinity.Name,
+		},
+		{
+			name: "configured plugins keep the configured order",
+			cfg: &schedulerconfig.UnitPlugins{
+				Locating:   pluginSet("c", "a"),
+				PreferNode: pluginSet("a"),
+				Grouping:   &schedulerconfig.Plugin{Name: "grouping"},
+			},
+			wantLocating:   []string{"c", "a"},
+			wantPreferNode: []string{"a"},
+			wantGrouping:   "grouping",
+		},
+		{
+			name: "empty plugin set disables the extension point",
+			cfg: &schedulerconfig.UnitPlugins{
+				Locating: pluginSet(),
+			},
+			wantLocating:   []string{},
+			wantPreferNode: []string{"b", "a", "c"},
+			wantGrouping:   joblevelaffinity.Name,
+		},
+		{
+			name: "unknown locating plugin",
+			cfg: &schedulerconfig.UnitPlugins{
+				Locating: pluginSet("unknown"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "grouping plugin without grouping extension point",
+			cfg: &schedulerconfig.UnitPlugins{
+				Grouping: &schedulerconfig.Plugin{Name: "a"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugins, err := NewUnitPlugins(pluginMap, pluginOrder, tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := locatingNames(plugins.Locating); !reflect.DeepEqual(got, tt.wantLocating) {
+				t.Errorf("expected locating plugins %v, got %v", tt.wantLocating, got)
+			}
+			if got := locatingNames(plugins.PreferNode); !reflect.DeepEqual(got, tt.wantPreferNode) {
+				t.Errorf("expected preferNode plugins %v, got %v", tt.wantPreferNode, got)
+			}
+			if got := plugins.Grouping.Name(); got != tt.wantGrouping {
+				t.Errorf("expected grouping plugin %v, got %v", tt.wantGrouping, got)
+			}
+		})
+	}
+}
@@ -20,13 +20,16 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/daemonset"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/gangrecovery"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/joblevelaffinity"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/networktopology"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/noop"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/rescheduling"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/unit_plugins/reservation"
@@ -42,6 +45,18 @@ type UnitPluginFactory = func(configuration runtime.Object, handle handle.UnitFr
 // All plugins must be in the registry before initializing the framework.
 type UnitRegistry map[string]UnitPluginFactory
 
+// Merge merges the provided registry to the current one, it returns an error
+// if a plugin in the provided registry is already registered.
+func (r UnitRegistry) Merge(in UnitRegistry) error {
+	for name, factory := range in {
+		if _, ok := r[name]; ok {
+			return fmt.Errorf("a unit plugin named %v already exists", name)
+		}
+		r[name] = factory
+	}
+	return nil
+}
+
 // NewOrderedPluginRegistry builds the registry with all the filter plugins.
 // If a filter plugin is not in the registry, it will be ignored.
 // So a new plugin having Filter method need be added to the registry.
@@ -52,6 +67,7 @@ func NewOrderedUnitPluginRegistry() framework.PluginOrder {
 			virtualkubelet.Name,
 			joblevelaffinity.Name,
 			rescheduling.Name,
+			gangrecovery.Name,
 			noop.Name,
 		},
 	}
@@ -66,23 +82,32 @@ func NewUnitInTreeRegistry() UnitRegistry {
 		virtualkubelet.Name:   virtualkubelet.New,
 		rescheduling.Name:     rescheduling.New,
 		reservation.Name:      reservation.New,
+		gangrecovery.Name:     gangrecovery.New,
+		networktopology.Name:  networktopology.New,
 	}
 }
 
+// NewUnitPluginsRegistry initializes the unit plugins enabled by the configuration, all the registered
+// plugins are initialized unless every extension point is specified.
 func NewUnitPluginsRegistry(
 	registry UnitRegistry,
-	pluginArgs map[string]*schedulerconfig.PluginConfig, // TODO: Do we need UnitPlugin args in future?
+	plugins *schedulerconfig.UnitPlugins,
+	pluginArgs map[string]*schedulerconfig.PluginConfig,
 	handler handle.UnitFrameworkHandle,
 ) framework.PluginMap {
 	pluginMap := framework.PluginMap{}
 
 	preparePlugin := func(pluginName string) error {
+		factory, ok := registry[pluginName]
+		if !ok {
+			return fmt.Errorf("unit plugin %v is not registered", pluginName)
+		}
 		var err error
 		if _, ok := pluginMap[pluginName]; !ok {
 			if pluginArgs[pluginName] != nil {
-				pluginMap[pluginName], err = registry[pluginName](pluginArgs[pluginName].Args.Object, handler)
+				pluginMap[pluginName], err = factory(pluginArgs[pluginName].Args.Object, handler)
 			} else {
-				pluginMap[pluginName], err = registry[pluginName](nil, handler)
+				pluginMap[pluginName], err = factory(nil, handler)
 			}
 		}
 		if err != nil {
@@ -91,7 +116,7 @@ func NewUnitPluginsRegistry(
 		return nil
 	}
 
-	for plName := range registry {
+	for _, plName := range enabledUnitPlugins(registry, plugins) {
 		if err := preparePlugin(plName); err != nil {
 			klog.ErrorS(err, "Failed to initialize UnitSchedulingRegistry", "registry", registry, "pluginArgs", pluginArgs)
 			klog.FlushAndExit(klog.ExitFlushTimeout, 1)
@@ -100,3 +125,27 @@ func NewUnitPluginsRegistry(
 
 	return pluginMap
 }
+
+func enabledUnitPlugins(registry UnitRegistry, plugins *schedulerconfig.UnitPlugins) []string {
+	if plugins == nil || plugins.Locating == nil || plugins.PreferNode == nil {
+		names := make([]string, 0, len(registry))
+		for plName := range registry {
+			names = append(names, plName)
+		}
+		return names
+	}
+
+	names := sets.NewString()
+	for _, plugin := range plugins.Locating.Plugins {
+		names.Insert(plugin.Name)
+	}
+	for _, plugin := range plugins.PreferNode.Plugins {
+		names.Insert(plugin.Name)
+	}
+	if plugins.Grouping != nil {
+		names.Insert(plugins.Grouping.Name)
+	} else {
+		names.Insert(joblevelaffinity.Name)
+	}
+	return names.List()
+}
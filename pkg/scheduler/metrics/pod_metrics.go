@@ -42,6 +42,22 @@ var (
 			StabilityLevel: metrics.ALPHA,
 		}, []string{pkgmetrics.TypeLabel, pkgmetrics.SchedulerLabel})
 
+	CacheDrift = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "cache_drift",
+			Help:           "Number of nodes and pods missed or redundant in the scheduler cache compared with informers.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ResourceLabel, pkgmetrics.TypeLabel, pkgmetrics.SchedulerLabel})
+
+	CacheResyncs = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "cache_resync_total",
+			Help:           "Number of nodes and pods resynced into the scheduler cache by cache self-healing.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ResourceLabel, pkgmetrics.TypeLabel, pkgmetrics.SchedulerLabel})
+
 	ClusterPodRequested = metrics.NewGaugeVec(
 		&metrics.GaugeOpts{
 			Subsystem: SchedulerSubsystem,
@@ -63,6 +79,22 @@ var (
 			Help:      "The capacity of reserved resource of node.",
 		}, []string{pkgmetrics.SubClusterLabel, pkgmetrics.QosLabel, pkgmetrics.ResourceLabel, pkgmetrics.SchedulerLabel})
 
+	OvercommitNodes = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "overcommit_nodes",
+			Help:           "Number of nodes whose allocatable is transformed by each overcommit profile.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ProfileLabel, pkgmetrics.SchedulerLabel})
+
+	OvercommitAllocatable = metrics.NewGaugeVec(
+		&metrics.GaugeOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "overcommit_allocatable",
+			Help:           "The allocatable of nodes transformed by each overcommit profile.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.ProfileLabel, pkgmetrics.QosLabel, pkgmetrics.ResourceLabel, pkgmetrics.SchedulerLabel})
+
 	NodeCounter = metrics.NewGaugeVec(
 		&metrics.GaugeOpts{
 			Subsystem: SchedulerSubsystem,
@@ -260,6 +292,33 @@ var (
 			Buckets:        metrics.ExponentialBuckets(1, 2, 10),
 			StabilityLevel: metrics.ALPHA,
 		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.SchedulerLabel})
+
+	nodeSearchBudget = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "node_search_budget",
+			Help:           "Number of feasible nodes to find before the scheduler stops searching, split by static or adaptive search.",
+			Buckets:        metrics.ExponentialBuckets(1, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.TypeLabel, pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.SchedulerLabel})
+
+	nodeSearchDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "node_search_duration_seconds",
+			Help:           "Latency of searching and scoring nodes in a node circle in seconds, split by static or adaptive search.",
+			Buckets:        metrics.ExponentialBuckets(0.0001, 2, 16),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.TypeLabel, pkgmetrics.ResultLabel, pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.SchedulerLabel})
+
+	nodeSearchSelectedScore = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "node_search_selected_score",
+			Help:           "Total score of the node selected for the pod, split by static or adaptive search.",
+			Buckets:        metrics.ExponentialBuckets(1, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.TypeLabel, pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.SchedulerLabel})
 )
 
 // setScheduler sets pkgmetrics.SchedulerLabel with scheduler instance name.
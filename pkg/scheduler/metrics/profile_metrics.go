@@ -129,3 +129,28 @@ func ObservePodFeasibleNodes(subCluster, qos, scheduler string, count float64) {
 		metrics.SchedulerLabel:  scheduler,
 	}).Observe(count)
 }
+
+// ObserveNodeSearchBudget records the number of feasible nodes to find for the pod in the given search mode.
+func ObserveNodeSearchBudget(podProperty *api.PodProperty, mode string, budget float64) {
+	labels := podProperty.ConvertToMetricsLabels()
+	labels[metrics.TypeLabel] = mode
+	setScheduler(labels)
+	nodeSearchBudget.With(labels).Observe(budget)
+}
+
+// ObserveNodeSearchDuration records the latency of searching and scoring nodes for the pod in the given search mode.
+func ObserveNodeSearchDuration(podProperty *api.PodProperty, mode, result string, duration float64) {
+	labels := podProperty.ConvertToMetricsLabels()
+	labels[metrics.TypeLabel] = mode
+	labels[metrics.ResultLabel] = result
+	setScheduler(labels)
+	nodeSearchDuration.With(labels).Observe(duration)
+}
+
+// ObserveNodeSearchSelectedScore records the score of the node selected for the pod in the given search mode.
+func ObserveNodeSearchSelectedScore(podProperty *api.PodProperty, mode string, score float64) {
+	labels := podProperty.ConvertToMetricsLabels()
+	labels[metrics.TypeLabel] = mode
+	setScheduler(labels)
+	nodeSearchSelectedScore.With(labels).Observe(score)
+}
@@ -31,6 +31,8 @@ var metricsList = []metrics.Registerable{
 	pendingUnits,
 	schedulerGoroutines,
 	CacheSize,
+	CacheDrift,
+	CacheResyncs,
 
 	podE2ESchedulingLatency,
 	podE2ESchedulingLatencyQuantile,
@@ -58,13 +60,21 @@ var metricsList = []metrics.Registerable{
 	ClusterPodRequested,
 	NodeCounter,
 	ClusterReservedResource,
+	OvercommitNodes,
+	OvercommitAllocatable,
 
 	podsUseMovement,
 	podEvaluatedNodes,
 	podFeasibleNodes,
+	nodeSearchBudget,
+	nodeSearchDuration,
+	nodeSearchSelectedScore,
 
 	schedulerUnitE2ELatency,
 	unitScheduleResult,
+	unitBackfillStarvationDuration,
+	unitBackfillAdmissions,
+	unitScaleUpHints,
 }
 
 // SchedulerName name of scheduler to produce metrics
@@ -138,3 +138,54 @@ func UnitScheduleResultObserve(unitProperty api.UnitProperty, result string, min
 	unitLabels[pkgmetrics.ResultLabel] = result
 	newUnitScheduleResultObserverMetric(unitLabels).Observe(minMember)
 }
+
+var (
+	unitBackfillStarvationDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "unit_backfill_starvation_duration_seconds",
+			Help:           "Duration for which a blocked unit holds a backfill reservation, by how the reservation ends.",
+			Buckets:        metrics.ExponentialBuckets(1, 2, 16),
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.SchedulerLabel, pkgmetrics.ResultLabel})
+
+	unitBackfillAdmissions = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "unit_backfill_admissions_total",
+			Help:           "Number of admission decisions of units onto the nodes reserved by backfill reservations, by the decision.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.SchedulerLabel, pkgmetrics.ResultLabel})
+
+	unitScaleUpHints = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "unit_scale_up_hints_total",
+			Help:           "Number of scale-up hints computed for long pending units, by whether a node template fits.",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{pkgmetrics.QosLabel, pkgmetrics.SubClusterLabel, pkgmetrics.UnitTypeLabel, pkgmetrics.SchedulerLabel, pkgmetrics.ResultLabel})
+)
+
+// UnitBackfillStarvationObserve records how long the unit held a backfill reservation and how the reservation ended.
+func UnitBackfillStarvationObserve(unitProperty api.UnitProperty, result string, duration float64) {
+	unitLabels := api.MustConvertToMetricsLabels(unitProperty)
+	unitLabels[pkgmetrics.ResultLabel] = result
+	setScheduler(unitLabels)
+	unitBackfillStarvationDuration.With(unitLabels).Observe(duration)
+}
+
+// UnitBackfillAdmissionInc records an admission decision of the unit onto the reserved nodes.
+func UnitBackfillAdmissionInc(unitProperty api.UnitProperty, result string) {
+	unitLabels := api.MustConvertToMetricsLabels(unitProperty)
+	unitLabels[pkgmetrics.ResultLabel] = result
+	setScheduler(unitLabels)
+	unitBackfillAdmissions.With(unitLabels).Inc()
+}
+
+// UnitScaleUpHintInc records a scale-up hint computed for the unit.
+func UnitScaleUpHintInc(unitProperty api.UnitProperty, result string) {
+	unitLabels := api.MustConvertToMetricsLabels(unitProperty)
+	unitLabels[pkgmetrics.ResultLabel] = result
+	setScheduler(unitLabels)
+	unitScaleUpHints.With(unitLabels).Inc()
+}
@@ -20,10 +20,14 @@ import (
 	"encoding/json"
 	"reflect"
 	"strings"
+	"time"
 
+	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	preemptionstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_store"
+	schedulerframework "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework"
+	godelqueue "github.com/kubewharf/godel-scheduler/pkg/scheduler/queue"
 )
 
 type schedulerOptions struct {
@@ -32,6 +36,18 @@ type schedulerOptions struct {
 
 	renewInterval int64
 	subClusterKey string
+
+	cacheComparePeriod     time.Duration
+	enableCacheSelfHealing bool
+
+	// out-of-tree registries are merged into the in-tree ones when building the scheduler.
+	outOfTreeRegistry           schedulerframework.Registry
+	outOfTreeUnitRegistry       schedulerframework.UnitRegistry
+	outOfTreePreemptionRegistry schedulerframework.Registry
+	outOfTreeQueueSortRegistry  godelqueue.UnitSortPluginRegistry
+
+	podMetricsLister       commoncache.PodMetricsLister
+	podMetricsSamplePeriod time.Duration
 }
 
 // Option configures a Scheduler
@@ -66,11 +82,87 @@ func WithSubClusterKey(key string) Option {
 	}
 }
 
+// WithCacheComparer enables the periodic comparison between cache and informers, 0 period disables it.
+func WithCacheComparer(period time.Duration, selfHealing bool) Option {
+	return func(o *schedulerOptions) {
+		o.cacheComparePeriod = period
+		o.enableCacheSelfHealing = selfHealing
+	}
+}
+
+// WithPodMetricsLister enables sampling the usage of running pods periodically, which is used
+// to build the usage profile of each owner.
+func WithPodMetricsLister(lister commoncache.PodMetricsLister, period time.Duration) Option {
+	return func(o *schedulerOptions) {
+		o.podMetricsLister = lister
+		o.podMetricsSamplePeriod = period
+	}
+}
+
+// WithFrameworkOutOfTreeRegistry sets the registry of out-of-tree pod plugins.
+func WithFrameworkOutOfTreeRegistry(registry schedulerframework.Registry) Option {
+	return func(o *schedulerOptions) {
+		o.outOfTreeRegistry = registry
+	}
+}
+
+// WithUnitFrameworkOutOfTreeRegistry sets the registry of out-of-tree unit plugins.
+func WithUnitFrameworkOutOfTreeRegistry(registry schedulerframework.UnitRegistry) Option {
+	return func(o *schedulerOptions) {
+		o.outOfTreeUnitRegistry = registry
+	}
+}
+
+// WithPreemptionOutOfTreeRegistry sets the registry of out-of-tree preemption plugins.
+func WithPreemptionOutOfTreeRegistry(registry schedulerframework.Registry) Option {
+	return func(o *schedulerOptions) {
+		o.outOfTreePreemptionRegistry = registry
+	}
+}
+
+// WithUnitQueueSortOutOfTreeRegistry sets the registry of out-of-tree unit queue sort plugins.
+func WithUnitQueueSortOutOfTreeRegistry(registry godelqueue.UnitSortPluginRegistry) Option {
+	return func(o *schedulerOptions) {
+		o.outOfTreeQueueSortRegistry = registry
+	}
+}
+
 var defaultSchedulerOptions = schedulerOptions{
 	renewInterval: config.DefaultRenewIntervalInSeconds,
 	subClusterKey: config.DefaultSubClusterKey,
 }
 
+// registries contains the plugin registries shared by all sub-cluster workflows.
+type registries struct {
+	pluginRegistry           schedulerframework.Registry
+	unitPluginRegistry       schedulerframework.UnitRegistry
+	preemptionPluginRegistry schedulerframework.Registry
+	queueSortPluginRegistry  godelqueue.UnitSortPluginRegistry
+}
+
+// buildRegistries merges the out-of-tree registries into the in-tree ones.
+func (o *schedulerOptions) buildRegistries() (*registries, error) {
+	r := &registries{
+		pluginRegistry:           schedulerframework.NewInTreeRegistry(),
+		unitPluginRegistry:       schedulerframework.NewUnitInTreeRegistry(),
+		preemptionPluginRegistry: schedulerframework.NewInTreePreemptionRegistry(),
+		queueSortPluginRegistry:  godelqueue.NewInTreeUnitSortPluginRegistry(),
+	}
+	if err := r.pluginRegistry.Merge(o.outOfTreeRegistry); err != nil {
+		return nil, err
+	}
+	if err := r.unitPluginRegistry.Merge(o.outOfTreeUnitRegistry); err != nil {
+		return nil, err
+	}
+	if err := r.preemptionPluginRegistry.Merge(o.outOfTreePreemptionRegistry); err != nil {
+		return nil, err
+	}
+	if err := r.queueSortPluginRegistry.Merge(o.outOfTreeQueueSortRegistry); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
 func renderOptions(opts ...Option) schedulerOptions {
 	options := defaultSchedulerOptions
 	for _, opt := range opts {
@@ -94,6 +186,10 @@ type subClusterConfig struct {
 	PluginConfigs           []config.PluginConfig
 	PreemptionPluginConfigs []config.PluginConfig
 	UnitQueueSortPlugin     *framework.PluginSpec
+	UnitPlugins             *config.UnitPlugins
+	UnitPluginConfigs       []config.PluginConfig
+	Backfill                *config.BackfillConfig
+	ScaleUpHint             *config.ScaleUpHintConfig
 
 	DisablePreemption      bool
 	CandidatesSelectPolicy string
@@ -140,6 +236,18 @@ func (c *subClusterConfig) complete(profile *config.GodelSchedulerProfile) {
 	if profile.UnitQueueSortPlugin != nil {
 		c.UnitQueueSortPlugin = framework.NewPluginSpec(profile.UnitQueueSortPlugin.Name)
 	}
+	if profile.UnitPlugins != nil {
+		c.UnitPlugins = profile.UnitPlugins
+	}
+	if profile.UnitPluginConfigs != nil {
+		c.UnitPluginConfigs = profile.UnitPluginConfigs
+	}
+	if profile.Backfill != nil {
+		c.Backfill = profile.Backfill
+	}
+	if profile.ScaleUpHint != nil {
+		c.ScaleUpHint = profile.ScaleUpHint
+	}
 
 	if profile.DisablePreemption != nil {
 		c.DisablePreemption = *profile.DisablePreemption
@@ -208,6 +316,10 @@ func newSubClusterConfigFromDefaultConfig(profile *config.GodelSchedulerProfile,
 		PluginConfigs:           defaultConfig.PluginConfigs,
 		PreemptionPluginConfigs: defaultConfig.PreemptionPluginConfigs,
 		UnitQueueSortPlugin:     defaultConfig.UnitQueueSortPlugin,
+		UnitPlugins:             defaultConfig.UnitPlugins,
+		UnitPluginConfigs:       defaultConfig.UnitPluginConfigs,
+		Backfill:                defaultConfig.Backfill,
+		ScaleUpHint:             defaultConfig.ScaleUpHint,
 
 		DisablePreemption:      defaultConfig.DisablePreemption,
 		CandidatesSelectPolicy: defaultConfig.CandidatesSelectPolicy,
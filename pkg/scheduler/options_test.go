@@ -29,6 +29,9 @@ import (
 	"github.com/kubewharf/godel-scheduler/cmd/scheduler/app/options"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
 	schedulerconfig "github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	schedulerframework "github.com/kubewharf/godel-scheduler/pkg/scheduler/framework"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/handle"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/framework/plugins/nodeaffinity"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilpointer "k8s.io/utils/pointer"
@@ -617,3 +620,34 @@ func TestLoadAndRenderFileV1beta1PreemptionProfileConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildRegistries(t *testing.T) {
+	fakeFactory := func(_ runtime.Object, _ handle.PodFrameworkHandle) (framework.Plugin, error) {
+		return nil, nil
+	}
+
+	options := renderOptions(
+		WithFrameworkOutOfTreeRegistry(schedulerframework.Registry{"FakeOutOfTree": fakeFactory}),
+		WithPreemptionOutOfTreeRegistry(schedulerframework.Registry{"FakeOutOfTreeChecker": fakeFactory}),
+	)
+	registries, err := options.buildRegistries()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := registries.pluginRegistry["FakeOutOfTree"]; !ok {
+		t.Errorf("expected out-of-tree plugin in the registry")
+	}
+	if _, ok := registries.preemptionPluginRegistry["FakeOutOfTreeChecker"]; !ok {
+		t.Errorf("expected out-of-tree preemption plugin in the registry")
+	}
+	if _, ok := registries.pluginRegistry[nodeaffinity.Name]; !ok {
+		t.Errorf("expected in-tree plugin in the registry")
+	}
+
+	options = renderOptions(
+		WithFrameworkOutOfTreeRegistry(schedulerframework.Registry{nodeaffinity.Name: fakeFactory}),
+	)
+	if _, err := options.buildRegistries(); err == nil {
+		t.Errorf("expected error when out-of-tree plugin conflicts with in-tree plugin")
+	}
+}
@@ -332,7 +332,8 @@ func (p *BlockQueue) readyToBeScheduled(unitInfo *framework.QueuedUnitInfo) bool
 	if unitInfo == nil || unitInfo.ScheduleUnit == nil {
 		return false
 	}
-	return unitInfo.ReadyToBePopulated() || p.cache.GetUnitSchedulingStatus(unitInfo.UnitKey) == unitstatus.ScheduledStatus
+	return (unitInfo.ReadyToBePopulated() && dependenciesReady(p.pgLister, unitInfo)) ||
+		p.cache.GetUnitSchedulingStatus(unitInfo.UnitKey) == unitstatus.ScheduledStatus
 }
 
 // Add adds a pod to the ready queue. It should be called only when a new pod
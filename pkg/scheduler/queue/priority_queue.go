@@ -403,7 +403,8 @@ func (p *PriorityQueue) readyToBeScheduled(unitInfo *framework.QueuedUnitInfo) b
 	if unitInfo == nil || unitInfo.ScheduleUnit == nil {
 		return false
 	}
-	return unitInfo.ReadyToBePopulated() || p.cache.GetUnitSchedulingStatus(unitInfo.UnitKey) == unitstatus.ScheduledStatus
+	return (unitInfo.ReadyToBePopulated() && dependenciesReady(p.pgLister, unitInfo)) ||
+		p.cache.GetUnitSchedulingStatus(unitInfo.UnitKey) == unitstatus.ScheduledStatus
 }
 
 // TODO: Add more interpretable information for pods that can't be scheduled due to timeout.
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"reflect"
 
+	schedulingv1a1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	"github.com/kubewharf/godel-scheduler-api/pkg/client/listers/scheduling/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
@@ -30,6 +32,7 @@ import (
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
 	"github.com/kubewharf/godel-scheduler/pkg/util/tracing"
+	unitutil "github.com/kubewharf/godel-scheduler/pkg/util/unit"
 )
 
 // newQueuedPodInfo builds a QueuedPodInfo object.
@@ -73,6 +76,28 @@ func isPodUpdated(oldPod, newPod *v1.Pod) bool {
 	return !reflect.DeepEqual(strip(oldPod), strip(newPod))
 }
 
+// dependenciesReady checks whether all the prerequisites of the unit have reached the required phases.
+// Dependencies are only declared by PodGroups, the other units are always ready.
+func dependenciesReady(pgLister v1alpha1.PodGroupLister, unit framework.ScheduleUnit) bool {
+	if unit.Type() != framework.PodGroupUnitType {
+		return true
+	}
+	reason, message, ok := unitutil.CheckDependencies(unit.GetNamespace(), unit.GetName(), unit.GetAnnotations(), func(namespace, name string) *schedulingv1a1.PodGroup {
+		if pgLister == nil {
+			return nil
+		}
+		pg, err := pgLister.PodGroups(namespace).Get(name)
+		if err != nil {
+			return nil
+		}
+		return pg
+	})
+	if !ok {
+		klog.V(5).InfoS("Unit is blocked by its dependencies", "unitKey", unit.GetKey(), "reason", reason, "message", message)
+	}
+	return ok
+}
+
 func unitInfoKeyFunc(obj interface{}) (string, error) {
 	unitInfo := obj.(*framework.QueuedUnitInfo)
 	return unitInfo.UnitKey, nil
@@ -103,12 +128,12 @@ func alwaysFalse(_, _ interface{}) bool {
 	return false
 }
 
-func InitUnitQueueSortPlugin(spec *framework.PluginSpec, pluginArgs map[string]*config.PluginConfig) (framework.UnitQueueSortPlugin, error) {
+func InitUnitQueueSortPlugin(registry UnitSortPluginRegistry, spec *framework.PluginSpec, pluginArgs map[string]*config.PluginConfig) (framework.UnitQueueSortPlugin, error) {
 	if spec == nil {
 		return nil, fmt.Errorf("queue unit sort plugin not specified")
 	}
 	plName := spec.GetName()
-	factory, ok := UnitSortPluginRegistry[plName]
+	factory, ok := registry[plName]
 	if !ok {
 		return nil, fmt.Errorf("unregiestered queue unit sort plugin: %v", plName)
 	}
@@ -120,7 +145,25 @@ func InitUnitQueueSortPlugin(spec *framework.PluginSpec, pluginArgs map[string]*
 
 type SortPluginFactory = func(runtime.Object) (framework.UnitQueueSortPlugin, error)
 
-var UnitSortPluginRegistry = map[string]SortPluginFactory{
-	unitqueuesort.FCFSName: unitqueuesort.NewFCFS,
-	unitqueuesort.Name:     unitqueuesort.New,
+// UnitSortPluginRegistry is a collection of all available unit queue sort plugins.
+type UnitSortPluginRegistry map[string]SortPluginFactory
+
+// Merge merges the provided registry to the current one, it returns an error
+// if a plugin in the provided registry is already registered.
+func (r UnitSortPluginRegistry) Merge(in UnitSortPluginRegistry) error {
+	for name, factory := range in {
+		if _, ok := r[name]; ok {
+			return fmt.Errorf("a queue sort plugin named %v already exists", name)
+		}
+		r[name] = factory
+	}
+	return nil
+}
+
+// NewInTreeUnitSortPluginRegistry builds the registry with all the in-tree unit queue sort plugins.
+func NewInTreeUnitSortPluginRegistry() UnitSortPluginRegistry {
+	return UnitSortPluginRegistry{
+		unitqueuesort.FCFSName: unitqueuesort.NewFCFS,
+		unitqueuesort.Name:     unitqueuesort.New,
+	}
 }
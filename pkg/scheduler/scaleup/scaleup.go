@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleup
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/klog/v2"
+
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/metrics"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+const (
+	// ResultRecommended means a node template makes the unit schedulable.
+	ResultRecommended = "recommended"
+	// ResultNoNodeTemplateFits means none of the node templates makes the unit schedulable.
+	ResultNoNodeTemplateFits = "noNodeTemplateFits"
+
+	// defaultMaxPods is the pod capacity of the hypothetical nodes if the template doesn't specify one.
+	defaultMaxPods = 110
+
+	// recomputeInterval is the min interval to simulate the same unit again, the recommendation is reused
+	// in between unless the number of pending pods changes.
+	recomputeInterval = time.Minute
+	// cacheExpiration is the time after which the recommendation of a unit that is not attempted anymore is dropped.
+	cacheExpiration = 10 * recomputeInterval
+)
+
+// FitFunc reports whether the pod fits in the node, it is usually backed by the filter plugins of the scheduler.
+type FitFunc func(pod *v1.Pod, nodeInfo framework.NodeInfo) bool
+
+// Recommendation is the scale-up hint of a unit, it is published in the message of the pod condition.
+type Recommendation struct {
+	// NodeTemplate is the name of the template to scale up, it is empty if none of the templates fits.
+	NodeTemplate string `json:"nodeTemplate,omitempty"`
+	// NodeCount is the number of nodes to add from the template.
+	NodeCount int `json:"nodeCount,omitempty"`
+	// Pods is the number of pending pods the recommendation is made for.
+	Pods int `json:"pods"`
+}
+
+// Recommender simulates long pending units against hypothetical nodes built from the node templates.
+type Recommender struct {
+	clock            clock.Clock
+	pendingThreshold time.Duration
+	templates        []config.NodeTemplate
+
+	mu sync.Mutex
+	// recommendations caches the latest recommendation of each unit, keyed by unit key.
+	recommendations map[string]*cachedRecommendation
+	lastCleanup     time.Time
+}
+
+type cachedRecommendation struct {
+	recommendation *Recommendation
+	timestamp      time.Time
+}
+
+// NewRecommender returns nil if scale-up hints are not configured, all the methods of a nil Recommender are no-ops.
+func NewRecommender(cfg *config.ScaleUpHintConfig, clock clock.Clock) *Recommender {
+	if cfg == nil {
+		return nil
+	}
+	pendingThresholdSeconds := cfg.PendingThresholdSeconds
+	if pendingThresholdSeconds == 0 {
+		pendingThresholdSeconds = config.DefaultScaleUpHintPendingThresholdSeconds
+	}
+	return &Recommender{
+		clock:            clock,
+		pendingThreshold: time.Duration(pendingThresholdSeconds) * time.Second,
+		templates:        cfg.NodeTemplates,
+		recommendations:  make(map[string]*cachedRecommendation),
+	}
+}
+
+// Recommend returns the scale-up hint of the pending pods of the unit, it returns nil if the unit hasn't been
+// pending for long enough. The pods are the ones not fitting in the existing nodes. The template needing the
+// fewest nodes is recommended, ties go to the one configured first. The simulation of the same unit is done
+// at most once per recomputeInterval unless the number of pending pods changes.
+func (r *Recommender) Recommend(unit *framework.QueuedUnitInfo, pods []*v1.Pod, fits FitFunc) *Recommendation {
+	if r == nil || unit == nil || len(pods) == 0 {
+		return nil
+	}
+	now := r.clock.Now()
+	if now.Sub(unit.InitialAttemptTimestamp) < r.pendingThreshold {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cleanup(now)
+	if cached, ok := r.recommendations[unit.UnitKey]; ok && cached.recommendation.Pods == len(pods) && now.Sub(cached.timestamp) < recomputeInterval {
+		return cached.recommendation
+	}
+
+	recommendation := &Recommendation{Pods: len(pods)}
+	for i := range r.templates {
+		count, ok := simulate(&r.templates[i], pods, fits)
+		if !ok {
+			continue
+		}
+		if recommendation.NodeTemplate == "" || count < recommendation.NodeCount {
+			recommendation.NodeTemplate, recommendation.NodeCount = r.templates[i].Name, count
+		}
+	}
+
+	result := ResultRecommended
+	if recommendation.NodeTemplate == "" {
+		result = ResultNoNodeTemplateFits
+	}
+	klog.V(4).InfoS("Computed scale-up hint for pending unit", "unitKey", unit.UnitKey, "result", result,
+		"nodeTemplate", recommendation.NodeTemplate, "nodeCount", recommendation.NodeCount, "pods", recommendation.Pods)
+	metrics.UnitScaleUpHintInc(unit.GetUnitProperty(), result)
+	r.recommendations[unit.UnitKey] = &cachedRecommendation{recommendation: recommendation, timestamp: now}
+	return recommendation
+}
+
+// Forget drops the cached recommendation of the unit, it should be called once the unit is scheduled.
+func (r *Recommender) Forget(unitKey string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.recommendations, unitKey)
+}
+
+// cleanup drops the recommendations of the units which are not attempted for cacheExpiration.
+// ATTENTION: r.mu must be held.
+func (r *Recommender) cleanup(now time.Time) {
+	if now.Sub(r.lastCleanup) < recomputeInterval {
+		return
+	}
+	r.lastCleanup = now
+	for unitKey, cached := range r.recommendations {
+		if now.Sub(cached.timestamp) >= cacheExpiration {
+			delete(r.recommendations, unitKey)
+		}
+	}
+}
+
+// PodCondition converts the recommendation to the pod condition published on the pending pods.
+func (rec *Recommendation) PodCondition() *v1.PodCondition {
+	if rec == nil {
+		return nil
+	}
+	condition := &v1.PodCondition{
+		Type:   podutil.ScaleUpRecommendationCondition,
+		Status: v1.ConditionTrue,
+		Reason: podutil.ScaleUpRecommendedReason,
+	}
+	if rec.NodeTemplate == "" {
+		condition.Status, condition.Reason = v1.ConditionFalse, podutil.NoNodeTemplateFitsReason
+	}
+	if message, err := json.Marshal(rec); err == nil {
+		condition.Message = string(message)
+	}
+	return condition
+}
+
+// simulate places the pods onto hypothetical nodes of the template first fit, and returns the number of nodes
+// needed. It returns false if some pod doesn't fit in an empty node or the template runs out of nodes.
+func simulate(template *config.NodeTemplate, pods []*v1.Pod, fits FitFunc) (int, bool) {
+	var nodeInfos []framework.NodeInfo
+	for _, pod := range pods {
+		placed := false
+		for _, nodeInfo := range nodeInfos {
+			if fits(pod, nodeInfo) {
+				nodeInfo.AddPod(pod)
+				placed = true
+				break
+			}
+		}
+		if placed {
+			continue
+		}
+		if template.MaxCount > 0 && len(nodeInfos) >= int(template.MaxCount) {
+			return 0, false
+		}
+		nodeInfo := framework.NewNodeInfo()
+		if err := nodeInfo.SetNode(newNode(template, len(nodeInfos))); err != nil {
+			klog.InfoS("Failed to build node from template", "nodeTemplate", template.Name, "err", err)
+			return 0, false
+		}
+		if !fits(pod, nodeInfo) {
+			return 0, false
+		}
+		nodeInfo.AddPod(pod)
+		nodeInfos = append(nodeInfos, nodeInfo)
+	}
+	return len(nodeInfos), true
+}
+
+// newNode builds a ready node from the template.
+func newNode(template *config.NodeTemplate, index int) *v1.Node {
+	name := fmt.Sprintf("%s-hint-%d", template.Name, index)
+	labels := make(map[string]string, len(template.Labels)+1)
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+	if _, ok := labels[v1.LabelHostname]; !ok {
+		labels[v1.LabelHostname] = name
+	}
+	allocatable := template.Allocatable.DeepCopy()
+	if _, ok := allocatable[v1.ResourcePods]; !ok {
+		allocatable[v1.ResourcePods] = *resource.NewQuantity(defaultMaxPods, resource.DecimalSI)
+	}
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       v1.NodeSpec{Taints: template.Taints},
+		Status: v1.NodeStatus{
+			Capacity:    allocatable,
+			Allocatable: allocatable,
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaleup
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
+	podutil "github.com/kubewharf/godel-scheduler/pkg/util/pod"
+)
+
+var testStartTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func makePod(name string, milliCPU int64, nodeSelector map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			UID:       types.UID(name),
+			Annotations: map[string]string{
+				podutil.PodLauncherAnnotationKey:     string(podutil.Kubelet),
+				podutil.PodResourceTypeAnnotationKey: string(podutil.GuaranteedPod),
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeSelector: nodeSelector,
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceCPU: *resource.NewMilliQuantity(milliCPU, resource.DecimalSI),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func makeUnit(name string, numPods int, milliCPU int64, nodeSelector map[string]string) *framework.QueuedUnitInfo {
+	pg := &v1alpha1.PodGroup{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	pg.Spec.MinMember = int32(numPods)
+	unit := framework.NewPodGroupUnit(pg, 100)
+	for i := 0; i < numPods; i++ {
+		unit.AddPod(&framework.QueuedPodInfo{Pod: makePod(name+"-"+strconv.Itoa(i), milliCPU, nodeSelector)})
+	}
+	return &framework.QueuedUnitInfo{
+		UnitKey:                 "podgroup/default/" + name,
+		ScheduleUnit:            unit,
+		InitialAttemptTimestamp: testStartTime,
+	}
+}
+
+func unitPods(unit *framework.QueuedUnitInfo) []*v1.Pod {
+	var pods []*v1.Pod
+	for _, podInfo := range unit.GetPods() {
+		pods = append(pods, podInfo.Pod)
+	}
+	return pods
+}
+
+func makeTemplate(name string, milliCPU int64, maxCount int32, labels map[string]string) config.NodeTemplate {
+	return config.NodeTemplate{
+		Name:        name,
+		Labels:      labels,
+		Allocatable: v1.ResourceList{v1.ResourceCPU: *resource.NewMilliQuantity(milliCPU, resource.DecimalSI)},
+		MaxCount:    maxCount,
+	}
+}
+
+// fitsByCPUAndSelector stands in for the filter plugins, it checks the cpu and the node selector of the pod.
+func fitsByCPUAndSelector(pod *v1.Pod, nodeInfo framework.NodeInfo) bool {
+	node := nodeInfo.GetNode()
+	for k, v := range pod.Spec.NodeSelector {
+		if node.Labels[k] != v {
+			return false
+		}
+	}
+	request := pod.Spec.Containers[0].Resources.Requests.Cpu().MilliValue()
+	return nodeInfo.GetGuaranteedRequested().MilliCPU+request <= nodeInfo.GetGuaranteedAllocatable().MilliCPU
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name         string
+		templates    []config.NodeTemplate
+		unit         *framework.QueuedUnitInfo
+		pendingFor   time.Duration
+		expected     *Recommendation
+		expectedCond v1.ConditionStatus
+	}{
+		{
+			name:       "unit not pending for long enough",
+			templates:  []config.NodeTemplate{makeTemplate("small", 2000, 0, nil)},
+			unit:       makeUnit("pg", 4, 2000, nil),
+			pendingFor: time.Minute,
+		},
+		{
+			name: "template needing the fewest nodes",
+			templates: []config.NodeTemplate{
+				makeTemplate("small", 2000, 0, nil),
+				makeTemplate("large", 8000, 0, nil),
+			},
+			unit:         makeUnit("pg", 4, 2000, nil),
+			pendingFor:   10 * time.Minute,
+			expected:     &Recommendation{NodeTemplate: "large", NodeCount: 1, Pods: 4},
+			expectedCond: v1.ConditionTrue,
+		},
+		{
+			name: "ties go to the template configured first",
+			templates: []config.NodeTemplate{
+				makeTemplate("a", 4000, 0, nil),
+				makeTemplate("b", 6000, 0, nil),
+			},
+			unit:         makeUnit("pg", 4, 2000, nil),
+			pendingFor:   10 * time.Minute,
+			expected:     &Recommendation{NodeTemplate: "a", NodeCount: 2, Pods: 4},
+			expectedCond: v1.ConditionTrue,
+		},
+		{
+			name: "template out of nodes",
+			templates: []config.NodeTemplate{
+				makeTemplate("small", 2000, 3, nil),
+				makeTemplate("medium", 4000, 2, nil),
+			},
+			unit:         makeUnit("pg", 4, 2000, nil),
+			pendingFor:   10 * time.Minute,
+			expected:     &Recommendation{NodeTemplate: "medium", NodeCount: 2, Pods: 4},
+			expectedCond: v1.ConditionTrue,
+		},
+		{
+			name: "only the template matching the node selector fits",
+			templates: []config.NodeTemplate{
+				makeTemplate("cpu", 8000, 0, map[string]string{"pool": "cpu"}),
+				makeTemplate("gpu", 4000, 0, map[string]string{"pool": "gpu"}),
+			},
+			unit:         makeUnit("pg", 4, 2000, map[string]string{"pool": "gpu"}),
+			pendingFor:   10 * time.Minute,
+			expected:     &Recommendation{NodeTemplate: "gpu", NodeCount: 2, Pods: 4},
+			expectedCond: v1.ConditionTrue,
+		},
+		{
+			name: "pods larger than any template",
+			templates: []config.NodeTemplate{
+				makeTemplate("small", 2000, 0, nil),
+			},
+			unit:         makeUnit("pg", 2, 4000, nil),
+			pendingFor:   10 * time.Minute,
+			expected:     &Recommendation{Pods: 2},
+			expectedCond: v1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecommender(&config.ScaleUpHintConfig{NodeTemplates: tt.templates}, clock.NewFakeClock(testStartTime.Add(tt.pendingFor)))
+			got := r.Recommend(tt.unit, unitPods(tt.unit), fitsByCPUAndSelector)
+			if tt.expected == nil {
+				if got != nil {
+					t.Fatalf("expected no recommendation, got %+v", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.expected {
+				t.Fatalf("expected recommendation %+v, got %+v", tt.expected, got)
+			}
+
+			condition := got.PodCondition()
+			if condition.Type != podutil.ScaleUpRecommendationCondition || condition.Status != tt.expectedCond {
+				t.Errorf("unexpected condition %+v", condition)
+			}
+			var message Recommendation
+			if err := json.Unmarshal([]byte(condition.Message), &message); err != nil || message != *got {
+				t.Errorf("expected condition message %+v, got %s", got, condition.Message)
+			}
+		})
+	}
+}
+
+func TestNilRecommender(t *testing.T) {
+	r := NewRecommender(nil, clock.NewFakeClock(testStartTime))
+	if r != nil {
+		t.Fatalf("expected nil recommender")
+	}
+	if got := r.Recommend(makeUnit("pg", 1, 1000, nil), unitPods(makeUnit("pg", 1, 1000, nil)), fitsByCPUAndSelector); got != nil {
+		t.Errorf("expected no recommendation, got %+v", got)
+	}
+	if got := r.Recommend(nil, nil, nil).PodCondition(); got != nil {
+		t.Errorf("expected no condition, got %+v", got)
+	}
+}
+
+func TestRecommendCache(t *testing.T) {
+	fakeClock := clock.NewFakeClock(testStartTime.Add(10 * time.Minute))
+	r := NewRecommender(&config.ScaleUpHintConfig{
+		NodeTemplates: []config.NodeTemplate{makeTemplate("cpu", 8000, 0, nil)},
+	}, fakeClock)
+
+	simulations := 0
+	fits := func(pod *v1.Pod, nodeInfo framework.NodeInfo) bool {
+		simulations++
+		return fitsByCPUAndSelector(pod, nodeInfo)
+	}
+	unit := makeUnit("pg", 4, 4000, nil)
+	pods := unitPods(unit)
+
+	expectSimulated := func(name string, pods []*v1.Pod, simulated bool, expected Recommendation) {
+		before := simulations
+		got := r.Recommend(unit, pods, fits)
+		if got == nil || *got != expected {
+			t.Fatalf("%s: expected recommendation %+v, got %+v", name, expected, got)
+		}
+		if (simulations > before) != simulated {
+			t.Fatalf("%s: expected simulated %v, got %v", name, simulated, simulations > before)
+		}
+	}
+
+	expectSimulated("first attempt", pods, true, Recommendation{NodeTemplate: "cpu", NodeCount: 2, Pods: 4})
+	expectSimulated("cached", pods, false, Recommendation{NodeTemplate: "cpu", NodeCount: 2, Pods: 4})
+	expectSimulated("pending pods changed", pods[:1], true, Recommendation{NodeTemplate: "cpu", NodeCount: 1, Pods: 1})
+
+	fakeClock.Step(recomputeInterval)
+	expectSimulated("cache expired", pods[:1], true, Recommendation{NodeTemplate: "cpu", NodeCount: 1, Pods: 1})
+
+	r.Forget(unit.UnitKey)
+	expectSimulated("forgotten", pods[:1], true, Recommendation{NodeTemplate: "cpu", NodeCount: 1, Pods: 1})
+
+	fakeClock.Step(cacheExpiration)
+	r.Recommend(makeUnit("other", 1, 1000, nil), unitPods(makeUnit("other", 1, 1000, nil)), fits)
+	if _, ok := r.recommendations[unit.UnitKey]; ok {
+		t.Errorf("expected the recommendation of %s to be purged", unit.UnitKey)
+	}
+}
@@ -38,8 +38,10 @@ import (
 	"k8s.io/klog/v2"
 
 	commoncache "github.com/kubewharf/godel-scheduler/pkg/common/cache"
+	commondebugger "github.com/kubewharf/godel-scheduler/pkg/common/cache/debugger"
 	"github.com/kubewharf/godel-scheduler/pkg/features"
 	framework "github.com/kubewharf/godel-scheduler/pkg/framework/api"
+	"github.com/kubewharf/godel-scheduler/pkg/plugins/nodepool"
 	"github.com/kubewharf/godel-scheduler/pkg/scheduler/apis/config"
 	godelcache "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache"
 	preemptionstore "github.com/kubewharf/godel-scheduler/pkg/scheduler/cache/commonstores/preemption_store"
@@ -75,6 +77,7 @@ type Scheduler struct {
 	informerFactory    informers.SharedInformerFactory
 	crdInformerFactory crdinformers.SharedInformerFactory
 	options            schedulerOptions
+	registries         *registries
 
 	podLister corelisters.PodLister
 	pgLister  v1alpha1.PodGroupLister
@@ -116,6 +119,10 @@ func New(
 		stopEverything = wait.NeverStop
 	}
 	options := renderOptions(opts...)
+	registries, err := options.buildRegistries()
+	if err != nil {
+		return nil, err
+	}
 	globalClock := clock.RealClock{}
 
 	podLister := informerFactory.Core().V1().Pods().Lister()
@@ -127,8 +134,9 @@ func New(
 
 	handlerWrapper := commoncache.MakeCacheHandlerWrapper().
 		ComponentName(godelSchedulerName).SchedulerType(*schedulerName).SubCluster(framework.DefaultSubCluster).
-		PodAssumedTTL(15 * time.Minute).Period(10 * time.Second).ReservationTTL(reservationTTL).StopCh(stopEverything).
-		PodLister(podLister).PodInformer(podInformer).PVCLister(pvcLister)
+		PodAssumedTTL(15*time.Minute).Period(10*time.Second).ReservationTTL(reservationTTL).StopCh(stopEverything).
+		PodLister(podLister).PodInformer(podInformer).PVCLister(pvcLister).
+		PodMetricsLister(options.podMetricsLister, options.podMetricsSamplePeriod)
 	if mayHasPreemption {
 		handlerWrapper.EnableStore(string(preemptionstore.Name))
 	}
@@ -145,6 +153,7 @@ func New(
 		informerFactory:        informerFactory,
 		crdInformerFactory:     crdInformerFactory,
 		options:                options,
+		registries:             registries,
 
 		podLister: podLister,
 		pgLister:  pgLister,
@@ -222,9 +231,26 @@ func (sched *Scheduler) Run(ctx context.Context) {
 		defer sched.movementController.Close()
 	}
 
+	if sched.options.cacheComparePeriod > 0 {
+		cachedebugger.NewPeriodicComparer(
+			sched.informerFactory.Core().V1().Nodes().Lister(),
+			sched.podLister,
+			sched.commonCache,
+			sched.assumedOrBoundPod,
+			sched.recorder,
+			sched.Name,
+			sched.options.enableCacheSelfHealing,
+		).Run(sched.options.cacheComparePeriod, ctx.Done())
+	}
+
 	sched.ScheduleSwitch.Run(ctx)
 }
 
+// CacheInspector returns the inspector exposing the content of scheduler cache.
+func (sched *Scheduler) CacheInspector() *commondebugger.CacheInspector {
+	return commondebugger.NewCacheInspector(sched.commonCache)
+}
+
 func (sched *Scheduler) createDataSet(idx int, subCluster string, switchType framework.SwitchType) ScheduleDataSet {
 	var subClusterConfig *subClusterConfig
 	if profile, ok := sched.options.subClusterProfiles[subCluster]; ok {
@@ -239,7 +265,7 @@ func (sched *Scheduler) createDataSet(idx int, subCluster string, switchType fra
 		pluginArg := subClusterConfig.PluginConfigs[index]
 		pluginArgs[pluginArg.Name] = &pluginArg
 	}
-	unitQueueSortPlugin, err := godelqueue.InitUnitQueueSortPlugin(subClusterConfig.UnitQueueSortPlugin, pluginArgs)
+	unitQueueSortPlugin, err := godelqueue.InitUnitQueueSortPlugin(sched.registries.queueSortPluginRegistry, subClusterConfig.UnitQueueSortPlugin, pluginArgs)
 	if err != nil {
 		panic(err)
 	}
@@ -248,6 +274,16 @@ func (sched *Scheduler) createDataSet(idx int, subCluster string, switchType fra
 		pluginArgs := subClusterConfig.PreemptionPluginConfigs[index]
 		preemptionPluginArgs[pluginArgs.Name] = &pluginArgs
 	}
+	// NodePoolChecker honors the same node pools as NodePool, so that they can't drift apart.
+	delete(preemptionPluginArgs, nodepool.NodePoolCheckerName)
+	if nodePoolArgs, ok := pluginArgs[nodepool.Name]; ok {
+		preemptionPluginArgs[nodepool.NodePoolCheckerName] = nodePoolArgs
+	}
+	unitPluginArgs := make(map[string]*config.PluginConfig)
+	for index := range subClusterConfig.UnitPluginConfigs {
+		pluginArgs := subClusterConfig.UnitPluginConfigs[index]
+		unitPluginArgs[pluginArgs.Name] = &pluginArgs
+	}
 
 	handler := commoncache.MakeCacheHandlerWrapper().
 		SubCluster(subCluster).SwitchType(switchType).
@@ -272,6 +308,8 @@ func (sched *Scheduler) createDataSet(idx int, subCluster string, switchType fra
 		subClusterConfig.PercentageOfNodesToScore,
 		subClusterConfig.IncreasedPercentageOfNodesToScore,
 		subClusterConfig.BasePlugins,
+		sched.registries.pluginRegistry,
+		sched.registries.preemptionPluginRegistry,
 		pluginArgs,
 		preemptionPluginArgs,
 	)
@@ -304,6 +342,11 @@ func (sched *Scheduler) createDataSet(idx int, subCluster string, switchType fra
 		schedulingQueue,
 		reconciler,
 		podScheduler,
+		sched.registries.unitPluginRegistry,
+		subClusterConfig.UnitPlugins,
+		unitPluginArgs,
+		subClusterConfig.Backfill,
+		subClusterConfig.ScaleUpHint,
 		sched.clock,
 		sched.recorder,
 		time.Duration(subClusterConfig.MaxWaitingDeletionDuration)*time.Second,
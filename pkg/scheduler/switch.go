@@ -58,6 +58,7 @@ type ScheduleDataSet interface {
 
 	Snapshot() *cache.Snapshot
 	SchedulingQueue() queue.SchedulingQueue
+	UnitScheduler() core.UnitScheduler
 	ScheduleFunc() func(context.Context)
 }
 
@@ -157,6 +158,10 @@ func (s *ScheduleDataSetImpl) SchedulingQueue() queue.SchedulingQueue {
 	return s.schedulingQueue
 }
 
+func (s *ScheduleDataSetImpl) UnitScheduler() core.UnitScheduler {
+	return s.unitScheduler
+}
+
 func (s *ScheduleDataSetImpl) ScheduleFunc() func(context.Context) {
 	return s.unitScheduler.Schedule
 }
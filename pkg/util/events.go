@@ -71,6 +71,10 @@ const (
 	CSINodeAdd = "CSINodeAdd"
 	// CSINodeUpdate is the event when a CSI node is updated in the cluster.
 	CSINodeUpdate = "CSINodeUpdate"
+	// CSIStorageCapacityAdd is the event when a CSIStorageCapacity is added in the cluster.
+	CSIStorageCapacityAdd = "CSIStorageCapacityAdd"
+	// CSIStorageCapacityUpdate is the event when a CSIStorageCapacity is updated in the cluster.
+	CSIStorageCapacityUpdate = "CSIStorageCapacityUpdate"
 	// NodeSpecUnschedulableChange is the event when unschedulable node spec is changed.
 	NodeSpecUnschedulableChange = "NodeSpecUnschedulableChange"
 	// NodeAllocatableChange is the event when node allocatable is changed.
@@ -57,6 +57,29 @@ func UpdatePreSchedulingCondition(pgcli pgclientset.Interface, pg *schedv1alpha1
 	return nil
 }
 
+// UpdatePendingCondition updates the PodGroupCondition which Phase is "Pending", or appends it if there is none.
+// It is used to expose why the PodGroup is held before being dispatched, nothing will be done if the PodGroup
+// has left the Pending phase or the condition is unchanged.
+func UpdatePendingCondition(pgcli pgclientset.Interface, pg *schedv1alpha1.PodGroup, cond schedv1alpha1.PodGroupCondition) error {
+	if pg.Status.Phase != "" && pg.Status.Phase != schedv1alpha1.PodGroupPending {
+		return nil
+	}
+
+	pgCopy := pg.DeepCopy()
+	for i := range pgCopy.Status.Conditions {
+		if pgCopy.Status.Conditions[i].Phase != schedv1alpha1.PodGroupPending {
+			continue
+		}
+		if existing := pgCopy.Status.Conditions[i]; existing.Reason == cond.Reason && existing.Message == cond.Message {
+			return nil
+		}
+		pgCopy.Status.Conditions[i] = cond
+		return PatchPodGroupCondition(pgcli, pg, pgCopy)
+	}
+	pgCopy.Status.Conditions = append(pgCopy.Status.Conditions, cond)
+	return PatchPodGroupCondition(pgcli, pg, pgCopy)
+}
+
 // PatchPodGroupCondition calculates the delta bytes change from <old> to <new>,
 // and then submit a request to API server to patch the podgroup changes.
 func PatchPodGroupCondition(crdCli pgclientset.Interface, old, new *schedv1alpha1.PodGroup) (err error) {
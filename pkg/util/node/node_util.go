@@ -22,6 +22,14 @@ const (
 	// GodelSchedulerNodeAnnotationKey is the annotation key in both Node and CNR api objects,
 	// value is the godel scheduler whose node partition contains this node
 	GodelSchedulerNodeAnnotationKey = "godel.bytedance.com/scheduler-name"
+
+	// ClusterAutoscalerScaleDownDisabledAnnotationKey is the node annotation key that stops cluster autoscaler
+	// from removing the node when its value is "true"
+	ClusterAutoscalerScaleDownDisabledAnnotationKey = "cluster-autoscaler.kubernetes.io/scale-down-disabled"
+
+	// ScaleDownDisabledByReservationAnnotationKey is the node annotation key marking that the scale down of the node
+	// is disabled by reservation controller because the node holds reservations, value is "true"
+	ScaleDownDisabledByReservationAnnotationKey = "godel.bytedance.com/scale-down-disabled-by-reservation"
 )
 
 func NodeOfThisScheduler(annotations map[string]string, schedulerName string) bool {
@@ -54,6 +54,17 @@ const (
 	// PodGroupNameAnnotationKey is pod annotation key, the value is name of PodGroup custom resource.
 	PodGroupNameAnnotationKey = "godel.bytedance.com/pod-group-name"
 
+	// PodRoleAnnotationKey is a pod annotation (or label) key, the value is the role of the pod in its PodGroup, e.g. ps or worker.
+	PodRoleAnnotationKey = "godel.bytedance.com/pod-role"
+
+	// RoleMinMemberAnnotationKey is a PodGroup annotation key, the value is the min member of each role in json, e.g. {"ps":1,"worker":4}.
+	// The min member of the PodGroup is still required, and it should be no less than the sum of the min member of all roles.
+	RoleMinMemberAnnotationKey = "godel.bytedance.com/role-min-member"
+
+	// AffinityRolesAnnotationKey is a PodGroup annotation key, the value is the comma separated roles that are co-located
+	// by the job level affinity. All the pods of the PodGroup are co-located if it is not set.
+	AffinityRolesAnnotationKey = "godel.bytedance.com/affinity-roles"
+
 	// PotentialVictimsAnnotationKey is a pod annotation key, value is the victims chosen by dispatcher
 	// this is used for best effort application pods
 	// values can be like: [{queue: queue1, application: app1}, {queue: queue2, application: app2}]...
@@ -73,9 +84,22 @@ const (
 	// PodLauncherAnnotationKey is a pod annotation key, value is the launcher of this pod (kubelet or node-manager)
 	PodLauncherAnnotationKey = "godel.bytedance.com/pod-launcher"
 
+	// NodeManagerNodeAnnotationKey is a pod annotation key set by the Annotation launcher of binder, value is the node
+	// that the node manager should launch the pod on
+	NodeManagerNodeAnnotationKey = "godel.bytedance.com/node-manager-node"
+
 	// InitialHandledTimestampAnnotationKey is a pod annotation key, value is the timestamp when the pod is first handled by Godel Scheduler
 	InitialHandledTimestampAnnotationKey = "godel.bytedance.com/initial-handled-timestamp"
 
+	// DispatchedTimestampAnnotationKey is a pod annotation key, value is the timestamp when the pod is dispatched to a scheduler by dispatcher
+	DispatchedTimestampAnnotationKey = "godel.bytedance.com/dispatched-timestamp"
+
+	// ScheduleStartedTimestampAnnotationKey is a pod annotation key, value is the timestamp when the scheduler starts the successful scheduling attempt of the pod
+	ScheduleStartedTimestampAnnotationKey = "godel.bytedance.com/schedule-started-timestamp"
+
+	// ScheduledTimestampAnnotationKey is a pod annotation key, value is the timestamp when the pod is assumed by scheduler
+	ScheduledTimestampAnnotationKey = "godel.bytedance.com/scheduled-timestamp"
+
 	// MicroTopologyKey is an annotation key for pod micro topology assigned by scheduler&binder
 	MicroTopologyKey = "godel.bytedance.com/micro-topology"
 
@@ -96,11 +120,28 @@ const (
 
 	// Pods with same request template share the same requirements.
 	PodRequestTemplateAnnotationKey = "godel.bytedance.com/request-template"
+	// ExpectedDurationAnnotationKey is the expected running duration of the pod in seconds, it is used to
+	// predict when the resources of the pod will be released.
+	ExpectedDurationAnnotationKey = "godel.bytedance.com/expected-duration-seconds"
+	// WorkloadClassAnnotationKey is a pod annotation key, value is the workload class (e.g. online, batch) which
+	// could be referenced by the preemption policy matrix.
+	WorkloadClassAnnotationKey = "godel.bytedance.com/workload-class"
 	// reservation related
 	MatchedReservationPlaceholderKey = "godel.bytedance.com/matched-reservation-placeholder"
 	ReservationTTLKey                = "godel.bytedance.com/reservation-ttl"
 )
 
+const (
+	// ScaleUpRecommendationCondition is a pod condition type set by scheduler on the pods of long pending units,
+	// the message is the json encoded recommendation of the nodes that make the unit schedulable.
+	ScaleUpRecommendationCondition v1.PodConditionType = "godel.bytedance.com/ScaleUpRecommendation"
+
+	// ScaleUpRecommendedReason means some nodes of a node template make the unit schedulable.
+	ScaleUpRecommendedReason = "ScaleUpRecommended"
+	// NoNodeTemplateFitsReason means none of the node templates makes the unit schedulable.
+	NoNodeTemplateFitsReason = "NoNodeTemplateFits"
+)
+
 type PodState string
 
 // please refer to the file: pod_state_machine.go in the same package for pod state change diagram.
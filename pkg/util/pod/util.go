@@ -39,6 +39,7 @@ import (
 	schedulingv1alpha1 "github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
 	"github.com/kubewharf/godel-scheduler/pkg/util"
 	"github.com/kubewharf/godel-scheduler/pkg/util/features"
+	"github.com/kubewharf/godel-scheduler/pkg/util/helper"
 	volumeutil "github.com/kubewharf/godel-scheduler/pkg/volume/persistentvolume/util"
 )
 
@@ -374,6 +375,17 @@ func UpdatePodCondition(status *v1.PodStatus, condition *v1.PodCondition) bool {
 	return !isEqual
 }
 
+// RemovePodCondition removes the pod condition of the given type from the status.
+// Returns true if the condition was present.
+func RemovePodCondition(status *v1.PodStatus, conditionType v1.PodConditionType) bool {
+	conditionIndex, _ := GetPodCondition(status, conditionType)
+	if conditionIndex < 0 {
+		return false
+	}
+	status.Conditions = append(status.Conditions[:conditionIndex], status.Conditions[conditionIndex+1:]...)
+	return true
+}
+
 // GetPodPriority returns priority of the given pod.
 func GetPodPriority(pod *v1.Pod) int32 {
 	if pod.Spec.Priority != nil {
@@ -385,6 +397,42 @@ func GetPodPriority(pod *v1.Pod) int32 {
 	return 0
 }
 
+// GetPodRole returns the role of the pod in its PodGroup, the annotation takes precedence over the label.
+func GetPodRole(pod *v1.Pod) string {
+	if role, ok := pod.Annotations[PodRoleAnnotationKey]; ok {
+		return role
+	}
+	return pod.Labels[PodRoleAnnotationKey]
+}
+
+// GetExpectedDuration returns the expected running duration of the given pod, false is returned if the
+// pod does not declare a valid one.
+func GetExpectedDuration(pod *v1.Pod) (time.Duration, bool) {
+	value, ok := pod.Annotations[ExpectedDurationAnnotationKey]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// GetExpectedEndTime returns the time when the given running pod is expected to finish, based on its start
+// time and expected running duration.
+func GetExpectedEndTime(pod *v1.Pod) (time.Time, bool) {
+	duration, ok := GetExpectedDuration(pod)
+	if !ok {
+		return time.Time{}, false
+	}
+	startTime := pod.CreationTimestamp.Time
+	if pod.Status.StartTime != nil {
+		startTime = pod.Status.StartTime.Time
+	}
+	return startTime.Add(duration), true
+}
+
 // GetPodResourceType return the resource type of the given pod
 // only Guaranteed and BestEffort are allowed.
 func GetPodResourceType(pod *v1.Pod) (PodResourceType, error) {
@@ -889,9 +937,13 @@ func CleanupPodAnnotations(client clientset.Interface, pod *v1.Pod) error {
 	delete(podCopy.Annotations, NominatedNodeAnnotationKey)
 	delete(podCopy.Annotations, FailedSchedulersAnnotationKey)
 	delete(podCopy.Annotations, MicroTopologyKey)
+	// the lifecycle timestamps of the previous scheduling attempt are stale
+	delete(podCopy.Annotations, ScheduleStartedTimestampAnnotationKey)
+	delete(podCopy.Annotations, ScheduledTimestampAnnotationKey)
 
-	// reset pod state to dispatched
+	// reset pod state to dispatched, the queueing stage restarts from now on
 	podCopy.Annotations[PodStateAnnotationKey] = string(PodDispatched)
+	podCopy.Annotations[DispatchedTimestampAnnotationKey] = time.Now().Format(helper.TimestampLayout)
 
 	return util.PatchPod(client, pod, podCopy)
 }
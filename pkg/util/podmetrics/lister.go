@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podmetrics
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s.io/client-go/rest"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// PodMetricsPath is the path of pod metrics served by metrics-server.
+const PodMetricsPath = "/apis/metrics.k8s.io/v1beta1/pods"
+
+// Lister lists the pod metrics of all namespaces from metrics.k8s.io. The metrics API doesn't
+// support watch, so it's expected to be called periodically.
+type Lister struct {
+	client rest.Interface
+}
+
+func NewLister(client rest.Interface) *Lister {
+	return &Lister{client: client}
+}
+
+func (l *Lister) List(ctx context.Context) ([]metricsv1beta1.PodMetrics, error) {
+	data, err := l.client.Get().AbsPath(PodMetricsPath).Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	list := &metricsv1beta1.PodMetricsList{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
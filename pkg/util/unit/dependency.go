@@ -0,0 +1,116 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+)
+
+const (
+	// DependsOnAnnotationKey declares the prerequisite PodGroups in the same namespace of a PodGroup, the value
+	// is comma separated PodGroup names with optional required phases, e.g. `ps-group,preprocess:Finished`.
+	// The unit is held until all the prerequisites reach the required phases. It is only supported on PodGroups,
+	// and a PodGroup can not depend on itself.
+	DependsOnAnnotationKey = "godel.bytedance.com/depends-on"
+
+	// DefaultDependencyPhase is used when the required phase of a prerequisite is not declared.
+	DefaultDependencyPhase = v1alpha1.PodGroupScheduled
+
+	// DependencyInvalid means the dependency declaration can not be parsed or the dependencies form a cycle.
+	DependencyInvalid = "InvalidDependency"
+	// DependencyNotFound means the prerequisite PodGroup does not exist.
+	DependencyNotFound = "PrerequisiteNotFound"
+	// DependencyPhaseNotReached means the prerequisite PodGroup has not reached the required phase.
+	DependencyPhaseNotReached = "PrerequisitePhaseNotReached"
+)
+
+// podGroupPhaseOrder is the order of the phases a PodGroup goes through, the phases out of it,
+// e.g. Timeout and Failed, never satisfy a dependency.
+var podGroupPhaseOrder = map[v1alpha1.PodGroupPhase]int{
+	v1alpha1.PodGroupPending:       0,
+	v1alpha1.PodGroupPreScheduling: 1,
+	v1alpha1.PodGroupScheduled:     2,
+	v1alpha1.PodGroupRunning:       3,
+	v1alpha1.PodGroupFinished:      4,
+}
+
+// Dependency means the unit depends on the PodGroup to reach the phase.
+type Dependency struct {
+	PodGroup string
+	Phase    v1alpha1.PodGroupPhase
+}
+
+// ParseDependencies parses the dependencies from the annotations of the PodGroup with the given name.
+func ParseDependencies(name string, annotations map[string]string) ([]Dependency, error) {
+	value, ok := annotations[DependsOnAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var dependencies []Dependency
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); len(item) == 0 {
+			continue
+		}
+		dependency := Dependency{PodGroup: item, Phase: DefaultDependencyPhase}
+		if i := strings.Index(item, ":"); i >= 0 {
+			dependency.PodGroup, dependency.Phase = item[:i], v1alpha1.PodGroupPhase(item[i+1:])
+		}
+		if len(dependency.PodGroup) == 0 {
+			return nil, fmt.Errorf("empty prerequisite PodGroup name in %q", value)
+		}
+		if dependency.PodGroup == name {
+			return nil, fmt.Errorf("PodGroup %s can not depend on itself", name)
+		}
+		if _, ok := podGroupPhaseOrder[dependency.Phase]; !ok {
+			return nil, fmt.Errorf("unsupported phase %q of prerequisite PodGroup %s", dependency.Phase, dependency.PodGroup)
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, nil
+}
+
+// PodGroupPhaseReached checks whether the current phase is the required phase or a later one.
+func PodGroupPhaseReached(current, required v1alpha1.PodGroupPhase) bool {
+	currentOrder, ok := podGroupPhaseOrder[current]
+	if !ok {
+		return false
+	}
+	return currentOrder >= podGroupPhaseOrder[required]
+}
+
+// CheckDependencies checks whether all the prerequisites of the unit declared in the annotations have reached
+// the required phases. If not, the reason and a readable message are returned for the first blocking one.
+func CheckDependencies(namespace, name string, annotations map[string]string, getPodGroup func(namespace, name string) *v1alpha1.PodGroup) (string, string, bool) {
+	dependencies, err := ParseDependencies(name, annotations)
+	if err != nil {
+		return DependencyInvalid, err.Error(), false
+	}
+	for _, dependency := range dependencies {
+		pg := getPodGroup(namespace, dependency.PodGroup)
+		if pg == nil {
+			return DependencyNotFound, fmt.Sprintf("prerequisite PodGroup %s/%s is not found", namespace, dependency.PodGroup), false
+		}
+		if !PodGroupPhaseReached(pg.Status.Phase, dependency.Phase) {
+			return DependencyPhaseNotReached, fmt.Sprintf("prerequisite PodGroup %s/%s is in phase %q, waiting for phase %q",
+				namespace, dependency.PodGroup, pg.Status.Phase, dependency.Phase), false
+		}
+	}
+	return "", "", true
+}
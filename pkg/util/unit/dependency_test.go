@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Godel Scheduler Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	podGroups := map[string]*v1alpha1.PodGroup{}
+	for name, phase := range map[string]v1alpha1.PodGroupPhase{
+		"ps":         v1alpha1.PodGroupScheduled,
+		"preprocess": v1alpha1.PodGroupRunning,
+		"timeout":    v1alpha1.PodGroupTimeout,
+	} {
+		podGroups["default/"+name] = &v1alpha1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Status:     v1alpha1.PodGroupStatus{Phase: phase},
+		}
+	}
+	getPodGroup := func(namespace, name string) *v1alpha1.PodGroup {
+		return podGroups[namespace+"/"+name]
+	}
+
+	tests := []struct {
+		name           string
+		dependsOn      *string
+		expectedReason string
+		expectedOK     bool
+	}{
+		{
+			name:       "no dependencies",
+			expectedOK: true,
+		},
+		{
+			name:       "default phase reached",
+			dependsOn:  stringPtr("ps"),
+			expectedOK: true,
+		},
+		{
+			name:       "later phase reached",
+			dependsOn:  stringPtr("ps, preprocess:Scheduled"),
+			expectedOK: true,
+		},
+		{
+			name:           "required phase not reached",
+			dependsOn:      stringPtr("ps,preprocess:Finished"),
+			expectedReason: DependencyPhaseNotReached,
+		},
+		{
+			name:           "phases out of order never satisfy dependencies",
+			dependsOn:      stringPtr("timeout:Pending"),
+			expectedReason: DependencyPhaseNotReached,
+		},
+		{
+			name:           "prerequisite not found",
+			dependsOn:      stringPtr("ps,worker"),
+			expectedReason: DependencyNotFound,
+		},
+		{
+			name:           "unsupported phase",
+			dependsOn:      stringPtr("ps:Timeout"),
+			expectedReason: DependencyInvalid,
+		},
+		{
+			name:           "empty name",
+			dependsOn:      stringPtr(":Scheduled"),
+			expectedReason: DependencyInvalid,
+		},
+		{
+			name:           "self reference",
+			dependsOn:      stringPtr("ps,worker-group"),
+			expectedReason: DependencyInvalid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tt.dependsOn != nil {
+				annotations[DependsOnAnnotationKey] = *tt.dependsOn
+			}
+			reason, _, ok := CheckDependencies("default", "worker-group", annotations, getPodGroup)
+			if ok != tt.expectedOK || reason != tt.expectedReason {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expectedReason, tt.expectedOK, reason, ok)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
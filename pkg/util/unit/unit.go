@@ -17,6 +17,7 @@ limitations under the License.
 package unit
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/kubewharf/godel-scheduler-api/pkg/apis/scheduling/v1alpha1"
@@ -28,6 +29,37 @@ import (
 
 const (
 	PodGroupFinalOpLock = "godel.bytedance.com/podgroup-final-op-lock"
+
+	// PodGroupRecoveryPolicyAnnotationKey specifies how a scheduled PodGroup reacts when some of its
+	// members fail or are evicted and the remaining members drop below minMember.
+	PodGroupRecoveryPolicyAnnotationKey = "godel.bytedance.com/pod-group-recovery-policy"
+	// PodGroupRecoveryTimeoutSecondsAnnotationKey specifies how long a PodGroup with the `WaitForReplacement`
+	// policy waits for replacement members before it is marked as Failed.
+	PodGroupRecoveryTimeoutSecondsAnnotationKey = "godel.bytedance.com/pod-group-recovery-timeout-seconds"
+	// PodGroupDegradedSinceAnnotationKey records the time (RFC3339) since when the PodGroup has been degraded.
+	PodGroupDegradedSinceAnnotationKey = "godel.bytedance.com/pod-group-degraded-since"
+	// PodGroupRestartedAtAnnotationKey records the time (RFC3339) of the latest gang restart, it is used to
+	// restart the schedule timeout of the PodGroup.
+	PodGroupRestartedAtAnnotationKey = "godel.bytedance.com/pod-group-restarted-at"
+	// PodGroupRestartCountAnnotationKey records how many times the gang has been restarted.
+	PodGroupRestartCountAnnotationKey = "godel.bytedance.com/pod-group-restart-count"
+)
+
+// PodGroupRecoveryPolicy describes the action taken when a scheduled PodGroup loses members.
+type PodGroupRecoveryPolicy string
+
+const (
+	// PodGroupRecoveryNone keeps the legacy behavior, the PodGroup will not react to member failures.
+	PodGroupRecoveryNone PodGroupRecoveryPolicy = ""
+	// PodGroupRecoveryRestart deletes all remaining members so that the whole gang is recreated and rescheduled.
+	PodGroupRecoveryRestart PodGroupRecoveryPolicy = "Restart"
+	// PodGroupRecoveryWaitForReplacement waits for replacement members until the recovery timeout.
+	PodGroupRecoveryWaitForReplacement PodGroupRecoveryPolicy = "WaitForReplacement"
+	// PodGroupRecoveryFail marks the PodGroup as Failed directly.
+	PodGroupRecoveryFail PodGroupRecoveryPolicy = "Fail"
+
+	// DefaultPodGroupRecoveryTimeout is used when `WaitForReplacement` is set without a valid timeout.
+	DefaultPodGroupRecoveryTimeout = 5 * time.Minute
 )
 
 // TODO: move to util package
@@ -103,3 +135,46 @@ func PodGroupFinalOp(pg *v1alpha1.PodGroup) string {
 func PodGroupFinalState(p v1alpha1.PodGroupPhase) bool {
 	return p == v1alpha1.PodGroupScheduled || p == v1alpha1.PodGroupTimeout
 }
+
+// GetPodGroupRecoveryPolicy returns the recovery policy of the PodGroup, unknown values are treated as PodGroupRecoveryNone.
+func GetPodGroupRecoveryPolicy(pg *v1alpha1.PodGroup) PodGroupRecoveryPolicy {
+	if pg == nil {
+		return PodGroupRecoveryNone
+	}
+	return ParsePodGroupRecoveryPolicy(pg.Annotations)
+}
+
+// ParsePodGroupRecoveryPolicy parses the recovery policy from PodGroup annotations.
+func ParsePodGroupRecoveryPolicy(annotations map[string]string) PodGroupRecoveryPolicy {
+	switch policy := PodGroupRecoveryPolicy(annotations[PodGroupRecoveryPolicyAnnotationKey]); policy {
+	case PodGroupRecoveryRestart, PodGroupRecoveryWaitForReplacement, PodGroupRecoveryFail:
+		return policy
+	}
+	return PodGroupRecoveryNone
+}
+
+// GetPodGroupRecoveryTimeout returns how long the PodGroup waits for replacement members.
+func GetPodGroupRecoveryTimeout(pg *v1alpha1.PodGroup) time.Duration {
+	if pg != nil && pg.Annotations != nil {
+		if v, err := strconv.ParseInt(pg.Annotations[PodGroupRecoveryTimeoutSecondsAnnotationKey], 10, 32); err == nil && v > 0 {
+			return time.Duration(v) * time.Second
+		}
+	}
+	return DefaultPodGroupRecoveryTimeout
+}
+
+// GetPodGroupTimeAnnotation parses a RFC3339 timestamp stored in the PodGroup annotations.
+func GetPodGroupTimeAnnotation(pg *v1alpha1.PodGroup, key string) (time.Time, bool) {
+	if pg == nil || pg.Annotations == nil {
+		return time.Time{}, false
+	}
+	v, ok := pg.Annotations[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -25,12 +25,15 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/storage/etcd3"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	storageinformers "k8s.io/client-go/informers/storage/v1"
 	clientset "k8s.io/client-go/kubernetes"
@@ -65,8 +68,41 @@ const (
 	ErrReasonNodeConflict ConflictReason = "node(s) had volume node affinity conflict"
 	// ErrUnboundImmediatePVC is used when the pod has an unbound PVC in immedate binding mode.
 	ErrUnboundImmediatePVC ConflictReason = "pod has unbound immediate PersistentVolumeClaims"
+	// ErrReasonNotEnoughSpace is used when a pod cannot start on a node because not enough storage space is available.
+	ErrReasonNotEnoughSpace ConflictReason = "node(s) did not have enough free storage"
 )
 
+// VolumeResource describes the size of a volume that will be bound or provisioned for an unbound claim.
+type VolumeResource struct {
+	// StorageClassName is the storage class of the claim.
+	StorageClassName string
+	// Requested is the storage requested by the claim in bytes.
+	Requested int64
+	// Capacity is the capacity of the matched PV for static binding, or the capacity reported by
+	// CSIStorageCapacity for dynamic provisioning. Zero means the capacity is unknown.
+	Capacity int64
+}
+
+// CapacityCheck contains additional parameters for NewVolumeBinder that
+// are only needed when checking volume sizes against available storage
+// capacity is desired.
+type CapacityCheck struct {
+	CSIDriverInformer          storageinformers.CSIDriverInformer
+	CSIStorageCapacityInformer storageinformers.CSIStorageCapacityInformer
+}
+
+// NewCapacityCheck returns the CapacityCheck built from informerFactory, nil will be returned
+// if the CSIStorageCapacity feature is disabled.
+func NewCapacityCheck(informerFactory informers.SharedInformerFactory) *CapacityCheck {
+	if !utilfeature.DefaultFeatureGate.Enabled(features.CSIStorageCapacity) {
+		return nil
+	}
+	return &CapacityCheck{
+		CSIDriverInformer:          informerFactory.Storage().V1().CSIDrivers(),
+		CSIStorageCapacityInformer: informerFactory.Storage().V1().CSIStorageCapacities(),
+	}
+}
+
 // InTreeToCSITranslator contains methods required to check migratable status
 // and perform translations from InTree PV's to CSI
 type InTreeToCSITranslator interface {
@@ -151,6 +187,12 @@ type BaseVolumeBinder interface {
 	//
 	// This function is called by the volume binding scheduler predicate and can be called in parallel
 	FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabels map[string]string) (reasons ConflictReasons, err error)
+
+	// FindPodVolumesWithResources is the same as FindPodVolumes, but it also returns the size of the
+	// volumes that will be bound or provisioned for the unbound claims of the Pod on the node.
+	//
+	// This function is called by the volume binding scheduler score plugin and can be called in parallel
+	FindPodVolumesWithResources(pod *v1.Pod, nodeName string, nodeLabels map[string]string) (resources []*VolumeResource, reasons ConflictReasons, err error)
 }
 
 type volumeBinder struct {
@@ -170,12 +212,17 @@ type baseVolumeBinder struct {
 	pvcCache        PVCAssumeCache
 	pvCache         PVAssumeCache
 	translator      InTreeToCSITranslator
+
+	// csiDriverLister and csiStorageCapacityLister are nil if capacity checking is disabled.
+	csiDriverLister          storagelisters.CSIDriverLister
+	csiStorageCapacityLister storagelisters.CSIStorageCapacityLister
 }
 
 func newBaseVolumeBinder(csiNodeInformer storageinformers.CSINodeInformer,
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
 	pvInformer coreinformers.PersistentVolumeInformer,
 	storageClassInformer storageinformers.StorageClassInformer,
+	capacityCheck *CapacityCheck,
 ) *baseVolumeBinder {
 	b := &baseVolumeBinder{
 		classLister:     storageClassInformer.Lister(),
@@ -184,20 +231,32 @@ func newBaseVolumeBinder(csiNodeInformer storageinformers.CSINodeInformer,
 		pvCache:         NewPVAssumeCache(pvInformer.Informer()),
 		translator:      csitrans.New(),
 	}
+	if capacityCheck != nil {
+		b.csiDriverLister = capacityCheck.CSIDriverInformer.Lister()
+		b.csiStorageCapacityLister = capacityCheck.CSIStorageCapacityInformer.Lister()
+	}
 	return b
 }
 
+// NewBaseVolumeBinder sets up the caches needed for the scheduler to check volume binding,
+// capacityCheck determines whether storage capacity is checked (nil disables it).
 func NewBaseVolumeBinder(
 	csiNodeInformer storageinformers.CSINodeInformer,
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
 	pvInformer coreinformers.PersistentVolumeInformer,
 	storageClassInformer storageinformers.StorageClassInformer,
+	capacityCheck *CapacityCheck,
 ) BaseVolumeBinder {
-	b := newBaseVolumeBinder(csiNodeInformer, pvcInformer, pvInformer, storageClassInformer)
+	b := newBaseVolumeBinder(csiNodeInformer, pvcInformer, pvInformer, storageClassInformer, capacityCheck)
 	return b
 }
 
 func (b *baseVolumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabels map[string]string) (reasons ConflictReasons, err error) {
+	_, reasons, err = b.FindPodVolumesWithResources(pod, nodeName, nodeLabels)
+	return
+}
+
+func (b *baseVolumeBinder) FindPodVolumesWithResources(pod *v1.Pod, nodeName string, nodeLabels map[string]string) (resources []*VolumeResource, reasons ConflictReasons, err error) {
 	// Warning: Below log needs high verbosity as it can be printed several times (#60933).
 	klog.V(5).InfoS("Entered FindPodVolumes in baseVolumeBinder", "pod", klog.KObj(pod), "nodeName", nodeName)
 
@@ -206,6 +265,7 @@ func (b *baseVolumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabe
 	// returns without an error.
 	unboundVolumesSatisfied := true
 	boundVolumesSatisfied := true
+	sufficientStorage := true
 	defer func() {
 		if err != nil {
 			return
@@ -216,6 +276,9 @@ func (b *baseVolumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabe
 		if !unboundVolumesSatisfied {
 			reasons = append(reasons, ErrReasonBindConflict)
 		}
+		if !sufficientStorage {
+			reasons = append(reasons, ErrReasonNotEnoughSpace)
+		}
 	}()
 
 	start := time.Now()
@@ -229,10 +292,13 @@ func (b *baseVolumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabe
 	podVolumes := podVolumesInfo{
 		boundVolumesSatisfied:   boundVolumesSatisfied,
 		unboundVolumesSatisfied: unboundVolumesSatisfied,
+		sufficientStorage:       sufficientStorage,
 	}
 	podVolumes, reasons, err = b.findPodVolumes(pod, nodeName, nodeLabels, podVolumes)
 	boundVolumesSatisfied = podVolumes.boundVolumesSatisfied
 	unboundVolumesSatisfied = podVolumes.unboundVolumesSatisfied
+	sufficientStorage = podVolumes.sufficientStorage
+	resources = podVolumes.volumeResources
 	return
 }
 
@@ -244,10 +310,11 @@ func NewVolumeBinder(
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
 	pvInformer coreinformers.PersistentVolumeInformer,
 	storageClassInformer storageinformers.StorageClassInformer,
+	capacityCheck *CapacityCheck,
 	bindTimeout time.Duration,
 ) GodelVolumeBinder {
 	b := &volumeBinder{
-		baseVolumeBinder: newBaseVolumeBinder(csiNodeInformer, pvcInformer, pvInformer, storageClassInformer),
+		baseVolumeBinder: newBaseVolumeBinder(csiNodeInformer, pvcInformer, pvInformer, storageClassInformer, capacityCheck),
 		kubeClient:       kubeClient,
 		nodeInformer:     nodeInformer,
 		podBindingCache:  NewPodBindingCache(),
@@ -281,6 +348,7 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabels m
 	// returns without an error.
 	unboundVolumesSatisfied := true
 	boundVolumesSatisfied := true
+	sufficientStorage := true
 	defer func() {
 		if err != nil {
 			return
@@ -291,6 +359,9 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabels m
 		if !unboundVolumesSatisfied {
 			reasons = append(reasons, ErrReasonBindConflict)
 		}
+		if !sufficientStorage {
+			reasons = append(reasons, ErrReasonNotEnoughSpace)
+		}
 	}()
 
 	start := time.Now()
@@ -327,12 +398,14 @@ func (b *volumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabels m
 	podVolumes := podVolumesInfo{
 		boundVolumesSatisfied:   boundVolumesSatisfied,
 		unboundVolumesSatisfied: unboundVolumesSatisfied,
+		sufficientStorage:       sufficientStorage,
 		matchedBindings:         matchedBindings,
 		provisionedClaims:       provisionedClaims,
 	}
 	podVolumes, reasons, err = b.findPodVolumes(pod, nodeName, nodeLabels, podVolumes)
 	boundVolumesSatisfied = podVolumes.boundVolumesSatisfied
 	unboundVolumesSatisfied = podVolumes.unboundVolumesSatisfied
+	sufficientStorage = podVolumes.sufficientStorage
 	matchedBindings = podVolumes.matchedBindings
 	provisionedClaims = podVolumes.provisionedClaims
 	return
@@ -819,41 +892,134 @@ func (b *baseVolumeBinder) findMatchingVolumes(pod *v1.Pod, claimsToBind []*v1.P
 // checkVolumeProvisions checks given unbound claims (the claims have gone through func
 // findMatchingVolumes, and do not have matching volumes for binding), and return true
 // if all of the claims are eligible for dynamic provision.
-func (b *baseVolumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v1.PersistentVolumeClaim, nodeName string, nodeLabels map[string]string) (provisionSatisfied bool, provisionedClaims []*v1.PersistentVolumeClaim, err error) {
+func (b *baseVolumeBinder) checkVolumeProvisions(pod *v1.Pod, claimsToProvision []*v1.PersistentVolumeClaim, nodeName string, nodeLabels map[string]string) (provisionSatisfied, sufficientStorage bool, provisionedClaims []*v1.PersistentVolumeClaim, resources []*VolumeResource, err error) {
 	provisionedClaims = []*v1.PersistentVolumeClaim{}
 
 	for _, claim := range claimsToProvision {
 		pvcName := getPVCName(claim)
 		className := helper.GetPersistentVolumeClaimClass(claim)
 		if className == "" {
-			return false, nil, fmt.Errorf("no class for claim %q", pvcName)
+			return false, false, nil, nil, fmt.Errorf("no class for claim %q", pvcName)
 		}
 
 		class, err := b.classLister.Get(className)
 		if err != nil {
-			return false, nil, fmt.Errorf("failed to find storage class %q", className)
+			return false, false, nil, nil, fmt.Errorf("failed to find storage class %q", className)
 		}
 		provisioner := class.Provisioner
 		if provisioner == "" || provisioner == pvutil.NotSupportedProvisioner {
 			klog.V(4).InfoS("StorageClass of PVC did not support dynamic provisioning", "storageClass", klog.KObj(class), "PVC", klog.KObj(claim))
-			return false, nil, nil
+			return false, true, nil, nil, nil
 		}
 
 		// Check if the node can satisfy the topology requirement in the class
 		if !helper.MatchTopologySelectorTerms(class.AllowedTopologies, labels.Set(nodeLabels)) {
 			klog.V(4).InfoS("Node failed to satisfy provisioning topology requirements of claim", "nodeName", nodeName, "PVC", klog.KObj(claim))
-			return false, nil, nil
+			return false, true, nil, nil, nil
 		}
 
-		// TODO: Check if capacity of the node domain in the storage class
+		// Check if capacity of the node domain in the storage class
 		// can satisfy resource requirement of given claim
+		sufficient, capacity, err := b.hasEnoughCapacity(provisioner, claim, class, nodeLabels)
+		if err != nil {
+			return false, false, nil, nil, err
+		}
+		if !sufficient {
+			klog.V(4).InfoS("Node has no accessible CSIStorageCapacity with enough capacity for PVC", "nodeName", nodeName, "PVC", klog.KObj(claim), "storageClass", klog.KObj(class))
+			return true, false, nil, nil, nil
+		}
 
 		provisionedClaims = append(provisionedClaims, claim)
-
+		var capacityQuantity resource.Quantity
+		if capacity != nil && capacity.Capacity != nil {
+			capacityQuantity = *capacity.Capacity
+		}
+		resources = append(resources, newVolumeResource(claim, capacityQuantity))
 	}
 	klog.V(4).InfoS("Provisioning for claims of pod that has no matching volumes on node", "pod", klog.KObj(pod), "nodeName", nodeName)
 
-	return true, provisionedClaims, nil
+	return true, true, provisionedClaims, resources, nil
+}
+
+// hasEnoughCapacity checks whether the provisioner has enough capacity left for a new volume of the given size
+// that is available from the node. The chosen CSIStorageCapacity is also returned, it is nil if the capacity
+// is not checked.
+func (b *baseVolumeBinder) hasEnoughCapacity(provisioner string, claim *v1.PersistentVolumeClaim, storageClass *storagev1.StorageClass, nodeLabels map[string]string) (bool, *storagev1.CSIStorageCapacity, error) {
+	// Capacity checking is disabled.
+	if b.csiDriverLister == nil || b.csiStorageCapacityLister == nil {
+		return true, nil, nil
+	}
+
+	quantity, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	if !ok {
+		// No capacity to check for.
+		return true, nil, nil
+	}
+
+	// Only enabled for CSI drivers which opt into it.
+	driver, err := b.csiDriverLister.Get(provisioner)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Either the provisioner is not a CSI driver or the driver does not
+			// opt into storage capacity scheduling. Either way, skip
+			// capacity checking.
+			return true, nil, nil
+		}
+		return false, nil, err
+	}
+	if driver.Spec.StorageCapacity == nil || !*driver.Spec.StorageCapacity {
+		return true, nil, nil
+	}
+
+	// Look for a matching CSIStorageCapacity object(s).
+	capacities, err := b.csiStorageCapacityLister.List(labels.Everything())
+	if err != nil {
+		return false, nil, err
+	}
+
+	sizeInBytes := quantity.Value()
+	for _, capacity := range capacities {
+		if capacity.StorageClassName == storageClass.Name &&
+			capacitySufficient(capacity, sizeInBytes) &&
+			nodeHasAccess(nodeLabels, capacity) {
+			// Enough capacity found.
+			return true, capacity, nil
+		}
+	}
+
+	return false, nil, nil
+}
+
+func capacitySufficient(capacity *storagev1.CSIStorageCapacity, sizeInBytes int64) bool {
+	limit := capacity.Capacity
+	if capacity.MaximumVolumeSize != nil {
+		// Prefer MaximumVolumeSize if available, it is more precise.
+		limit = capacity.MaximumVolumeSize
+	}
+	return limit != nil && limit.Value() >= sizeInBytes
+}
+
+func nodeHasAccess(nodeLabels map[string]string, capacity *storagev1.CSIStorageCapacity) bool {
+	if capacity.NodeTopology == nil {
+		// Unavailable
+		return false
+	}
+	// Only matching by label is supported.
+	selector, err := metav1.LabelSelectorAsSelector(capacity.NodeTopology)
+	if err != nil {
+		klog.ErrorS(err, "Unexpected error converting to a label selector", "nodeTopology", capacity.NodeTopology)
+		return false
+	}
+	return selector.Matches(labels.Set(nodeLabels))
+}
+
+func newVolumeResource(claim *v1.PersistentVolumeClaim, capacity resource.Quantity) *VolumeResource {
+	requested := claim.Spec.Resources.Requests[v1.ResourceStorage]
+	return &VolumeResource{
+		StorageClassName: helper.GetPersistentVolumeClaimClass(claim),
+		Requested:        requested.Value(),
+		Capacity:         capacity.Value(),
+	}
 }
 
 func (b *volumeBinder) revertAssumedPVs(bindings []*bindingInfo) {
@@ -969,8 +1135,10 @@ func (b *baseVolumeBinder) tryTranslatePVToCSI(pv *v1.PersistentVolume, csiNode
 type podVolumesInfo struct {
 	boundVolumesSatisfied   bool
 	unboundVolumesSatisfied bool
+	sufficientStorage       bool
 	matchedBindings         []*bindingInfo
 	provisionedClaims       []*v1.PersistentVolumeClaim
+	volumeResources         []*VolumeResource
 }
 
 func (b *baseVolumeBinder) findPodVolumes(pod *v1.Pod, nodeName string, nodeLabels map[string]string, podVolumes podVolumesInfo) (podVolumesInfo, ConflictReasons, error) {
@@ -1024,14 +1192,19 @@ func (b *baseVolumeBinder) findPodVolumes(pod *v1.Pod, nodeName string, nodeLabe
 				return podVolumes, nil, err
 			}
 			claimsToProvision = append(claimsToProvision, unboundClaims...)
+			for _, binding := range podVolumes.matchedBindings {
+				podVolumes.volumeResources = append(podVolumes.volumeResources, newVolumeResource(binding.pvc, binding.pv.Spec.Capacity[v1.ResourceStorage]))
+			}
 		}
 
 		// Check for claims to provision
 		if len(claimsToProvision) > 0 {
-			podVolumes.unboundVolumesSatisfied, podVolumes.provisionedClaims, err = b.checkVolumeProvisions(pod, claimsToProvision, nodeName, nodeLabels)
+			var provisionedResources []*VolumeResource
+			podVolumes.unboundVolumesSatisfied, podVolumes.sufficientStorage, podVolumes.provisionedClaims, provisionedResources, err = b.checkVolumeProvisions(pod, claimsToProvision, nodeName, nodeLabels)
 			if err != nil {
 				return podVolumes, nil, err
 			}
+			podVolumes.volumeResources = append(podVolumes.volumeResources, provisionedResources...)
 		}
 	}
 
@@ -22,11 +22,12 @@ import (
 
 // FakeVolumeBinderConfig holds configurations for fake volume binder.
 type FakeVolumeBinderConfig struct {
-	AllBound    bool
-	FindReasons ConflictReasons
-	FindErr     error
-	AssumeErr   error
-	BindErr     error
+	AllBound      bool
+	FindReasons   ConflictReasons
+	FindResources []*VolumeResource
+	FindErr       error
+	AssumeErr     error
+	BindErr       error
 }
 
 // NewFakeVolumeBinder sets up all the caches needed for the scheduler to make
@@ -49,6 +50,11 @@ func (b *FakeVolumeBinder) FindPodVolumes(pod *v1.Pod, nodeName string, nodeLabe
 	return b.config.FindReasons, b.config.FindErr
 }
 
+// FindPodVolumesWithResources implements BaseVolumeBinder.FindPodVolumesWithResources.
+func (b *FakeVolumeBinder) FindPodVolumesWithResources(pod *v1.Pod, nodeName string, nodeLabels map[string]string) ([]*VolumeResource, ConflictReasons, error) {
+	return b.config.FindResources, b.config.FindReasons, b.config.FindErr
+}
+
 // AssumePodVolumes implements GodelVolumeBinder.AssumePodVolumes.
 func (b *FakeVolumeBinder) AssumePodVolumes(assumedPod *v1.Pod, nodeName string) (bool, error) {
 	b.AssumeCalled = true
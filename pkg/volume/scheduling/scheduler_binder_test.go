@@ -133,6 +133,9 @@ type testEnv struct {
 	internalCSINodeInformer storageinformers.CSINodeInformer
 	internalPVCache         *assumeCache
 	internalPVCCache        *assumeCache
+
+	internalCSIDriverInformer          storageinformers.CSIDriverInformer
+	internalCSIStorageCapacityInformer storageinformers.CSIStorageCapacityInformer
 }
 
 func newTestBinder(t *testing.T, stopCh <-chan struct{}) *testEnv {
@@ -154,6 +157,8 @@ func newTestBinder(t *testing.T, stopCh <-chan struct{}) *testEnv {
 	csiNodeInformer := informerFactory.Storage().V1().CSINodes()
 	pvcInformer := informerFactory.Core().V1().PersistentVolumeClaims()
 	classInformer := informerFactory.Storage().V1().StorageClasses()
+	csiDriverInformer := informerFactory.Storage().V1().CSIDrivers()
+	csiStorageCapacityInformer := informerFactory.Storage().V1().CSIStorageCapacities()
 	binder := NewVolumeBinder(
 		client,
 		nodeInformer,
@@ -161,6 +166,10 @@ func newTestBinder(t *testing.T, stopCh <-chan struct{}) *testEnv {
 		pvcInformer,
 		informerFactory.Core().V1().PersistentVolumes(),
 		classInformer,
+		&CapacityCheck{
+			CSIDriverInformer:          csiDriverInformer,
+			CSIStorageCapacityInformer: csiStorageCapacityInformer,
+		},
 		3*time.Second)
 
 	// Wait for informers cache sync
@@ -257,6 +266,21 @@ func newTestBinder(t *testing.T, stopCh <-chan struct{}) *testEnv {
 		internalCSINodeInformer: csiNodeInformer,
 		internalPVCache:         internalPVCache,
 		internalPVCCache:        internalPVCCache,
+
+		internalCSIDriverInformer:          csiDriverInformer,
+		internalCSIStorageCapacityInformer: csiStorageCapacityInformer,
+	}
+}
+
+func (env *testEnv) initCSIDrivers(drivers []*storagev1.CSIDriver) {
+	for _, driver := range drivers {
+		env.internalCSIDriverInformer.Informer().GetIndexer().Add(driver)
+	}
+}
+
+func (env *testEnv) initCapacities(capacities []*storagev1.CSIStorageCapacity) {
+	for _, capacity := range capacities {
+		env.internalCSIStorageCapacityInformer.Informer().GetIndexer().Add(capacity)
 	}
 }
 
@@ -1036,6 +1060,143 @@ func TestFindPodVolumesWithProvisioning(t *testing.T) {
 	}
 }
 
+func makeCSIDriver(name string, storageCapacity bool) *storagev1.CSIDriver {
+	return &storagev1.CSIDriver{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       storagev1.CSIDriverSpec{StorageCapacity: &storageCapacity},
+	}
+}
+
+func makeCapacity(name, storageClassName string, node *v1.Node, capacityStr, maximumVolumeSizeStr string) *storagev1.CSIStorageCapacity {
+	c := &storagev1.CSIStorageCapacity{
+		ObjectMeta:       metav1.ObjectMeta{Name: name},
+		StorageClassName: storageClassName,
+		NodeTopology:     &metav1.LabelSelector{},
+	}
+	if node != nil {
+		c.NodeTopology.MatchLabels = map[string]string{nodeLabelKey: node.Labels[nodeLabelKey]}
+	}
+	if capacityStr != "" {
+		capacityQuantity := resource.MustParse(capacityStr)
+		c.Capacity = &capacityQuantity
+	}
+	if maximumVolumeSizeStr != "" {
+		maximumVolumeSizeQuantity := resource.MustParse(maximumVolumeSizeStr)
+		c.MaximumVolumeSize = &maximumVolumeSizeQuantity
+	}
+	return c
+}
+
+func TestFindPodVolumesWithCapacity(t *testing.T) {
+	type scenarioType struct {
+		// Inputs
+		pvs        []*v1.PersistentVolume
+		podPVCs    []*v1.PersistentVolumeClaim
+		drivers    []*storagev1.CSIDriver
+		capacities []*storagev1.CSIStorageCapacity
+
+		// Expected return values
+		expectedBindings   []*bindingInfo
+		expectedProvisions []*v1.PersistentVolumeClaim
+		expectedResources  []*VolumeResource
+		reasons            ConflictReasons
+	}
+	scenarios := map[string]scenarioType{
+		"not a CSI driver": {
+			podPVCs:            []*v1.PersistentVolumeClaim{provisionedPVC},
+			expectedProvisions: []*v1.PersistentVolumeClaim{provisionedPVC},
+			expectedResources:  []*VolumeResource{{StorageClassName: waitClassWithProvisioner, Requested: 1 << 30}},
+		},
+		"CSI driver without capacity tracking": {
+			podPVCs:            []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:            []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", false)},
+			expectedProvisions: []*v1.PersistentVolumeClaim{provisionedPVC},
+			expectedResources:  []*VolumeResource{{StorageClassName: waitClassWithProvisioner, Requested: 1 << 30}},
+		},
+		"no capacity": {
+			podPVCs: []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers: []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			reasons: ConflictReasons{ErrReasonNotEnoughSpace},
+		},
+		"insufficient capacity": {
+			podPVCs:    []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:    []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities: []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClassWithProvisioner, node1, "1", "")},
+			reasons:    ConflictReasons{ErrReasonNotEnoughSpace},
+		},
+		"insufficient maximum volume size": {
+			podPVCs:    []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:    []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities: []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClassWithProvisioner, node1, "4Gi", "1")},
+			reasons:    ConflictReasons{ErrReasonNotEnoughSpace},
+		},
+		"capacity not accessible from node": {
+			podPVCs:    []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:    []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities: []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClassWithProvisioner, node2, "4Gi", "")},
+			reasons:    ConflictReasons{ErrReasonNotEnoughSpace},
+		},
+		"capacity of another storage class": {
+			podPVCs:    []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:    []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities: []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClass, node1, "4Gi", "")},
+			reasons:    ConflictReasons{ErrReasonNotEnoughSpace},
+		},
+		"sufficient capacity": {
+			podPVCs:            []*v1.PersistentVolumeClaim{provisionedPVC},
+			drivers:            []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities:         []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClassWithProvisioner, node1, "4Gi", "")},
+			expectedProvisions: []*v1.PersistentVolumeClaim{provisionedPVC},
+			expectedResources:  []*VolumeResource{{StorageClassName: waitClassWithProvisioner, Requested: 1 << 30, Capacity: 4 << 30}},
+		},
+		"one-matched,one-provisioned": {
+			podPVCs:            []*v1.PersistentVolumeClaim{unboundPVC, provisionedPVC},
+			pvs:                []*v1.PersistentVolume{pvNode1a},
+			drivers:            []*storagev1.CSIDriver{makeCSIDriver("test-provisioner", true)},
+			capacities:         []*storagev1.CSIStorageCapacity{makeCapacity("net", waitClassWithProvisioner, node1, "4Gi", "")},
+			expectedBindings:   []*bindingInfo{makeBinding(unboundPVC, pvNode1a)},
+			expectedProvisions: []*v1.PersistentVolumeClaim{provisionedPVC},
+			expectedResources: []*VolumeResource{
+				{StorageClassName: waitClass, Requested: 1000000000, Capacity: 5000000000},
+				{StorageClassName: waitClassWithProvisioner, Requested: 1 << 30, Capacity: 4 << 30},
+			},
+		},
+	}
+
+	run := func(t *testing.T, scenario scenarioType) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Setup
+		testEnv := newTestBinder(t, ctx.Done())
+		testEnv.initVolumes(scenario.pvs, scenario.pvs)
+		testEnv.initClaims(scenario.podPVCs, scenario.podPVCs)
+		testEnv.initCSIDrivers(scenario.drivers)
+		testEnv.initCapacities(scenario.capacities)
+		pod := makePod(scenario.podPVCs)
+
+		// Execute
+		reasons, err := testEnv.binder.FindPodVolumes(pod, node1.Name, node1.Labels)
+		if err != nil {
+			t.Errorf("returned error: %v", err)
+		}
+		checkReasons(t, reasons, scenario.reasons)
+		testEnv.validatePodCache(t, node1.Name, pod, scenario.expectedBindings, scenario.expectedProvisions)
+
+		resources, _, err := testEnv.internalBinder.FindPodVolumesWithResources(pod, node1.Name, node1.Labels)
+		if err != nil {
+			t.Errorf("returned error: %v", err)
+		}
+		if !reflect.DeepEqual(resources, scenario.expectedResources) {
+			t.Errorf("resources do not match [A-expected, B-got]: %s", diff.ObjectDiff(scenario.expectedResources, resources))
+		}
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) { run(t, scenario) })
+	}
+}
+
 // TestFindPodVolumesWithCSIMigration aims to test the node affinity check procedure that's
 // done in FindPodVolumes. In order to reach this code path, the given PVCs must be bound to a PV.
 func TestFindPodVolumesWithCSIMigration(t *testing.T) {